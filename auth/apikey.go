@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	// APIKeyType is the scheme of the Authorization header API keys are
+	// sent with, APIKeyHeader is the header they can be sent in instead.
+	APIKeyType   = "ApiKey"
+	APIKeyHeader = "X-API-Key"
+
+	// apiKeyMarker starts every API key, so leaked keys are easy to spot.
+	apiKeyMarker = "sk"
+)
+
+// NewAPIKey returns a random API key, its prefix and the hash it is stored
+// under. Keys look like sk_<prefix>_<secret>, the prefix identifies a key
+// without revealing it.
+func NewAPIKey() (key, prefix, hash string, err error) {
+	b := make([]byte, 6+32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = hex.EncodeToString(b[:6])
+	key = apiKeyMarker + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(b[6:])
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// APIKeyPrefix returns the prefix of key, false when key is not shaped like
+// an API key.
+func APIKeyPrefix(key string) (string, bool) {
+	marker, rest, ok := strings.Cut(key, "_")
+	if !ok || marker != apiKeyMarker {
+		return "", false
+	}
+
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+
+	return prefix, true
+}
+
+// HashAPIKey returns the hash an API key is stored under, the keys are
+// random so a plain SHA-256 is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKey returns the API key of a request, sent in an Authorization header
+// using the ApiKey scheme or in an X-API-Key header.
+func APIKey(header http.Header) (string, bool) {
+	if key, ok := credentials(header.Get("Authorization"), APIKeyType); ok {
+		return key, true
+	}
+
+	key := strings.TrimSpace(header.Get(APIKeyHeader))
+	return key, key != ""
+}
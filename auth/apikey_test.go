@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"sqlc-rest-api/requests"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, hash, err := NewAPIKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key, "sk_"+prefix+"_"), key)
+	require.Equal(t, HashAPIKey(key), hash)
+	require.NotContains(t, hash, prefix)
+
+	got, ok := APIKeyPrefix(key)
+	require.True(t, ok)
+	require.Equal(t, prefix, got)
+
+	other, otherPrefix, _, err := NewAPIKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+	require.NotEqual(t, prefix, otherPrefix)
+
+	for _, key := range []string{"", "sk", "sk_abc", "sk__secret", "sk_abc_", "pk_abc_secret"} {
+		_, ok := APIKeyPrefix(key)
+		require.False(t, ok, key)
+	}
+}
+
+func TestAPIKeyHeader(t *testing.T) {
+	testCases := []struct {
+		name   string
+		header http.Header
+		key    string
+		ok     bool
+	}{
+		{"authorization", http.Header{"Authorization": {"ApiKey sk_abc_def"}}, "sk_abc_def", true},
+		{"scheme case", http.Header{"Authorization": {"apikey  sk_abc_def "}}, "sk_abc_def", true},
+		{"x-api-key", http.Header{"X-Api-Key": {"sk_abc_def"}}, "sk_abc_def", true},
+		{"bearer", http.Header{"Authorization": {"Bearer token"}}, "", false},
+		{"empty", http.Header{"Authorization": {"ApiKey "}, "X-Api-Key": {" "}}, "", false},
+		{"none", http.Header{}, "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			key, ok := APIKey(testCase.header)
+			require.Equal(t, testCase.ok, ok)
+			require.Equal(t, testCase.key, key)
+		})
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	ctx := context.Background()
+	require.True(t, HasScope(ctx, requests.ScopeUsersWrite))
+
+	ctx = WithScopes(ctx, []requests.Scope{requests.ScopeProductsRead})
+	require.True(t, HasScope(ctx, requests.ScopeProductsRead))
+	require.False(t, HasScope(ctx, requests.ScopeProductsWrite))
+
+	// a key without scopes can do nothing
+	require.False(t, HasScope(WithScopes(context.Background(), nil), requests.ScopeProductsRead))
+}
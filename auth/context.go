@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"strings"
+)
+
+type ctxKey struct{}
+
+type scopesKey struct{}
+
+// WithUser returns a copy of ctx made by user.
+func WithUser(ctx context.Context, user *responses.User) context.Context {
+	return context.WithValue(ctx, ctxKey{}, user)
+}
+
+// UserFrom returns the user ctx is made by, false for anonymous requests.
+func UserFrom(ctx context.Context) (*responses.User, bool) {
+	user, ok := ctx.Value(ctxKey{}).(*responses.User)
+	return user, ok && user != nil
+}
+
+// WithScopes returns a copy of ctx made with an API key limited to scopes.
+func WithScopes(ctx context.Context, scopes []requests.Scope) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ScopesFrom returns the scopes of the API key ctx is made with, false for
+// requests made without one.
+func ScopesFrom(ctx context.Context) ([]requests.Scope, bool) {
+	scopes, ok := ctx.Value(scopesKey{}).([]requests.Scope)
+	return scopes, ok
+}
+
+// HasScope reports whether ctx may act within scope. Only API keys are
+// limited to scopes, requests made without one have every scope.
+func HasScope(ctx context.Context, scope requests.Scope) bool {
+	scopes, ok := ScopesFrom(ctx)
+	if !ok {
+		return true
+	}
+
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BearerToken returns the token of an Authorization header using the Bearer
+// scheme, the scheme is case insensitive.
+func BearerToken(header string) (string, bool) {
+	return credentials(header, TokenType)
+}
+
+// credentials returns the credentials of an Authorization header using
+// scheme, the scheme is case insensitive.
+func credentials(header, scheme string) (string, bool) {
+	got, credentials, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(got, scheme) {
+		return "", false
+	}
+
+	credentials = strings.TrimSpace(credentials)
+	return credentials, credentials != ""
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength is the most bcrypt hashes, longer passwords would
+	// be silently truncated.
+	MaxPasswordLength = 72
+)
+
+var ErrPasswordMismatch = errors.New("password does not match")
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// CheckPassword returns ErrPasswordMismatch when password is not the one hash
+// was made from.
+func CheckPassword(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrPasswordMismatch
+	}
+
+	return err
+}
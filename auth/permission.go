@@ -0,0 +1,19 @@
+package auth
+
+// Permissions are granted to users through their roles, the names match the
+// permissions table. Reading permissions are for support staff, they never
+// allow changes.
+const (
+	PermissionReadProducts    = "products:read"
+	PermissionManageProducts  = "products:manage"
+	PermissionManageCatalog   = "catalog:manage"
+	PermissionReadInventory   = "inventory:read"
+	PermissionManageInventory = "inventory:manage"
+	PermissionReadOrders      = "orders:read"
+	PermissionManageOrders    = "orders:manage"
+	PermissionReadPayments    = "payments:read"
+	PermissionManagePayments  = "payments:manage"
+	PermissionReadUsers       = "users:read"
+	PermissionManageUsers     = "users:manage"
+	PermissionManageRoles     = "roles:manage"
+)
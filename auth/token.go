@@ -0,0 +1,159 @@
+// Package auth issues and verifies the credentials of users: bcrypt password
+// hashes, HS256 signed access tokens and opaque refresh tokens. The user a
+// request is made by travels in its context.Context, see WithUser.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAccessTTL and DefaultRefreshTTL are used when Tokens leaves
+	// the TTLs at zero. Access tokens cannot be revoked, they are kept short.
+	DefaultAccessTTL  = 15 * time.Minute
+	DefaultRefreshTTL = 30 * 24 * time.Hour
+
+	// TokenType is the scheme of the Authorization header access tokens
+	// are sent with.
+	TokenType = "Bearer"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+	ErrNoSecret     = errors.New("no token secret is configured")
+)
+
+// header is the only JOSE header access tokens are signed with.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims of an access token, the subject is the id of the user as a string
+// like RFC 7519 asks.
+type Claims struct {
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// UserID returns the id of the user the token was issued to.
+func (c Claims) UserID() (int64, error) {
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil || id < 1 {
+		return 0, ErrInvalidToken
+	}
+
+	return id, nil
+}
+
+// Tokens issues access tokens signed with Secret and refresh tokens, a zero
+// TTL uses the default one.
+type Tokens struct {
+	Secret     []byte
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+func (t Tokens) accessTTL() time.Duration {
+	if t.AccessTTL <= 0 {
+		return DefaultAccessTTL
+	}
+
+	return t.AccessTTL
+}
+
+// RefreshExpiry returns when a refresh token issued at now expires.
+func (t Tokens) RefreshExpiry(now time.Time) time.Time {
+	if t.RefreshTTL <= 0 {
+		return now.Add(DefaultRefreshTTL)
+	}
+
+	return now.Add(t.RefreshTTL)
+}
+
+// Access returns an access token of user id issued at now and when it
+// expires.
+func (t Tokens) Access(userID int64, now time.Time) (string, time.Time, error) {
+	if len(t.Secret) == 0 {
+		return "", time.Time{}, ErrNoSecret
+	}
+
+	expiresAt := now.Add(t.accessTTL())
+	claims, err := json.Marshal(Claims{
+		Subject:   strconv.FormatInt(userID, 10),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return unsigned + "." + t.sign(unsigned), expiresAt, nil
+}
+
+// Verify checks the signature and expiry of an access token and returns its
+// claims.
+func (t Tokens) Verify(token string, now time.Time) (Claims, error) {
+	if len(t.Secret) == 0 {
+		return Claims{}, ErrNoSecret
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(parts[2]), []byte(t.sign(parts[0]+"."+parts[1]))) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return Claims{}, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+func (t Tokens) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, t.Secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NewRefreshToken returns a random refresh token and the hash it is stored
+// under.
+func NewRefreshToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the hash a refresh token is stored under, the
+// tokens are random so a plain SHA-256 is enough.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
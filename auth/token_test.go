@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessToken(t *testing.T) {
+	tokens := Tokens{Secret: []byte("secret")}
+	now := time.Unix(1700000000, 0)
+
+	token, expiresAt, err := tokens.Access(42, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(DefaultAccessTTL), expiresAt)
+
+	claims, err := tokens.Verify(token, now.Add(DefaultAccessTTL-time.Second))
+	require.NoError(t, err)
+	id, err := claims.UserID()
+	require.NoError(t, err)
+	require.Equal(t, int64(42), id)
+
+	_, err = tokens.Verify(token, expiresAt)
+	require.ErrorIs(t, err, ErrExpiredToken)
+
+	parts := strings.Split(token, ".")
+	forged, _, err := Tokens{Secret: []byte("other")}.Access(1, now)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		tokens Tokens
+		token  string
+		err    error
+	}{
+		{"no secret", Tokens{}, token, ErrNoSecret},
+		{"other secret", Tokens{Secret: []byte("other")}, token, ErrInvalidToken},
+		{"forged", tokens, forged, ErrInvalidToken},
+		{"changed claims", tokens, parts[0] + "." + strings.Split(forged, ".")[1] + "." + parts[2], ErrInvalidToken},
+		{"alg none", tokens, "eyJhbGciOiJub25lIn0." + parts[1] + ".", ErrInvalidToken},
+		{"not a token", tokens, "token", ErrInvalidToken},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.tokens.Verify(testCase.token, now)
+			require.ErrorIs(t, err, testCase.err)
+		})
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	require.NoError(t, err)
+	require.Equal(t, HashRefreshToken(token), hash)
+	require.Len(t, hash, 64)
+
+	other, _, err := NewRefreshToken()
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+
+	now := time.Unix(1700000000, 0)
+	require.Equal(t, now.Add(DefaultRefreshTTL), Tokens{}.RefreshExpiry(now))
+	require.Equal(t, now.Add(time.Hour), Tokens{RefreshTTL: time.Hour}.RefreshExpiry(now))
+}
+
+func TestBearerToken(t *testing.T) {
+	testCases := []struct {
+		header string
+		token  string
+		ok     bool
+	}{
+		{"Bearer abc", "abc", true},
+		{"bearer  abc ", "abc", true},
+		{"Bearer", "", false},
+		{"Bearer ", "", false},
+		{"ApiKey abc", "", false},
+		{"", "", false},
+	}
+
+	for _, testCase := range testCases {
+		token, ok := BearerToken(testCase.header)
+		require.Equal(t, testCase.ok, ok, testCase.header)
+		require.Equal(t, testCase.token, token, testCase.header)
+	}
+}
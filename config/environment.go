@@ -1,8 +1,18 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Environment struct {
+	// StorageDriver selects the services.Service implementation, "memory"
+	// keeps everything in process, anything else uses the database.
+	StorageDriver string `mapstructure:"STORAGE_DRIVER"`
+
+	// DBDriver is either "postgres" or "sqlite", for sqlite DBName is the
+	// path of the database file.
 	DBDriver   string `mapstructure:"DB_DRIVER"`
 	DBUsername string `mapstructure:"DB_USERNAME"`
 	DBPassword string `mapstructure:"DB_PASSWORD"`
@@ -10,10 +20,72 @@ type Environment struct {
 	DBPort     string `mapstructure:"DB_PORT"`
 	DBName     string `mapstructure:"DB_NAME"`
 
+	// TxIsolation and TxMaxRetries tune postgres transactions, see
+	// services.NewTxOptions. Empty and zero keep services.DefaultTxOptions.
+	TxIsolation  string `mapstructure:"TX_ISOLATION"`
+	TxMaxRetries int    `mapstructure:"TX_MAX_RETRIES"`
+
 	ServerHost string `mapstructure:"SERVER_HOST"`
 	ServerPort string `mapstructure:"SERVER_PORT"`
 
 	ComplexityLimit int `mapstructure:"COMPLEXITY_LIMIT"`
+
+	// CursorSecret signs pagination cursors, when empty a random secret is
+	// used and cursors stop working after a restart.
+	CursorSecret string `mapstructure:"CURSOR_SECRET"`
+	// MaxPageSize caps first and last of cursor paginated lists, zero means
+	// services.DefaultMaxPageSize.
+	MaxPageSize int `mapstructure:"MAX_PAGE_SIZE"`
+
+	// DataloaderWait and DataloaderMaxBatch tune the GraphQL dataloaders,
+	// zero values use loaders.DefaultWait and loaders.DefaultMaxBatch.
+	DataloaderWait     time.Duration `mapstructure:"DATALOADER_WAIT"`
+	DataloaderMaxBatch int           `mapstructure:"DATALOADER_MAX_BATCH"`
+
+	// TrashRetention is how long deleted products stay restorable before the
+	// purge job, running every PurgeInterval, removes them for good. Zero
+	// values use jobs.DefaultTrashRetention and jobs.DefaultPurgeInterval.
+	TrashRetention time.Duration `mapstructure:"TRASH_RETENTION"`
+	PurgeInterval  time.Duration `mapstructure:"PURGE_INTERVAL"`
+
+	// ReservationTTL is how long stock reservations last unless the request
+	// asks otherwise, expired ones are released every
+	// ReservationSweepInterval. Zero values use services.DefaultReservationTTL
+	// and jobs.DefaultSweepInterval.
+	ReservationTTL           time.Duration `mapstructure:"RESERVATION_TTL"`
+	ReservationSweepInterval time.Duration `mapstructure:"RESERVATION_SWEEP_INTERVAL"`
+
+	// UserDeletePolicy is what deleting a user does to its products unless
+	// the request chooses: "restrict" (the default), "cascade" or
+	// "reassign" to UserReassignTo.
+	UserDeletePolicy string `mapstructure:"USER_DELETE_POLICY"`
+	UserReassignTo   int64  `mapstructure:"USER_REASSIGN_TO"`
+
+	// DisposableEmailDomains is a comma separated list of domains users can
+	// not sign up with, their subdomains are refused as well.
+	DisposableEmailDomains []string `mapstructure:"DISPOSABLE_EMAIL_DOMAINS"`
+
+	// DefaultCurrency is the currency of products created without one, empty
+	// means services.DefaultCurrency. CurrencyRounding is how converted prices
+	// are rounded: "half_even" (the default), "half_up", "down" or "up".
+	DefaultCurrency  string `mapstructure:"DEFAULT_CURRENCY"`
+	CurrencyRounding string `mapstructure:"CURRENCY_ROUNDING"`
+
+	// PaymentProvider is the gateway payments are made with, only "fake"
+	// (the default) is built in. Webhook deliveries must be signed with
+	// PaymentWebhookSecret and be no older than PaymentWebhookTolerance,
+	// zero means payments.DefaultTolerance. Without a secret every delivery
+	// is refused.
+	PaymentProvider         string        `mapstructure:"PAYMENT_PROVIDER"`
+	PaymentWebhookSecret    string        `mapstructure:"PAYMENT_WEBHOOK_SECRET"`
+	PaymentWebhookTolerance time.Duration `mapstructure:"PAYMENT_WEBHOOK_TOLERANCE"`
+
+	// AuthSecret signs access tokens, registering and logging in fail
+	// without it. Zero TTLs use auth.DefaultAccessTTL and
+	// auth.DefaultRefreshTTL.
+	AuthSecret      string        `mapstructure:"AUTH_SECRET"`
+	AccessTokenTTL  time.Duration `mapstructure:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL time.Duration `mapstructure:"REFRESH_TOKEN_TTL"`
 }
 
 func LoadEnv(path, envName string) (env Environment, err error) {
@@ -0,0 +1,40 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+	"sqlc-rest-api/config"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type Sqlite struct {
+	env config.Environment
+}
+
+func NewSqlite(env config.Environment) *Sqlite {
+	return &Sqlite{
+		env: env,
+	}
+}
+
+// Connect opens the database file named by DB_NAME. Foreign keys are off by
+// default in sqlite so they are enabled on every connection. Transactions
+// take the write lock when they begin, a deferred one that reads first could
+// not upgrade its lock while another writer holds it and would fail instead of
+// waiting for the busy timeout.
+func (s *Sqlite) Connect() (*sql.DB, error) {
+	db, err := sql.Open(
+		"sqlite3",
+		fmt.Sprintf(
+			"file:%s?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate",
+			s.env.DBName,
+		),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return db, err
+}
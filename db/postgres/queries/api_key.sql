@@ -0,0 +1,42 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    user_id,
+    name,
+    prefix,
+    key_hash,
+    scopes,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT * FROM api_keys
+WHERE prefix = $1 LIMIT 1;
+
+-- name: GetUserAPIKey :one
+SELECT * FROM api_keys
+WHERE id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: ListUserAPIKeys :many
+SELECT * FROM api_keys
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: UpdateAPIKey :one
+UPDATE api_keys
+SET
+    name = $1,
+    scopes = $2
+WHERE id = $3 AND user_id = $4
+RETURNING *;
+
+-- name: DeleteAPIKey :execrows
+DELETE FROM api_keys
+WHERE id = $1 AND user_id = $2;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = $1
+WHERE id = $2;
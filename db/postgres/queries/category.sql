@@ -0,0 +1,63 @@
+-- name: CreateCategory :one
+INSERT INTO categories (
+    name,
+    parent_id
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetCategory :one
+SELECT * FROM categories
+WHERE id = $1 LIMIT 1;
+
+-- name: GetBatchCategories :many
+SELECT * FROM categories
+WHERE id = ANY(@ids::BIGINT[]);
+
+-- name: ListCategories :many
+SELECT * FROM categories
+WHERE parent_id IS NOT DISTINCT FROM sqlc.narg('parent_id')
+ORDER BY LOWER(name), id;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET
+    name = $1,
+    parent_id = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $3
+RETURNING *;
+
+-- name: DeleteCategory :one
+DELETE FROM categories
+WHERE id = $1
+RETURNING id;
+
+-- name: CountChildCategories :one
+SELECT COUNT(*) FROM categories
+WHERE parent_id = $1;
+
+-- name: GetCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT categories.id, categories.parent_id FROM categories WHERE categories.id = sqlc.arg('id')
+    UNION ALL
+    SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+)
+SELECT id FROM ancestors;
+
+-- name: DeleteProductCategories :exec
+DELETE FROM product_categories
+WHERE product_id = $1;
+
+-- name: AddProductCategories :exec
+INSERT INTO product_categories (product_id, category_id)
+SELECT sqlc.arg('product_id')::BIGINT, UNNEST(sqlc.arg('category_ids')::BIGINT[])
+ON CONFLICT DO NOTHING;
+
+-- name: GetBatchProductCategories :many
+SELECT pc.product_id, c.id, c.name, c.parent_id, c.created_at, c.updated_at
+FROM product_categories pc
+JOIN categories c ON c.id = pc.category_id
+WHERE pc.product_id = ANY(@product_ids::BIGINT[])
+ORDER BY pc.product_id, LOWER(c.name), c.id;
@@ -0,0 +1,17 @@
+-- name: SetExchangeRate :one
+INSERT INTO exchange_rates (
+    base,
+    quote,
+    rate
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (base, quote) DO UPDATE
+SET
+    rate = EXCLUDED.rate,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListExchangeRates :many
+SELECT * FROM exchange_rates
+ORDER BY base, quote;
@@ -0,0 +1,75 @@
+-- name: EnsureInventory :exec
+INSERT INTO inventory (product_id)
+VALUES ($1)
+ON CONFLICT (product_id) DO NOTHING;
+
+-- name: LockInventory :one
+SELECT * FROM inventory
+WHERE product_id = $1
+FOR UPDATE;
+
+-- name: GetBatchInventory :many
+SELECT * FROM inventory
+WHERE product_id = ANY(@product_ids::BIGINT[]);
+
+-- name: SetInventory :one
+UPDATE inventory
+SET
+    on_hand = $1,
+    reserved = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE product_id = $3
+RETURNING *;
+
+-- name: CreateStockAdjustment :one
+INSERT INTO stock_adjustments (
+    product_id,
+    delta,
+    reason,
+    note
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListStockAdjustments :many
+SELECT * FROM stock_adjustments
+WHERE product_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: CreateReservation :one
+INSERT INTO reservations (
+    product_id,
+    quantity,
+    expires_at,
+    user_id
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetReservation :one
+SELECT * FROM reservations
+WHERE id = $1 LIMIT 1;
+
+-- name: CloseReservation :one
+UPDATE reservations
+SET
+    status = $1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND status = 'active'
+RETURNING *;
+
+-- name: ExpireReservations :many
+UPDATE reservations
+SET
+    status = 'expired',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id IN (
+    SELECT r.id FROM reservations r
+    WHERE r.status = 'active' AND r.expires_at < @expired_before
+    ORDER BY r.id
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
@@ -0,0 +1,49 @@
+-- name: CreateOrder :one
+INSERT INTO orders (
+    user_id,
+    currency,
+    total
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: CreateOrderItem :one
+INSERT INTO order_items (
+    order_id,
+    product_id,
+    product_name,
+    unit_price,
+    quantity
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetOrderItems :many
+SELECT * FROM order_items
+WHERE order_id = ANY(@order_ids::BIGINT[])
+ORDER BY order_id, id;
+
+-- name: UpdateOrderStatus :one
+UPDATE orders
+SET
+    status = @status,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND status = @from_status
+RETURNING *;
+
+-- name: GetUserOrders :many
+SELECT * FROM orders
+WHERE user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('after_id')::BIGINT IS NULL OR id < sqlc.narg('after_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('first');
+
+-- name: CountUserOrders :one
+SELECT COUNT(*) FROM orders
+WHERE user_id = $1;
@@ -0,0 +1,34 @@
+-- name: CreatePayment :one
+INSERT INTO payments (
+    order_id,
+    product_id,
+    provider,
+    intent_id,
+    amount,
+    currency,
+    user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetPayment :one
+SELECT * FROM payments
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPaymentByIntent :one
+SELECT * FROM payments
+WHERE provider = $1 AND intent_id = $2 LIMIT 1;
+
+-- name: LockPayment :one
+SELECT * FROM payments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdatePaymentStatus :one
+UPDATE payments
+SET
+    status = @status,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id AND status = @from_status
+RETURNING *;
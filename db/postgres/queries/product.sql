@@ -1,30 +1,87 @@
 -- name: ListProducts :many
 SELECT * FROM products
-WHERE user_id = $1
-ORDER BY id
-LIMIT $2
-OFFSET $3;
+WHERE deleted_at IS NULL
+    AND (sqlc.narg('user_id')::BIGINT IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('name')::TEXT IS NULL OR name ILIKE '%' || sqlc.narg('name') || '%' ESCAPE '\')
+    AND (sqlc.narg('min_price')::BIGINT IS NULL OR price >= sqlc.narg('min_price'))
+    AND (sqlc.narg('max_price')::BIGINT IS NULL OR price <= sqlc.narg('max_price'))
+    AND (sqlc.narg('created_after')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('created_after'))
+    AND (sqlc.narg('created_before')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('created_before'))
+ORDER BY
+    CASE WHEN sqlc.arg('order_by')::TEXT = 'name' AND NOT sqlc.arg('sort_desc')::BOOLEAN THEN name END ASC,
+    CASE WHEN sqlc.arg('order_by')::TEXT = 'name' AND sqlc.arg('sort_desc')::BOOLEAN THEN name END DESC,
+    CASE WHEN sqlc.arg('order_by')::TEXT = 'price' AND NOT sqlc.arg('sort_desc')::BOOLEAN THEN price END ASC,
+    CASE WHEN sqlc.arg('order_by')::TEXT = 'price' AND sqlc.arg('sort_desc')::BOOLEAN THEN price END DESC,
+    CASE WHEN sqlc.arg('order_by')::TEXT = 'created_at' AND NOT sqlc.arg('sort_desc')::BOOLEAN THEN created_at END ASC,
+    CASE WHEN sqlc.arg('order_by')::TEXT = 'created_at' AND sqlc.arg('sort_desc')::BOOLEAN THEN created_at END DESC,
+    CASE WHEN sqlc.arg('sort_desc')::BOOLEAN THEN id END DESC,
+    id ASC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountProducts :one
+SELECT COUNT(*) FROM products
+WHERE deleted_at IS NULL
+    AND (sqlc.narg('user_id')::BIGINT IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('name')::TEXT IS NULL OR name ILIKE '%' || sqlc.narg('name') || '%' ESCAPE '\')
+    AND (sqlc.narg('min_price')::BIGINT IS NULL OR price >= sqlc.narg('min_price'))
+    AND (sqlc.narg('max_price')::BIGINT IS NULL OR price <= sqlc.narg('max_price'))
+    AND (sqlc.narg('created_after')::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg('created_after'))
+    AND (sqlc.narg('created_before')::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg('created_before'));
 
 -- name: CreateProduct :one
 INSERT INTO products(
     user_id,
     name,
-    price
+    price,
+    currency
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 ) RETURNING *;
 
+-- name: BulkCreateProducts :many
+INSERT INTO products (user_id, name, price, currency)
+SELECT user_id, name, price, currency
+FROM unnest(
+    sqlc.arg('user_ids')::BIGINT[],
+    sqlc.arg('names')::TEXT[],
+    sqlc.arg('prices')::BIGINT[],
+    sqlc.arg('currencies')::TEXT[]
+) WITH ORDINALITY AS items(user_id, name, price, currency, position)
+ORDER BY position
+RETURNING *;
+
 -- name: GetProduct :one
 SELECT * FROM products
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1;
 
+-- name: GetBatchProducts :many
+SELECT * FROM products
+WHERE id = ANY(@ids::BIGINT[]) AND deleted_at IS NULL;
+
 -- name: UpdateProduct :one
 UPDATE products
 SET
-    name = $2,
-    price = $3
-WHERE id = $1
+    name = sqlc.arg('name'),
+    price = sqlc.arg('price'),
+    currency = sqlc.arg('currency'),
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+    AND (sqlc.narg('expected_version')::BIGINT IS NULL OR version = sqlc.narg('expected_version'))
+RETURNING *;
+
+-- name: PatchProduct :one
+UPDATE products
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    price = COALESCE(sqlc.narg('price'), price),
+    currency = COALESCE(sqlc.narg('currency'), currency),
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+    AND (sqlc.narg('expected_version')::BIGINT IS NULL OR version = sqlc.narg('expected_version'))
 RETURNING *;
 
 -- name: DeleteProduct :one
@@ -32,16 +89,297 @@ DELETE FROM products
 WHERE id = $1
 RETURNING id;
 
+-- name: SoftDeleteProduct :one
+UPDATE products
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id;
+
+-- name: BulkSoftDeleteProducts :many
+UPDATE products
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ANY(sqlc.arg('ids')::BIGINT[]) AND deleted_at IS NULL
+RETURNING id;
+
+-- name: BulkDeleteProducts :many
+DELETE FROM products
+WHERE id = ANY(sqlc.arg('ids')::BIGINT[])
+RETURNING id;
+
+-- name: GetProductOwners :many
+-- trashed products are included, they can still be restored or deleted.
+SELECT id, user_id FROM products
+WHERE id = ANY(sqlc.arg('ids')::BIGINT[]);
+
+-- name: RestoreProduct :one
+UPDATE products
+SET
+    deleted_at = NULL,
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
 -- name: GetUserProducts :many
 SELECT *
 FROM products
-WHERE user_id = sqlc.arg('user_id') AND created_at < sqlc.arg('after')
-ORDER BY created_at DESC
+WHERE user_id = sqlc.arg('user_id')
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id')::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag')::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (
+        sqlc.narg('after_created_at')::TIMESTAMPTZ IS NULL
+        OR (created_at, id) < (sqlc.narg('after_created_at'), sqlc.narg('after_id')::BIGINT)
+    )
+ORDER BY created_at DESC, id DESC
 LIMIT sqlc.arg('first');
 
+-- name: GetUserProductsBefore :many
+SELECT *
+FROM products
+WHERE user_id = sqlc.arg('user_id')
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id')::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag')::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (
+        sqlc.narg('before_created_at')::TIMESTAMPTZ IS NULL
+        OR (created_at, id) > (sqlc.narg('before_created_at'), sqlc.narg('before_id')::BIGINT)
+    )
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg('last');
+
 -- name: UserProductsHasNextPage :one
 SELECT EXISTS(
     SELECT 1
     FROM products
-    WHERE user_id = sqlc.arg('user_id') AND created_at < sqlc.arg('after')
-);
\ No newline at end of file
+    WHERE user_id = sqlc.arg('user_id')
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id')::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag')::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (created_at, id) < (sqlc.arg('created_at')::TIMESTAMPTZ, sqlc.arg('id')::BIGINT)
+);
+
+-- name: UserProductsHasPreviousPage :one
+SELECT EXISTS(
+    SELECT 1
+    FROM products
+    WHERE user_id = sqlc.arg('user_id')
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id')::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag')::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (created_at, id) > (sqlc.arg('created_at')::TIMESTAMPTZ, sqlc.arg('id')::BIGINT)
+);
+
+-- name: GetBatchUserProducts :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+FROM (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC, id DESC) AS position
+    FROM products
+    WHERE user_id = ANY(sqlc.arg('user_ids')::BIGINT[])
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id')::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag')::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (
+            sqlc.narg('after_created_at')::TIMESTAMPTZ IS NULL
+            OR (created_at, id) < (sqlc.narg('after_created_at'), sqlc.narg('after_id')::BIGINT)
+        )
+) AS ranked
+WHERE position <= sqlc.arg('first')
+ORDER BY user_id, created_at DESC, id DESC;
+
+-- name: GetBatchUserProductsBefore :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+FROM (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at ASC, id ASC) AS position
+    FROM products
+    WHERE user_id = ANY(sqlc.arg('user_ids')::BIGINT[])
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id')::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag')::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (
+            sqlc.narg('before_created_at')::TIMESTAMPTZ IS NULL
+            OR (created_at, id) > (sqlc.narg('before_created_at'), sqlc.narg('before_id')::BIGINT)
+        )
+) AS ranked
+WHERE position <= sqlc.arg('last')
+ORDER BY user_id, created_at ASC, id ASC;
+
+-- name: UsersWithProductsNotOlderThan :many
+SELECT DISTINCT user_id
+FROM products
+WHERE user_id = ANY(sqlc.arg('user_ids')::BIGINT[])
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id')::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag')::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (created_at, id) >= (sqlc.arg('created_at')::TIMESTAMPTZ, sqlc.arg('id')::BIGINT);
+
+-- name: UsersWithProductsNotNewerThan :many
+SELECT DISTINCT user_id
+FROM products
+WHERE user_id = ANY(sqlc.arg('user_ids')::BIGINT[])
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id')::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag')::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (created_at, id) <= (sqlc.arg('created_at')::TIMESTAMPTZ, sqlc.arg('id')::BIGINT);
+
+-- name: SearchProducts :many
+-- names are HTML escaped before ts_headline adds its marks.
+SELECT id, name, price, currency, user_id, created_at, version, updated_at, rank,
+    ts_headline('english', replace(replace(replace(name, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::TEXT AS highlight
+FROM (
+    SELECT products.*, query,
+        (ts_rank(search_vector, query) + word_similarity(sqlc.arg('query')::TEXT, name))::FLOAT8 AS rank
+    FROM products, websearch_to_tsquery('english', sqlc.arg('query')::TEXT) AS query
+    WHERE deleted_at IS NULL
+        AND (search_vector @@ query OR sqlc.arg('query')::TEXT <% name)
+) AS matches
+WHERE sqlc.narg('after_rank')::FLOAT8 IS NULL
+    OR rank < sqlc.narg('after_rank')
+    OR (rank = sqlc.narg('after_rank') AND id > sqlc.narg('after_id')::BIGINT)
+ORDER BY rank DESC, id ASC
+LIMIT sqlc.arg('first');
+
+-- name: ListDeletedProducts :many
+SELECT * FROM products
+WHERE deleted_at IS NOT NULL
+    AND (sqlc.narg('user_id')::BIGINT IS NULL OR user_id = sqlc.narg('user_id'))
+ORDER BY deleted_at DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountDeletedProducts :one
+SELECT COUNT(*) FROM products
+WHERE deleted_at IS NOT NULL
+    AND (sqlc.narg('user_id')::BIGINT IS NULL OR user_id = sqlc.narg('user_id'));
+
+-- name: PurgeDeletedProducts :execrows
+DELETE FROM products
+WHERE deleted_at < sqlc.arg('deleted_before')::TIMESTAMPTZ;
@@ -0,0 +1,41 @@
+-- name: ListRoles :many
+SELECT * FROM roles
+ORDER BY id;
+
+-- name: ListRolePermissions :many
+SELECT roles.name AS role, permissions.name AS permission
+FROM role_permissions
+JOIN roles ON roles.id = role_permissions.role_id
+JOIN permissions ON permissions.id = role_permissions.permission_id
+ORDER BY roles.id, permissions.id;
+
+-- name: GetUserRoles :many
+SELECT roles.name
+FROM user_roles
+JOIN roles ON roles.id = user_roles.role_id
+WHERE user_roles.user_id = $1
+ORDER BY roles.id;
+
+-- name: GetUserPermissions :many
+SELECT DISTINCT permissions.name
+FROM user_roles
+JOIN role_permissions ON role_permissions.role_id = user_roles.role_id
+JOIN permissions ON permissions.id = role_permissions.permission_id
+WHERE user_roles.user_id = $1
+ORDER BY permissions.name;
+
+-- name: AssignRole :execrows
+INSERT INTO user_roles (user_id, role_id)
+SELECT sqlc.arg('user_id')::BIGINT, id FROM roles
+WHERE name = sqlc.arg('role')
+ON CONFLICT DO NOTHING;
+
+-- name: RevokeRole :execrows
+DELETE FROM user_roles
+WHERE user_id = sqlc.arg('user_id')
+AND role_id = (SELECT id FROM roles WHERE name = sqlc.arg('role'));
+
+-- name: CountRoleUsers :one
+SELECT COUNT(*) FROM user_roles
+JOIN roles ON roles.id = user_roles.role_id
+WHERE roles.name = $1;
@@ -0,0 +1,39 @@
+-- name: CreateTag :one
+INSERT INTO tags (
+    name
+) VALUES (
+    $1
+)
+RETURNING *;
+
+-- name: ListTags :many
+SELECT * FROM tags
+ORDER BY name;
+
+-- name: DeleteTag :one
+DELETE FROM tags
+WHERE id = $1
+RETURNING id;
+
+-- name: UpsertTags :many
+INSERT INTO tags (name)
+SELECT UNNEST(@names::TEXT[])
+ON CONFLICT (name) DO UPDATE
+SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: DeleteProductTags :exec
+DELETE FROM product_tags
+WHERE product_id = $1;
+
+-- name: AddProductTags :exec
+INSERT INTO product_tags (product_id, tag_id)
+SELECT sqlc.arg('product_id')::BIGINT, UNNEST(sqlc.arg('tag_ids')::BIGINT[])
+ON CONFLICT DO NOTHING;
+
+-- name: GetBatchProductTags :many
+SELECT pt.product_id, t.id, t.name, t.created_at
+FROM product_tags pt
+JOIN tags t ON t.id = pt.tag_id
+WHERE pt.product_id = ANY(@product_ids::BIGINT[])
+ORDER BY pt.product_id, t.name;
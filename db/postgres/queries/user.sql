@@ -13,4 +13,63 @@ LIMIT 1;
 
 -- name: GetBatchUsers :many
 SELECT * FROM users
-WHERE id = ANY(@ids::BIGINT[]);
\ No newline at end of file
+WHERE id = ANY(@ids::BIGINT[]);
+
+-- name: PatchUser :one
+UPDATE users
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    email = COALESCE(sqlc.narg('email'), email),
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id')
+    AND (sqlc.narg('expected_version')::BIGINT IS NULL OR version = sqlc.narg('expected_version'))
+RETURNING *;
+
+-- name: UpdateUser :one
+UPDATE users
+SET
+    name = sqlc.arg('name'),
+    email = sqlc.arg('email'),
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id')
+    AND (sqlc.narg('expected_version')::BIGINT IS NULL OR version = sqlc.narg('expected_version'))
+RETURNING *;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE sqlc.narg('after_id')::BIGINT IS NULL OR id > sqlc.narg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('first');
+
+-- name: DeleteUser :one
+DELETE FROM users
+WHERE id = $1
+RETURNING id;
+
+-- name: CountUserProducts :one
+SELECT COUNT(*) FROM products
+WHERE user_id = $1;
+
+-- name: DeleteUserProducts :execrows
+DELETE FROM products
+WHERE user_id = $1;
+
+-- name: ReassignUserProducts :execrows
+UPDATE products
+SET
+    user_id = sqlc.arg('to_user_id'),
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = sqlc.arg('from_user_id');
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE LOWER(email COLLATE "C") = LOWER(sqlc.arg('email')::TEXT COLLATE "C")
+LIMIT 1;
+
+-- name: SetUserPassword :exec
+UPDATE users
+SET password_hash = $1
+WHERE id = $2;
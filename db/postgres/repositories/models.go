@@ -6,19 +6,164 @@ package repositories
 
 import (
 	"database/sql"
+	"time"
 )
 
+type APIKey struct {
+	ID         int64        `json:"id"`
+	UserID     int64        `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"key_hash"`
+	Scopes     string       `json:"scopes"`
+	ExpiresAt  sql.NullTime `json:"expires_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type Category struct {
+	ID        int64         `json:"id"`
+	Name      string        `json:"name"`
+	ParentID  sql.NullInt64 `json:"parent_id"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type ExchangeRate struct {
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Inventory struct {
+	ProductID int64     `json:"product_id"`
+	OnHand    int64     `json:"on_hand"`
+	Reserved  int64     `json:"reserved"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrderItem struct {
+	ID          int64         `json:"id"`
+	OrderID     int64         `json:"order_id"`
+	ProductID   sql.NullInt64 `json:"product_id"`
+	ProductName string        `json:"product_name"`
+	UnitPrice   int64         `json:"unit_price"`
+	Quantity    int64         `json:"quantity"`
+}
+
+type Order struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Status    string    `json:"status"`
+	Currency  string    `json:"currency"`
+	Total     int64     `json:"total"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Payment struct {
+	ID        int64         `json:"id"`
+	OrderID   sql.NullInt64 `json:"order_id"`
+	ProductID sql.NullInt64 `json:"product_id"`
+	Provider  string        `json:"provider"`
+	IntentID  string        `json:"intent_id"`
+	Status    string        `json:"status"`
+	Amount    int64         `json:"amount"`
+	Currency  string        `json:"currency"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	UserID    sql.NullInt64 `json:"user_id"`
+}
+
+type Permission struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ProductCategory struct {
+	ProductID  int64 `json:"product_id"`
+	CategoryID int64 `json:"category_id"`
+}
+
+type ProductTag struct {
+	ProductID int64 `json:"product_id"`
+	TagID     int64 `json:"tag_id"`
+}
+
 type Product struct {
+	ID           int64        `json:"id"`
+	Name         string       `json:"name"`
+	Price        int64        `json:"price"`
+	UserID       int64        `json:"user_id"`
+	CreatedAt    sql.NullTime `json:"created_at"`
+	SearchVector interface{}  `json:"search_vector"`
+	DeletedAt    sql.NullTime `json:"deleted_at"`
+	Version      int64        `json:"version"`
+	UpdatedAt    sql.NullTime `json:"updated_at"`
+	Currency     string       `json:"currency"`
+}
+
+type RefreshToken struct {
 	ID        int64        `json:"id"`
-	Name      string       `json:"name"`
-	Price     int64        `json:"price"`
 	UserID    int64        `json:"user_id"`
-	CreatedAt sql.NullTime `json:"created_at"`
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type Reservation struct {
+	ID        int64         `json:"id"`
+	ProductID int64         `json:"product_id"`
+	Quantity  int64         `json:"quantity"`
+	Status    string        `json:"status"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	UserID    sql.NullInt64 `json:"user_id"`
+}
+
+type RolePermission struct {
+	RoleID       int64 `json:"role_id"`
+	PermissionID int64 `json:"permission_id"`
+}
+
+type Role struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type StockAdjustment struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"product_id"`
+	Delta     int64     `json:"delta"`
+	Reason    string    `json:"reason"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type User struct {
-	ID        int64        `json:"id"`
-	Name      string       `json:"name"`
-	Email     string       `json:"email"`
-	CreatedAt sql.NullTime `json:"created_at"`
+	ID           int64          `json:"id"`
+	Name         string         `json:"name"`
+	Email        string         `json:"email"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	Version      int64          `json:"version"`
+	UpdatedAt    sql.NullTime   `json:"updated_at"`
+	PasswordHash sql.NullString `json:"password_hash"`
+}
+
+type UserRole struct {
+	UserID    int64     `json:"user_id"`
+	RoleID    int64     `json:"role_id"`
+	CreatedAt time.Time `json:"created_at"`
 }
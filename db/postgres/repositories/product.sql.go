@@ -8,26 +8,184 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+const bulkCreateProducts = `-- name: BulkCreateProducts :many
+INSERT INTO products (user_id, name, price, currency)
+SELECT user_id, name, price, currency
+FROM unnest(
+    $1::BIGINT[],
+    $2::TEXT[],
+    $3::BIGINT[],
+    $4::TEXT[]
+) WITH ORDINALITY AS items(user_id, name, price, currency, position)
+ORDER BY position
+RETURNING id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+`
+
+type BulkCreateProductsParams struct {
+	UserIds    []int64  `json:"user_ids"`
+	Names      []string `json:"names"`
+	Prices     []int64  `json:"prices"`
+	Currencies []string `json:"currencies"`
+}
+
+func (q *Queries) BulkCreateProducts(ctx context.Context, db DBTX, arg BulkCreateProductsParams) ([]Product, error) {
+	rows, err := db.QueryContext(ctx, bulkCreateProducts, pq.Array(arg.UserIds), pq.Array(arg.Names), pq.Array(arg.Prices), pq.Array(arg.Currencies))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const bulkDeleteProducts = `-- name: BulkDeleteProducts :many
+DELETE FROM products
+WHERE id = ANY($1::BIGINT[])
+RETURNING id
+`
+
+func (q *Queries) BulkDeleteProducts(ctx context.Context, db DBTX, ids []int64) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, bulkDeleteProducts, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const bulkSoftDeleteProducts = `-- name: BulkSoftDeleteProducts :many
+UPDATE products
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ANY($1::BIGINT[]) AND deleted_at IS NULL
+RETURNING id
+`
+
+func (q *Queries) BulkSoftDeleteProducts(ctx context.Context, db DBTX, ids []int64) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, bulkSoftDeleteProducts, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countDeletedProducts = `-- name: CountDeletedProducts :one
+SELECT COUNT(*) FROM products
+WHERE deleted_at IS NOT NULL
+    AND ($1::BIGINT IS NULL OR user_id = $1)
+`
+
+func (q *Queries) CountDeletedProducts(ctx context.Context, db DBTX, userID sql.NullInt64) (int64, error) {
+	row := db.QueryRowContext(ctx, countDeletedProducts, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countProducts = `-- name: CountProducts :one
+SELECT COUNT(*) FROM products
+WHERE deleted_at IS NULL
+    AND ($1::BIGINT IS NULL OR user_id = $1)
+    AND ($2::TEXT IS NULL OR name ILIKE '%' || $2 || '%' ESCAPE '\')
+    AND ($3::BIGINT IS NULL OR price >= $3)
+    AND ($4::BIGINT IS NULL OR price <= $4)
+    AND ($5::TIMESTAMPTZ IS NULL OR created_at >= $5)
+    AND ($6::TIMESTAMPTZ IS NULL OR created_at < $6)
+`
+
+type CountProductsParams struct {
+	UserID        sql.NullInt64  `json:"user_id"`
+	Name          sql.NullString `json:"name"`
+	MinPrice      sql.NullInt64  `json:"min_price"`
+	MaxPrice      sql.NullInt64  `json:"max_price"`
+	CreatedAfter  sql.NullTime   `json:"created_after"`
+	CreatedBefore sql.NullTime   `json:"created_before"`
+}
+
+func (q *Queries) CountProducts(ctx context.Context, db DBTX, arg CountProductsParams) (int64, error) {
+	row := db.QueryRowContext(ctx, countProducts, arg.UserID, arg.Name, arg.MinPrice, arg.MaxPrice, arg.CreatedAfter, arg.CreatedBefore)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO products(
     user_id,
     name,
-    price
+    price,
+    currency
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, name, price, user_id, created_at
+    $1, $2, $3, $4
+) RETURNING id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
 `
 
 type CreateProductParams struct {
-	UserID int64  `json:"user_id"`
-	Name   string `json:"name"`
-	Price  int64  `json:"price"`
+	UserID   int64  `json:"user_id"`
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Currency string `json:"currency"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, db DBTX, arg CreateProductParams) (Product, error) {
-	row := db.QueryRowContext(ctx, createProduct, arg.UserID, arg.Name, arg.Price)
+	row := db.QueryRowContext(ctx, createProduct, arg.UserID, arg.Name, arg.Price, arg.Currency)
 	var i Product
 	err := row.Scan(
 		&i.ID,
@@ -35,6 +193,11 @@ func (q *Queries) CreateProduct(ctx context.Context, db DBTX, arg CreateProductP
 		&i.Price,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.SearchVector,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -51,9 +214,202 @@ func (q *Queries) DeleteProduct(ctx context.Context, db DBTX, id int64) (int64,
 	return id, err
 }
 
+const getBatchProducts = `-- name: GetBatchProducts :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency FROM products
+WHERE id = ANY($1::BIGINT[]) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetBatchProducts(ctx context.Context, db DBTX, ids []int64) ([]Product, error) {
+	rows, err := db.QueryContext(ctx, getBatchProducts, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBatchUserProducts = `-- name: GetBatchUserProducts :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+FROM (
+    SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC, id DESC) AS position
+    FROM products
+    WHERE user_id = ANY($1::BIGINT[])
+        AND deleted_at IS NULL
+        AND (
+            $2::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = $2
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            $3::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = $3
+            )
+        )
+        AND (
+            $4::TIMESTAMPTZ IS NULL
+            OR (created_at, id) < ($4, $5::BIGINT)
+        )
+) AS ranked
+WHERE position <= $6
+ORDER BY user_id, created_at DESC, id DESC
+`
+
+type GetBatchUserProductsParams struct {
+	UserIds        []int64        `json:"user_ids"`
+	CategoryID     sql.NullInt64  `json:"category_id"`
+	Tag            sql.NullString `json:"tag"`
+	AfterCreatedAt sql.NullTime   `json:"after_created_at"`
+	AfterID        sql.NullInt64  `json:"after_id"`
+	First          int64          `json:"first"`
+}
+
+func (q *Queries) GetBatchUserProducts(ctx context.Context, db DBTX, arg GetBatchUserProductsParams) ([]Product, error) {
+	rows, err := db.QueryContext(ctx, getBatchUserProducts, pq.Array(arg.UserIds), arg.CategoryID, arg.Tag, arg.AfterCreatedAt, arg.AfterID, arg.First)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBatchUserProductsBefore = `-- name: GetBatchUserProductsBefore :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+FROM (
+    SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at ASC, id ASC) AS position
+    FROM products
+    WHERE user_id = ANY($1::BIGINT[])
+        AND deleted_at IS NULL
+        AND (
+            $2::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = $2
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            $3::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = $3
+            )
+        )
+        AND (
+            $4::TIMESTAMPTZ IS NULL
+            OR (created_at, id) > ($4, $5::BIGINT)
+        )
+) AS ranked
+WHERE position <= $6
+ORDER BY user_id, created_at ASC, id ASC
+`
+
+type GetBatchUserProductsBeforeParams struct {
+	UserIds         []int64        `json:"user_ids"`
+	CategoryID      sql.NullInt64  `json:"category_id"`
+	Tag             sql.NullString `json:"tag"`
+	BeforeCreatedAt sql.NullTime   `json:"before_created_at"`
+	BeforeID        sql.NullInt64  `json:"before_id"`
+	Last            int64          `json:"last"`
+}
+
+func (q *Queries) GetBatchUserProductsBefore(ctx context.Context, db DBTX, arg GetBatchUserProductsBeforeParams) ([]Product, error) {
+	rows, err := db.QueryContext(ctx, getBatchUserProductsBefore, pq.Array(arg.UserIds), arg.CategoryID, arg.Tag, arg.BeforeCreatedAt, arg.BeforeID, arg.Last)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProduct = `-- name: GetProduct :one
-SELECT id, name, price, user_id, created_at FROM products
-WHERE id = $1
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency FROM products
+WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1
 `
 
@@ -66,26 +422,94 @@ func (q *Queries) GetProduct(ctx context.Context, db DBTX, id int64) (Product, e
 		&i.Price,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.SearchVector,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
 
+const getProductOwners = `-- name: GetProductOwners :many
+-- trashed products are included, they can still be restored or deleted.
+SELECT id, user_id FROM products
+WHERE id = ANY($1::BIGINT[])
+`
+
+type GetProductOwnersRow struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+}
+
+func (q *Queries) GetProductOwners(ctx context.Context, db DBTX, ids []int64) ([]GetProductOwnersRow, error) {
+	rows, err := db.QueryContext(ctx, getProductOwners, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProductOwnersRow
+	for rows.Next() {
+		var i GetProductOwnersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserProducts = `-- name: GetUserProducts :many
-SELECT id, name, price, user_id, created_at
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
 FROM products
-WHERE user_id = $1 AND created_at < $2
-ORDER BY created_at DESC
-LIMIT $3
+WHERE user_id = $1
+    AND deleted_at IS NULL
+    AND (
+        $2::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = $2
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        $3::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = $3
+        )
+    )
+    AND (
+        $4::TIMESTAMPTZ IS NULL
+        OR (created_at, id) < ($4, $5::BIGINT)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT $6
 `
 
 type GetUserProductsParams struct {
-	UserID int64        `json:"user_id"`
-	After  sql.NullTime `json:"after"`
-	First  int32        `json:"first"`
+	UserID         int64          `json:"user_id"`
+	CategoryID     sql.NullInt64  `json:"category_id"`
+	Tag            sql.NullString `json:"tag"`
+	AfterCreatedAt sql.NullTime   `json:"after_created_at"`
+	AfterID        sql.NullInt64  `json:"after_id"`
+	First          int32          `json:"first"`
 }
 
 func (q *Queries) GetUserProducts(ctx context.Context, db DBTX, arg GetUserProductsParams) ([]Product, error) {
-	rows, err := db.QueryContext(ctx, getUserProducts, arg.UserID, arg.After, arg.First)
+	rows, err := db.QueryContext(ctx, getUserProducts, arg.UserID, arg.CategoryID, arg.Tag, arg.AfterCreatedAt, arg.AfterID, arg.First)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +523,11 @@ func (q *Queries) GetUserProducts(ctx context.Context, db DBTX, arg GetUserProdu
 			&i.Price,
 			&i.UserID,
 			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
 		); err != nil {
 			return nil, err
 		}
@@ -113,22 +542,166 @@ func (q *Queries) GetUserProducts(ctx context.Context, db DBTX, arg GetUserProdu
 	return items, nil
 }
 
-const listProducts = `-- name: ListProducts :many
-SELECT id, name, price, user_id, created_at FROM products
+const getUserProductsBefore = `-- name: GetUserProductsBefore :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+FROM products
 WHERE user_id = $1
-ORDER BY id
+    AND deleted_at IS NULL
+    AND (
+        $2::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = $2
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        $3::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = $3
+        )
+    )
+    AND (
+        $4::TIMESTAMPTZ IS NULL
+        OR (created_at, id) > ($4, $5::BIGINT)
+    )
+ORDER BY created_at ASC, id ASC
+LIMIT $6
+`
+
+type GetUserProductsBeforeParams struct {
+	UserID          int64          `json:"user_id"`
+	CategoryID      sql.NullInt64  `json:"category_id"`
+	Tag             sql.NullString `json:"tag"`
+	BeforeCreatedAt sql.NullTime   `json:"before_created_at"`
+	BeforeID        sql.NullInt64  `json:"before_id"`
+	Last            int32          `json:"last"`
+}
+
+func (q *Queries) GetUserProductsBefore(ctx context.Context, db DBTX, arg GetUserProductsBeforeParams) ([]Product, error) {
+	rows, err := db.QueryContext(ctx, getUserProductsBefore, arg.UserID, arg.CategoryID, arg.Tag, arg.BeforeCreatedAt, arg.BeforeID, arg.Last)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedProducts = `-- name: ListDeletedProducts :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency FROM products
+WHERE deleted_at IS NOT NULL
+    AND ($1::BIGINT IS NULL OR user_id = $1)
+ORDER BY deleted_at DESC, id DESC
 LIMIT $2
 OFFSET $3
 `
 
+type ListDeletedProductsParams struct {
+	UserID sql.NullInt64 `json:"user_id"`
+	Limit  int32         `json:"limit"`
+	Offset int32         `json:"offset"`
+}
+
+func (q *Queries) ListDeletedProducts(ctx context.Context, db DBTX, arg ListDeletedProductsParams) ([]Product, error) {
+	rows, err := db.QueryContext(ctx, listDeletedProducts, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProducts = `-- name: ListProducts :many
+SELECT id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency FROM products
+WHERE deleted_at IS NULL
+    AND ($1::BIGINT IS NULL OR user_id = $1)
+    AND ($2::TEXT IS NULL OR name ILIKE '%' || $2 || '%' ESCAPE '\')
+    AND ($3::BIGINT IS NULL OR price >= $3)
+    AND ($4::BIGINT IS NULL OR price <= $4)
+    AND ($5::TIMESTAMPTZ IS NULL OR created_at >= $5)
+    AND ($6::TIMESTAMPTZ IS NULL OR created_at < $6)
+ORDER BY
+    CASE WHEN $7::TEXT = 'name' AND NOT $8::BOOLEAN THEN name END ASC,
+    CASE WHEN $7::TEXT = 'name' AND $8::BOOLEAN THEN name END DESC,
+    CASE WHEN $7::TEXT = 'price' AND NOT $8::BOOLEAN THEN price END ASC,
+    CASE WHEN $7::TEXT = 'price' AND $8::BOOLEAN THEN price END DESC,
+    CASE WHEN $7::TEXT = 'created_at' AND NOT $8::BOOLEAN THEN created_at END ASC,
+    CASE WHEN $7::TEXT = 'created_at' AND $8::BOOLEAN THEN created_at END DESC,
+    CASE WHEN $8::BOOLEAN THEN id END DESC,
+    id ASC
+LIMIT $9
+OFFSET $10
+`
+
 type ListProductsParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID        sql.NullInt64  `json:"user_id"`
+	Name          sql.NullString `json:"name"`
+	MinPrice      sql.NullInt64  `json:"min_price"`
+	MaxPrice      sql.NullInt64  `json:"max_price"`
+	CreatedAfter  sql.NullTime   `json:"created_after"`
+	CreatedBefore sql.NullTime   `json:"created_before"`
+	OrderBy       string         `json:"order_by"`
+	SortDesc      bool           `json:"sort_desc"`
+	Limit         int32          `json:"limit"`
+	Offset        int32          `json:"offset"`
 }
 
 func (q *Queries) ListProducts(ctx context.Context, db DBTX, arg ListProductsParams) ([]Product, error) {
-	rows, err := db.QueryContext(ctx, listProducts, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := db.QueryContext(ctx, listProducts, arg.UserID, arg.Name, arg.MinPrice, arg.MaxPrice, arg.CreatedAfter, arg.CreatedBefore, arg.OrderBy, arg.SortDesc, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -142,6 +715,163 @@ func (q *Queries) ListProducts(ctx context.Context, db DBTX, arg ListProductsPar
 			&i.Price,
 			&i.UserID,
 			&i.CreatedAt,
+			&i.SearchVector,
+			&i.DeletedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const patchProduct = `-- name: PatchProduct :one
+UPDATE products
+SET
+    name = COALESCE($1, name),
+    price = COALESCE($2, price),
+    currency = COALESCE($3, currency),
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $4 AND deleted_at IS NULL
+    AND ($5::BIGINT IS NULL OR version = $5)
+RETURNING id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+`
+
+type PatchProductParams struct {
+	Name            sql.NullString `json:"name"`
+	Price           sql.NullInt64  `json:"price"`
+	Currency        sql.NullString `json:"currency"`
+	ID              int64          `json:"id"`
+	ExpectedVersion sql.NullInt64  `json:"expected_version"`
+}
+
+func (q *Queries) PatchProduct(ctx context.Context, db DBTX, arg PatchProductParams) (Product, error) {
+	row := db.QueryRowContext(ctx, patchProduct, arg.Name, arg.Price, arg.Currency, arg.ID, arg.ExpectedVersion)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Price,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.SearchVector,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const purgeDeletedProducts = `-- name: PurgeDeletedProducts :execrows
+DELETE FROM products
+WHERE deleted_at < $1::TIMESTAMPTZ
+`
+
+func (q *Queries) PurgeDeletedProducts(ctx context.Context, db DBTX, deletedBefore time.Time) (int64, error) {
+	result, err := db.ExecContext(ctx, purgeDeletedProducts, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreProduct = `-- name: RestoreProduct :one
+UPDATE products
+SET
+    deleted_at = NULL,
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
+`
+
+func (q *Queries) RestoreProduct(ctx context.Context, db DBTX, id int64) (Product, error) {
+	row := db.QueryRowContext(ctx, restoreProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Price,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.SearchVector,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const searchProducts = `-- name: SearchProducts :many
+-- names are HTML escaped before ts_headline adds its marks.
+SELECT id, name, price, currency, user_id, created_at, version, updated_at, rank,
+    ts_headline('english', replace(replace(replace(name, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), query, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::TEXT AS highlight
+FROM (
+    SELECT products.*, query,
+        (ts_rank(search_vector, query) + word_similarity($1::TEXT, name))::FLOAT8 AS rank
+    FROM products, websearch_to_tsquery('english', $1::TEXT) AS query
+    WHERE deleted_at IS NULL
+        AND (search_vector @@ query OR $1::TEXT <% name)
+) AS matches
+WHERE $2::FLOAT8 IS NULL
+    OR rank < $2
+    OR (rank = $2 AND id > $3::BIGINT)
+ORDER BY rank DESC, id ASC
+LIMIT $4
+`
+
+type SearchProductsParams struct {
+	Query     string          `json:"query"`
+	AfterRank sql.NullFloat64 `json:"after_rank"`
+	AfterID   sql.NullInt64   `json:"after_id"`
+	First     int32           `json:"first"`
+}
+
+type SearchProductsRow struct {
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	Price     int64        `json:"price"`
+	Currency  string       `json:"currency"`
+	UserID    int64        `json:"user_id"`
+	CreatedAt sql.NullTime `json:"created_at"`
+	Version   int64        `json:"version"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	Rank      float64      `json:"rank"`
+	Highlight string       `json:"highlight"`
+}
+
+func (q *Queries) SearchProducts(ctx context.Context, db DBTX, arg SearchProductsParams) ([]SearchProductsRow, error) {
+	rows, err := db.QueryContext(ctx, searchProducts, arg.Query, arg.AfterRank, arg.AfterID, arg.First)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchProductsRow
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.Currency,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.Rank,
+			&i.Highlight,
 		); err != nil {
 			return nil, err
 		}
@@ -156,23 +886,42 @@ func (q *Queries) ListProducts(ctx context.Context, db DBTX, arg ListProductsPar
 	return items, nil
 }
 
+const softDeleteProduct = `-- name: SoftDeleteProduct :one
+UPDATE products
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id
+`
+
+func (q *Queries) SoftDeleteProduct(ctx context.Context, db DBTX, id int64) (int64, error) {
+	row := db.QueryRowContext(ctx, softDeleteProduct, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE products
 SET
-    name = $2,
-    price = $3
-WHERE id = $1
-RETURNING id, name, price, user_id, created_at
+    name = $1,
+    price = $2,
+    currency = $3,
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $4 AND deleted_at IS NULL
+    AND ($5::BIGINT IS NULL OR version = $5)
+RETURNING id, name, price, user_id, created_at, search_vector, deleted_at, version, updated_at, currency
 `
 
 type UpdateProductParams struct {
-	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Price int64  `json:"price"`
+	Name            string        `json:"name"`
+	Price           int64         `json:"price"`
+	Currency        string        `json:"currency"`
+	ID              int64         `json:"id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, db DBTX, arg UpdateProductParams) (Product, error) {
-	row := db.QueryRowContext(ctx, updateProduct, arg.ID, arg.Name, arg.Price)
+	row := db.QueryRowContext(ctx, updateProduct, arg.Name, arg.Price, arg.Currency, arg.ID, arg.ExpectedVersion)
 	var i Product
 	err := row.Scan(
 		&i.ID,
@@ -180,6 +929,11 @@ func (q *Queries) UpdateProduct(ctx context.Context, db DBTX, arg UpdateProductP
 		&i.Price,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.SearchVector,
+		&i.DeletedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.Currency,
 	)
 	return i, err
 }
@@ -188,18 +942,198 @@ const userProductsHasNextPage = `-- name: UserProductsHasNextPage :one
 SELECT EXISTS(
     SELECT 1
     FROM products
-    WHERE user_id = $1 AND created_at < $2
+    WHERE user_id = $1
+        AND deleted_at IS NULL
+        AND (
+            $2::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = $2
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            $3::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = $3
+            )
+        )
+        AND (created_at, id) < ($4::TIMESTAMPTZ, $5::BIGINT)
 )
 `
 
 type UserProductsHasNextPageParams struct {
-	UserID int64        `json:"user_id"`
-	After  sql.NullTime `json:"after"`
+	UserID     int64          `json:"user_id"`
+	CategoryID sql.NullInt64  `json:"category_id"`
+	Tag        sql.NullString `json:"tag"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ID         int64          `json:"id"`
 }
 
 func (q *Queries) UserProductsHasNextPage(ctx context.Context, db DBTX, arg UserProductsHasNextPageParams) (bool, error) {
-	row := db.QueryRowContext(ctx, userProductsHasNextPage, arg.UserID, arg.After)
+	row := db.QueryRowContext(ctx, userProductsHasNextPage, arg.UserID, arg.CategoryID, arg.Tag, arg.CreatedAt, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const userProductsHasPreviousPage = `-- name: UserProductsHasPreviousPage :one
+SELECT EXISTS(
+    SELECT 1
+    FROM products
+    WHERE user_id = $1
+        AND deleted_at IS NULL
+        AND (
+            $2::BIGINT IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = $2
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            $3::TEXT IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = $3
+            )
+        )
+        AND (created_at, id) > ($4::TIMESTAMPTZ, $5::BIGINT)
+)
+`
+
+type UserProductsHasPreviousPageParams struct {
+	UserID     int64          `json:"user_id"`
+	CategoryID sql.NullInt64  `json:"category_id"`
+	Tag        sql.NullString `json:"tag"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ID         int64          `json:"id"`
+}
+
+func (q *Queries) UserProductsHasPreviousPage(ctx context.Context, db DBTX, arg UserProductsHasPreviousPageParams) (bool, error) {
+	row := db.QueryRowContext(ctx, userProductsHasPreviousPage, arg.UserID, arg.CategoryID, arg.Tag, arg.CreatedAt, arg.ID)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
 }
+
+const usersWithProductsNotNewerThan = `-- name: UsersWithProductsNotNewerThan :many
+SELECT DISTINCT user_id
+FROM products
+WHERE user_id = ANY($1::BIGINT[])
+    AND deleted_at IS NULL
+    AND (
+        $2::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = $2
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        $3::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = $3
+        )
+    )
+    AND (created_at, id) <= ($4::TIMESTAMPTZ, $5::BIGINT)
+`
+
+type UsersWithProductsNotNewerThanParams struct {
+	UserIds    []int64        `json:"user_ids"`
+	CategoryID sql.NullInt64  `json:"category_id"`
+	Tag        sql.NullString `json:"tag"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ID         int64          `json:"id"`
+}
+
+func (q *Queries) UsersWithProductsNotNewerThan(ctx context.Context, db DBTX, arg UsersWithProductsNotNewerThanParams) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, usersWithProductsNotNewerThan, pq.Array(arg.UserIds), arg.CategoryID, arg.Tag, arg.CreatedAt, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var user_id int64
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const usersWithProductsNotOlderThan = `-- name: UsersWithProductsNotOlderThan :many
+SELECT DISTINCT user_id
+FROM products
+WHERE user_id = ANY($1::BIGINT[])
+    AND deleted_at IS NULL
+    AND (
+        $2::BIGINT IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = $2
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        $3::TEXT IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = $3
+        )
+    )
+    AND (created_at, id) >= ($4::TIMESTAMPTZ, $5::BIGINT)
+`
+
+type UsersWithProductsNotOlderThanParams struct {
+	UserIds    []int64        `json:"user_ids"`
+	CategoryID sql.NullInt64  `json:"category_id"`
+	Tag        sql.NullString `json:"tag"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ID         int64          `json:"id"`
+}
+
+func (q *Queries) UsersWithProductsNotOlderThan(ctx context.Context, db DBTX, arg UsersWithProductsNotOlderThanParams) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, usersWithProductsNotOlderThan, pq.Array(arg.UserIds), arg.CategoryID, arg.Tag, arg.CreatedAt, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var user_id int64
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"sort"
 	"testing"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/require"
@@ -27,23 +30,98 @@ func TestGetProduct(t *testing.T) {
 func TestListProduct(t *testing.T) {
 	prod := createNewProduct(t)
 	arg := ListProductsParams{
-		Limit:  5,
-		Offset: 0,
-		UserID: prod.UserID,
+		UserID:  sql.NullInt64{Int64: prod.UserID, Valid: true},
+		OrderBy: "id",
+		Limit:   5,
+		Offset:  0,
 	}
 
 	prods, err := testRepo.ListProducts(context.Background(), testDB, arg)
 	require.NoError(t, err)
-	require.NotNil(t, prods)
-	require.GreaterOrEqual(t, len(prods), 1)
+	require.Len(t, prods, 1)
+	require.Equal(t, prod.ID, prods[0].ID)
+}
+
+func TestCountProducts(t *testing.T) {
+	prod := createNewProduct(t)
+	arg := CountProductsParams{
+		UserID: sql.NullInt64{Int64: prod.UserID, Valid: true},
+	}
+
+	total, err := testRepo.CountProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+}
+
+func TestGetUserProducts(t *testing.T) {
+	prod := createNewProduct(t)
+	arg := GetUserProductsParams{
+		UserID: prod.UserID,
+		First:  5,
+	}
+
+	prods, err := testRepo.GetUserProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Len(t, prods, 1)
+
+	arg.AfterCreatedAt = prod.CreatedAt
+	arg.AfterID = sql.NullInt64{Int64: prod.ID, Valid: true}
+	prods, err = testRepo.GetUserProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Empty(t, prods)
+
+	hasPrevious, err := testRepo.UserProductsHasPreviousPage(context.Background(), testDB, UserProductsHasPreviousPageParams{
+		UserID:    prod.UserID,
+		CreatedAt: prod.CreatedAt.Time,
+		ID:        prod.ID - 1,
+	})
+	require.NoError(t, err)
+	require.True(t, hasPrevious)
+}
+
+func TestSearchProducts(t *testing.T) {
+	prod := createNewProduct(t)
+	arg := SearchProductsParams{
+		Query: "test produc",
+		First: 100,
+	}
+
+	// the cut off word is not a lexeme of the name, only trigrams match it
+	results, err := testRepo.SearchProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+
+	var found *SearchProductsRow
+	for i := range results {
+		if results[i].ID == prod.ID {
+			found = &results[i]
+		}
+	}
+	require.NotNil(t, found)
+	require.Positive(t, found.Rank)
+
+	arg.AfterRank = sql.NullFloat64{Float64: found.Rank, Valid: true}
+	arg.AfterID = sql.NullInt64{Int64: prod.ID, Valid: true}
+	results, err = testRepo.SearchProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	for _, result := range results {
+		require.NotEqual(t, prod.ID, result.ID)
+	}
+
+	// stemming matches the plural
+	arg = SearchProductsParams{Query: "products", First: 1}
+	results, err = testRepo.SearchProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Contains(t, results[0].Highlight, "<mark>product</mark>")
 }
 
 func TestUpdateProduct(t *testing.T) {
 	prod := createNewProduct(t)
 	arg := UpdateProductParams{
-		Name:  "new product",
-		ID:    prod.ID,
-		Price: 555,
+		Name:     "new product",
+		ID:       prod.ID,
+		Price:    555,
+		Currency: prod.Currency,
 	}
 
 	newProd, err := testRepo.UpdateProduct(context.Background(), testDB, arg)
@@ -51,6 +129,35 @@ func TestUpdateProduct(t *testing.T) {
 	require.NotEmpty(t, newProd)
 	require.Equal(t, prod.ID, newProd.ID)
 	require.Equal(t, "new product", newProd.Name)
+	require.Equal(t, prod.Version+1, newProd.Version)
+
+	// a stale expected version leaves the product alone
+	arg.ExpectedVersion = sql.NullInt64{Int64: prod.Version, Valid: true}
+	_, err = testRepo.UpdateProduct(context.Background(), testDB, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.ExpectedVersion.Int64 = newProd.Version
+	newProd, err = testRepo.UpdateProduct(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Equal(t, prod.Version+2, newProd.Version)
+}
+
+func TestPatchProduct(t *testing.T) {
+	prod := createNewProduct(t)
+	arg := PatchProductParams{
+		Price: sql.NullInt64{Int64: 555, Valid: true},
+		ID:    prod.ID,
+	}
+
+	patched, err := testRepo.PatchProduct(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Equal(t, prod.Name, patched.Name)
+	require.Equal(t, int64(555), patched.Price)
+	require.Equal(t, prod.Version+1, patched.Version)
+
+	arg.ExpectedVersion = sql.NullInt64{Int64: prod.Version, Valid: true}
+	_, err = testRepo.PatchProduct(context.Background(), testDB, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
 }
 
 func TestDeleteProduct(t *testing.T) {
@@ -60,12 +167,97 @@ func TestDeleteProduct(t *testing.T) {
 	require.Equal(t, prod.ID, id)
 }
 
+func TestSoftDeleteProduct(t *testing.T) {
+	prod := createNewProduct(t)
+	id, err := testRepo.SoftDeleteProduct(context.Background(), testDB, prod.ID)
+	require.NoError(t, err)
+	require.Equal(t, prod.ID, id)
+
+	_, err = testRepo.GetProduct(context.Background(), testDB, prod.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testRepo.SoftDeleteProduct(context.Background(), testDB, prod.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := ListDeletedProductsParams{
+		UserID: sql.NullInt64{Int64: prod.UserID, Valid: true},
+		Limit:  5,
+	}
+	trash, err := testRepo.ListDeletedProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, prod.ID, trash[0].ID)
+	require.True(t, trash[0].DeletedAt.Valid)
+
+	restored, err := testRepo.RestoreProduct(context.Background(), testDB, prod.ID)
+	require.NoError(t, err)
+	require.False(t, restored.DeletedAt.Valid)
+
+	_, err = testRepo.RestoreProduct(context.Background(), testDB, prod.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestBulkCreateProducts(t *testing.T) {
+	prod := createNewProduct(t)
+	arg := BulkCreateProductsParams{
+		UserIds:    []int64{prod.UserID, prod.UserID},
+		Names:      []string{"first", "second"},
+		Prices:     []int64{100, 200},
+		Currencies: []string{"USD", "EUR"},
+	}
+
+	created, err := testRepo.BulkCreateProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+
+	sort.Slice(created, func(i, j int) bool { return created[i].ID < created[j].ID })
+	for i, p := range created {
+		require.Equal(t, arg.Names[i], p.Name)
+		require.Equal(t, arg.Prices[i], p.Price)
+		require.Equal(t, arg.Currencies[i], p.Currency)
+		require.Equal(t, int64(1), p.Version)
+	}
+}
+
+func TestBulkDeleteProducts(t *testing.T) {
+	first := createNewProduct(t)
+	second := createNewProduct(t)
+	ids := []int64{first.ID, second.ID}
+
+	deleted, err := testRepo.BulkSoftDeleteProducts(context.Background(), testDB, ids)
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids, deleted)
+
+	// already in the trash
+	deleted, err = testRepo.BulkSoftDeleteProducts(context.Background(), testDB, ids)
+	require.NoError(t, err)
+	require.Empty(t, deleted)
+
+	deleted, err = testRepo.BulkDeleteProducts(context.Background(), testDB, ids)
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids, deleted)
+}
+
+func TestPurgeDeletedProducts(t *testing.T) {
+	prod := createNewProduct(t)
+	_, err := testRepo.SoftDeleteProduct(context.Background(), testDB, prod.ID)
+	require.NoError(t, err)
+
+	purged, err := testRepo.PurgeDeletedProducts(context.Background(), testDB, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, purged, int64(1))
+
+	_, err = testRepo.RestoreProduct(context.Background(), testDB, prod.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func createNewProduct(t *testing.T) Product {
 	user := createNewUser(t)
 	arg := CreateProductParams{
-		Name:   "test product",
-		Price:  100,
-		UserID: user.ID,
+		Name:     "test product",
+		Price:    100,
+		Currency: "USD",
+		UserID:   user.ID,
 	}
 
 	prod, err := testRepo.CreateProduct(context.Background(), testDB, arg)
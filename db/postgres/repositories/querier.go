@@ -6,19 +6,109 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AddProductCategories(ctx context.Context, db DBTX, arg AddProductCategoriesParams) error
+	AddProductTags(ctx context.Context, db DBTX, arg AddProductTagsParams) error
+	AssignRole(ctx context.Context, db DBTX, arg AssignRoleParams) (int64, error)
+	BulkCreateProducts(ctx context.Context, db DBTX, arg BulkCreateProductsParams) ([]Product, error)
+	BulkDeleteProducts(ctx context.Context, db DBTX, ids []int64) ([]int64, error)
+	BulkSoftDeleteProducts(ctx context.Context, db DBTX, ids []int64) ([]int64, error)
+	CloseReservation(ctx context.Context, db DBTX, arg CloseReservationParams) (Reservation, error)
+	CountChildCategories(ctx context.Context, db DBTX, parentID sql.NullInt64) (int64, error)
+	CountDeletedProducts(ctx context.Context, db DBTX, userID sql.NullInt64) (int64, error)
+	CountProducts(ctx context.Context, db DBTX, arg CountProductsParams) (int64, error)
+	CountRoleUsers(ctx context.Context, db DBTX, name string) (int64, error)
+	CountUserOrders(ctx context.Context, db DBTX, userID int64) (int64, error)
+	CountUserProducts(ctx context.Context, db DBTX, userID int64) (int64, error)
+	CreateAPIKey(ctx context.Context, db DBTX, arg CreateAPIKeyParams) (APIKey, error)
+	CreateCategory(ctx context.Context, db DBTX, arg CreateCategoryParams) (Category, error)
+	CreateOrder(ctx context.Context, db DBTX, arg CreateOrderParams) (Order, error)
+	CreateOrderItem(ctx context.Context, db DBTX, arg CreateOrderItemParams) (OrderItem, error)
+	CreatePayment(ctx context.Context, db DBTX, arg CreatePaymentParams) (Payment, error)
 	CreateProduct(ctx context.Context, db DBTX, arg CreateProductParams) (Product, error)
+	CreateRefreshToken(ctx context.Context, db DBTX, arg CreateRefreshTokenParams) (RefreshToken, error)
+	CreateReservation(ctx context.Context, db DBTX, arg CreateReservationParams) (Reservation, error)
+	CreateStockAdjustment(ctx context.Context, db DBTX, arg CreateStockAdjustmentParams) (StockAdjustment, error)
+	CreateTag(ctx context.Context, db DBTX, name string) (Tag, error)
 	CreateUser(ctx context.Context, db DBTX, arg CreateUserParams) (User, error)
+	DeleteAPIKey(ctx context.Context, db DBTX, arg DeleteAPIKeyParams) (int64, error)
+	DeleteCategory(ctx context.Context, db DBTX, id int64) (int64, error)
 	DeleteProduct(ctx context.Context, db DBTX, id int64) (int64, error)
+	DeleteProductCategories(ctx context.Context, db DBTX, productID int64) error
+	DeleteProductTags(ctx context.Context, db DBTX, productID int64) error
+	DeleteTag(ctx context.Context, db DBTX, id int64) (int64, error)
+	DeleteUser(ctx context.Context, db DBTX, id int64) (int64, error)
+	DeleteUserProducts(ctx context.Context, db DBTX, userID int64) (int64, error)
+	EnsureInventory(ctx context.Context, db DBTX, productID int64) error
+	ExpireReservations(ctx context.Context, db DBTX, expiredBefore time.Time) ([]Reservation, error)
+	GetAPIKeyByPrefix(ctx context.Context, db DBTX, prefix string) (APIKey, error)
+	GetBatchCategories(ctx context.Context, db DBTX, ids []int64) ([]Category, error)
+	GetBatchInventory(ctx context.Context, db DBTX, productIds []int64) ([]Inventory, error)
+	GetBatchProductCategories(ctx context.Context, db DBTX, productIds []int64) ([]GetBatchProductCategoriesRow, error)
+	GetBatchProductTags(ctx context.Context, db DBTX, productIds []int64) ([]GetBatchProductTagsRow, error)
+	GetBatchProducts(ctx context.Context, db DBTX, ids []int64) ([]Product, error)
+	GetBatchUserProducts(ctx context.Context, db DBTX, arg GetBatchUserProductsParams) ([]Product, error)
+	GetBatchUserProductsBefore(ctx context.Context, db DBTX, arg GetBatchUserProductsBeforeParams) ([]Product, error)
 	GetBatchUsers(ctx context.Context, db DBTX, ids []int64) ([]User, error)
+	GetCategory(ctx context.Context, db DBTX, id int64) (Category, error)
+	GetCategoryAncestors(ctx context.Context, db DBTX, id int64) ([]int64, error)
+	GetOrder(ctx context.Context, db DBTX, id int64) (Order, error)
+	GetOrderItems(ctx context.Context, db DBTX, orderIds []int64) ([]OrderItem, error)
+	GetPayment(ctx context.Context, db DBTX, id int64) (Payment, error)
+	GetPaymentByIntent(ctx context.Context, db DBTX, arg GetPaymentByIntentParams) (Payment, error)
 	GetProduct(ctx context.Context, db DBTX, id int64) (Product, error)
+	GetProductOwners(ctx context.Context, db DBTX, ids []int64) ([]GetProductOwnersRow, error)
+	GetRefreshToken(ctx context.Context, db DBTX, tokenHash string) (RefreshToken, error)
+	GetReservation(ctx context.Context, db DBTX, id int64) (Reservation, error)
 	GetUser(ctx context.Context, db DBTX, id int64) (User, error)
+	GetUserAPIKey(ctx context.Context, db DBTX, arg GetUserAPIKeyParams) (APIKey, error)
+	GetUserByEmail(ctx context.Context, db DBTX, email string) (User, error)
+	GetUserOrders(ctx context.Context, db DBTX, arg GetUserOrdersParams) ([]Order, error)
+	GetUserPermissions(ctx context.Context, db DBTX, userID int64) ([]string, error)
 	GetUserProducts(ctx context.Context, db DBTX, arg GetUserProductsParams) ([]Product, error)
+	GetUserProductsBefore(ctx context.Context, db DBTX, arg GetUserProductsBeforeParams) ([]Product, error)
+	GetUserRoles(ctx context.Context, db DBTX, userID int64) ([]string, error)
+	ListCategories(ctx context.Context, db DBTX, parentID sql.NullInt64) ([]Category, error)
+	ListDeletedProducts(ctx context.Context, db DBTX, arg ListDeletedProductsParams) ([]Product, error)
+	ListExchangeRates(ctx context.Context, db DBTX) ([]ExchangeRate, error)
 	ListProducts(ctx context.Context, db DBTX, arg ListProductsParams) ([]Product, error)
+	ListRolePermissions(ctx context.Context, db DBTX) ([]ListRolePermissionsRow, error)
+	ListRoles(ctx context.Context, db DBTX) ([]Role, error)
+	ListStockAdjustments(ctx context.Context, db DBTX, arg ListStockAdjustmentsParams) ([]StockAdjustment, error)
+	ListTags(ctx context.Context, db DBTX) ([]Tag, error)
+	ListUserAPIKeys(ctx context.Context, db DBTX, userID int64) ([]APIKey, error)
+	ListUsers(ctx context.Context, db DBTX, arg ListUsersParams) ([]User, error)
+	LockInventory(ctx context.Context, db DBTX, productID int64) (Inventory, error)
+	LockPayment(ctx context.Context, db DBTX, id int64) (Payment, error)
+	PatchProduct(ctx context.Context, db DBTX, arg PatchProductParams) (Product, error)
+	PatchUser(ctx context.Context, db DBTX, arg PatchUserParams) (User, error)
+	PurgeDeletedProducts(ctx context.Context, db DBTX, deletedBefore time.Time) (int64, error)
+	ReassignUserProducts(ctx context.Context, db DBTX, arg ReassignUserProductsParams) (int64, error)
+	RestoreProduct(ctx context.Context, db DBTX, id int64) (Product, error)
+	RevokeRefreshToken(ctx context.Context, db DBTX, id int64) (int64, error)
+	RevokeRole(ctx context.Context, db DBTX, arg RevokeRoleParams) (int64, error)
+	RevokeUserRefreshTokens(ctx context.Context, db DBTX, userID int64) (int64, error)
+	SearchProducts(ctx context.Context, db DBTX, arg SearchProductsParams) ([]SearchProductsRow, error)
+	SetExchangeRate(ctx context.Context, db DBTX, arg SetExchangeRateParams) (ExchangeRate, error)
+	SetInventory(ctx context.Context, db DBTX, arg SetInventoryParams) (Inventory, error)
+	SetUserPassword(ctx context.Context, db DBTX, arg SetUserPasswordParams) error
+	SoftDeleteProduct(ctx context.Context, db DBTX, id int64) (int64, error)
+	TouchAPIKey(ctx context.Context, db DBTX, arg TouchAPIKeyParams) error
+	UpdateAPIKey(ctx context.Context, db DBTX, arg UpdateAPIKeyParams) (APIKey, error)
+	UpdateCategory(ctx context.Context, db DBTX, arg UpdateCategoryParams) (Category, error)
+	UpdateOrderStatus(ctx context.Context, db DBTX, arg UpdateOrderStatusParams) (Order, error)
+	UpdatePaymentStatus(ctx context.Context, db DBTX, arg UpdatePaymentStatusParams) (Payment, error)
 	UpdateProduct(ctx context.Context, db DBTX, arg UpdateProductParams) (Product, error)
+	UpdateUser(ctx context.Context, db DBTX, arg UpdateUserParams) (User, error)
+	UpsertTags(ctx context.Context, db DBTX, names []string) ([]Tag, error)
 	UserProductsHasNextPage(ctx context.Context, db DBTX, arg UserProductsHasNextPageParams) (bool, error)
+	UserProductsHasPreviousPage(ctx context.Context, db DBTX, arg UserProductsHasPreviousPageParams) (bool, error)
+	UsersWithProductsNotNewerThan(ctx context.Context, db DBTX, arg UsersWithProductsNotNewerThanParams) ([]int64, error)
+	UsersWithProductsNotOlderThan(ctx context.Context, db DBTX, arg UsersWithProductsNotOlderThanParams) ([]int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
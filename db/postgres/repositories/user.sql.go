@@ -7,17 +7,30 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/lib/pq"
 )
 
+const countUserProducts = `-- name: CountUserProducts :one
+SELECT COUNT(*) FROM products
+WHERE user_id = $1
+`
+
+func (q *Queries) CountUserProducts(ctx context.Context, db DBTX, userID int64) (int64, error) {
+	row := db.QueryRowContext(ctx, countUserProducts, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users(
     name,
     email
 ) VALUES (
     $1, $2
-) RETURNING id, name, email, created_at
+) RETURNING id, name, email, created_at, version, updated_at, password_hash
 `
 
 type CreateUserParams struct {
@@ -33,12 +46,40 @@ func (q *Queries) CreateUser(ctx context.Context, db DBTX, arg CreateUserParams)
 		&i.Name,
 		&i.Email,
 		&i.CreatedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.PasswordHash,
 	)
 	return i, err
 }
 
+const deleteUser = `-- name: DeleteUser :one
+DELETE FROM users
+WHERE id = $1
+RETURNING id
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, db DBTX, id int64) (int64, error) {
+	row := db.QueryRowContext(ctx, deleteUser, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteUserProducts = `-- name: DeleteUserProducts :execrows
+DELETE FROM products
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserProducts(ctx context.Context, db DBTX, userID int64) (int64, error) {
+	result, err := db.ExecContext(ctx, deleteUserProducts, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getBatchUsers = `-- name: GetBatchUsers :many
-SELECT id, name, email, created_at FROM users
+SELECT id, name, email, created_at, version, updated_at, password_hash FROM users
 WHERE id = ANY($1::BIGINT[])
 `
 
@@ -56,6 +97,9 @@ func (q *Queries) GetBatchUsers(ctx context.Context, db DBTX, ids []int64) ([]Us
 			&i.Name,
 			&i.Email,
 			&i.CreatedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.PasswordHash,
 		); err != nil {
 			return nil, err
 		}
@@ -71,7 +115,7 @@ func (q *Queries) GetBatchUsers(ctx context.Context, db DBTX, ids []int64) ([]Us
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, name, email, created_at FROM users 
+SELECT id, name, email, created_at, version, updated_at, password_hash FROM users 
 WHERE id = $1
 LIMIT 1
 `
@@ -84,6 +128,179 @@ func (q *Queries) GetUser(ctx context.Context, db DBTX, id int64) (User, error)
 		&i.Name,
 		&i.Email,
 		&i.CreatedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.PasswordHash,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, name, email, created_at, version, updated_at, password_hash FROM users
+WHERE LOWER(email COLLATE "C") = LOWER($1::TEXT COLLATE "C")
+LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, db DBTX, email string) (User, error) {
+	row := db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.PasswordHash,
+	)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email, created_at, version, updated_at, password_hash FROM users
+WHERE $1::BIGINT IS NULL OR id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListUsersParams struct {
+	AfterID sql.NullInt64 `json:"after_id"`
+	First   int32         `json:"first"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, db DBTX, arg ListUsersParams) ([]User, error) {
+	rows, err := db.QueryContext(ctx, listUsers, arg.AfterID, arg.First)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.PasswordHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const patchUser = `-- name: PatchUser :one
+UPDATE users
+SET
+    name = COALESCE($1, name),
+    email = COALESCE($2, email),
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $3
+    AND ($4::BIGINT IS NULL OR version = $4)
+RETURNING id, name, email, created_at, version, updated_at, password_hash
+`
+
+type PatchUserParams struct {
+	Name            sql.NullString `json:"name"`
+	Email           sql.NullString `json:"email"`
+	ID              int64          `json:"id"`
+	ExpectedVersion sql.NullInt64  `json:"expected_version"`
+}
+
+func (q *Queries) PatchUser(ctx context.Context, db DBTX, arg PatchUserParams) (User, error) {
+	row := db.QueryRowContext(ctx, patchUser, arg.Name, arg.Email, arg.ID, arg.ExpectedVersion)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.PasswordHash,
+	)
+	return i, err
+}
+
+const reassignUserProducts = `-- name: ReassignUserProducts :execrows
+UPDATE products
+SET
+    user_id = $1,
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $2
+`
+
+type ReassignUserProductsParams struct {
+	ToUserID   int64 `json:"to_user_id"`
+	FromUserID int64 `json:"from_user_id"`
+}
+
+func (q *Queries) ReassignUserProducts(ctx context.Context, db DBTX, arg ReassignUserProductsParams) (int64, error) {
+	result, err := db.ExecContext(ctx, reassignUserProducts, arg.ToUserID, arg.FromUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setUserPassword = `-- name: SetUserPassword :exec
+UPDATE users
+SET password_hash = $1
+WHERE id = $2
+`
+
+type SetUserPasswordParams struct {
+	PasswordHash sql.NullString `json:"password_hash"`
+	ID           int64          `json:"id"`
+}
+
+func (q *Queries) SetUserPassword(ctx context.Context, db DBTX, arg SetUserPasswordParams) error {
+	_, err := db.ExecContext(ctx, setUserPassword, arg.PasswordHash, arg.ID)
+	return err
+}
+
+const updateUser = `-- name: UpdateUser :one
+UPDATE users
+SET
+    name = $1,
+    email = $2,
+    version = version + 1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $3
+    AND ($4::BIGINT IS NULL OR version = $4)
+RETURNING id, name, email, created_at, version, updated_at, password_hash
+`
+
+type UpdateUserParams struct {
+	Name            string        `json:"name"`
+	Email           string        `json:"email"`
+	ID              int64         `json:"id"`
+	ExpectedVersion sql.NullInt64 `json:"expected_version"`
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, db DBTX, arg UpdateUserParams) (User, error) {
+	row := db.QueryRowContext(ctx, updateUser, arg.Name, arg.Email, arg.ID, arg.ExpectedVersion)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.PasswordHash,
 	)
 	return i, err
 }
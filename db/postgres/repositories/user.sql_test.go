@@ -2,8 +2,11 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +22,78 @@ func TestGetUser(t *testing.T) {
 	require.Equal(t, user, getUser)
 }
 
+func TestPatchUser(t *testing.T) {
+	user := createNewUser(t)
+	arg := PatchUserParams{
+		Name: sql.NullString{String: "patched", Valid: true},
+		ID:   user.ID,
+	}
+
+	patched, err := testRepo.PatchUser(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Equal(t, "patched", patched.Name)
+	require.Equal(t, user.Email, patched.Email)
+	require.Equal(t, user.Version+1, patched.Version)
+}
+
+func TestUpdateUser(t *testing.T) {
+	user := createNewUser(t)
+	arg := UpdateUserParams{
+		Name:            "updated",
+		Email:           uniqueEmail("updated"),
+		ID:              user.ID,
+		ExpectedVersion: sql.NullInt64{Int64: user.Version, Valid: true},
+	}
+
+	updated, err := testRepo.UpdateUser(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Email, updated.Email)
+	require.Equal(t, user.Version+1, updated.Version)
+
+	_, err = testRepo.UpdateUser(context.Background(), testDB, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListUsers(t *testing.T) {
+	first := createNewUser(t)
+	second := createNewUser(t)
+
+	arg := ListUsersParams{
+		AfterID: sql.NullInt64{Int64: first.ID - 1, Valid: true},
+		First:   2,
+	}
+	users, err := testRepo.ListUsers(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, first.ID, users[0].ID)
+	require.Equal(t, second.ID, users[1].ID)
+}
+
+func TestDeleteUser(t *testing.T) {
+	prod := createNewProduct(t)
+	heir := createNewUser(t)
+
+	count, err := testRepo.CountUserProducts(context.Background(), testDB, prod.UserID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	arg := ReassignUserProductsParams{ToUserID: heir.ID, FromUserID: prod.UserID}
+	reassigned, err := testRepo.ReassignUserProducts(context.Background(), testDB, arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), reassigned)
+
+	id, err := testRepo.DeleteUser(context.Background(), testDB, prod.UserID)
+	require.NoError(t, err)
+	require.Equal(t, prod.UserID, id)
+
+	deleted, err := testRepo.DeleteUserProducts(context.Background(), testDB, heir.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = testRepo.DeleteUser(context.Background(), testDB, prod.UserID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestGetBatchUsers(t *testing.T) {
 	count := 5
 	var ids []int64
@@ -49,7 +124,7 @@ func TestGetBatchUsers(t *testing.T) {
 func createNewUser(t *testing.T) User {
 	arg := CreateUserParams{
 		Name:  "royyan",
-		Email: "royyan@gmail.com",
+		Email: uniqueEmail("royyan"),
 	}
 
 	user, err := testRepo.CreateUser(context.Background(), testDB, arg)
@@ -58,3 +133,11 @@ func createNewUser(t *testing.T) User {
 
 	return user
 }
+
+var emailSeq int64
+
+// uniqueEmail keeps the unique email index happy across test runs on the same
+// database.
+func uniqueEmail(name string) string {
+	return fmt.Sprintf("%s.%d.%d@gmail.com", name, time.Now().UnixNano(), atomic.AddInt64(&emailSeq, 1))
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS products_created_at_id_idx;
+
+DROP INDEX IF EXISTS products_user_id_created_at_id_idx;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products_name_trgm_idx;
+
+DROP INDEX IF EXISTS products_search_vector_idx;
+
+ALTER TABLE IF EXISTS products
+DROP COLUMN IF EXISTS search_vector;
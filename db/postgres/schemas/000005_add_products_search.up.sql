@@ -0,0 +1,15 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- search_vector is maintained by postgres, the english configuration stems
+-- words so searching "shoes" also finds "shoe".
+ALTER TABLE IF EXISTS products
+ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+GENERATED ALWAYS AS (to_tsvector('english', name)) STORED;
+
+CREATE INDEX IF NOT EXISTS products_search_vector_idx
+ON products USING GIN (search_vector);
+
+-- trigrams keep the search tolerant to typos, see the <% operator in the
+-- SearchProducts query.
+CREATE INDEX IF NOT EXISTS products_name_trgm_idx
+ON products USING GIN (name gin_trgm_ops);
@@ -0,0 +1,8 @@
+-- products are soft deleted, a NULL deleted_at means the product is live.
+-- Rows past the trash retention are removed by the purge job.
+ALTER TABLE IF EXISTS products
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS products_deleted_at_idx
+ON products (deleted_at DESC, id DESC)
+WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS users
+DROP COLUMN IF EXISTS updated_at,
+DROP COLUMN IF EXISTS version;
+
+ALTER TABLE IF EXISTS products
+DROP COLUMN IF EXISTS updated_at,
+DROP COLUMN IF EXISTS version;
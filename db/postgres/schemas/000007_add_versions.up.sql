@@ -0,0 +1,13 @@
+-- version is bumped by every update, clients send it back through If-Match
+-- or expectedVersion so concurrent edits don't overwrite each other.
+ALTER TABLE IF EXISTS products
+ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1,
+ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE products SET updated_at = created_at;
+
+ALTER TABLE IF EXISTS users
+ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1,
+ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE users SET updated_at = created_at;
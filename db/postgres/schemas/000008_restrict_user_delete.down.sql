@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS products
+DROP CONSTRAINT IF EXISTS products_user_id_fkey;
+
+ALTER TABLE IF EXISTS products
+ADD CONSTRAINT products_user_id_fkey
+FOREIGN KEY (user_id)
+REFERENCES users (id);
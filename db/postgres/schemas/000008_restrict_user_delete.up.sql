@@ -0,0 +1,10 @@
+-- deleting a user never touches its products implicitly, the service applies
+-- the configured policy (restrict, cascade or reassign) before the delete.
+ALTER TABLE IF EXISTS products
+DROP CONSTRAINT IF EXISTS products_user_id_fkey;
+
+ALTER TABLE IF EXISTS products
+ADD CONSTRAINT products_user_id_fkey
+FOREIGN KEY (user_id)
+REFERENCES users (id)
+ON DELETE RESTRICT;
@@ -0,0 +1,6 @@
+-- emails are unique whatever their case, the service stores them normalized.
+-- Users sharing an email have to be merged before this migration can run.
+-- LOWER under the C collation only folds ASCII letters, like SQLite's LOWER.
+UPDATE users SET email = TRIM(email);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_key ON users (LOWER(email COLLATE "C"));
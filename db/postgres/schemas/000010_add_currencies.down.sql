@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS exchange_rates;
+
+ALTER TABLE products
+DROP COLUMN currency;
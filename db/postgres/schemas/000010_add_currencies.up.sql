@@ -0,0 +1,13 @@
+-- prices are amounts in the minor unit of their ISO 4217 currency, cents for
+-- USD. Products priced before currencies existed were priced in USD.
+ALTER TABLE products
+ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+-- one unit of base is worth rate units of quote
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base CHAR(3) NOT NULL,
+    quote CHAR(3) NOT NULL,
+    rate NUMERIC(24, 12) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (base, quote)
+);
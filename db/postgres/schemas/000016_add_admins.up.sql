@@ -0,0 +1,4 @@
+-- admins bypass the ownership policy of services, there is no endpoint making
+-- admins: they are promoted with
+-- UPDATE users SET is_admin = TRUE WHERE email = '...';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,95 @@
+-- every role includes the permissions of the roles below it: viewers read
+-- everything staff can read, editors also manage the catalog and admins also
+-- manage users and their roles. The first admin is made by inserting into
+-- user_roles, later ones are assigned through the API.
+CREATE TABLE IF NOT EXISTS roles (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(32) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS roles_name_key ON roles (name);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS permissions_name_key ON permissions (name);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id BIGINT NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission_id BIGINT NOT NULL REFERENCES permissions (id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role_id BIGINT NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_roles_role_id_idx ON user_roles (role_id);
+
+INSERT INTO roles (name, description) VALUES
+    ('viewer', 'reads everything staff can read'),
+    ('editor', 'manages products, the catalog, stock, orders and payments'),
+    ('admin', 'manages users and their roles')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('products:read', 'read trashed products'),
+    ('products:manage', 'change the products of every user'),
+    ('catalog:manage', 'manage categories, tags and exchange rates'),
+    ('inventory:read', 'read stock adjustments'),
+    ('inventory:manage', 'adjust stock'),
+    ('orders:read', 'read the orders and reservations of every user'),
+    ('orders:manage', 'change the status of orders'),
+    ('payments:read', 'read the payments of every user'),
+    ('payments:manage', 'refund payments'),
+    ('users:read', 'list users and their roles'),
+    ('users:manage', 'create, update and delete users'),
+    ('roles:manage', 'assign and revoke roles')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM (VALUES
+    ('viewer', 'products:read'),
+    ('viewer', 'inventory:read'),
+    ('viewer', 'orders:read'),
+    ('viewer', 'payments:read'),
+    ('viewer', 'users:read'),
+    ('editor', 'products:read'),
+    ('editor', 'inventory:read'),
+    ('editor', 'orders:read'),
+    ('editor', 'payments:read'),
+    ('editor', 'users:read'),
+    ('editor', 'products:manage'),
+    ('editor', 'catalog:manage'),
+    ('editor', 'inventory:manage'),
+    ('editor', 'orders:manage'),
+    ('editor', 'payments:manage')
+) AS grants (role, permission)
+JOIN roles ON roles.name = grants.role
+JOIN permissions ON permissions.name = grants.permission
+ON CONFLICT DO NOTHING;
+
+-- admins hold every permission
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM roles, permissions
+WHERE roles.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- the admins of users.is_admin become holders of the admin role
+INSERT INTO user_roles (user_id, role_id)
+SELECT users.id, roles.id
+FROM users, roles
+WHERE users.is_admin AND roles.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
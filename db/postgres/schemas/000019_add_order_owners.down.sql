@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS payments_user_id_idx;
+DROP INDEX IF EXISTS reservations_user_id_idx;
+
+ALTER TABLE IF EXISTS payments
+DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE IF EXISTS reservations
+DROP COLUMN IF EXISTS user_id;
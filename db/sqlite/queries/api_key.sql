@@ -0,0 +1,42 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    user_id,
+    name,
+    prefix,
+    key_hash,
+    scopes,
+    expires_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT * FROM api_keys
+WHERE prefix = ? LIMIT 1;
+
+-- name: GetUserAPIKey :one
+SELECT * FROM api_keys
+WHERE id = ? AND user_id = ? LIMIT 1;
+
+-- name: ListUserAPIKeys :many
+SELECT * FROM api_keys
+WHERE user_id = ?
+ORDER BY id;
+
+-- name: UpdateAPIKey :one
+UPDATE api_keys
+SET
+    name = ?,
+    scopes = ?
+WHERE id = ? AND user_id = ?
+RETURNING *;
+
+-- name: DeleteAPIKey :execrows
+DELETE FROM api_keys
+WHERE id = ? AND user_id = ?;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = ?
+WHERE id = ?;
@@ -0,0 +1,63 @@
+-- name: CreateCategory :one
+INSERT INTO categories (
+    name,
+    parent_id
+) VALUES (
+    ?, ?
+)
+RETURNING *;
+
+-- name: GetCategory :one
+SELECT * FROM categories
+WHERE id = ? LIMIT 1;
+
+-- name: GetBatchCategories :many
+SELECT * FROM categories
+WHERE id IN (SELECT value FROM json_each(sqlc.arg('ids')));
+
+-- name: ListCategories :many
+SELECT * FROM categories
+WHERE parent_id IS sqlc.narg('parent_id')
+ORDER BY LOWER(name), id;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET
+    name = ?,
+    parent_id = ?,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteCategory :one
+DELETE FROM categories
+WHERE id = ?
+RETURNING id;
+
+-- name: CountChildCategories :one
+SELECT COUNT(*) FROM categories
+WHERE parent_id = ?;
+
+-- name: GetCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT categories.id, categories.parent_id FROM categories WHERE categories.id = sqlc.arg('id')
+    UNION ALL
+    SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+)
+SELECT id FROM ancestors;
+
+-- name: DeleteProductCategories :exec
+DELETE FROM product_categories
+WHERE product_id = ?;
+
+-- name: AddProductCategories :exec
+INSERT INTO product_categories (product_id, category_id)
+SELECT sqlc.arg('product_id'), value FROM json_each(sqlc.arg('category_ids')) WHERE true
+ON CONFLICT DO NOTHING;
+
+-- name: GetBatchProductCategories :many
+SELECT pc.product_id, c.id, c.name, c.parent_id, c.created_at, c.updated_at
+FROM product_categories pc
+JOIN categories c ON c.id = pc.category_id
+WHERE pc.product_id IN (SELECT value FROM json_each(sqlc.arg('product_ids')))
+ORDER BY pc.product_id, LOWER(c.name), c.id;
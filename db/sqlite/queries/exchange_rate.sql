@@ -0,0 +1,17 @@
+-- name: SetExchangeRate :one
+INSERT INTO exchange_rates (
+    base,
+    quote,
+    rate
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (base, quote) DO UPDATE
+SET
+    rate = excluded.rate,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+RETURNING *;
+
+-- name: ListExchangeRates :many
+SELECT * FROM exchange_rates
+ORDER BY base, quote;
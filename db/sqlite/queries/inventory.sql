@@ -0,0 +1,72 @@
+-- name: EnsureInventory :exec
+INSERT INTO inventory (product_id)
+VALUES (?)
+ON CONFLICT (product_id) DO NOTHING;
+
+-- name: GetInventory :one
+SELECT * FROM inventory
+WHERE product_id = ? LIMIT 1;
+
+-- name: GetBatchInventory :many
+SELECT * FROM inventory
+WHERE product_id IN (SELECT value FROM json_each(sqlc.arg('product_ids')));
+
+-- name: SetInventory :one
+UPDATE inventory
+SET
+    on_hand = ?,
+    reserved = ?,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE product_id = ?
+RETURNING *;
+
+-- name: CreateStockAdjustment :one
+INSERT INTO stock_adjustments (
+    product_id,
+    delta,
+    reason,
+    note
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListStockAdjustments :many
+SELECT * FROM stock_adjustments
+WHERE product_id = ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: CreateReservation :one
+INSERT INTO reservations (
+    product_id,
+    quantity,
+    expires_at,
+    user_id
+) VALUES (
+    sqlc.arg('product_id'),
+    sqlc.arg('quantity'),
+    STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.arg('expires_at')),
+    sqlc.arg('user_id')
+)
+RETURNING *;
+
+-- name: GetReservation :one
+SELECT * FROM reservations
+WHERE id = ? LIMIT 1;
+
+-- name: CloseReservation :one
+UPDATE reservations
+SET
+    status = ?,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = ? AND status = 'active'
+RETURNING *;
+
+-- name: ExpireReservations :many
+UPDATE reservations
+SET
+    status = 'expired',
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE status = 'active' AND expires_at < STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.arg('expired_before'))
+RETURNING *;
@@ -0,0 +1,49 @@
+-- name: CreateOrder :one
+INSERT INTO orders (
+    user_id,
+    currency,
+    total
+) VALUES (
+    ?, ?, ?
+)
+RETURNING *;
+
+-- name: CreateOrderItem :one
+INSERT INTO order_items (
+    order_id,
+    product_id,
+    product_name,
+    unit_price,
+    quantity
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE id = ? LIMIT 1;
+
+-- name: GetOrderItems :many
+SELECT * FROM order_items
+WHERE order_id IN (SELECT value FROM json_each(sqlc.arg('order_ids')))
+ORDER BY order_id, id;
+
+-- name: UpdateOrderStatus :one
+UPDATE orders
+SET
+    status = sqlc.arg('status'),
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = sqlc.arg('id') AND status = sqlc.arg('from_status')
+RETURNING *;
+
+-- name: GetUserOrders :many
+SELECT * FROM orders
+WHERE user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('after_id') IS NULL OR id < sqlc.narg('after_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('first');
+
+-- name: CountUserOrders :one
+SELECT COUNT(*) FROM orders
+WHERE user_id = ?;
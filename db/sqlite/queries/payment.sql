@@ -0,0 +1,37 @@
+-- name: CreatePayment :one
+INSERT INTO payments (
+    order_id,
+    product_id,
+    provider,
+    intent_id,
+    amount,
+    currency,
+    user_id
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetPayment :one
+SELECT * FROM payments
+WHERE id = ? LIMIT 1;
+
+-- name: GetPaymentByIntent :one
+SELECT * FROM payments
+WHERE provider = ? AND intent_id = ? LIMIT 1;
+
+-- name: LockPayment :one
+-- sqlite has no row locks, the write takes the database write lock until the
+-- transaction ends.
+UPDATE payments
+SET status = status
+WHERE id = ?
+RETURNING *;
+
+-- name: UpdatePaymentStatus :one
+UPDATE payments
+SET
+    status = sqlc.arg('status'),
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = sqlc.arg('id') AND status = sqlc.arg('from_status')
+RETURNING *;
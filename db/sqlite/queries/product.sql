@@ -0,0 +1,381 @@
+-- name: ListProducts :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+    AND (sqlc.narg('user_id') IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('name') IS NULL OR name LIKE '%' || sqlc.narg('name') || '%' ESCAPE '\')
+    AND (sqlc.narg('min_price') IS NULL OR price >= sqlc.narg('min_price'))
+    AND (sqlc.narg('max_price') IS NULL OR price <= sqlc.narg('max_price'))
+    AND (sqlc.narg('created_after') IS NULL OR created_at >= STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('created_after')))
+    AND (sqlc.narg('created_before') IS NULL OR created_at < STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('created_before')))
+ORDER BY
+    CASE WHEN sqlc.arg('order_by') = 'name' AND NOT sqlc.arg('sort_desc') THEN name END ASC,
+    CASE WHEN sqlc.arg('order_by') = 'name' AND sqlc.arg('sort_desc') THEN name END DESC,
+    CASE WHEN sqlc.arg('order_by') = 'price' AND NOT sqlc.arg('sort_desc') THEN price END ASC,
+    CASE WHEN sqlc.arg('order_by') = 'price' AND sqlc.arg('sort_desc') THEN price END DESC,
+    CASE WHEN sqlc.arg('order_by') = 'created_at' AND NOT sqlc.arg('sort_desc') THEN created_at END ASC,
+    CASE WHEN sqlc.arg('order_by') = 'created_at' AND sqlc.arg('sort_desc') THEN created_at END DESC,
+    CASE WHEN sqlc.arg('sort_desc') THEN id END DESC,
+    id ASC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountProducts :one
+SELECT COUNT(*) FROM products
+WHERE deleted_at IS NULL
+    AND (sqlc.narg('user_id') IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('name') IS NULL OR name LIKE '%' || sqlc.narg('name') || '%' ESCAPE '\')
+    AND (sqlc.narg('min_price') IS NULL OR price >= sqlc.narg('min_price'))
+    AND (sqlc.narg('max_price') IS NULL OR price <= sqlc.narg('max_price'))
+    AND (sqlc.narg('created_after') IS NULL OR created_at >= STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('created_after')))
+    AND (sqlc.narg('created_before') IS NULL OR created_at < STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('created_before')));
+
+-- name: CreateProduct :one
+INSERT INTO products(
+    user_id,
+    name,
+    price,
+    currency,
+    updated_at
+) VALUES (
+    ?, ?, ?, ?, STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+) RETURNING *;
+
+-- name: BulkCreateProducts :many
+INSERT INTO products (user_id, name, price, currency, updated_at)
+SELECT
+    json_extract(value, '$.user_id'),
+    json_extract(value, '$.name'),
+    json_extract(value, '$.price'),
+    json_extract(value, '$.currency'),
+    STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+FROM json_each(sqlc.arg('products'))
+ORDER BY key
+RETURNING *;
+
+-- name: GetProduct :one
+SELECT * FROM products
+WHERE id = ? AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetBatchProducts :many
+SELECT * FROM products
+WHERE id IN (SELECT value FROM json_each(sqlc.arg('ids'))) AND deleted_at IS NULL;
+
+-- name: UpdateProduct :one
+UPDATE products
+SET
+    name = sqlc.arg('name'),
+    price = sqlc.arg('price'),
+    currency = sqlc.arg('currency'),
+    version = version + 1,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+    AND (sqlc.narg('expected_version') IS NULL OR version = sqlc.narg('expected_version'))
+RETURNING *;
+
+-- name: PatchProduct :one
+UPDATE products
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    price = COALESCE(sqlc.narg('price'), price),
+    currency = COALESCE(sqlc.narg('currency'), currency),
+    version = version + 1,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+    AND (sqlc.narg('expected_version') IS NULL OR version = sqlc.narg('expected_version'))
+RETURNING *;
+
+-- name: DeleteProduct :one
+DELETE FROM products
+WHERE id = ?
+RETURNING id;
+
+-- name: SoftDeleteProduct :one
+UPDATE products
+SET deleted_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = ? AND deleted_at IS NULL
+RETURNING id;
+
+-- name: BulkSoftDeleteProducts :many
+UPDATE products
+SET deleted_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id IN (SELECT value FROM json_each(sqlc.arg('ids'))) AND deleted_at IS NULL
+RETURNING id;
+
+-- name: BulkDeleteProducts :many
+DELETE FROM products
+WHERE id IN (SELECT value FROM json_each(sqlc.arg('ids')))
+RETURNING id;
+
+-- name: GetProductOwners :many
+-- trashed products are included, they can still be restored or deleted.
+SELECT id, user_id FROM products
+WHERE id IN (SELECT value FROM json_each(sqlc.arg('ids')));
+
+-- name: RestoreProduct :one
+UPDATE products
+SET
+    deleted_at = NULL,
+    version = version + 1,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = ? AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: GetUserProducts :many
+SELECT *
+FROM products
+WHERE user_id = sqlc.arg('user_id')
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id') IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag') IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (
+        sqlc.narg('after_created_at') IS NULL
+        OR (created_at, id) < (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('after_created_at')), sqlc.narg('after_id'))
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('first');
+
+-- name: GetUserProductsBefore :many
+SELECT *
+FROM products
+WHERE user_id = sqlc.arg('user_id')
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id') IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag') IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (
+        sqlc.narg('before_created_at') IS NULL
+        OR (created_at, id) > (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('before_created_at')), sqlc.narg('before_id'))
+    )
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg('last');
+
+-- name: UserProductsHasNextPage :one
+SELECT EXISTS(
+    SELECT 1
+    FROM products
+    WHERE user_id = sqlc.arg('user_id')
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id') IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag') IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (created_at, id) < (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.arg('created_at')), sqlc.arg('id'))
+);
+
+-- name: UserProductsHasPreviousPage :one
+SELECT EXISTS(
+    SELECT 1
+    FROM products
+    WHERE user_id = sqlc.arg('user_id')
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id') IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag') IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (created_at, id) > (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.arg('created_at')), sqlc.arg('id'))
+);
+
+-- name: GetBatchUserProducts :many
+SELECT id, name, price, user_id, created_at, deleted_at, version, updated_at, currency
+FROM (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at DESC, id DESC) AS position
+    FROM products
+    WHERE user_id IN (SELECT value FROM json_each(sqlc.arg('user_ids')))
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id') IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag') IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (
+            sqlc.narg('after_created_at') IS NULL
+            OR (created_at, id) < (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('after_created_at')), sqlc.narg('after_id'))
+        )
+)
+WHERE position <= sqlc.arg('first')
+ORDER BY user_id, created_at DESC, id DESC;
+
+-- name: GetBatchUserProductsBefore :many
+SELECT id, name, price, user_id, created_at, deleted_at, version, updated_at, currency
+FROM (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at ASC, id ASC) AS position
+    FROM products
+    WHERE user_id IN (SELECT value FROM json_each(sqlc.arg('user_ids')))
+        AND deleted_at IS NULL
+        AND (
+            sqlc.narg('category_id') IS NULL
+            OR id IN (
+                WITH RECURSIVE subtree AS (
+                    SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                    UNION ALL
+                    SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+                )
+                SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+            )
+        )
+        AND (
+            sqlc.narg('tag') IS NULL
+            OR id IN (
+                SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+                WHERE t.name = sqlc.narg('tag')
+            )
+        )
+        AND (
+            sqlc.narg('before_created_at') IS NULL
+            OR (created_at, id) > (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.narg('before_created_at')), sqlc.narg('before_id'))
+        )
+)
+WHERE position <= sqlc.arg('last')
+ORDER BY user_id, created_at ASC, id ASC;
+
+-- name: UsersWithProductsNotOlderThan :many
+SELECT DISTINCT user_id
+FROM products
+WHERE user_id IN (SELECT value FROM json_each(sqlc.arg('user_ids')))
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id') IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag') IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (created_at, id) >= (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.arg('created_at')), sqlc.arg('id'));
+
+-- name: UsersWithProductsNotNewerThan :many
+SELECT DISTINCT user_id
+FROM products
+WHERE user_id IN (SELECT value FROM json_each(sqlc.arg('user_ids')))
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg('category_id') IS NULL
+        OR id IN (
+            WITH RECURSIVE subtree AS (
+                SELECT categories.id FROM categories WHERE categories.id = sqlc.narg('category_id')
+                UNION ALL
+                SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+            )
+            SELECT pc.product_id FROM product_categories pc JOIN subtree ON subtree.id = pc.category_id
+        )
+    )
+    AND (
+        sqlc.narg('tag') IS NULL
+        OR id IN (
+            SELECT pt.product_id FROM product_tags pt JOIN tags t ON t.id = pt.tag_id
+            WHERE t.name = sqlc.narg('tag')
+        )
+    )
+    AND (created_at, id) <= (STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.arg('created_at')), sqlc.arg('id'));
+
+-- name: SearchProductCandidates :many
+-- sqlite has neither tsvector nor pg_trgm, candidates share at least one
+-- trigram with the query and are ranked by the service.
+SELECT *
+FROM products
+WHERE deleted_at IS NULL
+    AND EXISTS (
+        SELECT 1
+        FROM json_each(sqlc.arg('trigrams'))
+        WHERE instr(lower(name), value) > 0
+    );
+
+-- name: ListDeletedProducts :many
+SELECT * FROM products
+WHERE deleted_at IS NOT NULL
+    AND (sqlc.narg('user_id') IS NULL OR user_id = sqlc.narg('user_id'))
+ORDER BY deleted_at DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountDeletedProducts :one
+SELECT COUNT(*) FROM products
+WHERE deleted_at IS NOT NULL
+    AND (sqlc.narg('user_id') IS NULL OR user_id = sqlc.narg('user_id'));
+
+-- name: PurgeDeletedProducts :execrows
+DELETE FROM products
+WHERE deleted_at < STRFTIME('%Y-%m-%d %H:%M:%f', sqlc.arg('deleted_before'));
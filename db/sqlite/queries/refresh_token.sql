@@ -0,0 +1,23 @@
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetRefreshToken :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = ? LIMIT 1;
+
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = ? AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :execrows
+UPDATE refresh_tokens
+SET revoked_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE user_id = ? AND revoked_at IS NULL;
@@ -0,0 +1,39 @@
+-- name: CreateTag :one
+INSERT INTO tags (
+    name
+) VALUES (
+    ?
+)
+RETURNING *;
+
+-- name: ListTags :many
+SELECT * FROM tags
+ORDER BY name;
+
+-- name: DeleteTag :one
+DELETE FROM tags
+WHERE id = ?
+RETURNING id;
+
+-- name: UpsertTags :many
+INSERT INTO tags (name)
+SELECT value FROM json_each(sqlc.arg('names')) WHERE true
+ON CONFLICT (name) DO UPDATE
+SET name = excluded.name
+RETURNING *;
+
+-- name: DeleteProductTags :exec
+DELETE FROM product_tags
+WHERE product_id = ?;
+
+-- name: AddProductTags :exec
+INSERT INTO product_tags (product_id, tag_id)
+SELECT sqlc.arg('product_id'), value FROM json_each(sqlc.arg('tag_ids')) WHERE true
+ON CONFLICT DO NOTHING;
+
+-- name: GetBatchProductTags :many
+SELECT pt.product_id, t.id, t.name, t.created_at
+FROM product_tags pt
+JOIN tags t ON t.id = pt.tag_id
+WHERE pt.product_id IN (SELECT value FROM json_each(sqlc.arg('product_ids')))
+ORDER BY pt.product_id, t.name;
@@ -0,0 +1,75 @@
+-- name: CreateUser :one
+INSERT INTO users(
+    name,
+    email,
+    updated_at
+) VALUES (
+    ?, ?, STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+) RETURNING *;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = ?
+LIMIT 1;
+-- name: GetBatchUsers :many
+SELECT * FROM users
+WHERE id IN (SELECT value FROM json_each(sqlc.arg('ids')));
+
+-- name: PatchUser :one
+UPDATE users
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    email = COALESCE(sqlc.narg('email'), email),
+    version = version + 1,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = sqlc.arg('id')
+    AND (sqlc.narg('expected_version') IS NULL OR version = sqlc.narg('expected_version'))
+RETURNING *;
+
+-- name: UpdateUser :one
+UPDATE users
+SET
+    name = sqlc.arg('name'),
+    email = sqlc.arg('email'),
+    version = version + 1,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE id = sqlc.arg('id')
+    AND (sqlc.narg('expected_version') IS NULL OR version = sqlc.narg('expected_version'))
+RETURNING *;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE sqlc.narg('after_id') IS NULL OR id > sqlc.narg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('first');
+
+-- name: DeleteUser :one
+DELETE FROM users
+WHERE id = ?
+RETURNING id;
+
+-- name: CountUserProducts :one
+SELECT COUNT(*) FROM products
+WHERE user_id = ?;
+
+-- name: DeleteUserProducts :execrows
+DELETE FROM products
+WHERE user_id = ?;
+
+-- name: ReassignUserProducts :execrows
+UPDATE products
+SET
+    user_id = sqlc.arg('to_user_id'),
+    version = version + 1,
+    updated_at = STRFTIME('%Y-%m-%d %H:%M:%f', 'now')
+WHERE user_id = sqlc.arg('from_user_id');
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE LOWER(email) = LOWER(sqlc.arg('email'))
+LIMIT 1;
+
+-- name: SetUserPassword :exec
+UPDATE users
+SET password_hash = ?
+WHERE id = ?;
@@ -0,0 +1,9 @@
+-- sqlite cannot add a foreign key to an existing table, so unlike postgres the
+-- products table is created after users with the constraint inline.
+CREATE TABLE IF NOT EXISTS products (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    price BIGINT NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now'))
+);
@@ -0,0 +1,7 @@
+-- keyset pagination orders by (created_at, id) so ties on created_at still
+-- have a stable, unique position.
+CREATE INDEX IF NOT EXISTS products_user_id_created_at_id_idx
+ON products (user_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS products_created_at_id_idx
+ON products (created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS products_deleted_at_idx;
+
+ALTER TABLE products
+DROP COLUMN deleted_at;
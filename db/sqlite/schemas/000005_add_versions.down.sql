@@ -0,0 +1,11 @@
+ALTER TABLE users
+DROP COLUMN updated_at;
+
+ALTER TABLE users
+DROP COLUMN version;
+
+ALTER TABLE products
+DROP COLUMN updated_at;
+
+ALTER TABLE products
+DROP COLUMN version;
@@ -0,0 +1,19 @@
+-- version is bumped by every update, clients send it back through If-Match
+-- or expectedVersion so concurrent edits don't overwrite each other.
+-- SQLite can't add a column defaulting to the current time, the insert
+-- queries set updated_at instead.
+ALTER TABLE products
+ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE products
+ADD COLUMN updated_at TIMESTAMP;
+
+UPDATE products SET updated_at = created_at;
+
+ALTER TABLE users
+ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE users
+ADD COLUMN updated_at TIMESTAMP;
+
+UPDATE users SET updated_at = created_at;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS users_email_key;
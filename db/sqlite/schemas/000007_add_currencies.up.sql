@@ -0,0 +1,14 @@
+-- prices are amounts in the minor unit of their ISO 4217 currency, cents for
+-- USD. Products priced before currencies existed were priced in USD.
+ALTER TABLE products
+ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+
+-- one unit of base is worth rate units of quote. The rate is a decimal
+-- string, sqlite would round a NUMERIC column to a float.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    rate TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (base, quote)
+);
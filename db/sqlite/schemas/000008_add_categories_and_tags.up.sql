@@ -0,0 +1,36 @@
+-- categories form a tree, a category with subcategories cannot be deleted
+CREATE TABLE IF NOT EXISTS categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(255) NOT NULL,
+    parent_id BIGINT REFERENCES categories (id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+-- siblings have distinct names, root categories are siblings too
+CREATE UNIQUE INDEX IF NOT EXISTS categories_parent_name_key ON categories (COALESCE(parent_id, 0), LOWER(name));
+
+-- tag names are stored in lower case
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_key ON tags (name);
+
+CREATE TABLE IF NOT EXISTS product_categories (
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    category_id BIGINT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_categories_category_id_idx ON product_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_tags_tag_id_idx ON product_tags (tag_id);
@@ -0,0 +1,36 @@
+-- reserved units are held for a checkout, they still count as on hand until
+-- the reservation is committed. Products without a row have no stock.
+CREATE TABLE IF NOT EXISTS inventory (
+    product_id BIGINT PRIMARY KEY REFERENCES products (id) ON DELETE CASCADE,
+    on_hand BIGINT NOT NULL DEFAULT 0,
+    reserved BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT inventory_on_hand_check CHECK (on_hand >= 0),
+    CONSTRAINT inventory_reserved_check CHECK (reserved >= 0 AND reserved <= on_hand)
+);
+
+-- every change of on_hand with the reason it was made
+CREATE TABLE IF NOT EXISTS stock_adjustments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    delta BIGINT NOT NULL CHECK (delta <> 0),
+    reason VARCHAR(32) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS stock_adjustments_product_id_idx ON stock_adjustments (product_id, id);
+
+-- status is active until the reservation is released, expired or committed
+CREATE TABLE IF NOT EXISTS reservations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+-- the sweeper only looks for active reservations past their expiry
+CREATE INDEX IF NOT EXISTS reservations_active_expires_at_idx ON reservations (expires_at) WHERE status = 'active';
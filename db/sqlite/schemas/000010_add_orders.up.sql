@@ -0,0 +1,29 @@
+-- orders keep the purchase history of a user, a user with orders cannot be
+-- deleted. total is in the minor unit of currency.
+CREATE TABLE IF NOT EXISTS orders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    currency TEXT NOT NULL,
+    total BIGINT NOT NULL CHECK (total >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT orders_status_check CHECK (status IN ('pending', 'paid', 'shipped', 'cancelled'))
+);
+
+CREATE INDEX IF NOT EXISTS orders_user_id_idx ON orders (user_id, id);
+
+-- items snapshot the name and price of the product when the order was placed,
+-- unit_price is in the currency of the order. product_id is cleared when the
+-- product is deleted for good.
+CREATE TABLE IF NOT EXISTS order_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    order_id BIGINT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    product_id BIGINT REFERENCES products (id) ON DELETE SET NULL,
+    product_name VARCHAR(255) NOT NULL,
+    unit_price BIGINT NOT NULL CHECK (unit_price >= 0),
+    quantity BIGINT NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS order_items_order_id_idx ON order_items (order_id, id);
+CREATE INDEX IF NOT EXISTS order_items_product_id_idx ON order_items (product_id);
@@ -0,0 +1,22 @@
+-- a payment pays for an order or a single product through the gateway named
+-- provider. amount is in the minor unit of currency.
+CREATE TABLE IF NOT EXISTS payments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    order_id BIGINT REFERENCES orders (id) ON DELETE RESTRICT,
+    product_id BIGINT REFERENCES products (id) ON DELETE SET NULL,
+    provider VARCHAR(32) NOT NULL,
+    intent_id VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    currency TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    updated_at TIMESTAMP NOT NULL DEFAULT (STRFTIME('%Y-%m-%d %H:%M:%f', 'now')),
+    CONSTRAINT payments_status_check CHECK (status IN ('pending', 'succeeded', 'failed', 'refunded')),
+    CONSTRAINT payments_target_check CHECK (order_id IS NULL OR product_id IS NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS payments_provider_intent_id_key ON payments (provider, intent_id);
+
+-- an order is paid at most once, failed payments can be retried
+CREATE UNIQUE INDEX IF NOT EXISTS payments_order_id_key ON payments (order_id) WHERE status <> 'failed';
+CREATE INDEX IF NOT EXISTS payments_product_id_idx ON payments (product_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS refresh_tokens;
+ALTER TABLE users DROP COLUMN password_hash;
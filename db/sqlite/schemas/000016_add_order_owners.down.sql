@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS payments_user_id_idx;
+DROP INDEX IF EXISTS reservations_user_id_idx;
+
+ALTER TABLE payments
+DROP COLUMN user_id;
+
+ALTER TABLE reservations
+DROP COLUMN user_id;
@@ -0,0 +1,11 @@
+-- reservations and payments belong to the user who made them, only they and
+-- staff allowed to manage orders may see or change them. Rows made before
+-- owners were recorded have none.
+ALTER TABLE reservations
+ADD COLUMN user_id BIGINT REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE payments
+ADD COLUMN user_id BIGINT REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS reservations_user_id_idx ON reservations (user_id);
+CREATE INDEX IF NOT EXISTS payments_user_id_idx ON payments (user_id);
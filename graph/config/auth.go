@@ -0,0 +1,45 @@
+package config
+
+import (
+	"context"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/services"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// AuthMiddleware puts the user of the API key or bearer token of an operation
+// into its context, see auth.UserFrom. Operations made with an API key are
+// limited to its scopes, see HasScope. Operations without either run
+// anonymously, an invalid key or token fails the operation with UNAUTHORIZED,
+// presented by presenter. Install it with handler.Server.AroundOperations.
+func AuthMiddleware(service services.Service, presenter graphql.ErrorPresenterFunc) graphql.OperationMiddleware {
+	return func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		if _, ok := auth.UserFrom(ctx); ok {
+			return next(ctx)
+		}
+
+		headers := graphql.GetOperationContext(ctx).Headers
+		if key, ok := auth.APIKey(headers); ok {
+			user, apiKey, err := service.AuthenticateAPIKey(ctx, key)
+			if err != nil {
+				return graphql.OneShot(&graphql.Response{Errors: gqlerror.List{presenter(ctx, err)}})
+			}
+
+			return next(auth.WithScopes(auth.WithUser(ctx, user), apiKey.Scopes))
+		}
+
+		token, ok := auth.BearerToken(headers.Get("Authorization"))
+		if !ok {
+			return next(ctx)
+		}
+
+		user, err := service.Authenticate(ctx, token)
+		if err != nil {
+			return graphql.OneShot(&graphql.Response{Errors: gqlerror.List{presenter(ctx, err)}})
+		}
+
+		return next(auth.WithUser(ctx, user))
+	}
+}
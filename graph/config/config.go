@@ -14,6 +14,10 @@ func GraphConfig(service services.Service) generated.Config {
 	config := generated.Config{
 		Resolvers: resolver,
 	}
+	config.Directives.HasRole = HasRole
+	config.Directives.HasPermission = HasPermission
+	config.Directives.HasScope = HasScope
+	config.Directives.IsAuthenticated = IsAuthenticated
 
 	config.Complexity.Product.User = func(childComplexity int, input *requests.BindUriID) int {
 		if childComplexity > 4 {
@@ -28,14 +32,67 @@ func GraphConfig(service services.Service) generated.Config {
 			return COMPLEXITY_POINT
 		}
 
-		first := 5
+		size := services.DefaultPageSize
 		if input != nil {
 			if input.First != nil {
-				first = *input.First
+				size = *input.First
 			}
+			if input.Last != nil {
+				size = *input.Last
+			}
+		}
+
+		return (childComplexity * size) + 1
+	}
+
+	config.Complexity.Query.Products = func(childComplexity int, filter *requests.ProductFilter, orderBy *requests.ProductOrder, limit *int, offset *int) int {
+		if childComplexity > 12 {
+			return COMPLEXITY_POINT
+		}
+
+		l := 10
+		if limit != nil {
+			l = *limit
+		}
+
+		return (childComplexity * l) + 1
+	}
+
+	config.Complexity.Query.SearchProducts = func(childComplexity int, query string, first *int, after *string) int {
+		if childComplexity > 12 {
+			return COMPLEXITY_POINT
+		}
+
+		size := services.DefaultPageSize
+		if first != nil {
+			size = *first
+		}
+
+		return (childComplexity * size) + 1
+	}
+
+	config.Complexity.Query.Nodes = func(childComplexity int, ids []string) int {
+		if len(ids) > resolvers.MaxNodes {
+			return COMPLEXITY_POINT
+		}
+
+		return (childComplexity * len(ids)) + 1
+	}
+
+	config.Complexity.Category.Parent = func(childComplexity int) int {
+		if childComplexity > 4 {
+			return COMPLEXITY_POINT
 		}
 
-		return (childComplexity * first) + 1
+		return childComplexity + 1
+	}
+
+	config.Complexity.Category.Children = func(childComplexity int) int {
+		if childComplexity > 4 {
+			return COMPLEXITY_POINT
+		}
+
+		return childComplexity + 1
 	}
 
 	config.Complexity.ProductEdge.Node = func(childComplexity int) int {
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"context"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/services"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// HasRole implements the @hasRole directive: anonymous operations fail with
+// UNAUTHORIZED and users without role, or a role above it, with FORBIDDEN.
+func HasRole(ctx context.Context, obj interface{}, next graphql.Resolver, role requests.Role) (interface{}, error) {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return nil, services.UnauthorizedError("authentication required")
+	}
+
+	if !user.HasRole(role) {
+		return nil, services.ForbiddenError("role %s required", role)
+	}
+
+	return next(ctx)
+}
+
+// HasPermission implements the @hasPermission directive: anonymous operations
+// fail with UNAUTHORIZED and users whose roles do not grant permission with
+// FORBIDDEN, like requirePermission of the REST routes.
+func HasPermission(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (interface{}, error) {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return nil, services.UnauthorizedError("authentication required")
+	}
+
+	if !user.HasPermission(permission) {
+		return nil, services.ForbiddenError("permission %s required", permission)
+	}
+
+	return next(ctx)
+}
+
+// IsAuthenticated implements the @isAuthenticated directive: anonymous
+// operations fail with UNAUTHORIZED.
+func IsAuthenticated(ctx context.Context, obj interface{}, next graphql.Resolver) (interface{}, error) {
+	if _, ok := auth.UserFrom(ctx); !ok {
+		return nil, services.UnauthorizedError("authentication required")
+	}
+
+	return next(ctx)
+}
+
+// HasScope implements the @hasScope directive: operations made with an API
+// key that lacks scope fail with FORBIDDEN, see auth.HasScope.
+func HasScope(ctx context.Context, obj interface{}, next graphql.Resolver, scope requests.Scope) (interface{}, error) {
+	if !auth.HasScope(ctx, scope) {
+		return nil, services.ForbiddenError("API key scope %s required", scope)
+	}
+
+	return next(ctx)
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/services"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/sirupsen/logrus"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter attaches the services.ErrorCode of a resolver error as
+// extensions.code so clients don't have to match on the message. Ids refused
+// by the ID scalar are bad requests. Internal errors are logged and shown as
+// services.InternalErrorMessage, their message may carry SQL and driver
+// details.
+func ErrorPresenter(logger logrus.FieldLogger) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+		if _, ok := gqlErr.Extensions["code"]; ok {
+			return gqlErr
+		}
+
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+
+		code := services.ErrorCodeOf(err)
+		if errors.Is(err, helpers.ErrInvalidID) {
+			code = services.ErrBadRequest
+		}
+
+		if code == services.ErrInternal {
+			logger.WithError(err).WithField("path", gqlErr.Path.String()).Error("internal GraphQL error")
+			gqlErr.Message = services.InternalErrorMessage
+		}
+
+		gqlErr.Extensions["code"] = code
+		return gqlErr
+	}
+}
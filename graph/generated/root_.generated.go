@@ -30,56 +30,253 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	Category() CategoryResolver
 	Mutation() MutationResolver
+	Order() OrderResolver
 	Product() ProductResolver
 	Query() QueryResolver
 	User() UserResolver
 }
 
 type DirectiveRoot struct {
+	HasPermission   func(ctx context.Context, obj interface{}, next graphql.Resolver, permission string) (res interface{}, err error)
+	HasRole         func(ctx context.Context, obj interface{}, next graphql.Resolver, role requests.Role) (res interface{}, err error)
+	HasScope        func(ctx context.Context, obj interface{}, next graphql.Resolver, scope requests.Scope) (res interface{}, err error)
+	IsAuthenticated func(ctx context.Context, obj interface{}, next graphql.Resolver) (res interface{}, err error)
 }
 
 type ComplexityRoot struct {
+	BulkError struct {
+		Code    func(childComplexity int) int
+		Message func(childComplexity int) int
+	}
+
+	BulkProductResult struct {
+		Deleted func(childComplexity int) int
+		Error   func(childComplexity int) int
+		Index   func(childComplexity int) int
+		Product func(childComplexity int) int
+	}
+
+	BulkProductsResult struct {
+		Failed     func(childComplexity int) int
+		Results    func(childComplexity int) int
+		RolledBack func(childComplexity int) int
+		Succeeded  func(childComplexity int) int
+	}
+
+	Category struct {
+		Children  func(childComplexity int) int
+		CreatedAt func(childComplexity int) int
+		ID        func(childComplexity int) int
+		Name      func(childComplexity int) int
+		Parent    func(childComplexity int) int
+		ParentID  func(childComplexity int) int
+		UpdatedAt func(childComplexity int) int
+	}
+
 	DeletedProduct struct {
 		Deleted   func(childComplexity int) int
+		Permanent func(childComplexity int) int
 		ProductID func(childComplexity int) int
 	}
 
+	DeletedUser struct {
+		Deleted      func(childComplexity int) int
+		Policy       func(childComplexity int) int
+		Products     func(childComplexity int) int
+		ReassignedTo func(childComplexity int) int
+		UserID       func(childComplexity int) int
+	}
+
+	ExchangeRate struct {
+		Base      func(childComplexity int) int
+		Quote     func(childComplexity int) int
+		Rate      func(childComplexity int) int
+		UpdatedAt func(childComplexity int) int
+	}
+
 	Mutation struct {
-		CreateProduct func(childComplexity int, input requests.CreateProductRequest) int
-		CreateUser    func(childComplexity int, input requests.CreateUserRequest) int
-		DeleteProduct func(childComplexity int, input requests.BindUriID) int
-		UpdateProduct func(childComplexity int, input requests.UpdateProductRequest) int
+		AdjustStock          func(childComplexity int, input requests.AdjustStockRequest) int
+		AssignRole           func(childComplexity int, input requests.UserRoleRequest) int
+		BulkCreateProducts   func(childComplexity int, input []*requests.CreateProductRequest, atomic *bool) int
+		BulkDeleteProducts   func(childComplexity int, input requests.BulkDeleteProductsRequest, atomic *bool) int
+		BulkPatchProducts    func(childComplexity int, input []*requests.PatchProductRequest, atomic *bool) int
+		CommitReservation    func(childComplexity int, input requests.BindUriID) int
+		ConfirmPayment       func(childComplexity int, input requests.BindUriID) int
+		CreateCategory       func(childComplexity int, input requests.CreateCategoryRequest) int
+		CreateOrder          func(childComplexity int, input requests.CreateOrderRequest) int
+		CreateProduct        func(childComplexity int, input requests.CreateProductRequest) int
+		CreateReservation    func(childComplexity int, input requests.CreateReservationRequest) int
+		CreateTag            func(childComplexity int, input requests.CreateTagRequest) int
+		CreateUser           func(childComplexity int, input requests.CreateUserRequest) int
+		DeleteCategory       func(childComplexity int, input requests.BindUriID) int
+		DeleteProduct        func(childComplexity int, input requests.BindUriID, permanent *bool) int
+		DeleteTag            func(childComplexity int, input requests.BindUriID) int
+		DeleteUser           func(childComplexity int, input requests.DeleteUserRequest) int
+		PatchProduct         func(childComplexity int, input requests.PatchProductRequest) int
+		PatchUser            func(childComplexity int, input requests.PatchUserRequest) int
+		RefundPayment        func(childComplexity int, input requests.BindUriID) int
+		ReleaseReservation   func(childComplexity int, input requests.BindUriID) int
+		RestoreProduct       func(childComplexity int, input requests.BindUriID) int
+		RevokeRole           func(childComplexity int, input requests.UserRoleRequest) int
+		SetExchangeRate      func(childComplexity int, input requests.SetExchangeRateRequest) int
+		SetProductCategories func(childComplexity int, input requests.SetProductCategoriesRequest) int
+		SetProductTags       func(childComplexity int, input requests.SetProductTagsRequest) int
+		StartPayment         func(childComplexity int, input requests.StartPaymentRequest) int
+		UpdateCategory       func(childComplexity int, input requests.UpdateCategoryRequest) int
+		UpdateOrderStatus    func(childComplexity int, input requests.UpdateOrderStatusRequest) int
+		UpdateProduct        func(childComplexity int, input requests.UpdateProductRequest) int
+		UpdateUser           func(childComplexity int, input requests.UpdateUserRequest) int
 	}
 
-	PageInfo struct {
-		EndCursor   func(childComplexity int) int
-		HasNextPage func(childComplexity int) int
-		StartCursor func(childComplexity int) int
+	OffsetPageInfo struct {
+		Limit  func(childComplexity int) int
+		Offset func(childComplexity int) int
+		Total  func(childComplexity int) int
 	}
 
-	Product struct {
+	Order struct {
 		CreatedAt func(childComplexity int) int
 		ID        func(childComplexity int) int
-		Name      func(childComplexity int) int
-		Price     func(childComplexity int) int
-		User      func(childComplexity int, input *requests.BindUriID) int
+		Items     func(childComplexity int) int
+		Status    func(childComplexity int) int
+		Total     func(childComplexity int) int
+		UpdatedAt func(childComplexity int) int
+		User      func(childComplexity int) int
 		UserID    func(childComplexity int) int
 	}
 
-	ProductEdge struct {
+	OrderEdge struct {
 		Cursor func(childComplexity int) int
 		Node   func(childComplexity int) int
 	}
 
+	OrderItem struct {
+		ID          func(childComplexity int) int
+		ProductID   func(childComplexity int) int
+		ProductName func(childComplexity int) int
+		Quantity    func(childComplexity int) int
+		Total       func(childComplexity int) int
+		UnitPrice   func(childComplexity int) int
+	}
+
+	Orders struct {
+		Edges    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
+	}
+
+	PageInfo struct {
+		EndCursor       func(childComplexity int) int
+		HasNextPage     func(childComplexity int) int
+		HasPreviousPage func(childComplexity int) int
+		StartCursor     func(childComplexity int) int
+	}
+
+	Payment struct {
+		Amount       func(childComplexity int) int
+		ClientSecret func(childComplexity int) int
+		CreatedAt    func(childComplexity int) int
+		ID           func(childComplexity int) int
+		IntentID     func(childComplexity int) int
+		OrderID      func(childComplexity int) int
+		ProductID    func(childComplexity int) int
+		Provider     func(childComplexity int) int
+		Status       func(childComplexity int) int
+		UpdatedAt    func(childComplexity int) int
+		UserID       func(childComplexity int) int
+	}
+
+	Product struct {
+		Categories func(childComplexity int) int
+		CreatedAt  func(childComplexity int) int
+		DeletedAt  func(childComplexity int) int
+		ID         func(childComplexity int) int
+		Name       func(childComplexity int) int
+		Price      func(childComplexity int, currency *string) int
+		Stock      func(childComplexity int) int
+		Tags       func(childComplexity int) int
+		UpdatedAt  func(childComplexity int) int
+		User       func(childComplexity int, input *requests.BindUriID) int
+		UserID     func(childComplexity int) int
+		Version    func(childComplexity int) int
+	}
+
+	ProductEdge struct {
+		Cursor    func(childComplexity int) int
+		Highlight func(childComplexity int) int
+		Node      func(childComplexity int) int
+		Rank      func(childComplexity int) int
+	}
+
+	ProductList struct {
+		PageInfo func(childComplexity int) int
+		Products func(childComplexity int) int
+	}
+
 	Products struct {
 		Edges    func(childComplexity int) int
 		PageInfo func(childComplexity int) int
 	}
 
 	Query struct {
-		GetProduct func(childComplexity int, input requests.BindUriID) int
-		GetUser    func(childComplexity int, input requests.BindUriID) int
+		Categories       func(childComplexity int, input *requests.ListCategoriesRequest) int
+		Category         func(childComplexity int, input requests.BindUriID) int
+		ExchangeRates    func(childComplexity int) int
+		GetProduct       func(childComplexity int, input requests.BindUriID) int
+		GetUser          func(childComplexity int, input requests.BindUriID) int
+		Node             func(childComplexity int, id string) int
+		Nodes            func(childComplexity int, ids []string) int
+		Order            func(childComplexity int, input requests.BindUriID) int
+		Payment          func(childComplexity int, input requests.BindUriID) int
+		Products         func(childComplexity int, filter *requests.ProductFilter, orderBy *requests.ProductOrder, limit *int, offset *int) int
+		Reservation      func(childComplexity int, input requests.BindUriID) int
+		Roles            func(childComplexity int) int
+		SearchProducts   func(childComplexity int, query string, first *int, after *string) int
+		StockAdjustments func(childComplexity int, input requests.ListStockAdjustmentsRequest) int
+		Tags             func(childComplexity int) int
+		UserOrders       func(childComplexity int, input requests.GetUserOrdersRequest) int
+		UserRoles        func(childComplexity int, input requests.BindUriID) int
+		Users            func(childComplexity int, first *int, after *string) int
+	}
+
+	Reservation struct {
+		CreatedAt func(childComplexity int) int
+		ExpiresAt func(childComplexity int) int
+		ID        func(childComplexity int) int
+		ProductID func(childComplexity int) int
+		Quantity  func(childComplexity int) int
+		Status    func(childComplexity int) int
+		UpdatedAt func(childComplexity int) int
+		UserID    func(childComplexity int) int
+	}
+
+	RoleInfo struct {
+		Description func(childComplexity int) int
+		Name        func(childComplexity int) int
+		Permissions func(childComplexity int) int
+	}
+
+	Stock struct {
+		Available func(childComplexity int) int
+		OnHand    func(childComplexity int) int
+		ProductID func(childComplexity int) int
+		Reserved  func(childComplexity int) int
+	}
+
+	StockAdjustment struct {
+		CreatedAt func(childComplexity int) int
+		Delta     func(childComplexity int) int
+		ID        func(childComplexity int) int
+		Note      func(childComplexity int) int
+		ProductID func(childComplexity int) int
+		Reason    func(childComplexity int) int
+	}
+
+	Tag struct {
+		CreatedAt func(childComplexity int) int
+		ID        func(childComplexity int) int
+		Name      func(childComplexity int) int
 	}
 
 	User struct {
@@ -88,6 +285,18 @@ type ComplexityRoot struct {
 		ID        func(childComplexity int) int
 		Name      func(childComplexity int) int
 		Products  func(childComplexity int, input *requests.GetUserProductsRequest) int
+		UpdatedAt func(childComplexity int) int
+		Version   func(childComplexity int) int
+	}
+
+	UserEdge struct {
+		Cursor func(childComplexity int) int
+		Node   func(childComplexity int) int
+	}
+
+	Users struct {
+		Edges    func(childComplexity int) int
+		PageInfo func(childComplexity int) int
 	}
 }
 
@@ -106,6 +315,125 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 	_ = ec
 	switch typeName + "." + field {
 
+	case "BulkError.code":
+		if e.complexity.BulkError.Code == nil {
+			break
+		}
+
+		return e.complexity.BulkError.Code(childComplexity), true
+
+	case "BulkError.message":
+		if e.complexity.BulkError.Message == nil {
+			break
+		}
+
+		return e.complexity.BulkError.Message(childComplexity), true
+
+	case "BulkProductResult.deleted":
+		if e.complexity.BulkProductResult.Deleted == nil {
+			break
+		}
+
+		return e.complexity.BulkProductResult.Deleted(childComplexity), true
+
+	case "BulkProductResult.error":
+		if e.complexity.BulkProductResult.Error == nil {
+			break
+		}
+
+		return e.complexity.BulkProductResult.Error(childComplexity), true
+
+	case "BulkProductResult.index":
+		if e.complexity.BulkProductResult.Index == nil {
+			break
+		}
+
+		return e.complexity.BulkProductResult.Index(childComplexity), true
+
+	case "BulkProductResult.product":
+		if e.complexity.BulkProductResult.Product == nil {
+			break
+		}
+
+		return e.complexity.BulkProductResult.Product(childComplexity), true
+
+	case "BulkProductsResult.failed":
+		if e.complexity.BulkProductsResult.Failed == nil {
+			break
+		}
+
+		return e.complexity.BulkProductsResult.Failed(childComplexity), true
+
+	case "BulkProductsResult.results":
+		if e.complexity.BulkProductsResult.Results == nil {
+			break
+		}
+
+		return e.complexity.BulkProductsResult.Results(childComplexity), true
+
+	case "BulkProductsResult.rolled_back":
+		if e.complexity.BulkProductsResult.RolledBack == nil {
+			break
+		}
+
+		return e.complexity.BulkProductsResult.RolledBack(childComplexity), true
+
+	case "BulkProductsResult.succeeded":
+		if e.complexity.BulkProductsResult.Succeeded == nil {
+			break
+		}
+
+		return e.complexity.BulkProductsResult.Succeeded(childComplexity), true
+
+	case "Category.children":
+		if e.complexity.Category.Children == nil {
+			break
+		}
+
+		return e.complexity.Category.Children(childComplexity), true
+
+	case "Category.created_at":
+		if e.complexity.Category.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Category.CreatedAt(childComplexity), true
+
+	case "Category.id":
+		if e.complexity.Category.ID == nil {
+			break
+		}
+
+		return e.complexity.Category.ID(childComplexity), true
+
+	case "Category.name":
+		if e.complexity.Category.Name == nil {
+			break
+		}
+
+		return e.complexity.Category.Name(childComplexity), true
+
+	case "Category.parent":
+		if e.complexity.Category.Parent == nil {
+			break
+		}
+
+		return e.complexity.Category.Parent(childComplexity), true
+
+	case "Category.parent_id":
+		if e.complexity.Category.ParentID == nil {
+			break
+		}
+
+		return e.complexity.Category.ParentID(childComplexity), true
+
+	case "Category.updated_at":
+		if e.complexity.Category.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.Category.UpdatedAt(childComplexity), true
+
 	case "DeletedProduct.deleted":
 		if e.complexity.DeletedProduct.Deleted == nil {
 			break
@@ -113,6 +441,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DeletedProduct.Deleted(childComplexity), true
 
+	case "DeletedProduct.permanent":
+		if e.complexity.DeletedProduct.Permanent == nil {
+			break
+		}
+
+		return e.complexity.DeletedProduct.Permanent(childComplexity), true
+
 	case "DeletedProduct.product_id":
 		if e.complexity.DeletedProduct.ProductID == nil {
 			break
@@ -120,173 +455,1211 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DeletedProduct.ProductID(childComplexity), true
 
-	case "Mutation.CreateProduct":
-		if e.complexity.Mutation.CreateProduct == nil {
+	case "DeletedUser.deleted":
+		if e.complexity.DeletedUser.Deleted == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_CreateProduct_args(context.TODO(), rawArgs)
+		return e.complexity.DeletedUser.Deleted(childComplexity), true
+
+	case "DeletedUser.policy":
+		if e.complexity.DeletedUser.Policy == nil {
+			break
+		}
+
+		return e.complexity.DeletedUser.Policy(childComplexity), true
+
+	case "DeletedUser.products":
+		if e.complexity.DeletedUser.Products == nil {
+			break
+		}
+
+		return e.complexity.DeletedUser.Products(childComplexity), true
+
+	case "DeletedUser.reassigned_to":
+		if e.complexity.DeletedUser.ReassignedTo == nil {
+			break
+		}
+
+		return e.complexity.DeletedUser.ReassignedTo(childComplexity), true
+
+	case "DeletedUser.user_id":
+		if e.complexity.DeletedUser.UserID == nil {
+			break
+		}
+
+		return e.complexity.DeletedUser.UserID(childComplexity), true
+
+	case "ExchangeRate.base":
+		if e.complexity.ExchangeRate.Base == nil {
+			break
+		}
+
+		return e.complexity.ExchangeRate.Base(childComplexity), true
+
+	case "ExchangeRate.quote":
+		if e.complexity.ExchangeRate.Quote == nil {
+			break
+		}
+
+		return e.complexity.ExchangeRate.Quote(childComplexity), true
+
+	case "ExchangeRate.rate":
+		if e.complexity.ExchangeRate.Rate == nil {
+			break
+		}
+
+		return e.complexity.ExchangeRate.Rate(childComplexity), true
+
+	case "ExchangeRate.updated_at":
+		if e.complexity.ExchangeRate.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.ExchangeRate.UpdatedAt(childComplexity), true
+
+	case "Mutation.adjustStock":
+		if e.complexity.Mutation.AdjustStock == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_adjustStock_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.CreateProduct(childComplexity, args["input"].(requests.CreateProductRequest)), true
+		return e.complexity.Mutation.AdjustStock(childComplexity, args["input"].(requests.AdjustStockRequest)), true
 
-	case "Mutation.CreateUser":
-		if e.complexity.Mutation.CreateUser == nil {
+	case "Mutation.assignRole":
+		if e.complexity.Mutation.AssignRole == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_CreateUser_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_assignRole_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.CreateUser(childComplexity, args["input"].(requests.CreateUserRequest)), true
+		return e.complexity.Mutation.AssignRole(childComplexity, args["input"].(requests.UserRoleRequest)), true
 
-	case "Mutation.DeleteProduct":
-		if e.complexity.Mutation.DeleteProduct == nil {
+	case "Mutation.bulkCreateProducts":
+		if e.complexity.Mutation.BulkCreateProducts == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_DeleteProduct_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_bulkCreateProducts_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.DeleteProduct(childComplexity, args["input"].(requests.BindUriID)), true
+		return e.complexity.Mutation.BulkCreateProducts(childComplexity, args["input"].([]*requests.CreateProductRequest), args["atomic"].(*bool)), true
 
-	case "Mutation.UpdateProduct":
-		if e.complexity.Mutation.UpdateProduct == nil {
+	case "Mutation.bulkDeleteProducts":
+		if e.complexity.Mutation.BulkDeleteProducts == nil {
 			break
 		}
 
-		args, err := ec.field_Mutation_UpdateProduct_args(context.TODO(), rawArgs)
+		args, err := ec.field_Mutation_bulkDeleteProducts_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateProduct(childComplexity, args["input"].(requests.UpdateProductRequest)), true
+		return e.complexity.Mutation.BulkDeleteProducts(childComplexity, args["input"].(requests.BulkDeleteProductsRequest), args["atomic"].(*bool)), true
 
-	case "PageInfo.end_cursor":
-		if e.complexity.PageInfo.EndCursor == nil {
+	case "Mutation.bulkPatchProducts":
+		if e.complexity.Mutation.BulkPatchProducts == nil {
 			break
 		}
 
-		return e.complexity.PageInfo.EndCursor(childComplexity), true
+		args, err := ec.field_Mutation_bulkPatchProducts_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	case "PageInfo.has_next_page":
-		if e.complexity.PageInfo.HasNextPage == nil {
+		return e.complexity.Mutation.BulkPatchProducts(childComplexity, args["input"].([]*requests.PatchProductRequest), args["atomic"].(*bool)), true
+
+	case "Mutation.commitReservation":
+		if e.complexity.Mutation.CommitReservation == nil {
 			break
 		}
 
-		return e.complexity.PageInfo.HasNextPage(childComplexity), true
+		args, err := ec.field_Mutation_commitReservation_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	case "PageInfo.start_cursor":
-		if e.complexity.PageInfo.StartCursor == nil {
+		return e.complexity.Mutation.CommitReservation(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Mutation.confirmPayment":
+		if e.complexity.Mutation.ConfirmPayment == nil {
 			break
 		}
 
-		return e.complexity.PageInfo.StartCursor(childComplexity), true
+		args, err := ec.field_Mutation_confirmPayment_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	case "Product.created_at":
-		if e.complexity.Product.CreatedAt == nil {
+		return e.complexity.Mutation.ConfirmPayment(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Mutation.createCategory":
+		if e.complexity.Mutation.CreateCategory == nil {
 			break
 		}
 
-		return e.complexity.Product.CreatedAt(childComplexity), true
+		args, err := ec.field_Mutation_createCategory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	case "Product.id":
-		if e.complexity.Product.ID == nil {
+		return e.complexity.Mutation.CreateCategory(childComplexity, args["input"].(requests.CreateCategoryRequest)), true
+
+	case "Mutation.createOrder":
+		if e.complexity.Mutation.CreateOrder == nil {
 			break
 		}
 
-		return e.complexity.Product.ID(childComplexity), true
+		args, err := ec.field_Mutation_createOrder_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
 
-	case "Product.name":
-		if e.complexity.Product.Name == nil {
+		return e.complexity.Mutation.CreateOrder(childComplexity, args["input"].(requests.CreateOrderRequest)), true
+
+	case "Mutation.CreateProduct":
+		if e.complexity.Mutation.CreateProduct == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_CreateProduct_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateProduct(childComplexity, args["input"].(requests.CreateProductRequest)), true
+
+	case "Mutation.createReservation":
+		if e.complexity.Mutation.CreateReservation == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_createReservation_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateReservation(childComplexity, args["input"].(requests.CreateReservationRequest)), true
+
+	case "Mutation.createTag":
+		if e.complexity.Mutation.CreateTag == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_createTag_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateTag(childComplexity, args["input"].(requests.CreateTagRequest)), true
+
+	case "Mutation.CreateUser":
+		if e.complexity.Mutation.CreateUser == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_CreateUser_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateUser(childComplexity, args["input"].(requests.CreateUserRequest)), true
+
+	case "Mutation.deleteCategory":
+		if e.complexity.Mutation.DeleteCategory == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteCategory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteCategory(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Mutation.DeleteProduct":
+		if e.complexity.Mutation.DeleteProduct == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_DeleteProduct_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteProduct(childComplexity, args["input"].(requests.BindUriID), args["permanent"].(*bool)), true
+
+	case "Mutation.deleteTag":
+		if e.complexity.Mutation.DeleteTag == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteTag_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteTag(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Mutation.deleteUser":
+		if e.complexity.Mutation.DeleteUser == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteUser_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteUser(childComplexity, args["input"].(requests.DeleteUserRequest)), true
+
+	case "Mutation.patchProduct":
+		if e.complexity.Mutation.PatchProduct == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_patchProduct_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.PatchProduct(childComplexity, args["input"].(requests.PatchProductRequest)), true
+
+	case "Mutation.patchUser":
+		if e.complexity.Mutation.PatchUser == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_patchUser_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.PatchUser(childComplexity, args["input"].(requests.PatchUserRequest)), true
+
+	case "Mutation.refundPayment":
+		if e.complexity.Mutation.RefundPayment == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_refundPayment_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RefundPayment(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Mutation.releaseReservation":
+		if e.complexity.Mutation.ReleaseReservation == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_releaseReservation_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ReleaseReservation(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Mutation.restoreProduct":
+		if e.complexity.Mutation.RestoreProduct == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_restoreProduct_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RestoreProduct(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Mutation.revokeRole":
+		if e.complexity.Mutation.RevokeRole == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_revokeRole_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RevokeRole(childComplexity, args["input"].(requests.UserRoleRequest)), true
+
+	case "Mutation.setExchangeRate":
+		if e.complexity.Mutation.SetExchangeRate == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_setExchangeRate_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SetExchangeRate(childComplexity, args["input"].(requests.SetExchangeRateRequest)), true
+
+	case "Mutation.setProductCategories":
+		if e.complexity.Mutation.SetProductCategories == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_setProductCategories_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SetProductCategories(childComplexity, args["input"].(requests.SetProductCategoriesRequest)), true
+
+	case "Mutation.setProductTags":
+		if e.complexity.Mutation.SetProductTags == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_setProductTags_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.SetProductTags(childComplexity, args["input"].(requests.SetProductTagsRequest)), true
+
+	case "Mutation.startPayment":
+		if e.complexity.Mutation.StartPayment == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_startPayment_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.StartPayment(childComplexity, args["input"].(requests.StartPaymentRequest)), true
+
+	case "Mutation.updateCategory":
+		if e.complexity.Mutation.UpdateCategory == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateCategory_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateCategory(childComplexity, args["input"].(requests.UpdateCategoryRequest)), true
+
+	case "Mutation.updateOrderStatus":
+		if e.complexity.Mutation.UpdateOrderStatus == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateOrderStatus_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateOrderStatus(childComplexity, args["input"].(requests.UpdateOrderStatusRequest)), true
+
+	case "Mutation.UpdateProduct":
+		if e.complexity.Mutation.UpdateProduct == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_UpdateProduct_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateProduct(childComplexity, args["input"].(requests.UpdateProductRequest)), true
+
+	case "Mutation.updateUser":
+		if e.complexity.Mutation.UpdateUser == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateUser_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateUser(childComplexity, args["input"].(requests.UpdateUserRequest)), true
+
+	case "OffsetPageInfo.limit":
+		if e.complexity.OffsetPageInfo.Limit == nil {
+			break
+		}
+
+		return e.complexity.OffsetPageInfo.Limit(childComplexity), true
+
+	case "OffsetPageInfo.offset":
+		if e.complexity.OffsetPageInfo.Offset == nil {
+			break
+		}
+
+		return e.complexity.OffsetPageInfo.Offset(childComplexity), true
+
+	case "OffsetPageInfo.total":
+		if e.complexity.OffsetPageInfo.Total == nil {
+			break
+		}
+
+		return e.complexity.OffsetPageInfo.Total(childComplexity), true
+
+	case "Order.created_at":
+		if e.complexity.Order.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Order.CreatedAt(childComplexity), true
+
+	case "Order.id":
+		if e.complexity.Order.ID == nil {
+			break
+		}
+
+		return e.complexity.Order.ID(childComplexity), true
+
+	case "Order.items":
+		if e.complexity.Order.Items == nil {
+			break
+		}
+
+		return e.complexity.Order.Items(childComplexity), true
+
+	case "Order.status":
+		if e.complexity.Order.Status == nil {
+			break
+		}
+
+		return e.complexity.Order.Status(childComplexity), true
+
+	case "Order.total":
+		if e.complexity.Order.Total == nil {
+			break
+		}
+
+		return e.complexity.Order.Total(childComplexity), true
+
+	case "Order.updated_at":
+		if e.complexity.Order.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.Order.UpdatedAt(childComplexity), true
+
+	case "Order.user":
+		if e.complexity.Order.User == nil {
+			break
+		}
+
+		return e.complexity.Order.User(childComplexity), true
+
+	case "Order.user_id":
+		if e.complexity.Order.UserID == nil {
+			break
+		}
+
+		return e.complexity.Order.UserID(childComplexity), true
+
+	case "OrderEdge.cursor":
+		if e.complexity.OrderEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.OrderEdge.Cursor(childComplexity), true
+
+	case "OrderEdge.node":
+		if e.complexity.OrderEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.OrderEdge.Node(childComplexity), true
+
+	case "OrderItem.id":
+		if e.complexity.OrderItem.ID == nil {
+			break
+		}
+
+		return e.complexity.OrderItem.ID(childComplexity), true
+
+	case "OrderItem.product_id":
+		if e.complexity.OrderItem.ProductID == nil {
+			break
+		}
+
+		return e.complexity.OrderItem.ProductID(childComplexity), true
+
+	case "OrderItem.product_name":
+		if e.complexity.OrderItem.ProductName == nil {
+			break
+		}
+
+		return e.complexity.OrderItem.ProductName(childComplexity), true
+
+	case "OrderItem.quantity":
+		if e.complexity.OrderItem.Quantity == nil {
+			break
+		}
+
+		return e.complexity.OrderItem.Quantity(childComplexity), true
+
+	case "OrderItem.total":
+		if e.complexity.OrderItem.Total == nil {
+			break
+		}
+
+		return e.complexity.OrderItem.Total(childComplexity), true
+
+	case "OrderItem.unit_price":
+		if e.complexity.OrderItem.UnitPrice == nil {
+			break
+		}
+
+		return e.complexity.OrderItem.UnitPrice(childComplexity), true
+
+	case "Orders.edges":
+		if e.complexity.Orders.Edges == nil {
+			break
+		}
+
+		return e.complexity.Orders.Edges(childComplexity), true
+
+	case "Orders.page_info":
+		if e.complexity.Orders.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.Orders.PageInfo(childComplexity), true
+
+	case "PageInfo.end_cursor":
+		if e.complexity.PageInfo.EndCursor == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.EndCursor(childComplexity), true
+
+	case "PageInfo.has_next_page":
+		if e.complexity.PageInfo.HasNextPage == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.HasNextPage(childComplexity), true
+
+	case "PageInfo.has_previous_page":
+		if e.complexity.PageInfo.HasPreviousPage == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.HasPreviousPage(childComplexity), true
+
+	case "PageInfo.start_cursor":
+		if e.complexity.PageInfo.StartCursor == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.StartCursor(childComplexity), true
+
+	case "Payment.amount":
+		if e.complexity.Payment.Amount == nil {
+			break
+		}
+
+		return e.complexity.Payment.Amount(childComplexity), true
+
+	case "Payment.client_secret":
+		if e.complexity.Payment.ClientSecret == nil {
+			break
+		}
+
+		return e.complexity.Payment.ClientSecret(childComplexity), true
+
+	case "Payment.created_at":
+		if e.complexity.Payment.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Payment.CreatedAt(childComplexity), true
+
+	case "Payment.id":
+		if e.complexity.Payment.ID == nil {
+			break
+		}
+
+		return e.complexity.Payment.ID(childComplexity), true
+
+	case "Payment.intent_id":
+		if e.complexity.Payment.IntentID == nil {
+			break
+		}
+
+		return e.complexity.Payment.IntentID(childComplexity), true
+
+	case "Payment.order_id":
+		if e.complexity.Payment.OrderID == nil {
+			break
+		}
+
+		return e.complexity.Payment.OrderID(childComplexity), true
+
+	case "Payment.product_id":
+		if e.complexity.Payment.ProductID == nil {
+			break
+		}
+
+		return e.complexity.Payment.ProductID(childComplexity), true
+
+	case "Payment.provider":
+		if e.complexity.Payment.Provider == nil {
+			break
+		}
+
+		return e.complexity.Payment.Provider(childComplexity), true
+
+	case "Payment.status":
+		if e.complexity.Payment.Status == nil {
+			break
+		}
+
+		return e.complexity.Payment.Status(childComplexity), true
+
+	case "Payment.updated_at":
+		if e.complexity.Payment.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.Payment.UpdatedAt(childComplexity), true
+
+	case "Payment.user_id":
+		if e.complexity.Payment.UserID == nil {
+			break
+		}
+
+		return e.complexity.Payment.UserID(childComplexity), true
+
+	case "Product.categories":
+		if e.complexity.Product.Categories == nil {
+			break
+		}
+
+		return e.complexity.Product.Categories(childComplexity), true
+
+	case "Product.created_at":
+		if e.complexity.Product.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Product.CreatedAt(childComplexity), true
+
+	case "Product.deleted_at":
+		if e.complexity.Product.DeletedAt == nil {
+			break
+		}
+
+		return e.complexity.Product.DeletedAt(childComplexity), true
+
+	case "Product.id", "Product.database_id":
+		if e.complexity.Product.ID == nil {
+			break
+		}
+
+		return e.complexity.Product.ID(childComplexity), true
+
+	case "Product.name":
+		if e.complexity.Product.Name == nil {
+			break
+		}
+
+		return e.complexity.Product.Name(childComplexity), true
+
+	case "Product.price":
+		if e.complexity.Product.Price == nil {
+			break
+		}
+
+		args, err := ec.field_Product_price_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Product.Price(childComplexity, args["currency"].(*string)), true
+
+	case "Product.stock":
+		if e.complexity.Product.Stock == nil {
+			break
+		}
+
+		return e.complexity.Product.Stock(childComplexity), true
+
+	case "Product.tags":
+		if e.complexity.Product.Tags == nil {
+			break
+		}
+
+		return e.complexity.Product.Tags(childComplexity), true
+
+	case "Product.updated_at":
+		if e.complexity.Product.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.Product.UpdatedAt(childComplexity), true
+
+	case "Product.user":
+		if e.complexity.Product.User == nil {
+			break
+		}
+
+		args, err := ec.field_Product_user_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Product.User(childComplexity, args["input"].(*requests.BindUriID)), true
+
+	case "Product.user_id":
+		if e.complexity.Product.UserID == nil {
+			break
+		}
+
+		return e.complexity.Product.UserID(childComplexity), true
+
+	case "Product.version":
+		if e.complexity.Product.Version == nil {
+			break
+		}
+
+		return e.complexity.Product.Version(childComplexity), true
+
+	case "ProductEdge.cursor":
+		if e.complexity.ProductEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.ProductEdge.Cursor(childComplexity), true
+
+	case "ProductEdge.highlight":
+		if e.complexity.ProductEdge.Highlight == nil {
+			break
+		}
+
+		return e.complexity.ProductEdge.Highlight(childComplexity), true
+
+	case "ProductEdge.node":
+		if e.complexity.ProductEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.ProductEdge.Node(childComplexity), true
+
+	case "ProductEdge.rank":
+		if e.complexity.ProductEdge.Rank == nil {
+			break
+		}
+
+		return e.complexity.ProductEdge.Rank(childComplexity), true
+
+	case "ProductList.page_info":
+		if e.complexity.ProductList.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.ProductList.PageInfo(childComplexity), true
+
+	case "ProductList.products":
+		if e.complexity.ProductList.Products == nil {
+			break
+		}
+
+		return e.complexity.ProductList.Products(childComplexity), true
+
+	case "Products.edges":
+		if e.complexity.Products.Edges == nil {
+			break
+		}
+
+		return e.complexity.Products.Edges(childComplexity), true
+
+	case "Products.page_info":
+		if e.complexity.Products.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.Products.PageInfo(childComplexity), true
+
+	case "Query.categories":
+		if e.complexity.Query.Categories == nil {
+			break
+		}
+
+		args, err := ec.field_Query_categories_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Categories(childComplexity, args["input"].(*requests.ListCategoriesRequest)), true
+
+	case "Query.category":
+		if e.complexity.Query.Category == nil {
+			break
+		}
+
+		args, err := ec.field_Query_category_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Category(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Query.exchangeRates":
+		if e.complexity.Query.ExchangeRates == nil {
+			break
+		}
+
+		return e.complexity.Query.ExchangeRates(childComplexity), true
+
+	case "Query.GetProduct":
+		if e.complexity.Query.GetProduct == nil {
+			break
+		}
+
+		args, err := ec.field_Query_GetProduct_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.GetProduct(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Query.GetUser":
+		if e.complexity.Query.GetUser == nil {
+			break
+		}
+
+		args, err := ec.field_Query_GetUser_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.GetUser(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Query.node":
+		if e.complexity.Query.Node == nil {
+			break
+		}
+
+		args, err := ec.field_Query_node_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Node(childComplexity, args["id"].(string)), true
+
+	case "Query.nodes":
+		if e.complexity.Query.Nodes == nil {
+			break
+		}
+
+		args, err := ec.field_Query_nodes_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Nodes(childComplexity, args["ids"].([]string)), true
+
+	case "Query.order":
+		if e.complexity.Query.Order == nil {
+			break
+		}
+
+		args, err := ec.field_Query_order_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Order(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Query.payment":
+		if e.complexity.Query.Payment == nil {
+			break
+		}
+
+		args, err := ec.field_Query_payment_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Payment(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Query.products":
+		if e.complexity.Query.Products == nil {
+			break
+		}
+
+		args, err := ec.field_Query_products_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Products(childComplexity, args["filter"].(*requests.ProductFilter), args["orderBy"].(*requests.ProductOrder), args["limit"].(*int), args["offset"].(*int)), true
+
+	case "Query.reservation":
+		if e.complexity.Query.Reservation == nil {
+			break
+		}
+
+		args, err := ec.field_Query_reservation_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Reservation(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Query.roles":
+		if e.complexity.Query.Roles == nil {
+			break
+		}
+
+		return e.complexity.Query.Roles(childComplexity), true
+
+	case "Query.searchProducts":
+		if e.complexity.Query.SearchProducts == nil {
+			break
+		}
+
+		args, err := ec.field_Query_searchProducts_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.SearchProducts(childComplexity, args["query"].(string), args["first"].(*int), args["after"].(*string)), true
+
+	case "Query.stockAdjustments":
+		if e.complexity.Query.StockAdjustments == nil {
+			break
+		}
+
+		args, err := ec.field_Query_stockAdjustments_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.StockAdjustments(childComplexity, args["input"].(requests.ListStockAdjustmentsRequest)), true
+
+	case "Query.tags":
+		if e.complexity.Query.Tags == nil {
+			break
+		}
+
+		return e.complexity.Query.Tags(childComplexity), true
+
+	case "Query.userOrders":
+		if e.complexity.Query.UserOrders == nil {
+			break
+		}
+
+		args, err := ec.field_Query_userOrders_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.UserOrders(childComplexity, args["input"].(requests.GetUserOrdersRequest)), true
+
+	case "Query.userRoles":
+		if e.complexity.Query.UserRoles == nil {
+			break
+		}
+
+		args, err := ec.field_Query_userRoles_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.UserRoles(childComplexity, args["input"].(requests.BindUriID)), true
+
+	case "Query.users":
+		if e.complexity.Query.Users == nil {
+			break
+		}
+
+		args, err := ec.field_Query_users_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Users(childComplexity, args["first"].(*int), args["after"].(*string)), true
+
+	case "Reservation.created_at":
+		if e.complexity.Reservation.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Reservation.CreatedAt(childComplexity), true
+
+	case "Reservation.expires_at":
+		if e.complexity.Reservation.ExpiresAt == nil {
+			break
+		}
+
+		return e.complexity.Reservation.ExpiresAt(childComplexity), true
+
+	case "Reservation.id":
+		if e.complexity.Reservation.ID == nil {
+			break
+		}
+
+		return e.complexity.Reservation.ID(childComplexity), true
+
+	case "Reservation.product_id":
+		if e.complexity.Reservation.ProductID == nil {
+			break
+		}
+
+		return e.complexity.Reservation.ProductID(childComplexity), true
+
+	case "Reservation.quantity":
+		if e.complexity.Reservation.Quantity == nil {
+			break
+		}
+
+		return e.complexity.Reservation.Quantity(childComplexity), true
+
+	case "Reservation.status":
+		if e.complexity.Reservation.Status == nil {
+			break
+		}
+
+		return e.complexity.Reservation.Status(childComplexity), true
+
+	case "Reservation.updated_at":
+		if e.complexity.Reservation.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.Reservation.UpdatedAt(childComplexity), true
+
+	case "Reservation.user_id":
+		if e.complexity.Reservation.UserID == nil {
+			break
+		}
+
+		return e.complexity.Reservation.UserID(childComplexity), true
+
+	case "RoleInfo.description":
+		if e.complexity.RoleInfo.Description == nil {
+			break
+		}
+
+		return e.complexity.RoleInfo.Description(childComplexity), true
+
+	case "RoleInfo.name":
+		if e.complexity.RoleInfo.Name == nil {
+			break
+		}
+
+		return e.complexity.RoleInfo.Name(childComplexity), true
+
+	case "RoleInfo.permissions":
+		if e.complexity.RoleInfo.Permissions == nil {
+			break
+		}
+
+		return e.complexity.RoleInfo.Permissions(childComplexity), true
+
+	case "Stock.available":
+		if e.complexity.Stock.Available == nil {
 			break
 		}
 
-		return e.complexity.Product.Name(childComplexity), true
+		return e.complexity.Stock.Available(childComplexity), true
 
-	case "Product.price":
-		if e.complexity.Product.Price == nil {
+	case "Stock.on_hand":
+		if e.complexity.Stock.OnHand == nil {
 			break
 		}
 
-		return e.complexity.Product.Price(childComplexity), true
+		return e.complexity.Stock.OnHand(childComplexity), true
 
-	case "Product.user":
-		if e.complexity.Product.User == nil {
+	case "Stock.product_id":
+		if e.complexity.Stock.ProductID == nil {
 			break
 		}
 
-		args, err := ec.field_Product_user_args(context.TODO(), rawArgs)
-		if err != nil {
-			return 0, false
+		return e.complexity.Stock.ProductID(childComplexity), true
+
+	case "Stock.reserved":
+		if e.complexity.Stock.Reserved == nil {
+			break
 		}
 
-		return e.complexity.Product.User(childComplexity, args["input"].(*requests.BindUriID)), true
+		return e.complexity.Stock.Reserved(childComplexity), true
 
-	case "Product.user_id":
-		if e.complexity.Product.UserID == nil {
+	case "StockAdjustment.created_at":
+		if e.complexity.StockAdjustment.CreatedAt == nil {
 			break
 		}
 
-		return e.complexity.Product.UserID(childComplexity), true
+		return e.complexity.StockAdjustment.CreatedAt(childComplexity), true
 
-	case "ProductEdge.cursor":
-		if e.complexity.ProductEdge.Cursor == nil {
+	case "StockAdjustment.delta":
+		if e.complexity.StockAdjustment.Delta == nil {
 			break
 		}
 
-		return e.complexity.ProductEdge.Cursor(childComplexity), true
+		return e.complexity.StockAdjustment.Delta(childComplexity), true
 
-	case "ProductEdge.node":
-		if e.complexity.ProductEdge.Node == nil {
+	case "StockAdjustment.id":
+		if e.complexity.StockAdjustment.ID == nil {
 			break
 		}
 
-		return e.complexity.ProductEdge.Node(childComplexity), true
+		return e.complexity.StockAdjustment.ID(childComplexity), true
 
-	case "Products.edges":
-		if e.complexity.Products.Edges == nil {
+	case "StockAdjustment.note":
+		if e.complexity.StockAdjustment.Note == nil {
 			break
 		}
 
-		return e.complexity.Products.Edges(childComplexity), true
+		return e.complexity.StockAdjustment.Note(childComplexity), true
 
-	case "Products.page_info":
-		if e.complexity.Products.PageInfo == nil {
+	case "StockAdjustment.product_id":
+		if e.complexity.StockAdjustment.ProductID == nil {
 			break
 		}
 
-		return e.complexity.Products.PageInfo(childComplexity), true
+		return e.complexity.StockAdjustment.ProductID(childComplexity), true
 
-	case "Query.GetProduct":
-		if e.complexity.Query.GetProduct == nil {
+	case "StockAdjustment.reason":
+		if e.complexity.StockAdjustment.Reason == nil {
 			break
 		}
 
-		args, err := ec.field_Query_GetProduct_args(context.TODO(), rawArgs)
-		if err != nil {
-			return 0, false
+		return e.complexity.StockAdjustment.Reason(childComplexity), true
+
+	case "Tag.created_at":
+		if e.complexity.Tag.CreatedAt == nil {
+			break
 		}
 
-		return e.complexity.Query.GetProduct(childComplexity, args["input"].(requests.BindUriID)), true
+		return e.complexity.Tag.CreatedAt(childComplexity), true
 
-	case "Query.GetUser":
-		if e.complexity.Query.GetUser == nil {
+	case "Tag.id":
+		if e.complexity.Tag.ID == nil {
 			break
 		}
 
-		args, err := ec.field_Query_GetUser_args(context.TODO(), rawArgs)
-		if err != nil {
-			return 0, false
+		return e.complexity.Tag.ID(childComplexity), true
+
+	case "Tag.name":
+		if e.complexity.Tag.Name == nil {
+			break
 		}
 
-		return e.complexity.Query.GetUser(childComplexity, args["input"].(requests.BindUriID)), true
+		return e.complexity.Tag.Name(childComplexity), true
 
 	case "User.created_at":
 		if e.complexity.User.CreatedAt == nil {
@@ -302,7 +1675,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.User.Email(childComplexity), true
 
-	case "User.id":
+	case "User.id", "User.database_id":
 		if e.complexity.User.ID == nil {
 			break
 		}
@@ -328,6 +1701,48 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.User.Products(childComplexity, args["input"].(*requests.GetUserProductsRequest)), true
 
+	case "User.updated_at":
+		if e.complexity.User.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.User.UpdatedAt(childComplexity), true
+
+	case "User.version":
+		if e.complexity.User.Version == nil {
+			break
+		}
+
+		return e.complexity.User.Version(childComplexity), true
+
+	case "UserEdge.cursor":
+		if e.complexity.UserEdge.Cursor == nil {
+			break
+		}
+
+		return e.complexity.UserEdge.Cursor(childComplexity), true
+
+	case "UserEdge.node":
+		if e.complexity.UserEdge.Node == nil {
+			break
+		}
+
+		return e.complexity.UserEdge.Node(childComplexity), true
+
+	case "Users.edges":
+		if e.complexity.Users.Edges == nil {
+			break
+		}
+
+		return e.complexity.Users.Edges(childComplexity), true
+
+	case "Users.page_info":
+		if e.complexity.Users.PageInfo == nil {
+			break
+		}
+
+		return e.complexity.Users.PageInfo(childComplexity), true
+
 	}
 	return 0, false
 }
@@ -336,11 +1751,34 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	rc := graphql.GetOperationContext(ctx)
 	ec := executionContext{rc, e}
 	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
+		ec.unmarshalInputAdjustStock,
+		ec.unmarshalInputBulkDeleteProducts,
+		ec.unmarshalInputDeleteUser,
+		ec.unmarshalInputListCategories,
+		ec.unmarshalInputListStockAdjustments,
+		ec.unmarshalInputNewCategory,
+		ec.unmarshalInputNewOrder,
+		ec.unmarshalInputNewOrderItem,
 		ec.unmarshalInputNewProduct,
+		ec.unmarshalInputNewReservation,
+		ec.unmarshalInputNewTag,
 		ec.unmarshalInputNewUser,
+		ec.unmarshalInputPatchProduct,
+		ec.unmarshalInputPatchUser,
+		ec.unmarshalInputProductFilter,
+		ec.unmarshalInputProductOrder,
+		ec.unmarshalInputSetExchangeRate,
+		ec.unmarshalInputSetProductCategories,
+		ec.unmarshalInputSetProductTags,
+		ec.unmarshalInputStartPayment,
+		ec.unmarshalInputUpdateCategory,
+		ec.unmarshalInputUpdateOrderStatus,
 		ec.unmarshalInputUpdateProduct,
+		ec.unmarshalInputUpdateUser,
 		ec.unmarshalInputUriID,
+		ec.unmarshalInputUserOrders,
 		ec.unmarshalInputUserProducts,
+		ec.unmarshalInputUserRole,
 	)
 	first := true
 
@@ -401,18 +1839,314 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 }
 
 var sources = []*ast.Source{
-	{Name: "../schemas/product.graphqls", Input: `type Product {
+	{Name: "../schemas/api_key.graphqls", Input: `# hasScope refuses operations made with an API key that lacks scope,
+# operations made without one have every scope.
+directive @hasScope(scope: Scope!) on FIELD_DEFINITION
+
+enum Scope {
+    PRODUCTS_READ
+    PRODUCTS_WRITE
+    ORDERS_READ
+    ORDERS_WRITE
+    USERS_READ
+    USERS_WRITE
+}
+`, BuiltIn: false},
+	{Name: "../schemas/category.graphqls", Input: `type Category {
     id: ID!
     name: String!
-    price: Int!
+    parent_id: ID
+    created_at: Time!
+    updated_at: Time!
+    parent: Category
+    children: [Category!]!
+}
+
+type Tag {
+    id: ID!
+    name: String!
+    created_at: Time!
+}
+
+input NewCategory {
+    name: String!
+    parent_id: ID
+}
+
+input UpdateCategory {
+    id: ID!
+    name: String!
+    parent_id: ID
+}
+
+input NewTag {
+    name: String!
+}
+
+input ListCategories {
+    parent_id: ID
+}
+
+input SetProductCategories {
+    product_id: ID!
+    category_ids: [ID!]!
+}
+
+input SetProductTags {
+    product_id: ID!
+    tags: [String!]!
+}
+
+extend type Mutation {
+    createCategory(input: NewCategory!): Category! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+    updateCategory(input: UpdateCategory!): Category! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+    deleteCategory(input: UriID!): Boolean! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+    createTag(input: NewTag!): Tag! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+    deleteTag(input: UriID!): Boolean! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+    setProductCategories(input: SetProductCategories!): [Category!]! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+    setProductTags(input: SetProductTags!): [Tag!]! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+}
+
+extend type Query {
+    category(input: UriID!): Category! @hasScope(scope: PRODUCTS_READ)
+    categories(input: ListCategories): [Category!]! @hasScope(scope: PRODUCTS_READ)
+    tags: [Tag!]! @hasScope(scope: PRODUCTS_READ)
+}
+`, BuiltIn: false},
+	{Name: "../schemas/inventory.graphqls", Input: `enum StockReason {
+    RECEIVED
+    RETURNED
+    SOLD
+    DAMAGED
+    LOST
+    CORRECTION
+}
+
+enum ReservationStatus {
+    ACTIVE
+    RELEASED
+    EXPIRED
+    COMMITTED
+}
+
+type Stock {
+    product_id: ID!
+    on_hand: Int!
+    reserved: Int!
+    available: Int!
+}
+
+type StockAdjustment {
+    id: ID!
+    product_id: ID!
+    delta: Int!
+    reason: StockReason!
+    note: String!
+    created_at: Time!
+}
+
+type Reservation {
+    id: ID!
+    product_id: ID!
+    user_id: ID
+    quantity: Int!
+    status: ReservationStatus!
+    expires_at: Time!
+    created_at: Time!
+    updated_at: Time!
+}
+
+input AdjustStock {
+    product_id: ID!
+    delta: Int!
+    reason: StockReason!
+    note: String
+}
+
+input ListStockAdjustments {
+    product_id: ID!
+    limit: Int
+}
+
+input NewReservation {
+    product_id: ID!
+    quantity: Int!
+    ttl_seconds: Int
+}
+
+extend type Mutation {
+    adjustStock(input: AdjustStock!): Stock! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "inventory:manage")
+    createReservation(input: NewReservation!): Reservation! @hasScope(scope: ORDERS_WRITE) @isAuthenticated
+    releaseReservation(input: UriID!): Reservation! @hasScope(scope: ORDERS_WRITE) @isAuthenticated
+    commitReservation(input: UriID!): Reservation! @hasScope(scope: ORDERS_WRITE) @isAuthenticated
+}
+
+extend type Query {
+    stockAdjustments(input: ListStockAdjustments!): [StockAdjustment!]! @hasScope(scope: PRODUCTS_READ) @hasRole(role: VIEWER) @hasPermission(permission: "inventory:read")
+    reservation(input: UriID!): Reservation! @hasScope(scope: ORDERS_READ) @isAuthenticated
+}
+`, BuiltIn: false},
+	{Name: "../schemas/money.graphqls", Input: `scalar Money
+
+type ExchangeRate {
+    base: String!
+    quote: String!
+    rate: String!
+    updated_at: Time!
+}
+
+input SetExchangeRate {
+    base: String!
+    quote: String!
+    rate: String!
+}
+
+extend type Mutation {
+    setExchangeRate(input: SetExchangeRate!): ExchangeRate! @hasScope(scope: PRODUCTS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "catalog:manage")
+}
+
+extend type Query {
+    exchangeRates: [ExchangeRate!]! @hasScope(scope: PRODUCTS_READ)
+}
+`, BuiltIn: false},
+	{Name: "../schemas/node.graphqls", Input: `interface Node {
+    id: ID!
+}
+
+# node and nodes check the read scope of the type each id points to.
+extend type Query {
+    node(id: ID!): Node
+    nodes(ids: [ID!]!): [Node]!
+}
+`, BuiltIn: false},
+	{Name: "../schemas/order.graphqls", Input: `enum OrderStatus {
+    PENDING
+    PAID
+    SHIPPED
+    CANCELLED
+}
+
+type Order {
+    id: ID!
+    user_id: ID!
+    user: User!
+    status: OrderStatus!
+    total: Money!
+    items: [OrderItem!]!
+    created_at: Time!
+    updated_at: Time!
+}
+
+type OrderItem {
+    id: ID!
+    product_id: ID
+    product_name: String!
+    unit_price: Money!
+    quantity: Int!
+    total: Money!
+}
+
+type OrderEdge {
+    cursor: String!
+    node: Order!
+}
+
+type Orders {
+    edges: [OrderEdge!]!
+    page_info: PageInfo!
+}
+
+# user_id defaults to the caller, only staff allowed to manage orders may order
+# for other users.
+input NewOrder {
+    user_id: ID
+    currency: String
+    items: [NewOrderItem!]!
+}
+
+input NewOrderItem {
+    product_id: ID!
+    quantity: Int!
+}
+
+input UpdateOrderStatus {
+    id: ID!
+    status: OrderStatus!
+}
+
+input UserOrders {
+    user_id: ID!
+    first: Int
+    after: String
+}
+
+extend type Mutation {
+    createOrder(input: NewOrder!): Order! @hasScope(scope: ORDERS_WRITE) @isAuthenticated
+    updateOrderStatus(input: UpdateOrderStatus!): Order! @hasScope(scope: ORDERS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "orders:manage")
+}
+
+extend type Query {
+    order(input: UriID!): Order! @hasScope(scope: ORDERS_READ) @isAuthenticated
+    userOrders(input: UserOrders!): Orders! @hasScope(scope: ORDERS_READ) @isAuthenticated
+}
+`, BuiltIn: false},
+	{Name: "../schemas/payment.graphqls", Input: `enum PaymentStatus {
+    PENDING
+    SUCCEEDED
+    FAILED
+    REFUNDED
+}
+
+type Payment {
+    id: ID!
+    order_id: ID
+    product_id: ID
+    user_id: ID
+    provider: String!
+    intent_id: String!
+    status: PaymentStatus!
+    amount: Money!
+    client_secret: String
+    created_at: Time!
+    updated_at: Time!
+}
+
+input StartPayment {
+    order_id: ID
+    product_id: ID
+}
+
+extend type Mutation {
+    startPayment(input: StartPayment!): Payment! @hasScope(scope: ORDERS_WRITE) @isAuthenticated
+    confirmPayment(input: UriID!): Payment! @hasScope(scope: ORDERS_WRITE) @isAuthenticated
+    refundPayment(input: UriID!): Payment! @hasScope(scope: ORDERS_WRITE) @hasRole(role: EDITOR) @hasPermission(permission: "payments:manage")
+}
+
+extend type Query {
+    payment(input: UriID!): Payment! @hasScope(scope: ORDERS_READ) @isAuthenticated
+}
+`, BuiltIn: false},
+	{Name: "../schemas/product.graphqls", Input: `type Product implements Node {
+    id: ID!
+    database_id: Int!
+    name: String!
+    price(currency: String): Money!
     user_id: ID!
+    version: Int!
     created_at: Time!
+    updated_at: Time!
+    deleted_at: Time
     user(input: UriID): User!
+    categories: [Category!]!
+    tags: [Tag!]!
+    stock: Stock!
 }
 
 type ProductEdge {
     cursor: String!
     node: Product!
+    rank: Float
+    highlight: String
 }
 
 type Products {
@@ -422,49 +2156,218 @@ type Products {
 
 type DeletedProduct {
     deleted: Boolean!
+    permanent: Boolean!
     product_id: ID!
 }
 
+type BulkError {
+    code: String!
+    message: String!
+}
+
+type BulkProductResult {
+    index: Int!
+    product: Product
+    deleted: DeletedProduct
+    error: BulkError
+}
+
+type BulkProductsResult {
+    results: [BulkProductResult!]!
+    succeeded: Int!
+    failed: Int!
+    rolled_back: Boolean!
+}
+
+type ProductList {
+    products: [Product!]!
+    page_info: OffsetPageInfo!
+}
+
+type OffsetPageInfo {
+    limit: Int!
+    offset: Int!
+    total: Int!
+}
+
 type PageInfo {
     start_cursor: String!
     end_cursor: String!
     has_next_page: Boolean!
+    has_previous_page: Boolean!
 }
 
 input NewProduct {
     user_id: ID!
     name: String!
     price: Int!
+    currency: String
+}
+
+input ProductFilter {
+    user_id: ID
+    name: String
+    min_price: Int
+    max_price: Int
+    created_after: Time
+    created_before: Time
+}
+
+enum ProductOrderField {
+    ID
+    NAME
+    PRICE
+    CREATED_AT
+}
+
+enum OrderDirection {
+    ASC
+    DESC
+}
+
+input ProductOrder {
+    field: ProductOrderField! = ID
+    direction: OrderDirection! = ASC
 }
 
 input UpdateProduct {
     id: ID!
     name: String!
     price: Int!
+    currency: String
+    expectedVersion: Int
+}
+
+input PatchProduct {
+    id: ID!
+    name: String
+    price: Int
+    currency: String
+    expectedVersion: Int
+}
+
+input BulkDeleteProducts {
+    ids: [ID!]!
+    permanent: Boolean = false
 }
 
 extend type Mutation {
-    CreateProduct(input: NewProduct!): Product!
-    UpdateProduct(input: UpdateProduct!): Product!
-    DeleteProduct(input: UriID!): DeletedProduct!
+    CreateProduct(input: NewProduct!): Product! @hasScope(scope: PRODUCTS_WRITE)
+    UpdateProduct(input: UpdateProduct!): Product! @hasScope(scope: PRODUCTS_WRITE)
+    patchProduct(input: PatchProduct!): Product! @hasScope(scope: PRODUCTS_WRITE)
+    DeleteProduct(input: UriID!, permanent: Boolean = false): DeletedProduct! @hasScope(scope: PRODUCTS_WRITE)
+    restoreProduct(input: UriID!): Product! @hasScope(scope: PRODUCTS_WRITE)
+    bulkCreateProducts(input: [NewProduct!]!, atomic: Boolean = true): BulkProductsResult! @hasScope(scope: PRODUCTS_WRITE)
+    bulkPatchProducts(input: [PatchProduct!]!, atomic: Boolean = true): BulkProductsResult! @hasScope(scope: PRODUCTS_WRITE)
+    bulkDeleteProducts(input: BulkDeleteProducts!, atomic: Boolean = true): BulkProductsResult! @hasScope(scope: PRODUCTS_WRITE)
 }
 
 extend type Query {
-    GetProduct(input: UriID!): Product!
+    GetProduct(input: UriID!): Product! @hasScope(scope: PRODUCTS_READ)
+    products(filter: ProductFilter, orderBy: ProductOrder, limit: Int = 10, offset: Int = 0): ProductList! @hasScope(scope: PRODUCTS_READ)
+    searchProducts(query: String!, first: Int, after: String): Products! @hasScope(scope: PRODUCTS_READ)
 }`, BuiltIn: false},
-	{Name: "../schemas/user.graphqls", Input: `type User {
+	{Name: "../schemas/role.graphqls", Input: `# isAuthenticated refuses anonymous operations, the services check that the
+# user owns what it asks for.
+directive @isAuthenticated on FIELD_DEFINITION
+
+# hasRole refuses anonymous users and users without role, every role includes
+# the roles below it.
+directive @hasRole(role: Role!) on FIELD_DEFINITION
+
+# hasPermission refuses anonymous users and users whose roles do not grant
+# permission, the names match the permissions table.
+directive @hasPermission(permission: String!) on FIELD_DEFINITION
+
+enum Role {
+    VIEWER
+    EDITOR
+    ADMIN
+}
+
+type RoleInfo {
+    name: Role!
+    description: String!
+    permissions: [String!]!
+}
+
+input UserRole {
+    user_id: ID!
+    role: Role!
+}
+
+extend type Mutation {
+    assignRole(input: UserRole!): [Role!]! @hasScope(scope: USERS_WRITE) @hasRole(role: ADMIN) @hasPermission(permission: "roles:manage")
+    revokeRole(input: UserRole!): [Role!]! @hasScope(scope: USERS_WRITE) @hasRole(role: ADMIN) @hasPermission(permission: "roles:manage")
+}
+
+extend type Query {
+    roles: [RoleInfo!]! @hasScope(scope: USERS_READ) @hasRole(role: VIEWER) @hasPermission(permission: "users:read")
+    userRoles(input: UriID!): [Role!]! @hasScope(scope: USERS_READ) @hasRole(role: VIEWER) @hasPermission(permission: "users:read")
+}
+`, BuiltIn: false},
+	{Name: "../schemas/user.graphqls", Input: `type User implements Node {
     id: ID!
+    database_id: Int!
     name: String!
     email: String!
+    version: Int!
     created_at: Time!
+    updated_at: Time!
     products(input: UserProducts): Products! 
 }
 
+type UserEdge {
+    cursor: String!
+    node: User!
+}
+
+type Users {
+    edges: [UserEdge!]!
+    page_info: PageInfo!
+}
+
+enum UserDeletePolicy {
+    RESTRICT
+    CASCADE
+    REASSIGN
+}
+
+type DeletedUser {
+    deleted: Boolean!
+    user_id: ID!
+    policy: UserDeletePolicy!
+    products: Int!
+    reassigned_to: ID
+}
+
 input NewUser {
     name: String!
     email: String!
 }
 
+input UpdateUser {
+    id: ID!
+    name: String!
+    email: String!
+    expectedVersion: Int
+}
+
+input PatchUser {
+    id: ID!
+    name: String
+    email: String
+    expectedVersion: Int
+}
+
+input DeleteUser {
+    id: ID!
+    policy: UserDeletePolicy
+    reassign_to: ID
+}
+
+# ID inputs take either a database id or the global id of a node of the type
+# the input points to.
 input UriID {
     id: ID!
 }
@@ -473,14 +2376,22 @@ input UserProducts {
     user_id: ID
     first: Int
     after: String
+    last: Int
+    before: String
+    category_id: ID
+    tag: String
 }
 
 type Mutation {
-    CreateUser(input: NewUser!): User!
+    CreateUser(input: NewUser!): User! @hasScope(scope: USERS_WRITE) @hasRole(role: ADMIN) @hasPermission(permission: "users:manage")
+    updateUser(input: UpdateUser!): User! @hasScope(scope: USERS_WRITE) @hasRole(role: ADMIN) @hasPermission(permission: "users:manage")
+    patchUser(input: PatchUser!): User! @hasScope(scope: USERS_WRITE) @hasRole(role: ADMIN) @hasPermission(permission: "users:manage")
+    deleteUser(input: DeleteUser!): DeletedUser! @hasScope(scope: USERS_WRITE) @hasRole(role: ADMIN) @hasPermission(permission: "users:manage")
 }
 
 type Query {
-    GetUser(input: UriID!): User!
+    GetUser(input: UriID!): User! @hasScope(scope: USERS_READ)
+    users(first: Int, after: String): Users! @hasScope(scope: USERS_READ) @hasRole(role: VIEWER) @hasPermission(permission: "users:read")
 }
 
 scalar Time`, BuiltIn: false},
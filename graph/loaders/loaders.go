@@ -0,0 +1,421 @@
+// Package loaders batches and caches the lookups GraphQL resolvers make for
+// nested fields, so a list of products resolves its users with one query
+// instead of one per product.
+package loaders
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+const (
+	DefaultWait     = 2 * time.Millisecond
+	DefaultMaxBatch = 100
+)
+
+type Config struct {
+	// Wait is how long a loader collects keys before running a batch.
+	Wait time.Duration
+	// MaxBatch is the most keys sent to the service in one batch.
+	MaxBatch int
+}
+
+// Loaders must not be shared between requests, their caches are only valid
+// for the operation they were created for.
+type Loaders struct {
+	UserByID            *dataloader.Loader[int64, *responses.User]
+	ProductByID         *dataloader.Loader[int64, *responses.Product]
+	ProductsByUser      *dataloader.Loader[UserProductsKey, *responses.Products]
+	PriceIn             *dataloader.Loader[PriceKey, responses.Money]
+	CategoryByID        *dataloader.Loader[int64, *responses.Category]
+	CategoriesByProduct *dataloader.Loader[int64, []*responses.Category]
+	TagsByProduct       *dataloader.Loader[int64, []*responses.Tag]
+	StockByProduct      *dataloader.Loader[int64, *responses.Stock]
+}
+
+func New(service services.Service, cfg Config) *Loaders {
+	if cfg.Wait <= 0 {
+		cfg.Wait = DefaultWait
+	}
+
+	if cfg.MaxBatch <= 0 {
+		cfg.MaxBatch = DefaultMaxBatch
+	}
+
+	return &Loaders{
+		UserByID: dataloader.NewBatchedLoader(
+			batchUsers(service),
+			dataloader.WithWait[int64, *responses.User](cfg.Wait),
+			dataloader.WithBatchCapacity[int64, *responses.User](cfg.MaxBatch),
+		),
+		ProductByID: dataloader.NewBatchedLoader(
+			batchProducts(service),
+			dataloader.WithWait[int64, *responses.Product](cfg.Wait),
+			dataloader.WithBatchCapacity[int64, *responses.Product](cfg.MaxBatch),
+		),
+		ProductsByUser: dataloader.NewBatchedLoader(
+			batchUserProducts(service),
+			dataloader.WithWait[UserProductsKey, *responses.Products](cfg.Wait),
+			dataloader.WithBatchCapacity[UserProductsKey, *responses.Products](cfg.MaxBatch),
+		),
+		PriceIn: dataloader.NewBatchedLoader(
+			batchPrices(service),
+			dataloader.WithWait[PriceKey, responses.Money](cfg.Wait),
+			dataloader.WithBatchCapacity[PriceKey, responses.Money](cfg.MaxBatch),
+		),
+		CategoryByID: dataloader.NewBatchedLoader(
+			batchCategories(service),
+			dataloader.WithWait[int64, *responses.Category](cfg.Wait),
+			dataloader.WithBatchCapacity[int64, *responses.Category](cfg.MaxBatch),
+		),
+		CategoriesByProduct: dataloader.NewBatchedLoader(
+			batchProductCategories(service),
+			dataloader.WithWait[int64, []*responses.Category](cfg.Wait),
+			dataloader.WithBatchCapacity[int64, []*responses.Category](cfg.MaxBatch),
+		),
+		TagsByProduct: dataloader.NewBatchedLoader(
+			batchProductTags(service),
+			dataloader.WithWait[int64, []*responses.Tag](cfg.Wait),
+			dataloader.WithBatchCapacity[int64, []*responses.Tag](cfg.MaxBatch),
+		),
+		StockByProduct: dataloader.NewBatchedLoader(
+			batchStock(service),
+			dataloader.WithWait[int64, *responses.Stock](cfg.Wait),
+			dataloader.WithBatchCapacity[int64, *responses.Stock](cfg.MaxBatch),
+		),
+	}
+}
+
+type ctxKey struct{}
+
+func WithLoaders(ctx context.Context, l *Loaders) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// Middleware gives every GraphQL operation its own loaders, install it with
+// handler.Server.AroundOperations.
+func Middleware(service services.Service, cfg Config) graphql.OperationMiddleware {
+	return func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(WithLoaders(ctx, New(service, cfg)))
+	}
+}
+
+// For returns the loaders of the current operation, Middleware must be
+// installed.
+func For(ctx context.Context) *Loaders {
+	return ctx.Value(ctxKey{}).(*Loaders)
+}
+
+func (l *Loaders) GetUser(ctx context.Context, id int64) (*responses.User, error) {
+	return l.UserByID.Load(ctx, id)()
+}
+
+func (l *Loaders) GetProduct(ctx context.Context, id int64) (*responses.Product, error) {
+	return l.ProductByID.Load(ctx, id)()
+}
+
+func (l *Loaders) GetUserProducts(ctx context.Context, req requests.GetUserProductsRequest) (*responses.Products, error) {
+	return l.ProductsByUser.Load(ctx, NewUserProductsKey(req))()
+}
+
+// ConvertPrice returns price in currency, the prices of one operation are
+// converted together.
+func (l *Loaders) ConvertPrice(ctx context.Context, price responses.Money, currency string) (responses.Money, error) {
+	return l.PriceIn.Load(ctx, PriceKey{Price: price, Currency: currency})()
+}
+
+func (l *Loaders) GetCategory(ctx context.Context, id int64) (*responses.Category, error) {
+	return l.CategoryByID.Load(ctx, id)()
+}
+
+func (l *Loaders) GetProductCategories(ctx context.Context, productID int64) ([]*responses.Category, error) {
+	return l.CategoriesByProduct.Load(ctx, productID)()
+}
+
+func (l *Loaders) GetProductTags(ctx context.Context, productID int64) ([]*responses.Tag, error) {
+	return l.TagsByProduct.Load(ctx, productID)()
+}
+
+func (l *Loaders) GetProductStock(ctx context.Context, productID int64) (*responses.Stock, error) {
+	return l.StockByProduct.Load(ctx, productID)()
+}
+
+func batchUsers(service services.Service) dataloader.BatchFunc[int64, *responses.User] {
+	return func(ctx context.Context, ids []int64) []*dataloader.Result[*responses.User] {
+		results := make([]*dataloader.Result[*responses.User], len(ids))
+		users, err := service.GetBatchUsers(ctx, requests.GetBatchUsersRequest{IDs: ids})
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*responses.User]{Error: err}
+			}
+			return results
+		}
+
+		byID := make(map[int64]*responses.User, len(users))
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+
+		for i, id := range ids {
+			user, ok := byID[id]
+			if !ok {
+				results[i] = &dataloader.Result[*responses.User]{
+					Error: services.NotFoundError("user with id %d not found", id),
+				}
+				continue
+			}
+			results[i] = &dataloader.Result[*responses.User]{Data: user}
+		}
+
+		return results
+	}
+}
+
+func batchProducts(service services.Service) dataloader.BatchFunc[int64, *responses.Product] {
+	return func(ctx context.Context, ids []int64) []*dataloader.Result[*responses.Product] {
+		results := make([]*dataloader.Result[*responses.Product], len(ids))
+		products, err := service.GetBatchProducts(ctx, requests.GetBatchProductsRequest{IDs: ids})
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*responses.Product]{Error: err}
+			}
+			return results
+		}
+
+		byID := make(map[int64]*responses.Product, len(products))
+		for _, product := range products {
+			byID[product.ID] = product
+		}
+
+		for i, id := range ids {
+			product, ok := byID[id]
+			if !ok {
+				results[i] = &dataloader.Result[*responses.Product]{
+					Error: services.NotFoundError("product with id %d not found", id),
+				}
+				continue
+			}
+			results[i] = &dataloader.Result[*responses.Product]{Data: product}
+		}
+
+		return results
+	}
+}
+
+// UserProductsKey is a comparable GetUserProductsRequest. Keys that only
+// differ by user share the page arguments and filters and are fetched
+// together.
+type UserProductsKey struct {
+	UserID        int64
+	First         int
+	Last          int
+	After         string
+	Before        string
+	CategoryID    int64
+	Tag           string
+	HasFirst      bool
+	HasLast       bool
+	HasAfter      bool
+	HasBefore     bool
+	HasCategoryID bool
+	HasTag        bool
+}
+
+func NewUserProductsKey(req requests.GetUserProductsRequest) UserProductsKey {
+	key := UserProductsKey{UserID: req.UserID}
+	if req.First != nil {
+		key.First, key.HasFirst = *req.First, true
+	}
+	if req.Last != nil {
+		key.Last, key.HasLast = *req.Last, true
+	}
+	if req.After != nil {
+		key.After, key.HasAfter = *req.After, true
+	}
+	if req.Before != nil {
+		key.Before, key.HasBefore = *req.Before, true
+	}
+	if req.CategoryID != nil {
+		key.CategoryID, key.HasCategoryID = *req.CategoryID, true
+	}
+	if req.Tag != nil {
+		key.Tag, key.HasTag = *req.Tag, true
+	}
+
+	return key
+}
+
+func (k UserProductsKey) batchRequest(userIDs []int64) requests.GetBatchUserProductsRequest {
+	req := requests.GetBatchUserProductsRequest{UserIDs: userIDs}
+	if k.HasFirst {
+		req.First = &k.First
+	}
+	if k.HasLast {
+		req.Last = &k.Last
+	}
+	if k.HasAfter {
+		req.After = &k.After
+	}
+	if k.HasBefore {
+		req.Before = &k.Before
+	}
+	if k.HasCategoryID {
+		req.CategoryID = &k.CategoryID
+	}
+	if k.HasTag {
+		req.Tag = &k.Tag
+	}
+
+	return req
+}
+
+func batchUserProducts(service services.Service) dataloader.BatchFunc[UserProductsKey, *responses.Products] {
+	return func(ctx context.Context, keys []UserProductsKey) []*dataloader.Result[*responses.Products] {
+		results := make([]*dataloader.Result[*responses.Products], len(keys))
+
+		// group keys by page arguments, each group is one service call
+		groups := make(map[UserProductsKey][]int)
+		var order []UserProductsKey
+		for i, key := range keys {
+			page := key
+			page.UserID = 0
+			if _, ok := groups[page]; !ok {
+				order = append(order, page)
+			}
+			groups[page] = append(groups[page], i)
+		}
+
+		for _, page := range order {
+			indexes := groups[page]
+			userIDs := make([]int64, len(indexes))
+			for i, index := range indexes {
+				userIDs[i] = keys[index].UserID
+			}
+
+			pages, err := service.GetBatchUserProducts(ctx, page.batchRequest(userIDs))
+			for i, index := range indexes {
+				if err != nil {
+					results[index] = &dataloader.Result[*responses.Products]{Error: err}
+					continue
+				}
+				results[index] = &dataloader.Result[*responses.Products]{Data: pages[i]}
+			}
+		}
+
+		return results
+	}
+}
+
+// PriceKey is a price to convert into Currency.
+type PriceKey struct {
+	Price    responses.Money
+	Currency string
+}
+
+func batchPrices(service services.Service) dataloader.BatchFunc[PriceKey, responses.Money] {
+	return func(ctx context.Context, keys []PriceKey) []*dataloader.Result[responses.Money] {
+		results := make([]*dataloader.Result[responses.Money], len(keys))
+
+		// group keys by target currency, each group is one service call
+		groups := make(map[string][]int)
+		var order []string
+		for i, key := range keys {
+			if _, ok := groups[key.Currency]; !ok {
+				order = append(order, key.Currency)
+			}
+			groups[key.Currency] = append(groups[key.Currency], i)
+		}
+
+		for _, currency := range order {
+			indexes := groups[currency]
+			prices := make([]responses.Money, len(indexes))
+			for i, index := range indexes {
+				prices[i] = keys[index].Price
+			}
+
+			converted, err := service.ConvertPrices(ctx, prices, currency)
+			for i, index := range indexes {
+				if err != nil {
+					results[index] = &dataloader.Result[responses.Money]{Error: err}
+					continue
+				}
+				results[index] = &dataloader.Result[responses.Money]{Data: converted[i]}
+			}
+		}
+
+		return results
+	}
+}
+
+func batchCategories(service services.Service) dataloader.BatchFunc[int64, *responses.Category] {
+	return func(ctx context.Context, ids []int64) []*dataloader.Result[*responses.Category] {
+		results := make([]*dataloader.Result[*responses.Category], len(ids))
+		categories, err := service.GetBatchCategories(ctx, requests.GetBatchCategoriesRequest{IDs: ids})
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*responses.Category]{Error: err}
+			}
+			return results
+		}
+
+		byID := make(map[int64]*responses.Category, len(categories))
+		for _, category := range categories {
+			byID[category.ID] = category
+		}
+
+		for i, id := range ids {
+			category, ok := byID[id]
+			if !ok {
+				results[i] = &dataloader.Result[*responses.Category]{
+					Error: services.NotFoundError("category with id %d not found", id),
+				}
+				continue
+			}
+			results[i] = &dataloader.Result[*responses.Category]{Data: category}
+		}
+
+		return results
+	}
+}
+
+func batchProductCategories(service services.Service) dataloader.BatchFunc[int64, []*responses.Category] {
+	return func(ctx context.Context, productIDs []int64) []*dataloader.Result[[]*responses.Category] {
+		categories, err := service.GetBatchProductCategories(ctx, requests.GetBatchProductCategoriesRequest{ProductIDs: productIDs})
+		return batchResults(len(productIDs), categories, err)
+	}
+}
+
+func batchProductTags(service services.Service) dataloader.BatchFunc[int64, []*responses.Tag] {
+	return func(ctx context.Context, productIDs []int64) []*dataloader.Result[[]*responses.Tag] {
+		tags, err := service.GetBatchProductTags(ctx, requests.GetBatchProductTagsRequest{ProductIDs: productIDs})
+		return batchResults(len(productIDs), tags, err)
+	}
+}
+
+func batchStock(service services.Service) dataloader.BatchFunc[int64, *responses.Stock] {
+	return func(ctx context.Context, productIDs []int64) []*dataloader.Result[*responses.Stock] {
+		stocks, err := service.GetBatchStock(ctx, requests.GetBatchStockRequest{ProductIDs: productIDs})
+		return batchResults(len(productIDs), stocks, err)
+	}
+}
+
+// batchResults turns the values of a batch, one per key, into loader results.
+// err fails every key.
+func batchResults[V any](n int, values []V, err error) []*dataloader.Result[V] {
+	results := make([]*dataloader.Result[V], n)
+	for i := range results {
+		if err != nil {
+			results[i] = &dataloader.Result[V]{Error: err}
+			continue
+		}
+		results[i] = &dataloader.Result[V]{Data: values[i]}
+	}
+
+	return results
+}
@@ -0,0 +1,95 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"sqlc-rest-api/graph/generated"
+	"sqlc-rest-api/graph/loaders"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// Parent is the resolver for the parent field.
+func (r *categoryResolver) Parent(ctx context.Context, obj *responses.Category) (*responses.Category, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+
+	return loaders.For(ctx).GetCategory(ctx, *obj.ParentID)
+}
+
+// Children is the resolver for the children field.
+func (r *categoryResolver) Children(ctx context.Context, obj *responses.Category) ([]*responses.Category, error) {
+	return r.Service.ListCategories(ctx, requests.ListCategoriesRequest{ParentID: &obj.ID})
+}
+
+// CreateCategory is the resolver for the createCategory field.
+func (r *mutationResolver) CreateCategory(ctx context.Context, input requests.CreateCategoryRequest) (*responses.Category, error) {
+	return r.Service.CreateCategory(ctx, input)
+}
+
+// UpdateCategory is the resolver for the updateCategory field.
+func (r *mutationResolver) UpdateCategory(ctx context.Context, input requests.UpdateCategoryRequest) (*responses.Category, error) {
+	return r.Service.UpdateCategory(ctx, input)
+}
+
+// DeleteCategory is the resolver for the deleteCategory field.
+func (r *mutationResolver) DeleteCategory(ctx context.Context, input requests.BindUriID) (bool, error) {
+	if err := r.Service.DeleteCategory(ctx, input); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CreateTag is the resolver for the createTag field.
+func (r *mutationResolver) CreateTag(ctx context.Context, input requests.CreateTagRequest) (*responses.Tag, error) {
+	return r.Service.CreateTag(ctx, input)
+}
+
+// DeleteTag is the resolver for the deleteTag field.
+func (r *mutationResolver) DeleteTag(ctx context.Context, input requests.BindUriID) (bool, error) {
+	if err := r.Service.DeleteTag(ctx, input); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SetProductCategories is the resolver for the setProductCategories field.
+func (r *mutationResolver) SetProductCategories(ctx context.Context, input requests.SetProductCategoriesRequest) ([]*responses.Category, error) {
+	return r.Service.SetProductCategories(ctx, input)
+}
+
+// SetProductTags is the resolver for the setProductTags field.
+func (r *mutationResolver) SetProductTags(ctx context.Context, input requests.SetProductTagsRequest) ([]*responses.Tag, error) {
+	return r.Service.SetProductTags(ctx, input)
+}
+
+// Category is the resolver for the category field.
+func (r *queryResolver) Category(ctx context.Context, input requests.BindUriID) (*responses.Category, error) {
+	return r.Service.GetCategory(ctx, input)
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context, input *requests.ListCategoriesRequest) ([]*responses.Category, error) {
+	var req requests.ListCategoriesRequest
+	if input != nil {
+		req = *input
+	}
+
+	return r.Service.ListCategories(ctx, req)
+}
+
+// Tags is the resolver for the tags field.
+func (r *queryResolver) Tags(ctx context.Context) ([]*responses.Tag, error) {
+	return r.Service.ListTags(ctx)
+}
+
+// Category returns generated.CategoryResolver implementation.
+func (r *Resolver) Category() generated.CategoryResolver { return &categoryResolver{r} }
+
+type categoryResolver struct{ *Resolver }
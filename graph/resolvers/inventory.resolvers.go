@@ -0,0 +1,41 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// AdjustStock is the resolver for the adjustStock field.
+func (r *mutationResolver) AdjustStock(ctx context.Context, input requests.AdjustStockRequest) (*responses.Stock, error) {
+	return r.Service.AdjustStock(ctx, input)
+}
+
+// CreateReservation is the resolver for the createReservation field.
+func (r *mutationResolver) CreateReservation(ctx context.Context, input requests.CreateReservationRequest) (*responses.Reservation, error) {
+	return r.Service.CreateReservation(ctx, input)
+}
+
+// ReleaseReservation is the resolver for the releaseReservation field.
+func (r *mutationResolver) ReleaseReservation(ctx context.Context, input requests.BindUriID) (*responses.Reservation, error) {
+	return r.Service.ReleaseReservation(ctx, input)
+}
+
+// CommitReservation is the resolver for the commitReservation field.
+func (r *mutationResolver) CommitReservation(ctx context.Context, input requests.BindUriID) (*responses.Reservation, error) {
+	return r.Service.CommitReservation(ctx, input)
+}
+
+// StockAdjustments is the resolver for the stockAdjustments field.
+func (r *queryResolver) StockAdjustments(ctx context.Context, input requests.ListStockAdjustmentsRequest) ([]*responses.StockAdjustment, error) {
+	return r.Service.ListStockAdjustments(ctx, input)
+}
+
+// Reservation is the resolver for the reservation field.
+func (r *queryResolver) Reservation(ctx context.Context, input requests.BindUriID) (*responses.Reservation, error) {
+	return r.Service.GetReservation(ctx, input)
+}
@@ -0,0 +1,21 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// SetExchangeRate is the resolver for the setExchangeRate field.
+func (r *mutationResolver) SetExchangeRate(ctx context.Context, input requests.SetExchangeRateRequest) (*responses.ExchangeRate, error) {
+	return r.Service.SetExchangeRate(ctx, input)
+}
+
+// ExchangeRates is the resolver for the exchangeRates field.
+func (r *queryResolver) ExchangeRates(ctx context.Context) ([]*responses.ExchangeRate, error) {
+	return r.Service.ListExchangeRates(ctx)
+}
@@ -0,0 +1,94 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/graph/loaders"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+)
+
+// Type names used in global ids, they must match the GraphQL type names.
+const (
+	productType = "Product"
+	userType    = "User"
+)
+
+// MaxNodes is the most ids the nodes query accepts.
+const MaxNodes = 100
+
+// nodeThunk waits for a node started by loadNode.
+type nodeThunk func() (responses.Node, error)
+
+// node fetches the object a global id points to. Like Relay expects, ids of
+// objects that don't exist resolve to null instead of an error.
+func (r *Resolver) node(ctx context.Context, globalID string) (responses.Node, error) {
+	thunk, err := r.loadNode(ctx, globalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return thunk()
+}
+
+// loadNode starts fetching the object a global id points to through the
+// dataloaders, so the ids of one nodes query are fetched in batches. API keys
+// need the read scope of the type the id points to, like the query fetching
+// that type.
+func (r *Resolver) loadNode(ctx context.Context, globalID string) (nodeThunk, error) {
+	typename, id, err := helpers.DecodeGlobalID(globalID)
+	if err != nil {
+		return nil, &services.Error{
+			Code:    services.ErrBadRequest,
+			Message: fmt.Sprintf("invalid id %q", globalID),
+			Err:     err,
+		}
+	}
+
+	switch typename {
+	case productType:
+		if err := requireNodeScope(ctx, requests.ScopeProductsRead); err != nil {
+			return nil, err
+		}
+		load := loaders.For(ctx).ProductByID.Load(ctx, id)
+		return func() (responses.Node, error) {
+			return nodeResult(load())
+		}, nil
+	case userType:
+		if err := requireNodeScope(ctx, requests.ScopeUsersRead); err != nil {
+			return nil, err
+		}
+		load := loaders.For(ctx).UserByID.Load(ctx, id)
+		return func() (responses.Node, error) {
+			return nodeResult(load())
+		}, nil
+	default:
+		return func() (responses.Node, error) { return nil, nil }, nil
+	}
+}
+
+// requireNodeScope refuses nodes of a type the API key of the operation has no
+// scope to read, like the @hasScope directive.
+func requireNodeScope(ctx context.Context, scope requests.Scope) error {
+	if !auth.HasScope(ctx, scope) {
+		return services.ForbiddenError("API key scope %s required", scope)
+	}
+
+	return nil
+}
+
+// nodeResult turns a not found error into a null node.
+func nodeResult[T responses.Node](node T, err error) (responses.Node, error) {
+	if services.ErrorCodeOf(err) == services.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
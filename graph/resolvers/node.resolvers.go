@@ -0,0 +1,43 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+)
+
+// Node is the resolver for the node field.
+func (r *queryResolver) Node(ctx context.Context, id string) (responses.Node, error) {
+	return r.node(ctx, id)
+}
+
+// Nodes is the resolver for the nodes field.
+func (r *queryResolver) Nodes(ctx context.Context, ids []string) ([]responses.Node, error) {
+	if len(ids) > MaxNodes {
+		return nil, services.ValidationError("cannot fetch more than %d nodes at once", MaxNodes)
+	}
+
+	thunks := make([]nodeThunk, len(ids))
+	for i, id := range ids {
+		thunk, err := r.loadNode(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		thunks[i] = thunk
+	}
+
+	nodes := make([]responses.Node, len(ids))
+	for i, thunk := range thunks {
+		node, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = node
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,43 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"sqlc-rest-api/graph/generated"
+	"sqlc-rest-api/graph/loaders"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// CreateOrder is the resolver for the createOrder field.
+func (r *mutationResolver) CreateOrder(ctx context.Context, input requests.CreateOrderRequest) (*responses.Order, error) {
+	return r.Service.CreateOrder(ctx, input)
+}
+
+// UpdateOrderStatus is the resolver for the updateOrderStatus field.
+func (r *mutationResolver) UpdateOrderStatus(ctx context.Context, input requests.UpdateOrderStatusRequest) (*responses.Order, error) {
+	return r.Service.UpdateOrderStatus(ctx, input)
+}
+
+// User is the resolver for the user field.
+func (r *orderResolver) User(ctx context.Context, obj *responses.Order) (*responses.User, error) {
+	return loaders.For(ctx).GetUser(ctx, obj.UserID)
+}
+
+// Order is the resolver for the order field.
+func (r *queryResolver) Order(ctx context.Context, input requests.BindUriID) (*responses.Order, error) {
+	return r.Service.GetOrder(ctx, input)
+}
+
+// UserOrders is the resolver for the userOrders field.
+func (r *queryResolver) UserOrders(ctx context.Context, input requests.GetUserOrdersRequest) (*responses.Orders, error) {
+	return r.Service.GetUserOrders(ctx, input)
+}
+
+// Order returns generated.OrderResolver implementation.
+func (r *Resolver) Order() generated.OrderResolver { return &orderResolver{r} }
+
+type orderResolver struct{ *Resolver }
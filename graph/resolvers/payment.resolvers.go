@@ -0,0 +1,31 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// StartPayment is the resolver for the startPayment field.
+func (r *mutationResolver) StartPayment(ctx context.Context, input requests.StartPaymentRequest) (*responses.Payment, error) {
+	return r.Service.StartPayment(ctx, input)
+}
+
+// ConfirmPayment is the resolver for the confirmPayment field.
+func (r *mutationResolver) ConfirmPayment(ctx context.Context, input requests.BindUriID) (*responses.Payment, error) {
+	return r.Service.ConfirmPayment(ctx, input)
+}
+
+// RefundPayment is the resolver for the refundPayment field.
+func (r *mutationResolver) RefundPayment(ctx context.Context, input requests.BindUriID) (*responses.Payment, error) {
+	return r.Service.RefundPayment(ctx, input)
+}
+
+// Payment is the resolver for the payment field.
+func (r *queryResolver) Payment(ctx context.Context, input requests.BindUriID) (*responses.Payment, error) {
+	return r.Service.GetPayment(ctx, input)
+}
@@ -7,8 +7,11 @@ package resolvers
 import (
 	"context"
 	"sqlc-rest-api/graph/generated"
+	"sqlc-rest-api/graph/loaders"
+	"sqlc-rest-api/helpers"
 	"sqlc-rest-api/requests"
 	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
 )
 
 // CreateProduct is the resolver for the CreateProduct field.
@@ -21,18 +24,94 @@ func (r *mutationResolver) UpdateProduct(ctx context.Context, input requests.Upd
 	return r.Service.UpdateProduct(ctx, input)
 }
 
+// PatchProduct is the resolver for the patchProduct field.
+func (r *mutationResolver) PatchProduct(ctx context.Context, input requests.PatchProductRequest) (*responses.Product, error) {
+	return r.Service.PatchProduct(ctx, input)
+}
+
 // DeleteProduct is the resolver for the DeleteProduct field.
-func (r *mutationResolver) DeleteProduct(ctx context.Context, input requests.BindUriID) (*responses.DeletedProduct, error) {
-	return r.Service.DeleteProduct(ctx, input)
+func (r *mutationResolver) DeleteProduct(ctx context.Context, input requests.BindUriID, permanent *bool) (*responses.DeletedProduct, error) {
+	req := requests.DeleteProductRequest{ID: input.ID}
+	if permanent != nil {
+		req.Permanent = *permanent
+	}
+
+	return r.Service.DeleteProduct(ctx, req)
+}
+
+// RestoreProduct is the resolver for the restoreProduct field.
+func (r *mutationResolver) RestoreProduct(ctx context.Context, input requests.BindUriID) (*responses.Product, error) {
+	return r.Service.RestoreProduct(ctx, input)
+}
+
+// BulkCreateProducts is the resolver for the bulkCreateProducts field.
+func (r *mutationResolver) BulkCreateProducts(ctx context.Context, input []*requests.CreateProductRequest, atomic *bool) (*responses.BulkProductsResult, error) {
+	req := requests.BulkCreateProductsRequest{Atomic: atomic == nil || *atomic}
+	for _, product := range input {
+		req.Products = append(req.Products, *product)
+	}
+
+	return r.Service.BulkCreateProducts(ctx, req)
+}
+
+// BulkPatchProducts is the resolver for the bulkPatchProducts field.
+func (r *mutationResolver) BulkPatchProducts(ctx context.Context, input []*requests.PatchProductRequest, atomic *bool) (*responses.BulkProductsResult, error) {
+	req := requests.BulkPatchProductsRequest{Atomic: atomic == nil || *atomic}
+	for _, product := range input {
+		req.Products = append(req.Products, *product)
+	}
+
+	return r.Service.BulkPatchProducts(ctx, req)
+}
+
+// BulkDeleteProducts is the resolver for the bulkDeleteProducts field.
+func (r *mutationResolver) BulkDeleteProducts(ctx context.Context, input requests.BulkDeleteProductsRequest, atomic *bool) (*responses.BulkProductsResult, error) {
+	input.Atomic = atomic == nil || *atomic
+
+	return r.Service.BulkDeleteProducts(ctx, input)
+}
+
+// ID is the resolver for the id field.
+func (r *productResolver) ID(ctx context.Context, obj *responses.Product) (string, error) {
+	return helpers.EncodeGlobalID(productType, obj.ID), nil
+}
+
+// Price is the resolver for the price field.
+func (r *productResolver) Price(ctx context.Context, obj *responses.Product, currency *string) (*responses.Money, error) {
+	if currency == nil || *currency == obj.Price.Currency {
+		return &obj.Price, nil
+	}
+
+	price, err := loaders.For(ctx).ConvertPrice(ctx, obj.Price, *currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &price, nil
 }
 
 // User is the resolver for the user field.
 func (r *productResolver) User(ctx context.Context, obj *responses.Product, input *requests.BindUriID) (*responses.User, error) {
-	arg := requests.BindUriID{
-		ID: obj.UserID,
+	return loaders.For(ctx).GetUser(ctx, obj.UserID)
+}
+
+// Categories is the resolver for the categories field.
+func (r *productResolver) Categories(ctx context.Context, obj *responses.Product) ([]*responses.Category, error) {
+	return loaders.For(ctx).GetProductCategories(ctx, obj.ID)
+}
+
+// Tags is the resolver for the tags field.
+func (r *productResolver) Tags(ctx context.Context, obj *responses.Product) ([]*responses.Tag, error) {
+	return loaders.For(ctx).GetProductTags(ctx, obj.ID)
+}
+
+// Stock is the resolver for the stock field.
+func (r *productResolver) Stock(ctx context.Context, obj *responses.Product) (*responses.Stock, error) {
+	if obj.Stock != nil {
+		return obj.Stock, nil
 	}
 
-	return r.Service.GetUser(ctx, arg)
+	return loaders.For(ctx).GetProductStock(ctx, obj.ID)
 }
 
 // GetProduct is the resolver for the GetProduct field.
@@ -40,6 +119,36 @@ func (r *queryResolver) GetProduct(ctx context.Context, input requests.BindUriID
 	return r.Service.GetProduct(ctx, input)
 }
 
+// Products is the resolver for the products field.
+func (r *queryResolver) Products(ctx context.Context, filter *requests.ProductFilter, orderBy *requests.ProductOrder, limit *int, offset *int) (*responses.ProductList, error) {
+	req := requests.ListProductsRequest{Limit: services.DefaultListLimit}
+	if filter != nil {
+		req.Filter = *filter
+	}
+	if orderBy != nil {
+		req.Order = *orderBy
+	}
+	if limit != nil {
+		req.Limit = *limit
+	}
+	if offset != nil {
+		req.Offset = *offset
+	}
+
+	return r.Service.ListProducts(ctx, req)
+}
+
+// SearchProducts is the resolver for the searchProducts field.
+func (r *queryResolver) SearchProducts(ctx context.Context, query string, first *int, after *string) (*responses.Products, error) {
+	req := requests.SearchProductsRequest{
+		Query: query,
+		First: first,
+		After: after,
+	}
+
+	return r.Service.SearchProducts(ctx, req)
+}
+
 // Product returns generated.ProductResolver implementation.
 func (r *Resolver) Product() generated.ProductResolver { return &productResolver{r} }
 
@@ -0,0 +1,31 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.24
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// AssignRole is the resolver for the assignRole field.
+func (r *mutationResolver) AssignRole(ctx context.Context, input requests.UserRoleRequest) ([]requests.Role, error) {
+	return r.Service.AssignRole(ctx, input)
+}
+
+// RevokeRole is the resolver for the revokeRole field.
+func (r *mutationResolver) RevokeRole(ctx context.Context, input requests.UserRoleRequest) ([]requests.Role, error) {
+	return r.Service.RevokeRole(ctx, input)
+}
+
+// Roles is the resolver for the roles field.
+func (r *queryResolver) Roles(ctx context.Context) ([]*responses.Role, error) {
+	return r.Service.ListRoles(ctx)
+}
+
+// UserRoles is the resolver for the userRoles field.
+func (r *queryResolver) UserRoles(ctx context.Context, input requests.BindUriID) ([]requests.Role, error) {
+	return r.Service.GetUserRoles(ctx, input)
+}
@@ -7,6 +7,8 @@ package resolvers
 import (
 	"context"
 	"sqlc-rest-api/graph/generated"
+	"sqlc-rest-api/graph/loaders"
+	"sqlc-rest-api/helpers"
 	"sqlc-rest-api/requests"
 	"sqlc-rest-api/responses"
 )
@@ -16,31 +18,45 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input requests.Create
 	return r.Service.CreateUser(ctx, input)
 }
 
+// UpdateUser is the resolver for the updateUser field.
+func (r *mutationResolver) UpdateUser(ctx context.Context, input requests.UpdateUserRequest) (*responses.User, error) {
+	return r.Service.UpdateUser(ctx, input)
+}
+
+// PatchUser is the resolver for the patchUser field.
+func (r *mutationResolver) PatchUser(ctx context.Context, input requests.PatchUserRequest) (*responses.User, error) {
+	return r.Service.PatchUser(ctx, input)
+}
+
+// DeleteUser is the resolver for the deleteUser field.
+func (r *mutationResolver) DeleteUser(ctx context.Context, input requests.DeleteUserRequest) (*responses.DeletedUser, error) {
+	return r.Service.DeleteUser(ctx, input)
+}
+
 // GetUser is the resolver for the GetUser field.
 func (r *queryResolver) GetUser(ctx context.Context, input requests.BindUriID) (*responses.User, error) {
 	return r.Service.GetUser(ctx, input)
 }
 
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, first *int, after *string) (*responses.Users, error) {
+	return r.Service.ListUsers(ctx, requests.ListUsersRequest{First: first, After: after})
+}
+
+// ID is the resolver for the id field.
+func (r *userResolver) ID(ctx context.Context, obj *responses.User) (string, error) {
+	return helpers.EncodeGlobalID(userType, obj.ID), nil
+}
+
 // Products is the resolver for the products field.
 func (r *userResolver) Products(ctx context.Context, obj *responses.User, input *requests.GetUserProductsRequest) (*responses.Products, error) {
-	var after *string
-	first := 5
+	var arg requests.GetUserProductsRequest
 	if input != nil {
-		if input.First != nil {
-			first = 5
-		}
-		if input.After != nil {
-			after = input.After
-		}
-	}
-
-	arg := requests.GetUserProductsRequest{
-		UserID: obj.ID,
-		First:  &first,
-		After:  after,
+		arg = *input
 	}
+	arg.UserID = obj.ID
 
-	return r.Service.GetUserProducts(ctx, arg)
+	return loaders.For(ctx).GetUserProducts(ctx, arg)
 }
 
 // Mutation returns generated.MutationResolver implementation.
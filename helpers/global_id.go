@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+var (
+	ErrInvalidGlobalID = errors.New("invalid global id")
+	ErrInvalidID       = errors.New("invalid id")
+)
+
+// EncodeGlobalID returns the Relay global object id of a row, the base64 of
+// "Typename:id".
+func EncodeGlobalID(typename string, id int64) string {
+	return base64.StdEncoding.EncodeToString([]byte(typename + ":" + strconv.FormatInt(id, 10)))
+}
+
+// DecodeGlobalID splits a global id made by EncodeGlobalID back into its type
+// name and database id.
+func DecodeGlobalID(globalID string) (string, int64, error) {
+	decoded, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return "", 0, ErrInvalidGlobalID
+	}
+
+	typename, rawID, ok := strings.Cut(string(decoded), ":")
+	if !ok || typename == "" {
+		return "", 0, ErrInvalidGlobalID
+	}
+
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id < 1 {
+		return "", 0, ErrInvalidGlobalID
+	}
+
+	return typename, id, nil
+}
+
+// globalIDTypes is the type of object the id inputs of a field point to, the
+// inputs named id or ids are looked up by "Object.field", the others by their
+// own name.
+var globalIDTypes = map[string]string{
+	"product_id":  "Product",
+	"user_id":     "User",
+	"reassign_to": "User",
+
+	"Query.GetProduct":            "Product",
+	"Mutation.UpdateProduct":      "Product",
+	"Mutation.patchProduct":       "Product",
+	"Mutation.DeleteProduct":      "Product",
+	"Mutation.restoreProduct":     "Product",
+	"Mutation.bulkPatchProducts":  "Product",
+	"Mutation.bulkDeleteProducts": "Product",
+
+	"Query.GetUser":       "User",
+	"Query.userRoles":     "User",
+	"Mutation.updateUser": "User",
+	"Mutation.patchUser":  "User",
+	"Mutation.deleteUser": "User",
+	"Product.user":        "User",
+}
+
+// globalIDType returns the type name global ids must have in the input being
+// unmarshaled, or "" when the input points to objects without global ids.
+func globalIDType(ctx context.Context) string {
+	var input string
+	for path := graphql.GetPathContext(ctx); path != nil; path = path.Parent {
+		if path.Field != nil {
+			input = *path.Field
+			break
+		}
+	}
+
+	if input != "id" && input != "ids" {
+		return globalIDTypes[input]
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil {
+		return ""
+	}
+
+	return globalIDTypes[fc.Object+"."+fc.Field.Name]
+}
+
+// MarshalID and UnmarshalID bind the GraphQL ID scalar to database ids.
+// Inputs take either the database id or the global id of the object, so the
+// id of a node can be passed back as is. Global ids of another type than the
+// input points to are refused instead of being read as that input's id, the
+// errors of UnmarshalID wrap ErrInvalidID.
+func MarshalID(id int64) graphql.ContextMarshaler {
+	return graphql.ContextWriterFunc(func(ctx context.Context, w io.Writer) error {
+		graphql.MarshalInt64(id).MarshalGQL(w)
+		return nil
+	})
+}
+
+func UnmarshalID(ctx context.Context, v any) (int64, error) {
+	if s, ok := v.(string); ok {
+		if typename, id, err := DecodeGlobalID(s); err == nil {
+			if expected := globalIDType(ctx); typename != expected {
+				return 0, fmt.Errorf("%w: %q is the id of a %s", ErrInvalidID, s, typename)
+			}
+			return id, nil
+		}
+	}
+
+	id, err := graphql.UnmarshalInt64(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidID, err)
+	}
+
+	return id, nil
+}
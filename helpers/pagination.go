@@ -1,29 +1,190 @@
 package helpers
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"sqlc-rest-api/responses"
+	"strconv"
+	"strings"
 	"time"
 )
 
-func DecodeCursor(cursor string) time.Time {
-	ds, err := base64.StdEncoding.DecodeString(cursor)
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorSecret signs every cursor handed out. Without a configured secret a
+// random one is used, so cursors do not survive a restart.
+var cursorSecret = randomSecret()
+
+// SetCursorSecret replaces the key cursors are signed with, it must be called
+// before the server starts handling requests.
+func SetCursorSecret(secret string) {
+	if secret != "" {
+		cursorSecret = []byte(secret)
+	}
+}
+
+// Cursor is the keyset position of a product in the newest first ordering,
+// the id breaks ties between products created at the same time.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// EncodeCursor returns an opaque cursor for the given position. The payload
+// is followed by its HMAC so clients cannot forge or edit cursors.
+func EncodeCursor(createdAt time.Time, id int64) string {
+	return encodeCursor(strconv.FormatInt(createdAt.UnixNano(), 10) + ":" + strconv.FormatInt(id, 10))
+}
+
+// DecodeCursor verifies and decodes a cursor made by EncodeCursor, anything
+// else is reported as ErrInvalidCursor.
+func DecodeCursor(cursor string) (Cursor, error) {
+	payload, err := verifyCursor(cursor)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	nanos, id, ok := strings.Cut(payload, ":")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	c := Cursor{CreatedAt: time.Unix(0, n).UTC()}
+	c.ID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// SearchCursor is the position of a product in relevance ordered search
+// results, the id breaks ties between products ranked the same.
+type SearchCursor struct {
+	Rank float64
+	ID   int64
+}
+
+// searchCursorPrefix keeps search cursors and created_at cursors apart, a
+// cursor of one kind never decodes as the other.
+const searchCursorPrefix = "search:"
+
+// EncodeSearchCursor returns a signed cursor for a search result, ranks are
+// formatted exactly so the position round trips.
+func EncodeSearchCursor(rank float64, id int64) string {
+	return encodeCursor(searchCursorPrefix + strconv.FormatFloat(rank, 'g', -1, 64) + ":" + strconv.FormatInt(id, 10))
+}
+
+// DecodeSearchCursor verifies and decodes a cursor made by
+// EncodeSearchCursor, anything else is reported as ErrInvalidCursor.
+func DecodeSearchCursor(cursor string) (SearchCursor, error) {
+	payload, err := verifyCursor(cursor)
+	if err != nil {
+		return SearchCursor{}, err
+	}
+
+	if !strings.HasPrefix(payload, searchCursorPrefix) {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	rank, id, ok := strings.Cut(strings.TrimPrefix(payload, searchCursorPrefix), ":")
+	if !ok {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	var c SearchCursor
+	c.Rank, err = strconv.ParseFloat(rank, 64)
+	if err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	c.ID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// idCursorPrefix keeps id cursors apart from the other kinds.
+const idCursorPrefix = "id:"
+
+// EncodeIDCursor returns a signed cursor for lists in id order.
+func EncodeIDCursor(id int64) string {
+	return encodeCursor(idCursorPrefix + strconv.FormatInt(id, 10))
+}
+
+// DecodeIDCursor verifies and decodes a cursor made by EncodeIDCursor,
+// anything else is reported as ErrInvalidCursor.
+func DecodeIDCursor(cursor string) (int64, error) {
+	payload, err := verifyCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	if !strings.HasPrefix(payload, idCursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(payload, idCursorPrefix), 10, 64)
 	if err != nil {
-		return time.Now()
+		return 0, ErrInvalidCursor
+	}
+
+	return id, nil
+}
+
+func encodeCursor(payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + sign(payload)
+}
+
+// verifyCursor checks the signature of cursor and returns its payload.
+func verifyCursor(cursor string) (string, error) {
+	encoded, signature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return "", ErrInvalidCursor
 	}
 
-	timestamp, err := time.Parse("2006-01-02 15:04:05 -0700 MST", string(ds))
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
 	if err != nil {
-		return time.Now()
+		return "", ErrInvalidCursor
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(sign(string(payload)))) {
+		return "", ErrInvalidCursor
+	}
+
+	return string(payload), nil
+}
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func randomSecret() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
 	}
 
-	return timestamp
+	return secret
 }
 
-func NewPageInfo(startCursor, endCursor string, hasNextPage bool) *responses.PageInfo {
+func NewPageInfo(startCursor, endCursor string, hasNextPage, hasPreviousPage bool) *responses.PageInfo {
 	return &responses.PageInfo{
-		StartCursor: startCursor,
-		EndCursor:   endCursor,
-		HasNextPage: hasNextPage,
+		StartCursor:     startCursor,
+		EndCursor:       endCursor,
+		HasNextPage:     hasNextPage,
+		HasPreviousPage: hasPreviousPage,
 	}
 }
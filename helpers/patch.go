@@ -0,0 +1,421 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalidPatch is returned for patch documents that are not valid JSON
+	// or hold unknown operations.
+	ErrInvalidPatch = errors.New("invalid patch document")
+
+	// ErrPatchPath is returned when a JSON Patch operation points at a
+	// location that does not exist in the document.
+	ErrPatchPath = errors.New("patch path not found")
+
+	// ErrPatchTestFailed is returned when a JSON Patch test operation does not
+	// match the document.
+	ErrPatchTestFailed = errors.New("patch test failed")
+)
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) to doc: members of
+// the patch replace the members of doc, null members remove them.
+func ApplyMergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+
+	return t
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies a JSON Patch (RFC 6902) to doc. The operations are
+// applied in order and the whole patch fails when one of them does.
+func ApplyJSONPatch(doc, patch []byte) ([]byte, error) {
+	target, err := decodeJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for i, op := range ops {
+		target, err = applyOperation(target, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func applyOperation(doc any, op patchOperation) (any, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("%w: %s operation without a path", ErrInvalidPatch, op.Op)
+	}
+
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s operation without a value", ErrInvalidPatch, op.Op)
+		}
+
+		value, err := decodeJSON(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+
+		switch op.Op {
+		case "add":
+			return addValue(doc, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			if doc, _, err = removeValue(doc, path); err != nil {
+				return nil, err
+			}
+			return addValue(doc, path, value)
+		default:
+			current, err := getValue(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !equalJSON(current, value) {
+				return nil, fmt.Errorf("%w: %s", ErrPatchTestFailed, *op.Path)
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err = removeValue(doc, path)
+		return doc, err
+	case "move", "copy":
+		if op.From == nil {
+			return nil, fmt.Errorf("%w: %s operation without from", ErrInvalidPatch, op.Op)
+		}
+
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+		if op.Op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, fmt.Errorf("%w: cannot move %s into itself", ErrInvalidPatch, *op.From)
+			}
+			doc, value, err = removeValue(doc, from)
+		} else {
+			value, err = getValue(doc, from)
+			if err == nil {
+				value, err = copyJSON(value)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped tokens, the
+// empty pointer refers to the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func isPrefix(prefix, tokens []string) bool {
+	if len(prefix) > len(tokens) {
+		return false
+	}
+
+	for i := range prefix {
+		if prefix[i] != tokens[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// arrayIndex parses an array index token, max is the largest index allowed.
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrPatchPath, token)
+	}
+
+	return i, nil
+}
+
+func getValue(node any, tokens []string) (any, error) {
+	for _, token := range tokens {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q", ErrPatchPath, token)
+			}
+			node = child
+		case []any:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("%w: %q is not a container", ErrPatchPath, token)
+		}
+	}
+
+	return node, nil
+}
+
+// addValue returns node with value added at tokens. Arrays are returned as new
+// slices so callers always store the result.
+func addValue(node any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	token, rest := tokens[0], tokens[1:]
+	switch n := node.(type) {
+	case map[string]any:
+		if len(rest) == 0 {
+			n[token] = value
+			return n, nil
+		}
+
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("%w: member %q", ErrPatchPath, token)
+		}
+
+		child, err := addValue(child, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+
+		return n, nil
+	case []any:
+		if len(rest) == 0 {
+			i := len(n)
+			if token != "-" {
+				var err error
+				if i, err = arrayIndex(token, len(n)); err != nil {
+					return nil, err
+				}
+			}
+
+			added := make([]any, 0, len(n)+1)
+			added = append(added, n[:i]...)
+			added = append(added, value)
+			return append(added, n[i:]...), nil
+		}
+
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := addValue(n[i], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+
+		return n, nil
+	default:
+		return nil, fmt.Errorf("%w: %q is not a container", ErrPatchPath, token)
+	}
+}
+
+// removeValue returns node without the value at tokens together with the
+// removed value.
+func removeValue(node any, tokens []string) (any, any, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+
+	token, rest := tokens[0], tokens[1:]
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: member %q", ErrPatchPath, token)
+		}
+
+		if len(rest) == 0 {
+			delete(n, token)
+			return n, child, nil
+		}
+
+		child, removed, err := removeValue(child, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = child
+
+		return n, removed, nil
+	case []any:
+		i, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(rest) == 0 {
+			removed := n[i]
+			return append(n[:i:i], n[i+1:]...), removed, nil
+		}
+
+		child, removed, err := removeValue(n[i], rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		n[i] = child
+
+		return n, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %q is not a container", ErrPatchPath, token)
+	}
+}
+
+// equalJSON compares decoded JSON values, numbers are equal when they have the
+// same value whatever their notation.
+func equalJSON(a, b any) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equalJSON(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equalJSON(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if x == y {
+			return true
+		}
+		fx, errx := x.Float64()
+		fy, erry := y.Float64()
+		return errx == nil && erry == nil && fx == fy
+	default:
+		return a == b
+	}
+}
+
+func copyJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON(data)
+}
+
+// decodeJSON decodes a single JSON value keeping numbers as json.Number so
+// integers survive the round trip.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	return v, nil
+}
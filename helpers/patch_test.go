@@ -0,0 +1,132 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	doc := `{"name":"product","price":100,"tags":{"color":"red","size":"xl"}}`
+
+	testCases := []struct {
+		name     string
+		patch    string
+		expected string
+		err      error
+	}{
+		{
+			name:     "replace member",
+			patch:    `{"price":150}`,
+			expected: `{"name":"product","price":150,"tags":{"color":"red","size":"xl"}}`,
+		},
+		{
+			name:     "null removes member",
+			patch:    `{"name":null,"tags":{"size":null}}`,
+			expected: `{"price":100,"tags":{"color":"red"}}`,
+		},
+		{
+			name:     "empty patch keeps document",
+			patch:    `{}`,
+			expected: doc,
+		},
+		{
+			name:  "invalid json",
+			patch: `{"price":`,
+			err:   ErrInvalidPatch,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := ApplyMergePatch([]byte(doc), []byte(testCase.patch))
+			if testCase.err != nil {
+				require.ErrorIs(t, err, testCase.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, testCase.expected, string(result))
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	doc := `{"name":"product","price":100,"tags":["a","b"]}`
+
+	testCases := []struct {
+		name     string
+		patch    string
+		expected string
+		err      error
+	}{
+		{
+			name:     "replace member",
+			patch:    `[{"op":"replace","path":"/price","value":150}]`,
+			expected: `{"name":"product","price":150,"tags":["a","b"]}`,
+		},
+		{
+			name:     "add and remove array items",
+			patch:    `[{"op":"add","path":"/tags/1","value":"c"},{"op":"add","path":"/tags/-","value":"d"},{"op":"remove","path":"/tags/0"}]`,
+			expected: `{"name":"product","price":100,"tags":["c","b","d"]}`,
+		},
+		{
+			name:     "move and copy",
+			patch:    `[{"op":"copy","from":"/name","path":"/title"},{"op":"move","from":"/price","path":"/cost"}]`,
+			expected: `{"name":"product","title":"product","cost":100,"tags":["a","b"]}`,
+		},
+		{
+			name:     "escaped pointer",
+			patch:    `[{"op":"add","path":"/a~1b~0c","value":1}]`,
+			expected: `{"name":"product","price":100,"tags":["a","b"],"a/b~c":1}`,
+		},
+		{
+			name:     "passing test",
+			patch:    `[{"op":"test","path":"/price","value":100.0},{"op":"replace","path":"/name","value":"new"}]`,
+			expected: `{"name":"new","price":100,"tags":["a","b"]}`,
+		},
+		{
+			name:  "failing test",
+			patch: `[{"op":"replace","path":"/name","value":"new"},{"op":"test","path":"/price","value":99}]`,
+			err:   ErrPatchTestFailed,
+		},
+		{
+			name:  "replace missing member",
+			patch: `[{"op":"replace","path":"/missing","value":1}]`,
+			err:   ErrPatchPath,
+		},
+		{
+			name:  "array index out of range",
+			patch: `[{"op":"remove","path":"/tags/2"}]`,
+			err:   ErrPatchPath,
+		},
+		{
+			name:  "unknown operation",
+			patch: `[{"op":"merge","path":"/name","value":"new"}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			name:  "missing value",
+			patch: `[{"op":"add","path":"/name"}]`,
+			err:   ErrInvalidPatch,
+		},
+		{
+			name:  "not a list of operations",
+			patch: `{"op":"add","path":"/name","value":"new"}`,
+			err:   ErrInvalidPatch,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := ApplyJSONPatch([]byte(doc), []byte(testCase.patch))
+			if testCase.err != nil {
+				require.ErrorIs(t, err, testCase.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, testCase.expected, string(result))
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package helpers
 
 import (
+	"database/sql"
 	"sqlc-rest-api/db/postgres/repositories"
+	"sqlc-rest-api/requests"
 	"sqlc-rest-api/responses"
+	"strings"
+	"time"
 )
 
 func SuccessResponse(message string, data any) responses.ApiResponse {
@@ -20,9 +24,22 @@ func ProductResponse(source any) *responses.Product {
 		product = responses.Product{
 			ID:        p.ID,
 			Name:      p.Name,
-			Price:     p.Price,
+			Price:     responses.Money{Amount: p.Price, Currency: p.Currency},
 			UserID:    p.UserID,
 			CreatedAt: p.CreatedAt.Time,
+			UpdatedAt: p.UpdatedAt.Time,
+			DeletedAt: timeResponse(p.DeletedAt),
+			Version:   p.Version,
+		}
+	case repositories.SearchProductsRow:
+		product = responses.Product{
+			ID:        p.ID,
+			Name:      p.Name,
+			Price:     responses.Money{Amount: p.Price, Currency: p.Currency},
+			UserID:    p.UserID,
+			CreatedAt: p.CreatedAt.Time,
+			UpdatedAt: p.UpdatedAt.Time,
+			Version:   p.Version,
 		}
 	default:
 		panic("incompatible source")
@@ -31,6 +48,325 @@ func ProductResponse(source any) *responses.Product {
 	return &product
 }
 
+func ExchangeRateResponse(source any) *responses.ExchangeRate {
+	var rate responses.ExchangeRate
+	switch r := source.(type) {
+	case repositories.ExchangeRate:
+		rate = responses.ExchangeRate{
+			Base:      r.Base,
+			Quote:     r.Quote,
+			Rate:      trimDecimal(r.Rate),
+			UpdatedAt: r.UpdatedAt,
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return &rate
+}
+
+func ExchangeRateSliceResponse(source any) []*responses.ExchangeRate {
+	rates := []*responses.ExchangeRate{}
+	switch s := source.(type) {
+	case []repositories.ExchangeRate:
+		for _, rate := range s {
+			rates = append(rates, ExchangeRateResponse(rate))
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return rates
+}
+
+func CategoryResponse(source any) *responses.Category {
+	var category responses.Category
+	switch c := source.(type) {
+	case repositories.Category:
+		category = responses.Category{
+			ID:        c.ID,
+			Name:      c.Name,
+			ParentID:  int64Response(c.ParentID),
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		}
+	case repositories.GetBatchProductCategoriesRow:
+		category = responses.Category{
+			ID:        c.ID,
+			Name:      c.Name,
+			ParentID:  int64Response(c.ParentID),
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return &category
+}
+
+func CategorySliceResponse(source any) []*responses.Category {
+	categories := []*responses.Category{}
+	switch s := source.(type) {
+	case []repositories.Category:
+		for _, category := range s {
+			categories = append(categories, CategoryResponse(category))
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return categories
+}
+
+func TagResponse(source any) *responses.Tag {
+	var tag responses.Tag
+	switch t := source.(type) {
+	case repositories.Tag:
+		tag = responses.Tag{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt}
+	case repositories.GetBatchProductTagsRow:
+		tag = responses.Tag{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt}
+	default:
+		panic("incompatible source")
+	}
+
+	return &tag
+}
+
+func TagSliceResponse(source any) []*responses.Tag {
+	tags := []*responses.Tag{}
+	switch s := source.(type) {
+	case []repositories.Tag:
+		for _, tag := range s {
+			tags = append(tags, TagResponse(tag))
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return tags
+}
+
+// StockResponse reports the stock of an inventory row, products without one
+// have the zero Stock.
+func StockResponse(source any) *responses.Stock {
+	var stock responses.Stock
+	switch i := source.(type) {
+	case repositories.Inventory:
+		stock = responses.Stock{ProductID: i.ProductID, OnHand: i.OnHand, Reserved: i.Reserved}
+	default:
+		panic("incompatible source")
+	}
+
+	stock.Available = stock.OnHand - stock.Reserved
+	return &stock
+}
+
+func StockAdjustmentResponse(source any) *responses.StockAdjustment {
+	var adjustment responses.StockAdjustment
+	switch a := source.(type) {
+	case repositories.StockAdjustment:
+		adjustment = responses.StockAdjustment{
+			ID:        a.ID,
+			ProductID: a.ProductID,
+			Delta:     a.Delta,
+			Reason:    requests.StockReason(a.Reason),
+			Note:      a.Note,
+			CreatedAt: a.CreatedAt,
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return &adjustment
+}
+
+func StockAdjustmentSliceResponse(source any) []*responses.StockAdjustment {
+	adjustments := []*responses.StockAdjustment{}
+	switch s := source.(type) {
+	case []repositories.StockAdjustment:
+		for _, adjustment := range s {
+			adjustments = append(adjustments, StockAdjustmentResponse(adjustment))
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return adjustments
+}
+
+func ReservationResponse(source any) *responses.Reservation {
+	var reservation responses.Reservation
+	switch r := source.(type) {
+	case repositories.Reservation:
+		reservation = responses.Reservation{
+			ID:        r.ID,
+			ProductID: r.ProductID,
+			UserID:    int64Response(r.UserID),
+			Quantity:  r.Quantity,
+			Status:    responses.ReservationStatus(r.Status),
+			ExpiresAt: r.ExpiresAt,
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.UpdatedAt,
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return &reservation
+}
+
+func PaymentResponse(source any) *responses.Payment {
+	var payment responses.Payment
+	switch p := source.(type) {
+	case repositories.Payment:
+		payment = responses.Payment{
+			ID:        p.ID,
+			OrderID:   int64Response(p.OrderID),
+			ProductID: int64Response(p.ProductID),
+			UserID:    int64Response(p.UserID),
+			Provider:  p.Provider,
+			IntentID:  p.IntentID,
+			Status:    responses.PaymentStatus(p.Status),
+			Amount:    responses.Money{Amount: p.Amount, Currency: p.Currency},
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return &payment
+}
+
+// OrderResponse converts an order and its items, items of other orders are
+// skipped.
+func OrderResponse(source any, items any) *responses.Order {
+	var order responses.Order
+	switch o := source.(type) {
+	case repositories.Order:
+		order = responses.Order{
+			ID:        o.ID,
+			UserID:    o.UserID,
+			Status:    requests.OrderStatus(o.Status),
+			Total:     responses.Money{Amount: o.Total, Currency: o.Currency},
+			CreatedAt: o.CreatedAt,
+			UpdatedAt: o.UpdatedAt,
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	order.Items = orderItems(&order, orderItemSliceResponse(items))
+	return &order
+}
+
+// orderItems returns the items of order with their amounts in its currency.
+func orderItems(order *responses.Order, items []*responses.OrderItem) []*responses.OrderItem {
+	result := []*responses.OrderItem{}
+	for _, item := range items {
+		if item.OrderID != order.ID {
+			continue
+		}
+
+		item.UnitPrice.Currency = order.Total.Currency
+		item.Total = responses.Money{Amount: item.UnitPrice.Amount * item.Quantity, Currency: order.Total.Currency}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// orderItemSliceResponse leaves the currency of the amounts empty, the items
+// don't know the currency of their order.
+func orderItemSliceResponse(source any) []*responses.OrderItem {
+	items := []*responses.OrderItem{}
+	switch s := source.(type) {
+	case []repositories.OrderItem:
+		for _, i := range s {
+			items = append(items, &responses.OrderItem{
+				ID:          i.ID,
+				OrderID:     i.OrderID,
+				ProductID:   int64Response(i.ProductID),
+				ProductName: i.ProductName,
+				UnitPrice:   responses.Money{Amount: i.UnitPrice},
+				Quantity:    i.Quantity,
+			})
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return items
+}
+
+// OrdersResponse converts a page of orders, items holds the items of all of
+// them.
+func OrdersResponse(source any, items any, hasNextPage, hasPreviousPage bool) *responses.Orders {
+	orders := []*responses.Order{}
+	switch s := source.(type) {
+	case []repositories.Order:
+		for _, order := range s {
+			orders = append(orders, OrderResponse(order, []repositories.OrderItem{}))
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	if len(orders) < 1 {
+		return &responses.Orders{
+			Edges:    []*responses.OrderEdge{},
+			PageInfo: NewPageInfo("", "", false, false),
+		}
+	}
+
+	all := orderItemSliceResponse(items)
+	edges := make([]*responses.OrderEdge, len(orders))
+	for i, order := range orders {
+		order.Items = orderItems(order, all)
+		edges[i] = &responses.OrderEdge{
+			Cursor: EncodeIDCursor(order.ID),
+			Node:   order,
+		}
+	}
+
+	sc := edges[0].Cursor
+	ec := edges[len(edges)-1].Cursor
+
+	return &responses.Orders{
+		Edges:    edges,
+		PageInfo: NewPageInfo(sc, ec, hasNextPage, hasPreviousPage),
+	}
+}
+
+// trimDecimal drops the trailing zeros postgres pads NUMERIC values with, so
+// every backend reports a rate the same way.
+func trimDecimal(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// timeResponse returns nil for NULL timestamps so they are left out of the
+// response.
+func timeResponse(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+func int64Response(n sql.NullInt64) *int64 {
+	if !n.Valid {
+		return nil
+	}
+
+	return &n.Int64
+}
+
 func UserResponse(source any) *responses.User {
 	var user responses.User
 	switch u := source.(type) {
@@ -40,6 +376,8 @@ func UserResponse(source any) *responses.User {
 			Name:      u.Name,
 			Email:     u.Email,
 			CreatedAt: u.CreatedAt.Time,
+			UpdatedAt: u.UpdatedAt.Time,
+			Version:   u.Version,
 		}
 	default:
 		panic("incompatible source")
@@ -47,3 +385,181 @@ func UserResponse(source any) *responses.User {
 
 	return &user
 }
+
+func ProductsResponse(source any, hasNextPage, hasPreviousPage bool) *responses.Products {
+	products := ProductSliceResponse(source)
+	if len(products) < 1 {
+		return &responses.Products{
+			Edges:    []*responses.ProductEdge{},
+			PageInfo: NewPageInfo("", "", false, false),
+		}
+	}
+
+	edges := make([]*responses.ProductEdge, len(products))
+	for i, product := range products {
+		edges[i] = &responses.ProductEdge{
+			Cursor: EncodeCursor(product.CreatedAt, product.ID),
+			Node:   product,
+		}
+	}
+
+	sc := edges[0].Cursor
+	ec := edges[len(edges)-1].Cursor
+
+	return &responses.Products{
+		Edges:    edges,
+		PageInfo: NewPageInfo(sc, ec, hasNextPage, hasPreviousPage),
+	}
+}
+
+func UsersResponse(source any, hasNextPage, hasPreviousPage bool) *responses.Users {
+	users := UserSliceResponse(source)
+	if len(users) < 1 {
+		return &responses.Users{
+			Edges:    []*responses.UserEdge{},
+			PageInfo: NewPageInfo("", "", false, false),
+		}
+	}
+
+	edges := make([]*responses.UserEdge, len(users))
+	for i, user := range users {
+		edges[i] = &responses.UserEdge{
+			Cursor: EncodeIDCursor(user.ID),
+			Node:   user,
+		}
+	}
+
+	sc := edges[0].Cursor
+	ec := edges[len(edges)-1].Cursor
+
+	return &responses.Users{
+		Edges:    edges,
+		PageInfo: NewPageInfo(sc, ec, hasNextPage, hasPreviousPage),
+	}
+}
+
+func ProductListResponse(source any, limit, offset int, total int64) *responses.ProductList {
+	return &responses.ProductList{
+		Products: ProductSliceResponse(source),
+		PageInfo: &responses.OffsetPageInfo{
+			Limit:  limit,
+			Offset: offset,
+			Total:  total,
+		},
+	}
+}
+
+func ProductSliceResponse(source any) []*responses.Product {
+	products := []*responses.Product{}
+	switch p := source.(type) {
+	case []repositories.Product:
+		for _, prod := range p {
+			products = append(products, ProductResponse(prod))
+		}
+	case []*responses.Product:
+		products = append(products, p...)
+	default:
+		panic("incompatible source")
+	}
+
+	return products
+}
+
+func UserSliceResponse(source any) []*responses.User {
+	users := []*responses.User{}
+	switch u := source.(type) {
+	case []repositories.User:
+		for _, user := range u {
+			users = append(users, UserResponse(user))
+		}
+	case []*responses.User:
+		users = append(users, u...)
+	default:
+		panic("incompatible source")
+	}
+
+	return users
+}
+
+// RoleSliceResponse converts roles, grants holds the permissions of all of
+// them.
+func RoleSliceResponse(source any, grants any) []*responses.Role {
+	permissions := make(map[string][]string)
+	switch g := grants.(type) {
+	case []repositories.ListRolePermissionsRow:
+		for _, grant := range g {
+			permissions[grant.Role] = append(permissions[grant.Role], grant.Permission)
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	roles := []*responses.Role{}
+	switch r := source.(type) {
+	case []repositories.Role:
+		for _, role := range r {
+			roles = append(roles, roleResponse(role.Name, role.Description, permissions[role.Name]))
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return roles
+}
+
+func roleResponse(name, description string, permissions []string) *responses.Role {
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return &responses.Role{
+		Name:        requests.Role(name),
+		Description: description,
+		Permissions: permissions,
+	}
+}
+
+func APIKeyResponse(source any) *responses.APIKey {
+	var key responses.APIKey
+	switch k := source.(type) {
+	case repositories.APIKey:
+		key = responses.APIKey{
+			ID:         k.ID,
+			UserID:     k.UserID,
+			Name:       k.Name,
+			Prefix:     k.Prefix,
+			Scopes:     scopesResponse(k.Scopes),
+			ExpiresAt:  timeResponse(k.ExpiresAt),
+			LastUsedAt: timeResponse(k.LastUsedAt),
+			CreatedAt:  k.CreatedAt,
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return &key
+}
+
+func APIKeySliceResponse(source any) []*responses.APIKey {
+	keys := []*responses.APIKey{}
+	switch k := source.(type) {
+	case []repositories.APIKey:
+		for _, key := range k {
+			keys = append(keys, APIKeyResponse(key))
+		}
+	default:
+		panic("incompatible source")
+	}
+
+	return keys
+}
+
+// scopesResponse splits the space separated scopes of an API key.
+func scopesResponse(s string) []requests.Scope {
+	scopes := []requests.Scope{}
+	for _, scope := range strings.Fields(s) {
+		scopes = append(scopes, requests.Scope(scope))
+	}
+
+	return scopes
+}
@@ -2,7 +2,6 @@ package helpers
 
 import (
 	"bytes"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,10 +24,12 @@ func NewProductTest(user responses.User) responses.Product {
 	product := responses.Product{
 		ID:        1,
 		Name:      "Test Product",
-		Price:     100,
+		Price:     responses.Money{Amount: 100, Currency: "USD"},
 		UserID:    user.ID,
+		Version:   1,
 		CreatedAt: time.Now(),
 	}
+	product.UpdatedAt = product.CreatedAt
 
 	return product
 }
@@ -38,11 +39,11 @@ func NewProductsTest(n int, userID int64) *responses.Products {
 	for i := 0; i < n; i++ {
 		tt := time.Now()
 		edge := responses.ProductEdge{
-			Cursor: base64.StdEncoding.EncodeToString([]byte(tt.String())),
+			Cursor: EncodeCursor(tt, int64(i+1)),
 			Node: &responses.Product{
 				ID:        int64(i + 1),
 				Name:      fmt.Sprintf("Product %d", i+1),
-				Price:     100,
+				Price:     responses.Money{Amount: 100, Currency: "USD"},
 				UserID:    userID,
 				CreatedAt: tt,
 			},
@@ -54,13 +55,13 @@ func NewProductsTest(n int, userID int64) *responses.Products {
 	if len(productEdges) < 1 {
 		return &responses.Products{
 			Edges:    []*responses.ProductEdge{},
-			PageInfo: NewPageInfo("", "", false),
+			PageInfo: NewPageInfo("", "", false, false),
 		}
 	}
 
-	sc := base64.StdEncoding.EncodeToString([]byte(productEdges[0].Node.CreatedAt.String()))
-	ec := base64.StdEncoding.EncodeToString([]byte(productEdges[len(productEdges)-1].Node.CreatedAt.String()))
-	pageInfo := NewPageInfo(sc, ec, true)
+	sc := productEdges[0].Cursor
+	ec := productEdges[len(productEdges)-1].Cursor
+	pageInfo := NewPageInfo(sc, ec, true, false)
 
 	return &responses.Products{
 		Edges:    productEdges,
@@ -68,6 +69,27 @@ func NewProductsTest(n int, userID int64) *responses.Products {
 	}
 }
 
+// NewSearchProductsTest returns search results with decreasing ranks and the
+// product names highlighted.
+func NewSearchProductsTest(n int, userID int64) *responses.Products {
+	products := NewProductsTest(n, userID)
+	for i, edge := range products.Edges {
+		rank := float64(n - i)
+		highlight := fmt.Sprintf("<mark>Product</mark> %d", i+1)
+
+		edge.Cursor = EncodeSearchCursor(rank, edge.Node.ID)
+		edge.Rank = &rank
+		edge.Highlight = &highlight
+	}
+
+	if n > 0 {
+		products.PageInfo.StartCursor = products.Edges[0].Cursor
+		products.PageInfo.EndCursor = products.Edges[n-1].Cursor
+	}
+
+	return products
+}
+
 func NewProductDeletedTest(productID int64) *responses.DeletedProduct {
 	return &responses.DeletedProduct{
 		Deleted:   true,
@@ -76,12 +98,16 @@ func NewProductDeletedTest(productID int64) *responses.DeletedProduct {
 }
 
 func NewUserTest() responses.User {
-	return responses.User{
+	user := responses.User{
 		ID:        1,
 		Name:      "royyan",
 		Email:     "roy@gmail.com",
+		Version:   1,
 		CreatedAt: time.Now(),
 	}
+	user.UpdatedAt = user.CreatedAt
+
+	return user
 }
 
 func NewGraphQLRequestTest(name, query string, vars map[string]any) GraphQLRequest {
@@ -100,7 +126,7 @@ func NewCreateProductRequestTest(user *responses.User, product *responses.Produc
 	return requests.CreateProductRequest{
 		UserID: user.ID,
 		Name:   product.Name,
-		Price:  product.Price,
+		Price:  product.Price.Amount,
 	}
 }
 
@@ -118,7 +144,7 @@ func NewUpdateProductRequestTest(product *responses.Product) requests.UpdateProd
 	return requests.UpdateProductRequest{
 		ID:    product.ID,
 		Name:  product.Name,
-		Price: product.Price,
+		Price: product.Price.Amount,
 	}
 }
 
@@ -130,6 +156,14 @@ func NewGetUserProductsRequestTest(userID int64, first *int, after *string) requ
 	}
 }
 
+func NewGetBatchUserProductsRequestTest(userIDs []int64, first *int, after *string) requests.GetBatchUserProductsRequest {
+	return requests.GetBatchUserProductsRequest{
+		UserIDs: userIDs,
+		First:   first,
+		After:   after,
+	}
+}
+
 func RequireProductMatchTest(t *testing.T, body *bytes.Buffer, expectedProduct responses.Product) {
 	jsonData, err := io.ReadAll(body)
 	require.NoError(t, err)
@@ -142,16 +176,79 @@ func RequireProductMatchTest(t *testing.T, body *bytes.Buffer, expectedProduct r
 	require.Equal(t, expectedProduct.Name, product.Name)
 	require.Equal(t, expectedProduct.Price, product.Price)
 	require.Equal(t, expectedProduct.UserID, product.UserID)
+	require.Equal(t, expectedProduct.Version, product.Version)
+}
+
+func RequireProductListMatchTest(t *testing.T, body *bytes.Buffer, expectedList responses.ProductList) {
+	jsonData, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var products []responses.Product
+	parseJson(t, jsonData, "data.products", &products)
+
+	var pageInfo responses.OffsetPageInfo
+	parseJson(t, jsonData, "data.page_info", &pageInfo)
+
+	require.Len(t, products, len(expectedList.Products))
+	for i, expectedProduct := range expectedList.Products {
+		require.Equal(t, expectedProduct.ID, products[i].ID)
+		require.Equal(t, expectedProduct.Name, products[i].Name)
+	}
+	require.Equal(t, *expectedList.PageInfo, pageInfo)
+}
+
+// RequireSearchProductsMatchTest compares the cursor, rank and highlight of
+// every edge, it works for REST and GraphQL responses alike.
+func RequireSearchProductsMatchTest(t *testing.T, jsonPath string, body bytes.Buffer, expectedProducts responses.Products) {
+	jsonData, err := io.ReadAll(&body)
+	require.NoError(t, err)
+
+	var products struct {
+		Edges []struct {
+			Cursor    string   `json:"cursor"`
+			Rank      *float64 `json:"rank"`
+			Highlight *string  `json:"highlight"`
+		} `json:"edges"`
+	}
+	parseJson(t, jsonData, jsonPath, &products)
+
+	require.Len(t, products.Edges, len(expectedProducts.Edges))
+	for i, expected := range expectedProducts.Edges {
+		require.Equal(t, expected.Cursor, products.Edges[i].Cursor)
+		require.Equal(t, expected.Rank, products.Edges[i].Rank)
+		require.Equal(t, expected.Highlight, products.Edges[i].Highlight)
+	}
+}
+
+// graphProduct and graphUser mirror the responses types as GraphQL exposes
+// them, with id being the global object id.
+type graphProduct struct {
+	ID     string          `json:"id"`
+	Name   string          `json:"name"`
+	Price  responses.Money `json:"price"`
+	UserID int64           `json:"user_id"`
+}
+
+type graphUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type graphProducts struct {
+	Edges []struct {
+		Node graphProduct `json:"node"`
+	} `json:"edges"`
 }
 
 func GraphProductMatchTest(t *testing.T, jsonPath string, body bytes.Buffer, expectedProduct responses.Product) {
 	jsonData, err := io.ReadAll(&body)
 	require.NoError(t, err)
 
-	var product responses.Product
+	var product graphProduct
 	parseJson(t, jsonData, jsonPath, &product)
 
-	require.Equal(t, expectedProduct.ID, product.ID)
+	require.Equal(t, EncodeGlobalID("Product", expectedProduct.ID), product.ID)
 	require.Equal(t, expectedProduct.Name, product.Name)
 	require.Equal(t, expectedProduct.Price, product.Price)
 	require.Equal(t, expectedProduct.UserID, product.UserID)
@@ -161,7 +258,7 @@ func GraphProductsMatchTest(t *testing.T, jsonPath string, body bytes.Buffer, ex
 	jsonData, err := io.ReadAll(&body)
 	require.NoError(t, err)
 
-	var products responses.Products
+	var products graphProducts
 	parseJson(t, jsonData, jsonPath, &products)
 
 	require.Equal(t, len(expectedProducts.Edges), len(products.Edges))
@@ -171,14 +268,34 @@ func GraphUserMatchTest(t *testing.T, jsonPath string, body bytes.Buffer, expect
 	jsonData, err := io.ReadAll(&body)
 	require.NoError(t, err)
 
-	var user responses.User
+	var user graphUser
 	parseJson(t, jsonData, jsonPath, &user)
 
-	require.Equal(t, expectedUser.ID, user.ID)
+	require.Equal(t, EncodeGlobalID("User", expectedUser.ID), user.ID)
 	require.Equal(t, expectedUser.Name, user.Name)
 	require.Equal(t, expectedUser.Email, user.Email)
 }
 
+// GraphBulkResultTest decodes the bulk mutation result at jsonPath, product
+// selections must leave out the global id.
+func GraphBulkResultTest(t *testing.T, jsonPath string, body bytes.Buffer) responses.BulkProductsResult {
+	jsonData, err := io.ReadAll(&body)
+	require.NoError(t, err)
+
+	var result responses.BulkProductsResult
+	parseJson(t, jsonData, jsonPath, &result)
+
+	return result
+}
+
+// GraphDecodeTest decodes the value at jsonPath into placeholder.
+func GraphDecodeTest(t *testing.T, jsonPath string, body bytes.Buffer, placeholder any) {
+	jsonData, err := io.ReadAll(&body)
+	require.NoError(t, err)
+
+	parseJson(t, jsonData, jsonPath, placeholder)
+}
+
 func GraphExpectComplexityLimit(t *testing.T, jsonPath string, body bytes.Buffer) {
 	jsonData, err := io.ReadAll(&body)
 	require.NoError(t, err)
@@ -189,6 +306,16 @@ func GraphExpectComplexityLimit(t *testing.T, jsonPath string, body bytes.Buffer
 	require.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", complexity)
 }
 
+func GraphExpectErrorCode(t *testing.T, jsonPath string, body bytes.Buffer, expectedCode string) {
+	jsonData, err := io.ReadAll(&body)
+	require.NoError(t, err)
+
+	var code string
+	parseJson(t, jsonData, jsonPath, &code)
+
+	require.Equal(t, expectedCode, code)
+}
+
 func parseJson(t *testing.T, data []byte, path string, placeholder any) {
 	valid := gjson.ValidBytes(data)
 	require.True(t, valid)
@@ -0,0 +1,77 @@
+// Package jobs holds the background work the server runs next to the REST and
+// GraphQL handlers.
+package jobs
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	DefaultPurgeInterval  = time.Hour
+)
+
+// Purger permanently deletes the products that stayed in the trash for longer
+// than Retention, checking every Interval.
+type Purger struct {
+	Service   services.Service
+	Retention time.Duration
+	Interval  time.Duration
+	Logger    logrus.FieldLogger
+}
+
+// NewPurger returns a Purger, zero retention and interval use
+// DefaultTrashRetention and DefaultPurgeInterval.
+func NewPurger(service services.Service, retention, interval time.Duration, logger logrus.FieldLogger) *Purger {
+	if retention <= 0 {
+		retention = DefaultTrashRetention
+	}
+
+	if interval <= 0 {
+		interval = DefaultPurgeInterval
+	}
+
+	return &Purger{
+		Service:   service,
+		Retention: retention,
+		Interval:  interval,
+		Logger:    logger,
+	}
+}
+
+// Run purges right away and then once every Interval until ctx is done.
+// Failures are logged and retried on the next tick.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.Purge(ctx)
+		if err != nil {
+			p.Logger.WithError(err).Error("failed to purge deleted products")
+		} else if purged > 0 {
+			p.Logger.WithField("purged", purged).Info("purged deleted products")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge deletes the products moved to the trash more than Retention ago and
+// returns how many were deleted.
+func (p *Purger) Purge(ctx context.Context) (int64, error) {
+	req := requests.PurgeDeletedProductsRequest{
+		DeletedBefore: time.Now().Add(-p.Retention),
+	}
+
+	return p.Service.PurgeDeletedProducts(ctx, req)
+}
@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger() logrus.FieldLogger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return logger
+}
+
+func TestNewPurgerDefaults(t *testing.T) {
+	purger := NewPurger(nil, 0, 0, newTestLogger())
+	require.Equal(t, DefaultTrashRetention, purger.Retention)
+	require.Equal(t, DefaultPurgeInterval, purger.Interval)
+
+	purger = NewPurger(nil, time.Hour, time.Minute, newTestLogger())
+	require.Equal(t, time.Hour, purger.Retention)
+	require.Equal(t, time.Minute, purger.Interval)
+}
+
+func TestPurge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	purger := NewPurger(service, 24*time.Hour, time.Hour, newTestLogger())
+
+	start := time.Now()
+	service.EXPECT().
+		PurgeDeletedProducts(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, req requests.PurgeDeletedProductsRequest) (int64, error) {
+			require.WithinDuration(t, start.Add(-24*time.Hour), req.DeletedBefore, time.Second)
+			return 3, nil
+		})
+
+	purged, err := purger.Purge(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), purged)
+}
+
+func TestRunStopsWhenContextIsDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	purger := NewPurger(service, time.Hour, time.Millisecond, newTestLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	service.EXPECT().
+		PurgeDeletedProducts(gomock.Any(), gomock.Any()).
+		MinTimes(2).
+		DoAndReturn(func(context.Context, requests.PurgeDeletedProductsRequest) (int64, error) {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+			// failures must not stop the purger
+			return 0, fmt.Errorf("internal server error")
+		})
+
+	done := make(chan struct{})
+	go func() {
+		purger.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("purger did not stop after the context was cancelled")
+	}
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const DefaultSweepInterval = time.Minute
+
+// Sweeper gives the units of expired reservations back to the stock,
+// checking every Interval.
+type Sweeper struct {
+	Service  services.Service
+	Interval time.Duration
+	Logger   logrus.FieldLogger
+}
+
+// NewSweeper returns a Sweeper, a zero interval uses DefaultSweepInterval.
+func NewSweeper(service services.Service, interval time.Duration, logger logrus.FieldLogger) *Sweeper {
+	if interval <= 0 {
+		interval = DefaultSweepInterval
+	}
+
+	return &Sweeper{
+		Service:  service,
+		Interval: interval,
+		Logger:   logger,
+	}
+}
+
+// Run sweeps right away and then once every Interval until ctx is done.
+// Failures are logged and retried on the next tick.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		released, err := s.Sweep(ctx)
+		if err != nil {
+			s.Logger.WithError(err).Error("failed to release expired reservations")
+		} else if released > 0 {
+			s.Logger.WithField("released", released).Info("released expired reservations")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep releases the reservations that expired by now and returns how many
+// were released.
+func (s *Sweeper) Sweep(ctx context.Context) (int64, error) {
+	req := requests.ReleaseExpiredReservationsRequest{
+		ExpiredBefore: time.Now(),
+	}
+
+	return s.Service.ReleaseExpiredReservations(ctx, req)
+}
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSweeperDefaults(t *testing.T) {
+	sweeper := NewSweeper(nil, 0, newTestLogger())
+	require.Equal(t, DefaultSweepInterval, sweeper.Interval)
+
+	sweeper = NewSweeper(nil, time.Second, newTestLogger())
+	require.Equal(t, time.Second, sweeper.Interval)
+}
+
+func TestSweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	sweeper := NewSweeper(service, time.Minute, newTestLogger())
+
+	start := time.Now()
+	service.EXPECT().
+		ReleaseExpiredReservations(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, req requests.ReleaseExpiredReservationsRequest) (int64, error) {
+			require.WithinDuration(t, start, req.ExpiredBefore, time.Second)
+			return 2, nil
+		})
+
+	released, err := sweeper.Sweep(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), released)
+}
+
+func TestSweeperRunStopsWhenContextIsDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	sweeper := NewSweeper(service, time.Millisecond, newTestLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	service.EXPECT().
+		ReleaseExpiredReservations(gomock.Any(), gomock.Any()).
+		MinTimes(2).
+		DoAndReturn(func(context.Context, requests.ReleaseExpiredReservationsRequest) (int64, error) {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+			// failures must not stop the sweeper
+			return 0, fmt.Errorf("internal server error")
+		})
+
+	done := make(chan struct{})
+	go func() {
+		sweeper.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sweeper did not stop after the context was cancelled")
+	}
+}
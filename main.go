@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"sqlc-rest-api/auth"
 	"sqlc-rest-api/config"
 	"sqlc-rest-api/db/drivers"
 	"sqlc-rest-api/db/postgres/repositories"
 	"sqlc-rest-api/graph/generated"
+	"sqlc-rest-api/graph/loaders"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/jobs"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
 	"sqlc-rest-api/services"
 
+	sqliterepo "sqlc-rest-api/db/sqlite/repositories"
 	graphconfig "sqlc-rest-api/graph/config"
 	gs "sqlc-rest-api/servers/gin"
 
@@ -23,17 +32,29 @@ func main() {
 		logger.Fatal("Failed to laod environment variables :", err)
 	}
 
-	db, err := drivers.NewPostgres(env).Connect()
+	helpers.SetCursorSecret(env.CursorSecret)
+	service, err := newService(env)
 	if err != nil {
-		logger.Fatal("Failed to connect database :", err)
+		logger.Fatal("Failed to create service :", err)
 	}
 
-	pqRepo := repositories.New()
-	service := services.NewPostgresService(db, pqRepo)
+	purger := jobs.NewPurger(service, env.TrashRetention, env.PurgeInterval, logger)
+	go purger.Run(context.Background())
+
+	sweeper := jobs.NewSweeper(service, env.ReservationSweepInterval, logger)
+	go sweeper.Run(context.Background())
+
 	graph := handler.NewDefaultServer(
 		generated.NewExecutableSchema(graphconfig.GraphConfig(service)),
 	)
 
+	presenter := graphconfig.ErrorPresenter(logger)
+	graph.SetErrorPresenter(presenter)
+	graph.AroundOperations(graphconfig.AuthMiddleware(service, presenter))
+	graph.AroundOperations(loaders.Middleware(service, loaders.Config{
+		Wait:     env.DataloaderWait,
+		MaxBatch: env.DataloaderMaxBatch,
+	}))
 	graph.Use(extension.FixedComplexityLimit(env.ComplexityLimit))
 	ginserver, err := gs.NewGinServer(service, env, graph)
 	if err != nil {
@@ -45,3 +66,89 @@ func main() {
 		logger.Fatal("Failed to start server :", err)
 	}
 }
+
+func newService(env config.Environment) (services.Service, error) {
+	options, err := newServiceOptions(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if env.StorageDriver == "memory" {
+		service := services.NewMemoryService()
+		service.Options = options
+		return service, nil
+	}
+
+	switch env.DBDriver {
+	case "sqlite", "sqlite3":
+		db, err := drivers.NewSqlite(env).Connect()
+		if err != nil {
+			return nil, err
+		}
+
+		service := services.NewSqliteService(db, sqliterepo.New())
+		service.Options = options
+		return service, nil
+	default:
+		txOptions, err := services.NewTxOptions(env.TxIsolation, env.TxMaxRetries)
+		if err != nil {
+			return nil, err
+		}
+
+		db, err := drivers.NewPostgres(env).Connect()
+		if err != nil {
+			return nil, err
+		}
+
+		pqRepo := repositories.New()
+		service := services.NewPostgresService(db, pqRepo)
+		service.TxOptions = txOptions
+		service.Options = options
+		return service, nil
+	}
+}
+
+// newServiceOptions builds the settings every storage driver shares.
+func newServiceOptions(env config.Environment) (services.Options, error) {
+	userDeletion := services.UserDeletion{
+		Policy:     requests.UserDeletePolicy(env.UserDeletePolicy),
+		ReassignTo: env.UserReassignTo,
+	}
+	if err := userDeletion.Validate(); err != nil {
+		return services.Options{}, err
+	}
+	currencies := services.Currencies{
+		Default:  env.DefaultCurrency,
+		Rounding: services.Rounding(env.CurrencyRounding),
+	}
+	if err := currencies.Validate(); err != nil {
+		return services.Options{}, err
+	}
+	provider, err := newPaymentProvider(env.PaymentProvider)
+	if err != nil {
+		return services.Options{}, err
+	}
+
+	return services.Options{
+		MaxPageSize:    env.MaxPageSize,
+		UserDeletion:   userDeletion,
+		Emails:         services.NewEmailPolicy(env.DisposableEmailDomains),
+		Currencies:     currencies,
+		ReservationTTL: env.ReservationTTL,
+		Payments:       provider,
+		Tokens: auth.Tokens{
+			Secret:     []byte(env.AuthSecret),
+			AccessTTL:  env.AccessTokenTTL,
+			RefreshTTL: env.RefreshTokenTTL,
+		},
+	}, nil
+}
+
+func newPaymentProvider(name string) (payments.Provider, error) {
+	switch name {
+	case "", "fake":
+		return payments.NewFake(), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
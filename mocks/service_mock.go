@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	payments "sqlc-rest-api/payments"
 	requests "sqlc-rest-api/requests"
 	responses "sqlc-rest-api/responses"
 
@@ -36,6 +37,202 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AdjustStock mocks base method.
+func (m *MockService) AdjustStock(ctx context.Context, req requests.AdjustStockRequest) (*responses.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, req)
+	ret0, _ := ret[0].(*responses.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockServiceMockRecorder) AdjustStock(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockService)(nil).AdjustStock), ctx, req)
+}
+
+// AssignRole mocks base method.
+func (m *MockService) AssignRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", ctx, req)
+	ret0, _ := ret[0].([]requests.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockServiceMockRecorder) AssignRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockService)(nil).AssignRole), ctx, req)
+}
+
+// Authenticate mocks base method.
+func (m *MockService) Authenticate(ctx context.Context, token string) (*responses.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(*responses.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockServiceMockRecorder) Authenticate(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockService)(nil).Authenticate), ctx, token)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockService) AuthenticateAPIKey(ctx context.Context, key string) (*responses.User, *responses.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*responses.User)
+	ret1, _ := ret[1].(*responses.APIKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockServiceMockRecorder) AuthenticateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockService)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// BulkCreateProducts mocks base method.
+func (m *MockService) BulkCreateProducts(ctx context.Context, req requests.BulkCreateProductsRequest) (*responses.BulkProductsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateProducts", ctx, req)
+	ret0, _ := ret[0].(*responses.BulkProductsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreateProducts indicates an expected call of BulkCreateProducts.
+func (mr *MockServiceMockRecorder) BulkCreateProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateProducts", reflect.TypeOf((*MockService)(nil).BulkCreateProducts), ctx, req)
+}
+
+// BulkDeleteProducts mocks base method.
+func (m *MockService) BulkDeleteProducts(ctx context.Context, req requests.BulkDeleteProductsRequest) (*responses.BulkProductsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDeleteProducts", ctx, req)
+	ret0, _ := ret[0].(*responses.BulkProductsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkDeleteProducts indicates an expected call of BulkDeleteProducts.
+func (mr *MockServiceMockRecorder) BulkDeleteProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDeleteProducts", reflect.TypeOf((*MockService)(nil).BulkDeleteProducts), ctx, req)
+}
+
+// BulkPatchProducts mocks base method.
+func (m *MockService) BulkPatchProducts(ctx context.Context, req requests.BulkPatchProductsRequest) (*responses.BulkProductsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkPatchProducts", ctx, req)
+	ret0, _ := ret[0].(*responses.BulkProductsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkPatchProducts indicates an expected call of BulkPatchProducts.
+func (mr *MockServiceMockRecorder) BulkPatchProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkPatchProducts", reflect.TypeOf((*MockService)(nil).BulkPatchProducts), ctx, req)
+}
+
+// CommitReservation mocks base method.
+func (m *MockService) CommitReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitReservation", ctx, req)
+	ret0, _ := ret[0].(*responses.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitReservation indicates an expected call of CommitReservation.
+func (mr *MockServiceMockRecorder) CommitReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitReservation", reflect.TypeOf((*MockService)(nil).CommitReservation), ctx, req)
+}
+
+// ConfirmPayment mocks base method.
+func (m *MockService) ConfirmPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPayment", ctx, req)
+	ret0, _ := ret[0].(*responses.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPayment indicates an expected call of ConfirmPayment.
+func (mr *MockServiceMockRecorder) ConfirmPayment(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPayment", reflect.TypeOf((*MockService)(nil).ConfirmPayment), ctx, req)
+}
+
+// ConvertPrices mocks base method.
+func (m *MockService) ConvertPrices(ctx context.Context, prices []responses.Money, currency string) ([]responses.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertPrices", ctx, prices, currency)
+	ret0, _ := ret[0].([]responses.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertPrices indicates an expected call of ConvertPrices.
+func (mr *MockServiceMockRecorder) ConvertPrices(ctx, prices, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertPrices", reflect.TypeOf((*MockService)(nil).ConvertPrices), ctx, prices, currency)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockService) CreateAPIKey(ctx context.Context, req requests.CreateAPIKeyRequest) (*responses.NewAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, req)
+	ret0, _ := ret[0].(*responses.NewAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockServiceMockRecorder) CreateAPIKey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockService)(nil).CreateAPIKey), ctx, req)
+}
+
+// CreateCategory mocks base method.
+func (m *MockService) CreateCategory(ctx context.Context, req requests.CreateCategoryRequest) (*responses.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, req)
+	ret0, _ := ret[0].(*responses.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockServiceMockRecorder) CreateCategory(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockService)(nil).CreateCategory), ctx, req)
+}
+
+// CreateOrder mocks base method.
+func (m *MockService) CreateOrder(ctx context.Context, req requests.CreateOrderRequest) (*responses.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, req)
+	ret0, _ := ret[0].(*responses.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockServiceMockRecorder) CreateOrder(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockService)(nil).CreateOrder), ctx, req)
+}
+
 // CreateProduct mocks base method.
 func (m *MockService) CreateProduct(ctx context.Context, req requests.CreateProductRequest) (*responses.Product, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +248,36 @@ func (mr *MockServiceMockRecorder) CreateProduct(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockService)(nil).CreateProduct), ctx, req)
 }
 
+// CreateReservation mocks base method.
+func (m *MockService) CreateReservation(ctx context.Context, req requests.CreateReservationRequest) (*responses.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReservation", ctx, req)
+	ret0, _ := ret[0].(*responses.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReservation indicates an expected call of CreateReservation.
+func (mr *MockServiceMockRecorder) CreateReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReservation", reflect.TypeOf((*MockService)(nil).CreateReservation), ctx, req)
+}
+
+// CreateTag mocks base method.
+func (m *MockService) CreateTag(ctx context.Context, req requests.CreateTagRequest) (*responses.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, req)
+	ret0, _ := ret[0].(*responses.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockServiceMockRecorder) CreateTag(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockService)(nil).CreateTag), ctx, req)
+}
+
 // CreateUser mocks base method.
 func (m *MockService) CreateUser(ctx context.Context, req requests.CreateUserRequest) (*responses.User, error) {
 	m.ctrl.T.Helper()
@@ -66,8 +293,36 @@ func (mr *MockServiceMockRecorder) CreateUser(ctx, req interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockService)(nil).CreateUser), ctx, req)
 }
 
+// DeleteAPIKey mocks base method.
+func (m *MockService) DeleteAPIKey(ctx context.Context, req requests.BindUriID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockServiceMockRecorder) DeleteAPIKey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockService)(nil).DeleteAPIKey), ctx, req)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockService) DeleteCategory(ctx context.Context, req requests.BindUriID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockServiceMockRecorder) DeleteCategory(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockService)(nil).DeleteCategory), ctx, req)
+}
+
 // DeleteProduct mocks base method.
-func (m *MockService) DeleteProduct(ctx context.Context, req requests.BindUriID) (*responses.DeletedProduct, error) {
+func (m *MockService) DeleteProduct(ctx context.Context, req requests.DeleteProductRequest) (*responses.DeletedProduct, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteProduct", ctx, req)
 	ret0, _ := ret[0].(*responses.DeletedProduct)
@@ -81,6 +336,200 @@ func (mr *MockServiceMockRecorder) DeleteProduct(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockService)(nil).DeleteProduct), ctx, req)
 }
 
+// DeleteTag mocks base method.
+func (m *MockService) DeleteTag(ctx context.Context, req requests.BindUriID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockServiceMockRecorder) DeleteTag(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockService)(nil).DeleteTag), ctx, req)
+}
+
+// DeleteUser mocks base method.
+func (m *MockService) DeleteUser(ctx context.Context, req requests.DeleteUserRequest) (*responses.DeletedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, req)
+	ret0, _ := ret[0].(*responses.DeletedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockServiceMockRecorder) DeleteUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockService)(nil).DeleteUser), ctx, req)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockService) GetAPIKey(ctx context.Context, req requests.BindUriID) (*responses.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, req)
+	ret0, _ := ret[0].(*responses.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockServiceMockRecorder) GetAPIKey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockService)(nil).GetAPIKey), ctx, req)
+}
+
+// GetBatchCategories mocks base method.
+func (m *MockService) GetBatchCategories(ctx context.Context, req requests.GetBatchCategoriesRequest) ([]*responses.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchCategories", ctx, req)
+	ret0, _ := ret[0].([]*responses.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchCategories indicates an expected call of GetBatchCategories.
+func (mr *MockServiceMockRecorder) GetBatchCategories(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchCategories", reflect.TypeOf((*MockService)(nil).GetBatchCategories), ctx, req)
+}
+
+// GetBatchProductCategories mocks base method.
+func (m *MockService) GetBatchProductCategories(ctx context.Context, req requests.GetBatchProductCategoriesRequest) ([][]*responses.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchProductCategories", ctx, req)
+	ret0, _ := ret[0].([][]*responses.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchProductCategories indicates an expected call of GetBatchProductCategories.
+func (mr *MockServiceMockRecorder) GetBatchProductCategories(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchProductCategories", reflect.TypeOf((*MockService)(nil).GetBatchProductCategories), ctx, req)
+}
+
+// GetBatchProductTags mocks base method.
+func (m *MockService) GetBatchProductTags(ctx context.Context, req requests.GetBatchProductTagsRequest) ([][]*responses.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchProductTags", ctx, req)
+	ret0, _ := ret[0].([][]*responses.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchProductTags indicates an expected call of GetBatchProductTags.
+func (mr *MockServiceMockRecorder) GetBatchProductTags(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchProductTags", reflect.TypeOf((*MockService)(nil).GetBatchProductTags), ctx, req)
+}
+
+// GetBatchProducts mocks base method.
+func (m *MockService) GetBatchProducts(ctx context.Context, req requests.GetBatchProductsRequest) ([]*responses.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchProducts", ctx, req)
+	ret0, _ := ret[0].([]*responses.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchProducts indicates an expected call of GetBatchProducts.
+func (mr *MockServiceMockRecorder) GetBatchProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchProducts", reflect.TypeOf((*MockService)(nil).GetBatchProducts), ctx, req)
+}
+
+// GetBatchStock mocks base method.
+func (m *MockService) GetBatchStock(ctx context.Context, req requests.GetBatchStockRequest) ([]*responses.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchStock", ctx, req)
+	ret0, _ := ret[0].([]*responses.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchStock indicates an expected call of GetBatchStock.
+func (mr *MockServiceMockRecorder) GetBatchStock(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchStock", reflect.TypeOf((*MockService)(nil).GetBatchStock), ctx, req)
+}
+
+// GetBatchUserProducts mocks base method.
+func (m *MockService) GetBatchUserProducts(ctx context.Context, req requests.GetBatchUserProductsRequest) ([]*responses.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchUserProducts", ctx, req)
+	ret0, _ := ret[0].([]*responses.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchUserProducts indicates an expected call of GetBatchUserProducts.
+func (mr *MockServiceMockRecorder) GetBatchUserProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchUserProducts", reflect.TypeOf((*MockService)(nil).GetBatchUserProducts), ctx, req)
+}
+
+// GetBatchUsers mocks base method.
+func (m *MockService) GetBatchUsers(ctx context.Context, req requests.GetBatchUsersRequest) ([]*responses.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchUsers", ctx, req)
+	ret0, _ := ret[0].([]*responses.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchUsers indicates an expected call of GetBatchUsers.
+func (mr *MockServiceMockRecorder) GetBatchUsers(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchUsers", reflect.TypeOf((*MockService)(nil).GetBatchUsers), ctx, req)
+}
+
+// GetCategory mocks base method.
+func (m *MockService) GetCategory(ctx context.Context, req requests.BindUriID) (*responses.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", ctx, req)
+	ret0, _ := ret[0].(*responses.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockServiceMockRecorder) GetCategory(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockService)(nil).GetCategory), ctx, req)
+}
+
+// GetOrder mocks base method.
+func (m *MockService) GetOrder(ctx context.Context, req requests.BindUriID) (*responses.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, req)
+	ret0, _ := ret[0].(*responses.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockServiceMockRecorder) GetOrder(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockService)(nil).GetOrder), ctx, req)
+}
+
+// GetPayment mocks base method.
+func (m *MockService) GetPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayment", ctx, req)
+	ret0, _ := ret[0].(*responses.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayment indicates an expected call of GetPayment.
+func (mr *MockServiceMockRecorder) GetPayment(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayment", reflect.TypeOf((*MockService)(nil).GetPayment), ctx, req)
+}
+
 // GetProduct mocks base method.
 func (m *MockService) GetProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
 	m.ctrl.T.Helper()
@@ -96,41 +545,550 @@ func (mr *MockServiceMockRecorder) GetProduct(ctx, req interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockService)(nil).GetProduct), ctx, req)
 }
 
-// GetUser mocks base method.
-func (m *MockService) GetUser(ctx context.Context, req requests.BindUriID) (*responses.User, error) {
+// GetReservation mocks base method.
+func (m *MockService) GetReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, req)
-	ret0, _ := ret[0].(*responses.User)
+	ret := m.ctrl.Call(m, "GetReservation", ctx, req)
+	ret0, _ := ret[0].(*responses.Reservation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockServiceMockRecorder) GetUser(ctx, req interface{}) *gomock.Call {
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockServiceMockRecorder) GetReservation(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockService)(nil).GetUser), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockService)(nil).GetReservation), ctx, req)
 }
 
-// GetUserProducts mocks base method.
-func (m *MockService) GetUserProducts(ctx context.Context, req requests.GetUserProductsRequest) (*responses.Products, error) {
+// GetStock mocks base method.
+func (m *MockService) GetStock(ctx context.Context, req requests.BindUriID) (*responses.Stock, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserProducts", ctx, req)
-	ret0, _ := ret[0].(*responses.Products)
+	ret := m.ctrl.Call(m, "GetStock", ctx, req)
+	ret0, _ := ret[0].(*responses.Stock)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserProducts indicates an expected call of GetUserProducts.
-func (mr *MockServiceMockRecorder) GetUserProducts(ctx, req interface{}) *gomock.Call {
+// GetStock indicates an expected call of GetStock.
+func (mr *MockServiceMockRecorder) GetStock(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProducts", reflect.TypeOf((*MockService)(nil).GetUserProducts), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockService)(nil).GetStock), ctx, req)
 }
 
-// UpdateProduct mocks base method.
-func (m *MockService) UpdateProduct(ctx context.Context, req requests.UpdateProductRequest) (*responses.Product, error) {
+// GetUser mocks base method.
+func (m *MockService) GetUser(ctx context.Context, req requests.BindUriID) (*responses.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProduct", ctx, req)
-	ret0, _ := ret[0].(*responses.Product)
+	ret := m.ctrl.Call(m, "GetUser", ctx, req)
+	ret0, _ := ret[0].(*responses.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockServiceMockRecorder) GetUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockService)(nil).GetUser), ctx, req)
+}
+
+// GetUserOrders mocks base method.
+func (m *MockService) GetUserOrders(ctx context.Context, req requests.GetUserOrdersRequest) (*responses.Orders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrders", ctx, req)
+	ret0, _ := ret[0].(*responses.Orders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrders indicates an expected call of GetUserOrders.
+func (mr *MockServiceMockRecorder) GetUserOrders(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrders", reflect.TypeOf((*MockService)(nil).GetUserOrders), ctx, req)
+}
+
+// GetUserProducts mocks base method.
+func (m *MockService) GetUserProducts(ctx context.Context, req requests.GetUserProductsRequest) (*responses.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProducts", ctx, req)
+	ret0, _ := ret[0].(*responses.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProducts indicates an expected call of GetUserProducts.
+func (mr *MockServiceMockRecorder) GetUserProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProducts", reflect.TypeOf((*MockService)(nil).GetUserProducts), ctx, req)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockService) GetUserRoles(ctx context.Context, req requests.BindUriID) ([]requests.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, req)
+	ret0, _ := ret[0].([]requests.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockServiceMockRecorder) GetUserRoles(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockService)(nil).GetUserRoles), ctx, req)
+}
+
+// HandlePaymentEvent mocks base method.
+func (m *MockService) HandlePaymentEvent(ctx context.Context, event payments.Event) (*responses.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlePaymentEvent", ctx, event)
+	ret0, _ := ret[0].(*responses.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandlePaymentEvent indicates an expected call of HandlePaymentEvent.
+func (mr *MockServiceMockRecorder) HandlePaymentEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePaymentEvent", reflect.TypeOf((*MockService)(nil).HandlePaymentEvent), ctx, event)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockService) ListAPIKeys(ctx context.Context) ([]*responses.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]*responses.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockServiceMockRecorder) ListAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockService)(nil).ListAPIKeys), ctx)
+}
+
+// ListCategories mocks base method.
+func (m *MockService) ListCategories(ctx context.Context, req requests.ListCategoriesRequest) ([]*responses.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", ctx, req)
+	ret0, _ := ret[0].([]*responses.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockServiceMockRecorder) ListCategories(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockService)(nil).ListCategories), ctx, req)
+}
+
+// ListDeletedProducts mocks base method.
+func (m *MockService) ListDeletedProducts(ctx context.Context, req requests.ListDeletedProductsRequest) (*responses.ProductList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedProducts", ctx, req)
+	ret0, _ := ret[0].(*responses.ProductList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedProducts indicates an expected call of ListDeletedProducts.
+func (mr *MockServiceMockRecorder) ListDeletedProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedProducts", reflect.TypeOf((*MockService)(nil).ListDeletedProducts), ctx, req)
+}
+
+// ListExchangeRates mocks base method.
+func (m *MockService) ListExchangeRates(ctx context.Context) ([]*responses.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchangeRates", ctx)
+	ret0, _ := ret[0].([]*responses.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchangeRates indicates an expected call of ListExchangeRates.
+func (mr *MockServiceMockRecorder) ListExchangeRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangeRates", reflect.TypeOf((*MockService)(nil).ListExchangeRates), ctx)
+}
+
+// ListProducts mocks base method.
+func (m *MockService) ListProducts(ctx context.Context, req requests.ListProductsRequest) (*responses.ProductList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", ctx, req)
+	ret0, _ := ret[0].(*responses.ProductList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockServiceMockRecorder) ListProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockService)(nil).ListProducts), ctx, req)
+}
+
+// ListRoles mocks base method.
+func (m *MockService) ListRoles(ctx context.Context) ([]*responses.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]*responses.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockServiceMockRecorder) ListRoles(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockService)(nil).ListRoles), ctx)
+}
+
+// ListStockAdjustments mocks base method.
+func (m *MockService) ListStockAdjustments(ctx context.Context, req requests.ListStockAdjustmentsRequest) ([]*responses.StockAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockAdjustments", ctx, req)
+	ret0, _ := ret[0].([]*responses.StockAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockAdjustments indicates an expected call of ListStockAdjustments.
+func (mr *MockServiceMockRecorder) ListStockAdjustments(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockAdjustments", reflect.TypeOf((*MockService)(nil).ListStockAdjustments), ctx, req)
+}
+
+// ListTags mocks base method.
+func (m *MockService) ListTags(ctx context.Context) ([]*responses.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx)
+	ret0, _ := ret[0].([]*responses.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockServiceMockRecorder) ListTags(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockService)(nil).ListTags), ctx)
+}
+
+// ListUsers mocks base method.
+func (m *MockService) ListUsers(ctx context.Context, req requests.ListUsersRequest) (*responses.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, req)
+	ret0, _ := ret[0].(*responses.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockServiceMockRecorder) ListUsers(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockService)(nil).ListUsers), ctx, req)
+}
+
+// Login mocks base method.
+func (m *MockService) Login(ctx context.Context, req requests.LoginRequest) (*responses.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, req)
+	ret0, _ := ret[0].(*responses.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockServiceMockRecorder) Login(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockService)(nil).Login), ctx, req)
+}
+
+// Logout mocks base method.
+func (m *MockService) Logout(ctx context.Context, req requests.RefreshTokenRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockServiceMockRecorder) Logout(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockService)(nil).Logout), ctx, req)
+}
+
+// PatchProduct mocks base method.
+func (m *MockService) PatchProduct(ctx context.Context, req requests.PatchProductRequest) (*responses.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchProduct", ctx, req)
+	ret0, _ := ret[0].(*responses.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchProduct indicates an expected call of PatchProduct.
+func (mr *MockServiceMockRecorder) PatchProduct(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchProduct", reflect.TypeOf((*MockService)(nil).PatchProduct), ctx, req)
+}
+
+// PatchUser mocks base method.
+func (m *MockService) PatchUser(ctx context.Context, req requests.PatchUserRequest) (*responses.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", ctx, req)
+	ret0, _ := ret[0].(*responses.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockServiceMockRecorder) PatchUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockService)(nil).PatchUser), ctx, req)
+}
+
+// PurgeDeletedProducts mocks base method.
+func (m *MockService) PurgeDeletedProducts(ctx context.Context, req requests.PurgeDeletedProductsRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedProducts", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedProducts indicates an expected call of PurgeDeletedProducts.
+func (mr *MockServiceMockRecorder) PurgeDeletedProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedProducts", reflect.TypeOf((*MockService)(nil).PurgeDeletedProducts), ctx, req)
+}
+
+// RefreshSession mocks base method.
+func (m *MockService) RefreshSession(ctx context.Context, req requests.RefreshTokenRequest) (*responses.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, req)
+	ret0, _ := ret[0].(*responses.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockServiceMockRecorder) RefreshSession(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockService)(nil).RefreshSession), ctx, req)
+}
+
+// RefundPayment mocks base method.
+func (m *MockService) RefundPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", ctx, req)
+	ret0, _ := ret[0].(*responses.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockServiceMockRecorder) RefundPayment(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockService)(nil).RefundPayment), ctx, req)
+}
+
+// Register mocks base method.
+func (m *MockService) Register(ctx context.Context, req requests.RegisterRequest) (*responses.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, req)
+	ret0, _ := ret[0].(*responses.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockServiceMockRecorder) Register(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockService)(nil).Register), ctx, req)
+}
+
+// ReleaseExpiredReservations mocks base method.
+func (m *MockService) ReleaseExpiredReservations(ctx context.Context, req requests.ReleaseExpiredReservationsRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseExpiredReservations", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseExpiredReservations indicates an expected call of ReleaseExpiredReservations.
+func (mr *MockServiceMockRecorder) ReleaseExpiredReservations(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseExpiredReservations", reflect.TypeOf((*MockService)(nil).ReleaseExpiredReservations), ctx, req)
+}
+
+// ReleaseReservation mocks base method.
+func (m *MockService) ReleaseReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReservation", ctx, req)
+	ret0, _ := ret[0].(*responses.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseReservation indicates an expected call of ReleaseReservation.
+func (mr *MockServiceMockRecorder) ReleaseReservation(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockService)(nil).ReleaseReservation), ctx, req)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockService) RestoreProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, req)
+	ret0, _ := ret[0].(*responses.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockServiceMockRecorder) RestoreProduct(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockService)(nil).RestoreProduct), ctx, req)
+}
+
+// RevokeRole mocks base method.
+func (m *MockService) RevokeRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, req)
+	ret0, _ := ret[0].([]requests.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockServiceMockRecorder) RevokeRole(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockService)(nil).RevokeRole), ctx, req)
+}
+
+// SearchProducts mocks base method.
+func (m *MockService) SearchProducts(ctx context.Context, req requests.SearchProductsRequest) (*responses.Products, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProducts", ctx, req)
+	ret0, _ := ret[0].(*responses.Products)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockServiceMockRecorder) SearchProducts(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockService)(nil).SearchProducts), ctx, req)
+}
+
+// SetExchangeRate mocks base method.
+func (m *MockService) SetExchangeRate(ctx context.Context, req requests.SetExchangeRateRequest) (*responses.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExchangeRate", ctx, req)
+	ret0, _ := ret[0].(*responses.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockServiceMockRecorder) SetExchangeRate(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockService)(nil).SetExchangeRate), ctx, req)
+}
+
+// SetProductCategories mocks base method.
+func (m *MockService) SetProductCategories(ctx context.Context, req requests.SetProductCategoriesRequest) ([]*responses.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductCategories", ctx, req)
+	ret0, _ := ret[0].([]*responses.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductCategories indicates an expected call of SetProductCategories.
+func (mr *MockServiceMockRecorder) SetProductCategories(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductCategories", reflect.TypeOf((*MockService)(nil).SetProductCategories), ctx, req)
+}
+
+// SetProductTags mocks base method.
+func (m *MockService) SetProductTags(ctx context.Context, req requests.SetProductTagsRequest) ([]*responses.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductTags", ctx, req)
+	ret0, _ := ret[0].([]*responses.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductTags indicates an expected call of SetProductTags.
+func (mr *MockServiceMockRecorder) SetProductTags(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductTags", reflect.TypeOf((*MockService)(nil).SetProductTags), ctx, req)
+}
+
+// StartPayment mocks base method.
+func (m *MockService) StartPayment(ctx context.Context, req requests.StartPaymentRequest) (*responses.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartPayment", ctx, req)
+	ret0, _ := ret[0].(*responses.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartPayment indicates an expected call of StartPayment.
+func (mr *MockServiceMockRecorder) StartPayment(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartPayment", reflect.TypeOf((*MockService)(nil).StartPayment), ctx, req)
+}
+
+// UpdateAPIKey mocks base method.
+func (m *MockService) UpdateAPIKey(ctx context.Context, req requests.UpdateAPIKeyRequest) (*responses.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKey", ctx, req)
+	ret0, _ := ret[0].(*responses.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAPIKey indicates an expected call of UpdateAPIKey.
+func (mr *MockServiceMockRecorder) UpdateAPIKey(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKey", reflect.TypeOf((*MockService)(nil).UpdateAPIKey), ctx, req)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockService) UpdateCategory(ctx context.Context, req requests.UpdateCategoryRequest) (*responses.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, req)
+	ret0, _ := ret[0].(*responses.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockServiceMockRecorder) UpdateCategory(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockService)(nil).UpdateCategory), ctx, req)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockService) UpdateOrderStatus(ctx context.Context, req requests.UpdateOrderStatusRequest) (*responses.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, req)
+	ret0, _ := ret[0].(*responses.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockServiceMockRecorder) UpdateOrderStatus(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockService)(nil).UpdateOrderStatus), ctx, req)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockService) UpdateProduct(ctx context.Context, req requests.UpdateProductRequest) (*responses.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, req)
+	ret0, _ := ret[0].(*responses.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -140,3 +1098,18 @@ func (mr *MockServiceMockRecorder) UpdateProduct(ctx, req interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockService)(nil).UpdateProduct), ctx, req)
 }
+
+// UpdateUser mocks base method.
+func (m *MockService) UpdateUser(ctx context.Context, req requests.UpdateUserRequest) (*responses.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, req)
+	ret0, _ := ret[0].(*responses.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockServiceMockRecorder) UpdateUser(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockService)(nil).UpdateUser), ctx, req)
+}
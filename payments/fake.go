@@ -0,0 +1,107 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FakeDeclinedAmount is the amount Fake declines to capture, like the test
+// cards of real gateways it lets callers exercise failed payments.
+const FakeDeclinedAmount = 402
+
+// Fake is an in process Provider. Intent ids count up from pi_fake_1 and
+// every capture succeeds unless the amount is FakeDeclinedAmount, so runs are
+// repeatable.
+type Fake struct {
+	// Prefix starts the ids of intents, fakes sharing a database need
+	// different prefixes.
+	Prefix string
+
+	mu      sync.Mutex
+	intents map[string]Intent
+	last    int64
+}
+
+func NewFake() *Fake {
+	return &Fake{
+		Prefix:  "pi_fake_",
+		intents: make(map[string]Intent),
+	}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) CreateIntent(ctx context.Context, params IntentParams) (Intent, error) {
+	if params.Amount < 0 {
+		return Intent{}, fmt.Errorf("invalid amount %d", params.Amount)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.last++
+	id := fmt.Sprintf("%s%d", f.Prefix, f.last)
+	intent := Intent{
+		ID:       id,
+		Amount:   params.Amount,
+		Currency: params.Currency,
+		Status:   StatusPending,
+	}
+	f.intents[id] = intent
+
+	intent.ClientSecret = id + "_secret"
+	return intent, nil
+}
+
+func (f *Fake) Capture(ctx context.Context, intentID string) (Intent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	intent, ok := f.intents[intentID]
+	if !ok {
+		return Intent{}, ErrUnknownIntent
+	}
+
+	if intent.Status != StatusPending {
+		return intent, nil
+	}
+
+	intent.Status = StatusSucceeded
+	if intent.Amount == FakeDeclinedAmount {
+		intent.Status = StatusFailed
+	}
+	f.intents[intentID] = intent
+
+	return intent, nil
+}
+
+// Refund gives back amount of a captured intent, the intent is refunded once
+// all of it was given back.
+func (f *Fake) Refund(ctx context.Context, intentID string, amount int64) (Intent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	intent, ok := f.intents[intentID]
+	if !ok {
+		return Intent{}, ErrUnknownIntent
+	}
+
+	if intent.Status != StatusSucceeded {
+		return intent, ErrNotCaptured
+	}
+
+	if amount < 1 || intent.Refunded+amount > intent.Amount {
+		return intent, ErrRefundAmount
+	}
+
+	intent.Refunded += amount
+	if intent.Refunded == intent.Amount {
+		intent.Status = StatusRefunded
+	}
+	f.intents[intentID] = intent
+
+	return intent, nil
+}
@@ -0,0 +1,61 @@
+package payments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	intent, err := fake.CreateIntent(ctx, IntentParams{Amount: 500, Currency: "USD"})
+	require.NoError(t, err)
+	require.Equal(t, "pi_fake_1", intent.ID)
+	require.Equal(t, "pi_fake_1_secret", intent.ClientSecret)
+	require.Equal(t, StatusPending, intent.Status)
+
+	_, err = fake.Refund(ctx, intent.ID, 100)
+	require.ErrorIs(t, err, ErrNotCaptured)
+
+	captured, err := fake.Capture(ctx, intent.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusSucceeded, captured.Status)
+	require.Empty(t, captured.ClientSecret)
+
+	// capturing again returns the intent unchanged
+	captured, err = fake.Capture(ctx, intent.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusSucceeded, captured.Status)
+
+	refunded, err := fake.Refund(ctx, intent.ID, 200)
+	require.NoError(t, err)
+	require.Equal(t, StatusSucceeded, refunded.Status)
+	require.Equal(t, int64(200), refunded.Refunded)
+
+	_, err = fake.Refund(ctx, intent.ID, 301)
+	require.ErrorIs(t, err, ErrRefundAmount)
+
+	refunded, err = fake.Refund(ctx, intent.ID, 300)
+	require.NoError(t, err)
+	require.Equal(t, StatusRefunded, refunded.Status)
+
+	_, err = fake.Capture(ctx, "pi_unknown")
+	require.ErrorIs(t, err, ErrUnknownIntent)
+}
+
+func TestFakeDeclines(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+	fake.Prefix = "pi_test_"
+
+	intent, err := fake.CreateIntent(ctx, IntentParams{Amount: FakeDeclinedAmount, Currency: "USD"})
+	require.NoError(t, err)
+	require.Equal(t, "pi_test_1", intent.ID)
+
+	declined, err := fake.Capture(ctx, intent.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusFailed, declined.Status)
+}
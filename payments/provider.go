@@ -0,0 +1,58 @@
+// Package payments talks to payment gateways. Provider hides the gateway from
+// the services, Fake is a deterministic gateway for local development and
+// tests.
+package payments
+
+import (
+	"context"
+	"errors"
+)
+
+// Status is the state of an intent at the gateway. Intents start pending and
+// move to succeeded or failed when they are captured, succeeded intents can be
+// refunded.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusRefunded  Status = "refunded"
+)
+
+var (
+	ErrUnknownIntent = errors.New("unknown payment intent")
+	ErrNotCaptured   = errors.New("payment intent was not captured")
+	ErrRefundAmount  = errors.New("refund exceeds the captured amount")
+)
+
+// Intent is a payment the gateway knows about. ClientSecret lets clients
+// complete the payment with the gateway directly, it is only returned when the
+// intent is created. Amounts are in the minor unit of Currency.
+type Intent struct {
+	ID           string
+	Amount       int64
+	Currency     string
+	Status       Status
+	Refunded     int64
+	ClientSecret string
+}
+
+// IntentParams describe a new intent, Reference ties it to the payment that
+// created it.
+type IntentParams struct {
+	Amount    int64
+	Currency  string
+	Reference string
+}
+
+// Provider is a payment gateway. Capture and Refund return the intent as it
+// is after the call, capturing an intent that already succeeded or failed
+// returns it unchanged.
+type Provider interface {
+	// Name identifies the gateway, intent ids are only unique per gateway.
+	Name() string
+	CreateIntent(ctx context.Context, params IntentParams) (Intent, error)
+	Capture(ctx context.Context, intentID string) (Intent, error)
+	Refund(ctx context.Context, intentID string, amount int64) (Intent, error)
+}
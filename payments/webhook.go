@@ -0,0 +1,89 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of webhook deliveries in the form
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">". Signing the
+// timestamp with the body stops old deliveries from being replayed.
+const SignatureHeader = "X-Payment-Signature"
+
+// DefaultTolerance is how old a delivery may be when Verify is given no
+// tolerance.
+const DefaultTolerance = 5 * time.Minute
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Event tells that the intent IntentID moved to Status. Deliveries may be
+// repeated or arrive out of order, the services settle payments so that
+// handling an event again changes nothing. ID is the provider's id of the
+// event, it is not used to deduplicate deliveries.
+type Event struct {
+	ID       string `json:"id"`
+	IntentID string `json:"intent_id"`
+	Status   Status `json:"status"`
+}
+
+// Sign returns the SignatureHeader value of payload delivered at at.
+func Sign(secret, payload []byte, at time.Time) string {
+	t := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + t + ",v1=" + signature(secret, t, payload)
+}
+
+// Verify checks that header is a signature of payload made with secret no
+// longer than tolerance before now.
+func Verify(secret, payload []byte, header string, now time.Time, tolerance time.Duration) error {
+	if len(secret) == 0 {
+		return ErrInvalidSignature
+	}
+
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	var t string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := signature(secret, t, payload)
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+func signature(secret []byte, t string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,41 @@
+package payments
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("whsec_test")
+	payload := []byte(`{"id":"evt_1","intent_id":"pi_fake_1","status":"succeeded"}`)
+	now := time.Unix(1700000000, 0)
+	header := Sign(secret, payload, now)
+
+	require.NoError(t, Verify(secret, payload, header, now, 0))
+	require.NoError(t, Verify(secret, payload, header, now.Add(DefaultTolerance), 0))
+
+	testCases := []struct {
+		name    string
+		secret  []byte
+		payload []byte
+		header  string
+		now     time.Time
+	}{
+		{"no secret", nil, payload, header, now},
+		{"other secret", []byte("whsec_other"), payload, header, now},
+		{"changed payload", secret, []byte(`{"status":"refunded"}`), header, now},
+		{"too old", secret, payload, header, now.Add(DefaultTolerance + time.Second)},
+		{"from the future", secret, payload, header, now.Add(-DefaultTolerance - time.Second)},
+		{"no header", secret, payload, "", now},
+		{"no timestamp", secret, payload, header[len("t=1700000000,"):], now},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Verify(testCase.secret, testCase.payload, testCase.header, testCase.now, 0)
+			require.ErrorIs(t, err, ErrInvalidSignature)
+		})
+	}
+}
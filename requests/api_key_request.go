@@ -0,0 +1,73 @@
+package requests
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scope limits what an API key may do, products:read over REST and the
+// PRODUCTS_READ GraphQL enum value.
+type Scope string
+
+const (
+	ScopeProductsRead  Scope = "products:read"
+	ScopeProductsWrite Scope = "products:write"
+	ScopeOrdersRead    Scope = "orders:read"
+	ScopeOrdersWrite   Scope = "orders:write"
+	ScopeUsersRead     Scope = "users:read"
+	ScopeUsersWrite    Scope = "users:write"
+)
+
+// Scopes lists the known scopes.
+var Scopes = []Scope{
+	ScopeProductsRead,
+	ScopeProductsWrite,
+	ScopeOrdersRead,
+	ScopeOrdersWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+}
+
+// Valid reports whether s is one of the known scopes.
+func (s Scope) Valid() bool {
+	for _, scope := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Scope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Scope must be a string")
+	}
+
+	*s = Scope(strings.ToLower(strings.Replace(str, "_", ":", 1)))
+	return nil
+}
+
+func (s Scope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(strings.Replace(string(s), ":", "_", 1))))
+}
+
+// CreateAPIKeyRequest creates an API key of the calling user, a nil
+// ExpiresAt never expires.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []Scope    `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// UpdateAPIKeyRequest renames an API key and replaces its scopes, the key
+// itself and its expiry do not change.
+type UpdateAPIKeyRequest struct {
+	ID     int64
+	Name   string  `json:"name" binding:"required"`
+	Scopes []Scope `json:"scopes" binding:"required,min=1"`
+}
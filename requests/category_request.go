@@ -0,0 +1,53 @@
+package requests
+
+// CreateCategoryRequest creates a root category when ParentID is nil.
+type CreateCategoryRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID *int64 `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+// UpdateCategoryRequest renames a category and moves it under ParentID, a nil
+// ParentID makes it a root category. A category cannot be moved into its own
+// subtree.
+type UpdateCategoryRequest struct {
+	ID       int64
+	Name     string `json:"name" binding:"required"`
+	ParentID *int64 `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+// ListCategoriesRequest lists the children of ParentID, the root categories
+// when it is nil.
+type ListCategoriesRequest struct {
+	ParentID *int64 `json:"parent_id" form:"parent_id" binding:"omitempty,min=1"`
+}
+
+// CreateTagRequest.Name is stored in lower case, tags are case-insensitive.
+type CreateTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SetProductCategoriesRequest replaces the categories of a product, an empty
+// list removes them all.
+type SetProductCategoriesRequest struct {
+	ProductID   int64   `json:"-"`
+	CategoryIDs []int64 `json:"category_ids" binding:"required"`
+}
+
+// SetProductTagsRequest replaces the tags of a product, tags that don't exist
+// yet are created.
+type SetProductTagsRequest struct {
+	ProductID int64    `json:"-"`
+	Tags      []string `json:"tags" binding:"required"`
+}
+
+type GetBatchCategoriesRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+type GetBatchProductCategoriesRequest struct {
+	ProductIDs []int64 `json:"product_ids"`
+}
+
+type GetBatchProductTagsRequest struct {
+	ProductIDs []int64 `json:"product_ids"`
+}
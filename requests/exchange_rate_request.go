@@ -0,0 +1,23 @@
+package requests
+
+// BindUriCurrencyPair binds the base and quote currency of an exchange rate
+// from the path.
+type BindUriCurrencyPair struct {
+	Base  string `json:"base" binding:"required" uri:"base"`
+	Quote string `json:"quote" binding:"required" uri:"quote"`
+}
+
+// SetExchangeRateRequest stores how many units of Quote one unit of Base is
+// worth, replacing the previous rate of the pair. Rate is a decimal string
+// like "0.92" so it keeps its precision.
+type SetExchangeRateRequest struct {
+	Base  string
+	Quote string
+	Rate  string `json:"rate" binding:"required"`
+}
+
+// CurrencyRequest converts the prices of a response into Currency, they are
+// left in their own currency when it is empty.
+type CurrencyRequest struct {
+	Currency string `json:"currency" form:"currency"`
+}
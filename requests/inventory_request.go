@@ -0,0 +1,72 @@
+package requests
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StockReason is why the stock of a product changed. Like ProductOrderField
+// it is lower case over REST and an upper case GraphQL enum value.
+type StockReason string
+
+const (
+	StockReceived   StockReason = "received"
+	StockReturned   StockReason = "returned"
+	StockSold       StockReason = "sold"
+	StockDamaged    StockReason = "damaged"
+	StockLost       StockReason = "lost"
+	StockCorrection StockReason = "correction"
+)
+
+func (r *StockReason) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("StockReason must be a string")
+	}
+
+	*r = StockReason(strings.ToLower(s))
+	return nil
+}
+
+func (r StockReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(r))))
+}
+
+// AdjustStockRequest adds Delta units to the stock on hand of a product,
+// negative deltas remove units. On hand can never drop below the reserved
+// units.
+type AdjustStockRequest struct {
+	ProductID int64       `json:"-"`
+	Delta     int64       `json:"delta" binding:"required"`
+	Reason    StockReason `json:"reason" binding:"required,oneof=received returned sold damaged lost correction"`
+	Note      string      `json:"note" binding:"max=255"`
+}
+
+// ListStockAdjustmentsRequest lists the latest adjustments of a product,
+// newest first.
+type ListStockAdjustmentsRequest struct {
+	ProductID int64 `json:"product_id"`
+	Limit     int   `json:"limit" form:"limit,default=50" binding:"min=1,max=100"`
+}
+
+// CreateReservationRequest holds Quantity units of a product until they are
+// committed, released or the reservation expires. TTLSeconds defaults to
+// the TTL of the service.
+type CreateReservationRequest struct {
+	ProductID  int64  `json:"product_id" binding:"required,min=1"`
+	Quantity   int64  `json:"quantity" binding:"required,min=1"`
+	TTLSeconds *int64 `json:"ttl_seconds" binding:"omitempty,min=1"`
+}
+
+// ReleaseExpiredReservationsRequest releases the active reservations that
+// expired before ExpiredBefore.
+type ReleaseExpiredReservationsRequest struct {
+	ExpiredBefore time.Time `json:"expired_before"`
+}
+
+type GetBatchStockRequest struct {
+	ProductIDs []int64 `json:"product_ids"`
+}
@@ -0,0 +1,62 @@
+package requests
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OrderStatus is lower case over REST and an upper case GraphQL enum value.
+// Orders start pending and end shipped or cancelled.
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderShipped   OrderStatus = "shipped"
+	OrderCancelled OrderStatus = "cancelled"
+)
+
+func (s *OrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("OrderStatus must be a string")
+	}
+
+	*s = OrderStatus(strings.ToLower(str))
+	return nil
+}
+
+func (s OrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+// CreateOrderRequest prices every item at the current price of its product.
+// Currency defaults to the currency of the first product, prices in other
+// currencies are converted with the stored exchange rates. UserID defaults to
+// the caller, only staff allowed to manage orders may order for other users.
+type CreateOrderRequest struct {
+	UserID   int64              `json:"user_id" binding:"omitempty,min=1"`
+	Currency string             `json:"currency"`
+	Items    []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+type OrderItemRequest struct {
+	ProductID int64 `json:"product_id" binding:"required,min=1"`
+	Quantity  int64 `json:"quantity" binding:"required,min=1"`
+}
+
+// UpdateOrderStatusRequest moves an order to Status, only the transitions of
+// the order state machine are allowed.
+type UpdateOrderStatusRequest struct {
+	ID     int64       `json:"-"`
+	Status OrderStatus `json:"status" binding:"required,oneof=pending paid shipped cancelled"`
+}
+
+// GetUserOrdersRequest pages through the orders of a user, newest first.
+type GetUserOrdersRequest struct {
+	UserID int64   `json:"user_id" uri:"id"`
+	First  *int    `json:"first" form:"first" binding:"omitempty,min=1"`
+	After  *string `json:"after" form:"after"`
+}
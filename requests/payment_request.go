@@ -0,0 +1,8 @@
+package requests
+
+// StartPaymentRequest pays for a pending order or for one unit of a product,
+// exactly one of OrderID and ProductID is set.
+type StartPaymentRequest struct {
+	OrderID   *int64 `json:"order_id" binding:"omitempty,min=1"`
+	ProductID *int64 `json:"product_id" binding:"omitempty,min=1"`
+}
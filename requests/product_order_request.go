@@ -0,0 +1,55 @@
+package requests
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ProductOrderField and OrderDirection are lower case in REST query strings
+// and upper case GraphQL enum values, the GraphQL marshalers convert between
+// the two.
+type ProductOrderField string
+
+const (
+	ProductOrderID        ProductOrderField = "id"
+	ProductOrderName      ProductOrderField = "name"
+	ProductOrderPrice     ProductOrderField = "price"
+	ProductOrderCreatedAt ProductOrderField = "created_at"
+)
+
+func (f *ProductOrderField) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("ProductOrderField must be a string")
+	}
+
+	*f = ProductOrderField(strings.ToLower(s))
+	return nil
+}
+
+func (f ProductOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(f))))
+}
+
+type OrderDirection string
+
+const (
+	OrderAsc  OrderDirection = "asc"
+	OrderDesc OrderDirection = "desc"
+)
+
+func (d *OrderDirection) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("OrderDirection must be a string")
+	}
+
+	*d = OrderDirection(strings.ToLower(s))
+	return nil
+}
+
+func (d OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(d))))
+}
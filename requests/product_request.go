@@ -1,17 +1,122 @@
 package requests
 
+import "time"
+
+// CreateProductRequest.Price is in the minor unit of Currency, an empty
+// Currency is the configured default currency. UserID is only honored for
+// editors and admins, other users own the products they create.
 type CreateProductRequest struct {
-	UserID int64  `json:"user_id" binding:"required,min=1"`
-	Price  int64  `json:"price" binding:"required,min=1"`
-	Name   string `json:"name" binding:"required"`
+	UserID   int64  `json:"user_id" binding:"required,min=1"`
+	Price    int64  `json:"price" binding:"required,min=1"`
+	Name     string `json:"name" binding:"required"`
+	Currency string `json:"currency"`
 }
 
 type BindUriID struct {
 	ID int64 `json:"id" binding:"required,min=1" uri:"id"`
 }
 
+type GetBatchProductsRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+// DeleteProductRequest moves a product to the trash, Permanent deletes it
+// right away instead.
+type DeleteProductRequest struct {
+	ID        int64 `json:"id" binding:"required,min=1" uri:"id"`
+	Permanent bool  `json:"permanent" form:"permanent"`
+}
+
+// UpdateProductRequest only applies when the product is still at
+// ExpectedVersion, a nil ExpectedVersion overwrites whatever is stored. REST
+// clients set it through the If-Match header. An empty Currency keeps the
+// stored one.
 type UpdateProductRequest struct {
-	ID    int64
-	Name  string `json:"name" binding:"required"`
-	Price int64  `json:"price" binding:"required"`
+	ID              int64
+	Name            string `json:"name" binding:"required"`
+	Price           int64  `json:"price" binding:"required"`
+	Currency        string `json:"currency"`
+	ExpectedVersion *int64 `json:"-"`
+}
+
+// PatchProductRequest only changes the fields that are set, the others keep
+// their stored value.
+type PatchProductRequest struct {
+	ID              int64   `json:"id"`
+	Name            *string `json:"name"`
+	Price           *int64  `json:"price"`
+	Currency        *string `json:"currency"`
+	ExpectedVersion *int64  `json:"expected_version"`
+}
+
+// ProductDocument is the product as seen by PATCH documents. The patched
+// document must pass the same rules as an update.
+type ProductDocument struct {
+	Name     string `json:"name" binding:"required"`
+	Price    int64  `json:"price" binding:"required"`
+	Currency string `json:"currency" binding:"required"`
+}
+
+// BulkOptions are the query parameters shared by the bulk endpoints. Atomic
+// requests apply every item or none of them, otherwise each item succeeds or
+// fails on its own.
+type BulkOptions struct {
+	Atomic bool `form:"atomic,default=true"`
+}
+
+type BulkCreateProductsRequest struct {
+	Products []CreateProductRequest `json:"products" binding:"required,min=1"`
+	Atomic   bool                   `json:"-"`
+}
+
+type BulkPatchProductsRequest struct {
+	Products []PatchProductRequest `json:"products" binding:"required,min=1"`
+	Atomic   bool                  `json:"-"`
+}
+
+type BulkDeleteProductsRequest struct {
+	IDs       []int64 `json:"ids" binding:"required,min=1"`
+	Permanent bool    `json:"permanent"`
+	Atomic    bool    `json:"-"`
+}
+
+type ProductFilter struct {
+	UserID        *int64     `json:"user_id" form:"user_id" binding:"omitempty,min=1"`
+	Name          *string    `json:"name" form:"name"`
+	MinPrice      *int64     `json:"min_price" form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice      *int64     `json:"max_price" form:"max_price" binding:"omitempty,min=0"`
+	CreatedAfter  *time.Time `json:"created_after" form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `json:"created_before" form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type ProductOrder struct {
+	Field     ProductOrderField `json:"field" form:"order_by,default=id" binding:"oneof=id name price created_at"`
+	Direction OrderDirection    `json:"direction" form:"direction,default=asc" binding:"oneof=asc desc"`
+}
+
+type ListProductsRequest struct {
+	Filter ProductFilter
+	Order  ProductOrder
+	Limit  int `json:"limit" form:"limit,default=10" binding:"min=1,max=100"`
+	Offset int `json:"offset" form:"offset,default=0" binding:"min=0"`
+}
+
+// SearchProductsRequest pages through products matching Query, most relevant
+// first.
+type SearchProductsRequest struct {
+	Query string  `json:"query" form:"q" binding:"required"`
+	First *int    `json:"first" form:"first" binding:"omitempty,min=1"`
+	After *string `json:"after" form:"after"`
+}
+
+type ListDeletedProductsRequest struct {
+	UserID *int64 `json:"user_id" form:"user_id" binding:"omitempty,min=1"`
+	Limit  int    `json:"limit" form:"limit,default=10" binding:"min=1,max=100"`
+	Offset int    `json:"offset" form:"offset,default=0" binding:"min=0"`
+}
+
+// PurgeDeletedProductsRequest permanently deletes the products moved to the
+// trash before DeletedBefore.
+type PurgeDeletedProductsRequest struct {
+	DeletedBefore time.Time `json:"deleted_before"`
 }
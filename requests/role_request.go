@@ -0,0 +1,56 @@
+package requests
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Role is lower case over REST and an upper case GraphQL enum value. Every
+// role includes the roles below it: editors are viewers and admins are
+// editors.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	return roleRanks[r] > 0
+}
+
+// Includes reports whether r is role or a role above it.
+func (r Role) Includes(role Role) bool {
+	return role.Valid() && roleRanks[r] >= roleRanks[role]
+}
+
+func (r *Role) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Role must be a string")
+	}
+
+	*r = Role(strings.ToLower(s))
+	return nil
+}
+
+func (r Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(r))))
+}
+
+// UserRoleRequest names a role of a user, assigning a role twice and
+// revoking a role the user does not have change nothing.
+type UserRoleRequest struct {
+	UserID int64 `json:"user_id" uri:"id" binding:"required,min=1"`
+	Role   Role  `json:"role" uri:"role" binding:"required,oneof=admin editor viewer"`
+}
@@ -1,12 +1,108 @@
 package requests
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CreateUserRequest only checks that Email is given, the service validates and
+// normalizes it so GraphQL and PATCH documents get the same rules.
 type CreateUserRequest struct {
 	Name  string `json:"name" binding:"required"`
 	Email string `json:"email" binding:"required"`
 }
 
+// UpdateUserRequest only applies when the user is still at ExpectedVersion,
+// REST clients set it through the If-Match header.
+type UpdateUserRequest struct {
+	ID              int64
+	Name            string `json:"name" binding:"required"`
+	Email           string `json:"email" binding:"required"`
+	ExpectedVersion *int64 `json:"-"`
+}
+
+// PatchUserRequest only changes the fields that are set, the others keep their
+// stored value.
+type PatchUserRequest struct {
+	ID              int64
+	Name            *string `json:"name"`
+	Email           *string `json:"email"`
+	ExpectedVersion *int64  `json:"-"`
+}
+
+// UserDocument is the user as seen by PATCH documents. The patched document
+// must pass the same rules as a new user.
+type UserDocument struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required"`
+}
+
+// UserDeletePolicy decides what happens to the products of a deleted user.
+// Restrict refuses to delete users that still own products, cascade deletes
+// them with the user and reassign hands them over to another user.
+type UserDeletePolicy string
+
+const (
+	UserDeleteRestrict UserDeletePolicy = "restrict"
+	UserDeleteCascade  UserDeletePolicy = "cascade"
+	UserDeleteReassign UserDeletePolicy = "reassign"
+)
+
+func (p *UserDeletePolicy) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("UserDeletePolicy must be a string")
+	}
+
+	*p = UserDeletePolicy(strings.ToLower(s))
+	return nil
+}
+
+func (p UserDeletePolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(p))))
+}
+
+// DeleteUserRequest uses the configured policy when Policy is empty,
+// ReassignTo is the user receiving the products of a reassign.
+type DeleteUserRequest struct {
+	ID         int64            `json:"id" binding:"required,min=1" uri:"id"`
+	Policy     UserDeletePolicy `json:"policy" form:"policy" binding:"omitempty,oneof=restrict cascade reassign"`
+	ReassignTo *int64           `json:"reassign_to" form:"reassign_to" binding:"omitempty,min=1"`
+}
+
+// ListUsersRequest pages through users in id order.
+type ListUsersRequest struct {
+	First *int    `json:"first" form:"first" binding:"omitempty,min=1"`
+	After *string `json:"after" form:"after"`
+}
+
+// GetUserProductsRequest only returns the products in CategoryID, or in one of
+// its subcategories, and the products tagged with Tag when they are set.
 type GetUserProductsRequest struct {
-	UserID int64   `json:"user_id" uri:"id"`
-	First  *int    `json:"first" form:"first,default=5" binding:"number,min=1"`
-	After  *string `json:"after" form:"after"`
+	UserID     int64   `json:"user_id" uri:"id"`
+	First      *int    `json:"first" form:"first" binding:"omitempty,min=1"`
+	After      *string `json:"after" form:"after"`
+	Last       *int    `json:"last" form:"last" binding:"omitempty,min=1"`
+	Before     *string `json:"before" form:"before"`
+	CategoryID *int64  `json:"category_id" form:"category_id" binding:"omitempty,min=1"`
+	Tag        *string `json:"tag" form:"tag"`
+}
+
+type GetBatchUsersRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+// GetBatchUserProductsRequest fetches the same page of products for several
+// users at once, the page arguments and filters behave like
+// GetUserProductsRequest.
+type GetBatchUserProductsRequest struct {
+	UserIDs    []int64 `json:"user_ids"`
+	First      *int    `json:"first"`
+	After      *string `json:"after"`
+	Last       *int    `json:"last"`
+	Before     *string `json:"before"`
+	CategoryID *int64  `json:"category_id"`
+	Tag        *string `json:"tag"`
 }
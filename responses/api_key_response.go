@@ -0,0 +1,35 @@
+package responses
+
+import (
+	"sqlc-rest-api/requests"
+	"time"
+)
+
+// APIKey describes an API key without the key, only its Prefix.
+type APIKey struct {
+	ID         int64            `json:"id"`
+	UserID     int64            `json:"user_id"`
+	Name       string           `json:"name"`
+	Prefix     string           `json:"prefix"`
+	Scopes     []requests.Scope `json:"scopes"`
+	ExpiresAt  *time.Time       `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time       `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// HasScope reports whether the key may act within scope.
+func (k *APIKey) HasScope(scope requests.Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewAPIKey holds a created API key, Key is only ever shown here.
+type NewAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
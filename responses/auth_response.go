@@ -0,0 +1,15 @@
+package responses
+
+import "time"
+
+// Session holds the tokens of a logged in user. The access token goes into
+// the Authorization header of requests until ExpiresAt, the refresh token
+// gets a new Session once, until RefreshExpiresAt.
+type Session struct {
+	User             *User     `json:"user"`
+	TokenType        string    `json:"token_type"`
+	AccessToken      string    `json:"access_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
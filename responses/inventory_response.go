@@ -0,0 +1,64 @@
+package responses
+
+import (
+	"fmt"
+	"io"
+	"sqlc-rest-api/requests"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stock of a product. Reserved units are still on hand, Available is what
+// can be reserved or sold right now.
+type Stock struct {
+	ProductID int64 `json:"product_id"`
+	OnHand    int64 `json:"on_hand"`
+	Reserved  int64 `json:"reserved"`
+	Available int64 `json:"available"`
+}
+
+type StockAdjustment struct {
+	ID        int64                `json:"id"`
+	ProductID int64                `json:"product_id"`
+	Delta     int64                `json:"delta"`
+	Reason    requests.StockReason `json:"reason"`
+	Note      string               `json:"note"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// ReservationStatus is lower case over REST and an upper case GraphQL enum
+// value.
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationExpired   ReservationStatus = "expired"
+	ReservationCommitted ReservationStatus = "committed"
+)
+
+func (s *ReservationStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("ReservationStatus must be a string")
+	}
+
+	*s = ReservationStatus(strings.ToLower(str))
+	return nil
+}
+
+func (s ReservationStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+type Reservation struct {
+	ID        int64             `json:"id"`
+	ProductID int64             `json:"product_id"`
+	UserID    *int64            `json:"user_id"`
+	Quantity  int64             `json:"quantity"`
+	Status    ReservationStatus `json:"status"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,116 @@
+package responses
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Money is an amount in the minor unit of its ISO 4217 currency, cents for
+// USD and yen for JPY. It is the Money scalar of the GraphQL schema and has
+// the same JSON form over REST.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// String formats m in major units, 1999 USD is "19.99 USD".
+func (m Money) String() string {
+	digits, _ := MinorUnits(m.Currency)
+	if digits == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	scale := int64(1)
+	for i := 0; i < digits; i++ {
+		scale *= 10
+	}
+
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, digits, amount%scale, m.Currency)
+}
+
+func (m *Money) UnmarshalGQL(v any) error {
+	fields, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("Money must be an object with amount and currency")
+	}
+
+	currency, ok := fields["currency"].(string)
+	if !ok {
+		return fmt.Errorf("Money currency must be a string")
+	}
+
+	var amount int64
+	switch a := fields["amount"].(type) {
+	case json.Number:
+		n, err := a.Int64()
+		if err != nil {
+			return fmt.Errorf("Money amount must be an integer: %w", err)
+		}
+		amount = n
+	case int64:
+		amount = a
+	case int:
+		amount = int64(a)
+	default:
+		return fmt.Errorf("Money amount must be an integer")
+	}
+
+	*m = Money{Amount: amount, Currency: currency}
+	return nil
+}
+
+func (m Money) MarshalGQL(w io.Writer) {
+	fmt.Fprintf(w, `{"amount":%d,"currency":%s}`, m.Amount, strconv.Quote(m.Currency))
+}
+
+// ExchangeRate says one unit of Base is worth Rate units of Quote. Rate is a
+// decimal string so it keeps its precision.
+type ExchangeRate struct {
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// currencies lists the ISO 4217 currencies by the number of digits of their
+// minor unit.
+var currencies = map[int]string{
+	0: "BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF",
+	2: "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV " +
+		"BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CNY COP COU CRC CUC CUP CVE " +
+		"CZK DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GTQ GYD HKD " +
+		"HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD " +
+		"LSL MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN " +
+		"NIO NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD RUB SAR SBD SCR SDG " +
+		"SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD " +
+		"TWD TZS UAH USD USN UYU UZS VED VES WST XCD YER ZAR ZMW ZWL",
+	3: "BHD IQD JOD KWD LYD OMR TND",
+	4: "CLF UYW",
+}
+
+var minorUnits = func() map[string]int {
+	units := make(map[string]int)
+	for digits, codes := range currencies {
+		for _, code := range strings.Fields(codes) {
+			units[code] = digits
+		}
+	}
+
+	return units
+}()
+
+// MinorUnits returns the number of digits of the minor unit of currency, 2
+// for USD, and whether currency is a known ISO 4217 code.
+func MinorUnits(currency string) (int, bool) {
+	digits, ok := minorUnits[currency]
+	return digits, ok
+}
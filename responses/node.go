@@ -0,0 +1,11 @@
+package responses
+
+// Node is implemented by every type that can be refetched with the GraphQL
+// node query.
+type Node interface {
+	IsNode()
+}
+
+func (Product) IsNode() {}
+
+func (User) IsNode() {}
@@ -0,0 +1,40 @@
+package responses
+
+import (
+	"sqlc-rest-api/requests"
+	"time"
+)
+
+// Order.Total is the sum of the totals of its items, every amount is in the
+// currency of the order.
+type Order struct {
+	ID        int64                `json:"id"`
+	UserID    int64                `json:"user_id"`
+	Status    requests.OrderStatus `json:"status"`
+	Total     Money                `json:"total"`
+	Items     []*OrderItem         `json:"items"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// OrderItem holds the name and price its product had when the order was
+// placed. ProductID is nil once the product is deleted for good.
+type OrderItem struct {
+	ID          int64  `json:"id"`
+	OrderID     int64  `json:"order_id"`
+	ProductID   *int64 `json:"product_id"`
+	ProductName string `json:"product_name"`
+	UnitPrice   Money  `json:"unit_price"`
+	Quantity    int64  `json:"quantity"`
+	Total       Money  `json:"total"`
+}
+
+type Orders struct {
+	Edges    []*OrderEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"page_info"`
+}
+
+type OrderEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Order `json:"node"`
+}
@@ -1,7 +1,14 @@
 package responses
 
 type PageInfo struct {
-	StartCursor string `json:"start_cursor"`
-	EndCursor   string `json:"end_cursor"`
-	HasNextPage bool   `json:"has_next_page"`
+	StartCursor     string `json:"start_cursor"`
+	EndCursor       string `json:"end_cursor"`
+	HasNextPage     bool   `json:"has_next_page"`
+	HasPreviousPage bool   `json:"has_previous_page"`
+}
+
+type OffsetPageInfo struct {
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	Total  int64 `json:"total"`
 }
@@ -0,0 +1,53 @@
+package responses
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentStatus is lower case over REST and an upper case GraphQL enum value.
+// Payments start pending, move to succeeded or failed when they are confirmed
+// and succeeded payments can be refunded.
+type PaymentStatus string
+
+const (
+	PaymentPending   PaymentStatus = "pending"
+	PaymentSucceeded PaymentStatus = "succeeded"
+	PaymentFailed    PaymentStatus = "failed"
+	PaymentRefunded  PaymentStatus = "refunded"
+)
+
+func (s *PaymentStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("PaymentStatus must be a string")
+	}
+
+	*s = PaymentStatus(strings.ToLower(str))
+	return nil
+}
+
+func (s PaymentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(strings.ToUpper(string(s))))
+}
+
+// Payment pays for an order or a product, the other id is nil. UserID is the
+// user paying, nil for payments started before payers were recorded.
+// ClientSecret is only set when the payment is started, clients hand it to the
+// provider to complete the payment.
+type Payment struct {
+	ID           int64         `json:"id"`
+	OrderID      *int64        `json:"order_id"`
+	ProductID    *int64        `json:"product_id"`
+	UserID       *int64        `json:"user_id"`
+	Provider     string        `json:"provider"`
+	IntentID     string        `json:"intent_id"`
+	Status       PaymentStatus `json:"status"`
+	Amount       Money         `json:"amount"`
+	ClientSecret string        `json:"client_secret,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
@@ -2,13 +2,19 @@ package responses
 
 import "time"
 
+// Product.Version starts at 1 and grows with every update. Stock is only set
+// when a single product is fetched, stock changes do not bump the version.
 type Product struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Price     int64     `json:"price"`
-	UserID    int64     `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	User      *User     `json:"user,omitempty"`
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Price     Money      `json:"price"`
+	UserID    int64      `json:"user_id"`
+	Version   int64      `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	User      *User      `json:"user,omitempty"`
+	Stock     *Stock     `json:"stock,omitempty"`
 }
 
 type Products struct {
@@ -16,12 +22,47 @@ type Products struct {
 	PageInfo *PageInfo      `json:"page_info"`
 }
 
+// ProductEdge only carries Rank and Highlight in search results. Highlight is
+// the HTML escaped product name with matched words wrapped in <mark> tags.
 type ProductEdge struct {
-	Cursor string   `json:"cursor"`
-	Node   *Product `json:"node"`
+	Cursor    string   `json:"cursor"`
+	Node      *Product `json:"node"`
+	Rank      *float64 `json:"rank,omitempty"`
+	Highlight *string  `json:"highlight,omitempty"`
 }
 
+// DeletedProduct is Permanent when the product was removed for good rather
+// than moved to the trash.
 type DeletedProduct struct {
 	Deleted   bool  `json:"deleted"`
+	Permanent bool  `json:"permanent"`
 	ProductID int64 `json:"product_id"`
 }
+
+type ProductList struct {
+	Products []*Product      `json:"products"`
+	PageInfo *OffsetPageInfo `json:"page_info"`
+}
+
+// BulkProductResult is the outcome of the item at Index of a bulk request,
+// creates and patches set Product and deletes set Deleted when they succeed.
+type BulkProductResult struct {
+	Index   int             `json:"index"`
+	Product *Product        `json:"product,omitempty"`
+	Deleted *DeletedProduct `json:"deleted,omitempty"`
+	Error   *BulkError      `json:"error,omitempty"`
+}
+
+type BulkError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BulkProductsResult holds one result per item in request order. Nothing was
+// written when RolledBack is set, the failed items tell why.
+type BulkProductsResult struct {
+	Results    []*BulkProductResult `json:"results"`
+	Succeeded  int                  `json:"succeeded"`
+	Failed     int                  `json:"failed"`
+	RolledBack bool                 `json:"rolled_back"`
+}
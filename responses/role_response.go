@@ -0,0 +1,10 @@
+package responses
+
+import "sqlc-rest-api/requests"
+
+// Role lists the permissions a role grants.
+type Role struct {
+	Name        requests.Role `json:"name"`
+	Description string        `json:"description"`
+	Permissions []string      `json:"permissions"`
+}
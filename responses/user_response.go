@@ -1,11 +1,64 @@
 package responses
 
-import "time"
+import (
+	"sqlc-rest-api/requests"
+	"time"
+)
 
+// User.Roles and User.Permissions are only loaded for the user of a request
+// or a session.
 type User struct {
 	ID        int64     `json:"id"`
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
+	Version   int64     `json:"version"`
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 	Products  *Products `json:"products,omitempty"`
+
+	Roles       []requests.Role `json:"roles,omitempty"`
+	Permissions []string        `json:"permissions,omitempty"`
+}
+
+// HasRole reports whether the user has role or a role above it.
+func (u *User) HasRole(role requests.Role) bool {
+	for _, r := range u.Roles {
+		if r.Includes(role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasPermission reports whether one of the roles of the user grants
+// permission.
+func (u *User) HasPermission(permission string) bool {
+	for _, p := range u.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Users struct {
+	Edges    []*UserEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"page_info"`
+}
+
+type UserEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *User  `json:"node"`
+}
+
+// DeletedUser reports how many products the policy deleted or reassigned,
+// ReassignedTo is only set by the reassign policy.
+type DeletedUser struct {
+	Deleted      bool                      `json:"deleted"`
+	UserID       int64                     `json:"user_id"`
+	Policy       requests.UserDeletePolicy `json:"policy"`
+	Products     int64                     `json:"products"`
+	ReassignedTo *int64                    `json:"reassigned_to,omitempty"`
 }
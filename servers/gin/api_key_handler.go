@@ -0,0 +1,124 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) CreateAPIKey(c *gin.Context) {
+	var req requests.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	key, err := gs.Service.CreateAPIKey(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"api_key": key,
+	}
+
+	resp := helpers.SuccessResponse("API key created successfully, store the key now as it is not shown again", data)
+	c.JSON(201, resp)
+}
+
+func (gs *GinServer) ListAPIKeys(c *gin.Context) {
+	keys, err := gs.Service.ListAPIKeys(c)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"api_keys": keys,
+	}
+
+	resp := helpers.SuccessResponse("list API keys successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) GetAPIKey(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	key, err := gs.Service.GetAPIKey(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"api_key": key,
+	}
+
+	resp := helpers.SuccessResponse("get API key successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) UpdateAPIKey(c *gin.Context) {
+	var req requests.UpdateAPIKeyRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.ID = uri.ID
+	key, err := gs.Service.UpdateAPIKey(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"api_key": key,
+	}
+
+	resp := helpers.SuccessResponse("update API key successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) DeleteAPIKey(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := gs.Service.DeleteAPIKey(c, uri); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"api_key_id": uri.ID,
+	}
+
+	resp := helpers.SuccessResponse("API key deleted successfully", data)
+	c.JSON(200, resp)
+}
@@ -0,0 +1,248 @@
+package ginserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	created := &responses.NewAPIKey{
+		APIKey: &responses.APIKey{
+			ID:        1,
+			UserID:    staff.ID,
+			Name:      "nightly import",
+			Prefix:    "0123456789ab",
+			Scopes:    []requests.Scope{requests.ScopeProductsRead},
+			CreatedAt: time.Now(),
+		},
+		Key: "sk_0123456789ab_secret",
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		authenticate  bool
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "API key created successfully",
+			body:         `{"name":"nightly import","scopes":["products:read"]}`,
+			authenticate: true,
+			mock: func(service *mocks.MockService) {
+				req := requests.CreateAPIKeyRequest{Name: "nightly import", Scopes: []requests.Scope{requests.ScopeProductsRead}}
+				service.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(created, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Contains(t, rec.Body.String(), `"key":"sk_0123456789ab_secret"`)
+				require.Contains(t, rec.Body.String(), `"scopes":["products:read"]`)
+			},
+		},
+		{
+			name:         "no scopes",
+			body:         `{"name":"nightly import","scopes":[]}`,
+			authenticate: true,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:         "unknown scope",
+			body:         `{"name":"nightly import","scopes":["products:delete"]}`,
+			authenticate: true,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ValidationError("unknown scope %q", "products:delete"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			name: "anonymous",
+			body: `{"name":"nightly import","scopes":["products:read"]}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateAPIKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/api-keys", strings.NewReader(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			if testCase.authenticate {
+				authenticateStaff(service, request)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	key := &responses.APIKey{
+		ID:     1,
+		UserID: staff.ID,
+		Scopes: []requests.Scope{requests.ScopeProductsRead},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		header        http.Header
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "read within scope",
+			method: http.MethodGet,
+			path:   "/products/1",
+			header: http.Header{"X-Api-Key": {"sk_0123456789ab_secret"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Eq("sk_0123456789ab_secret")).
+					Times(1).
+					Return(staff, key, nil)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(&responses.Product{ID: 1}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:   "write without scope",
+			method: http.MethodDelete,
+			path:   "/products/1",
+			header: http.Header{"Authorization": {"ApiKey sk_0123456789ab_secret"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Eq("sk_0123456789ab_secret")).
+					Times(1).
+					Return(staff, key, nil)
+				service.EXPECT().
+					DeleteProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+				require.Contains(t, rec.Body.String(), "products:write")
+			},
+		},
+		{
+			name:   "other resource",
+			method: http.MethodGet,
+			path:   "/orders/1",
+			header: http.Header{"X-Api-Key": {"sk_0123456789ab_secret"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(staff, key, nil)
+				service.EXPECT().
+					GetOrder(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+				require.Contains(t, rec.Body.String(), "orders:read")
+			},
+		},
+		{
+			name:   "invalid key",
+			method: http.MethodGet,
+			path:   "/products/1",
+			header: http.Header{"X-Api-Key": {"sk_0123456789ab_guessed"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, nil, services.UnauthorizedError("invalid API key"))
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name:   "bearer tokens have every scope",
+			method: http.MethodGet,
+			path:   "/orders/1",
+			header: http.Header{"Authorization": {"Bearer staff"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("staff")).
+					Times(1).
+					Return(staff, nil)
+				service.EXPECT().
+					GetOrder(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(&responses.Order{ID: 1}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(testCase.method, testCase.path, nil)
+			require.NoError(t, err)
+			request.Header = testCase.header
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package ginserver
+
+import (
+	"net/http"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) Register(c *gin.Context) {
+	var req requests.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	session, err := gs.Service.Register(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"session": session,
+	}
+
+	resp := helpers.SuccessResponse("user registered successfully", data)
+	c.JSON(201, resp)
+}
+
+func (gs *GinServer) Login(c *gin.Context) {
+	var req requests.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	session, err := gs.Service.Login(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"session": session,
+	}
+
+	resp := helpers.SuccessResponse("logged in successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) RefreshSession(c *gin.Context) {
+	var req requests.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	session, err := gs.Service.RefreshSession(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"session": session,
+	}
+
+	resp := helpers.SuccessResponse("session refreshed successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) Logout(c *gin.Context) {
+	var req requests.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := gs.Service.Logout(c, req); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	resp := helpers.SuccessResponse("logged out successfully", nil)
+	c.JSON(200, resp)
+}
+
+// Me returns the user the request is made by.
+func (gs *GinServer) Me(c *gin.Context) {
+	user, _ := auth.UserFrom(c)
+
+	data := gin.H{
+		"user": user,
+	}
+
+	resp := helpers.SuccessResponse("get user successfully", data)
+	c.JSON(200, resp)
+}
+
+// authenticate puts the user of the API key or bearer token of a request into
+// the context of the request, see auth.UserFrom. Requests made with an API key
+// are limited to its scopes, see auth.ScopesFrom. Requests without either
+// stay anonymous, invalid keys and tokens are refused.
+func (gs *GinServer) authenticate(c *gin.Context) {
+	if key, ok := auth.APIKey(c.Request.Header); ok {
+		user, apiKey, err := gs.Service.AuthenticateAPIKey(c, key)
+		if err != nil {
+			serviceError(c, err)
+			c.Abort()
+			return
+		}
+
+		ctx := auth.WithScopes(auth.WithUser(c.Request.Context(), user), apiKey.Scopes)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		return
+	}
+
+	token, ok := auth.BearerToken(c.GetHeader("Authorization"))
+	if !ok {
+		c.Next()
+		return
+	}
+
+	user, err := gs.Service.Authenticate(c, token)
+	if err != nil {
+		serviceError(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), user))
+	c.Next()
+}
+
+// requireUser refuses anonymous requests, it runs after authenticate.
+func (gs *GinServer) requireUser(c *gin.Context) {
+	if _, ok := auth.UserFrom(c); !ok {
+		serviceError(c, services.UnauthorizedError("authentication required"))
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// requirePermission refuses requests of users whose roles do not grant
+// permission, it runs after authenticate.
+func (gs *GinServer) requirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.UserFrom(c)
+		if !ok {
+			serviceError(c, services.UnauthorizedError("authentication required"))
+			c.Abort()
+			return
+		}
+
+		if !user.HasPermission(permission) {
+			serviceError(c, services.ForbiddenError("permission %s required", permission))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireScope refuses requests made with API keys that lack read, for GET
+// and HEAD requests, or write, for the others. It runs after authenticate.
+func (gs *GinServer) requireScope(read, write requests.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := write
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = read
+		}
+
+		if !auth.HasScope(c, scope) {
+			serviceError(c, services.ForbiddenError("API key scope %s required", scope))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,273 @@
+package ginserver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func testSession() *responses.Session {
+	return &responses.Session{
+		User:             &responses.User{ID: 1, Name: "royyan", Email: "royyan@gmail.com"},
+		TokenType:        auth.TokenType,
+		AccessToken:      "access",
+		ExpiresAt:        time.Now().Add(auth.DefaultAccessTTL),
+		RefreshToken:     "refresh",
+		RefreshExpiresAt: time.Now().Add(auth.DefaultRefreshTTL),
+	}
+}
+
+func TestAuthEndpoints(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "registered successfully",
+			path: "/auth/register",
+			body: `{"name":"royyan","email":"royyan@gmail.com","password":"correct horse"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Register(gomock.Any(), gomock.Eq(requests.RegisterRequest{Name: "royyan", Email: "royyan@gmail.com", Password: "correct horse"})).
+					Times(1).
+					Return(testSession(), nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Contains(t, rec.Body.String(), `"access_token":"access"`)
+				require.Contains(t, rec.Body.String(), `"refresh_token":"refresh"`)
+			},
+		},
+		{
+			name: "register without password",
+			path: "/auth/register",
+			body: `{"name":"royyan","email":"royyan@gmail.com"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Register(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "register email taken",
+			path: "/auth/register",
+			body: `{"name":"royyan","email":"royyan@gmail.com","password":"correct horse"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Register(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("email royyan@gmail.com is already taken"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			name: "logged in successfully",
+			path: "/auth/login",
+			body: `{"email":"royyan@gmail.com","password":"correct horse"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Login(gomock.Any(), gomock.Eq(requests.LoginRequest{Email: "royyan@gmail.com", Password: "correct horse"})).
+					Times(1).
+					Return(testSession(), nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"token_type":"Bearer"`)
+			},
+		},
+		{
+			name: "wrong password",
+			path: "/auth/login",
+			body: `{"email":"royyan@gmail.com","password":"wrong horse"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Login(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.UnauthorizedError("invalid email or password"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "session refreshed successfully",
+			path: "/auth/refresh",
+			body: `{"refresh_token":"refresh"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					RefreshSession(gomock.Any(), gomock.Eq(requests.RefreshTokenRequest{RefreshToken: "refresh"})).
+					Times(1).
+					Return(testSession(), nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "refresh token reused",
+			path: "/auth/refresh",
+			body: `{"refresh_token":"refresh"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					RefreshSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.UnauthorizedError("invalid refresh token"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "logged out successfully",
+			path: "/auth/logout",
+			body: `{"refresh_token":"refresh"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Logout(gomock.Any(), gomock.Eq(requests.RefreshTokenRequest{RefreshToken: "refresh"})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, testCase.path, bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	user := &responses.User{ID: 1, Name: "royyan", Email: "royyan@gmail.com"}
+	testCases := []struct {
+		name          string
+		path          string
+		authorization string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "current user",
+			path:          "/auth/me",
+			authorization: "Bearer access",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("access")).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"email":"royyan@gmail.com"`)
+			},
+		},
+		{
+			name: "current user anonymous",
+			path: "/auth/me",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name:          "expired token",
+			path:          "/products/1",
+			authorization: "Bearer expired",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("expired")).
+					Times(1).
+					Return(nil, services.UnauthorizedError("access token has expired"))
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name:          "user reaches the service",
+			path:          "/products/1",
+			authorization: "Bearer access",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("access")).
+					Times(1).
+					Return(user, nil)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
+						got, ok := auth.UserFrom(ctx)
+						require.True(t, ok)
+						require.Equal(t, user.ID, got.ID)
+						return &responses.Product{ID: 1, UserID: user.ID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			require.NoError(t, err)
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
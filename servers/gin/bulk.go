@@ -0,0 +1,27 @@
+package ginserver
+
+import (
+	"net/http"
+	"sqlc-rest-api/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bulkResponse writes the result of a bulk request with status when every item
+// succeeded. Partial successes are 207 Multi-Status and rolled back atomic
+// requests 422 as nothing was applied, the failed items tell why.
+func bulkResponse(c *gin.Context, status int, message string, result *responses.BulkProductsResult) {
+	switch {
+	case result.RolledBack:
+		status = http.StatusUnprocessableEntity
+		message = "bulk request rolled back"
+	case result.Failed > 0:
+		status = http.StatusMultiStatus
+	}
+
+	c.JSON(status, responses.ApiResponse{
+		Success: result.Failed == 0,
+		Message: message,
+		Data:    result,
+	})
+}
@@ -0,0 +1,165 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) CreateCategory(c *gin.Context) {
+	var req requests.CreateCategoryRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	category, err := gs.Service.CreateCategory(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"category": category,
+	}
+
+	resp := helpers.SuccessResponse("category created successfully", data)
+	c.JSON(201, resp)
+}
+
+func (gs *GinServer) GetCategory(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	category, err := gs.Service.GetCategory(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"category": category,
+	}
+
+	resp := helpers.SuccessResponse("get category successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) ListCategories(c *gin.Context) {
+	var req requests.ListCategoriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	categories, err := gs.Service.ListCategories(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"categories": categories,
+	}
+
+	resp := helpers.SuccessResponse("list categories successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) UpdateCategory(c *gin.Context) {
+	var req requests.UpdateCategoryRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.ID = uri.ID
+	category, err := gs.Service.UpdateCategory(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"category": category,
+	}
+
+	resp := helpers.SuccessResponse("update category successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) DeleteCategory(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := gs.Service.DeleteCategory(c, uri); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"category_id": uri.ID,
+	}
+
+	resp := helpers.SuccessResponse("category deleted successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) SetProductCategories(c *gin.Context) {
+	var req requests.SetProductCategoriesRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.ProductID = uri.ID
+	categories, err := gs.Service.SetProductCategories(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"categories": categories,
+	}
+
+	resp := helpers.SuccessResponse("set product categories successfully", data)
+	c.JSON(200, resp)
+}
@@ -0,0 +1,254 @@
+package ginserver
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCategory(t *testing.T) {
+	parentID := int64(1)
+
+	testCases := []struct {
+		name          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "category created successfully",
+			body: `{"name":"Shoes","parent_id":1}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.CreateCategoryRequest{Name: "Shoes", ParentID: &parentID}
+				service.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Category{ID: 2, Name: "Shoes", ParentID: &parentID, CreatedAt: time.Now()}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Contains(t, rec.Body.String(), `"parent_id":1`)
+			},
+		},
+		{
+			name: "name not given",
+			body: `{"parent_id":1}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "parent not found",
+			body: `{"name":"Shoes","parent_id":99}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NewError(services.ErrForeignKeyViolation, "parent category with id 99 not found"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			name: "name taken",
+			body: `{"name":"Shoes","parent_id":1}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError(`category "Shoes" already exists at this level`))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/categories", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            int64
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "category deleted successfully",
+			id:   1,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "category has subcategories",
+			id:   1,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(services.ConflictError("category with id 1 still has 2 subcategories"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			name: "invalid id",
+			id:   0,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/categories/%d", testCase.id)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestSetProductCategories(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "categories set successfully",
+			body: `{"category_ids":[2,3]}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.SetProductCategoriesRequest{ProductID: 1, CategoryIDs: []int64{2, 3}}
+				service.EXPECT().
+					SetProductCategories(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return([]*responses.Category{{ID: 2, Name: "Boots"}, {ID: 3, Name: "Shoes"}}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"name":"Boots"`)
+			},
+		},
+		{
+			name: "empty list removes every category",
+			body: `{"category_ids":[]}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.SetProductCategoriesRequest{ProductID: 1, CategoryIDs: []int64{}}
+				service.EXPECT().
+					SetProductCategories(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return([]*responses.Category{}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "category_ids not given",
+			body: `{}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SetProductCategories(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "category not found",
+			body: `{"category_ids":[99]}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SetProductCategories(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NewError(services.ErrForeignKeyViolation, "category with id 99 not found"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPut, "/products/1/categories", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
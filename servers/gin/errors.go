@@ -0,0 +1,43 @@
+package ginserver
+
+import (
+	"net/http"
+	"sqlc-rest-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errorStatus = map[services.ErrorCode]int{
+	services.ErrBadRequest:          http.StatusBadRequest,
+	services.ErrNotFound:            http.StatusNotFound,
+	services.ErrValidation:          http.StatusUnprocessableEntity,
+	services.ErrConflict:            http.StatusConflict,
+	services.ErrPreconditionFailed:  http.StatusPreconditionFailed,
+	services.ErrForeignKeyViolation: http.StatusUnprocessableEntity,
+	services.ErrUnauthorized:        http.StatusUnauthorized,
+	services.ErrForbidden:           http.StatusForbidden,
+	services.ErrPaymentProvider:     http.StatusBadGateway,
+	services.ErrInternal:            http.StatusInternalServerError,
+}
+
+// serviceError writes err returned by the service with the status matching its
+// services.ErrorCode. Internal errors are attached to the request, so gin's
+// logger writes them, and shown as services.InternalErrorMessage.
+func serviceError(c *gin.Context, err error) {
+	code := services.ErrorCodeOf(err)
+	status, ok := errorStatus[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	message := err.Error()
+	if code == services.ErrInternal {
+		_ = c.Error(err)
+		message = services.InternalErrorMessage
+	}
+
+	c.JSON(status, gin.H{
+		"message": message,
+		"code":    code,
+	})
+}
@@ -0,0 +1,77 @@
+package ginserver
+
+import (
+	"fmt"
+	"net/http"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// etag is the strong entity tag of a resource at version.
+func etag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// productETag is the entity tag of a product. Stock changes without bumping
+// the version, so the stock a product carries is part of its tag. GetProduct
+// and the product writes all return the stock, so a write answers with the
+// tag the next GET sends.
+func productETag(product *responses.Product) string {
+	if product.Stock == nil {
+		return etag(product.Version)
+	}
+
+	return fmt.Sprintf(`"%d-%d-%d"`, product.Version, product.Stock.OnHand, product.Stock.Reserved)
+}
+
+func setETag(c *gin.Context, tag string) {
+	c.Header("ETag", tag)
+}
+
+// notModified answers 304 when the If-None-Match header of the request
+// matches the current entity tag. If-None-Match uses the weak comparison so
+// W/ prefixes are ignored.
+func notModified(c *gin.Context, current string) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			setETag(c, current)
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// ifMatchVersion returns the version the If-Match header of the request
+// expects, nil when the header is missing or "*". Only a single strong entity
+// tag can be turned into a version, anything else never matches.
+func ifMatchVersion(c *gin.Context) (*int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, services.PreconditionFailedError("If-Match must be * or a single strong entity tag")
+	}
+
+	// updates never touch stock, the stock part of a product tag is ignored
+	rawVersion, _, _ := strings.Cut(header[1:len(header)-1], "-")
+	version, err := strconv.ParseInt(rawVersion, 10, 64)
+	if err != nil {
+		return nil, services.PreconditionFailedError("If-Match %s does not match any version", header)
+	}
+
+	return &version, nil
+}
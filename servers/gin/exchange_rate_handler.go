@@ -0,0 +1,57 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) ListExchangeRates(c *gin.Context) {
+	rates, err := gs.Service.ListExchangeRates(c)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"exchange_rates": rates,
+	}
+
+	resp := helpers.SuccessResponse("list exchange rates successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) SetExchangeRate(c *gin.Context) {
+	var req requests.SetExchangeRateRequest
+	var uri requests.BindUriCurrencyPair
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.Base = uri.Base
+	req.Quote = uri.Quote
+	rate, err := gs.Service.SetExchangeRate(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"exchange_rate": rate,
+	}
+
+	resp := helpers.SuccessResponse("set exchange rate successfully", data)
+	c.JSON(200, resp)
+}
@@ -0,0 +1,92 @@
+package ginserver
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetExchangeRate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pair          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "exchange rate set successfully",
+			pair: "USD/EUR",
+			body: `{"rate":"0.92"}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.SetExchangeRateRequest{Base: "USD", Quote: "EUR", Rate: "0.92"}
+				service.EXPECT().
+					SetExchangeRate(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.ExchangeRate{Base: "USD", Quote: "EUR", Rate: "0.92", UpdatedAt: time.Now()}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"rate":"0.92"`)
+			},
+		},
+		{
+			name: "rate not given",
+			pair: "USD/EUR",
+			body: `{}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SetExchangeRate(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "invalid rate",
+			pair: "USD/EUR",
+			body: `{"rate":"-1"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SetExchangeRate(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ValidationError(`rate must be a positive decimal number, got "-1"`))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/exchange-rates/%s", testCase.pair)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
@@ -24,6 +24,9 @@ func NewGinServer(service services.Service, env config.Environment, graph *handl
 		Graph:   graph,
 	}
 
+	// services read the user of a request from the *gin.Context handlers
+	// pass them, see authenticate
+	gs.Engine.ContextWithFallback = true
 	gs.setupRoutes()
 
 	return gs, nil
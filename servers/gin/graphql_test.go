@@ -2,13 +2,22 @@ package ginserver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sqlc-rest-api/auth"
+	graphconfig "sqlc-rest-api/graph/config"
+	"sqlc-rest-api/graph/generated"
 	"sqlc-rest-api/helpers"
 	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
 	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -54,7 +63,7 @@ func TestQueryGetProduct(t *testing.T) {
 			},
 			mock: func(service *mocks.MockService) {
 				getProductArg := helpers.NewBindUriIDRequestTest(product.ID)
-				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				getUsersArg := requests.GetBatchUsersRequest{IDs: []int64{user.ID}}
 
 				service.EXPECT().
 					GetProduct(gomock.Any(), gomock.Eq(getProductArg)).
@@ -62,15 +71,150 @@ func TestQueryGetProduct(t *testing.T) {
 					Return(&product, nil)
 
 				service.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					GetBatchUsers(gomock.Any(), gomock.Eq(getUsersArg)).
 					Times(1).
-					Return(&user, nil)
+					Return([]*responses.User{&user}, nil)
 			},
 			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
 				helpers.GraphProductMatchTest(t, "data.GetProduct", *rec.Body, product)
 				helpers.GraphUserMatchTest(t, "data.GetProduct.user", *rec.Body, user)
 			},
 		},
+		{
+			name: "get product by global id",
+			query: `
+				query GetProduct($getProductReq: UriID!) {
+					GetProduct(input: $getProductReq) {
+						id
+						name
+						price
+						user_id
+						created_at
+					}
+				}
+			`,
+			operationName: "GetProduct",
+			variables: gin.H{
+				"getProductReq": gin.H{
+					"id": helpers.EncodeGlobalID("Product", product.ID),
+				},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(helpers.NewBindUriIDRequestTest(product.ID))).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphProductMatchTest(t, "data.GetProduct", *rec.Body, product)
+			},
+		},
+		{
+			name: "global id of another type",
+			query: `
+				query GetProduct($getProductReq: UriID!) {
+					GetProduct(input: $getProductReq) {
+						id
+						name
+					}
+				}
+			`,
+			operationName: "GetProduct",
+			variables: gin.H{
+				"getProductReq": gin.H{
+					"id": helpers.EncodeGlobalID("User", product.ID),
+				},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrBadRequest))
+			},
+		},
+		{
+			name: "product not found",
+			query: `
+				query GetProduct($getProductReq: UriID!) {
+					GetProduct(input: $getProductReq) {
+						id
+						name
+					}
+				}
+			`,
+			operationName: "GetProduct",
+			variables: gin.H{
+				"getProductReq": gin.H{
+					"id": product.ID,
+				},
+			},
+			mock: func(service *mocks.MockService) {
+				getProductArg := helpers.NewBindUriIDRequestTest(product.ID)
+
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(getProductArg)).
+					Times(1).
+					Return(nil, services.NotFoundError("product with id %d not found", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrNotFound))
+			},
+		},
+		{
+			name: "internal error hidden",
+			query: `
+				query GetProduct($getProductReq: UriID!) {
+					GetProduct(input: $getProductReq) {
+						id
+						name
+					}
+				}
+			`,
+			operationName: "GetProduct",
+			variables: gin.H{
+				"getProductReq": gin.H{
+					"id": product.ID,
+				},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf(`pq: relation "products" does not exist`))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrInternal))
+				require.NotContains(t, rec.Body.String(), "relation")
+				require.Contains(t, rec.Body.String(), services.InternalErrorMessage)
+			},
+		},
+		{
+			name: "invalid id",
+			query: `
+				query GetProduct($getProductReq: UriID!) {
+					GetProduct(input: $getProductReq) {
+						id
+						name
+					}
+				}
+			`,
+			operationName: "GetProduct",
+			variables: gin.H{
+				"getProductReq": gin.H{
+					"id": "not an id",
+				},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrBadRequest))
+			},
+		},
 		{
 			name: "complexity limit product user more than 4",
 			query: `
@@ -103,14 +247,14 @@ func TestQueryGetProduct(t *testing.T) {
 			},
 			mock: func(service *mocks.MockService) {
 				getProductArg := helpers.NewBindUriIDRequestTest(product.ID)
-				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				getUsersArg := requests.GetBatchUsersRequest{IDs: []int64{user.ID}}
 
 				service.EXPECT().
 					GetProduct(gomock.Any(), gomock.Eq(getProductArg)).
 					Times(0)
 
 				service.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					GetBatchUsers(gomock.Any(), gomock.Eq(getUsersArg)).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
@@ -143,8 +287,10 @@ func TestQueryGetProduct(t *testing.T) {
 	}
 }
 
-func TestQueryGetUser(t *testing.T) {
+func TestQueryProducts(t *testing.T) {
 	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+	list := helpers.ProductListResponse([]*responses.Product{&product}, 5, 0, 1)
 
 	testCases := []struct {
 		name          string
@@ -155,238 +301,442 @@ func TestQueryGetUser(t *testing.T) {
 		checkResponse func(t *testing.T, rec httptest.ResponseRecorder)
 	}{
 		{
-			name: "get user successfully",
+			name: "list products successfully",
 			query: `
-				query GetUser($getUserReq: UriID!) {
-					GetUser(input: $getUserReq) {
-						id
-						name
-						email
-						created_at
+				query Products($filter: ProductFilter, $orderBy: ProductOrder) {
+					products(filter: $filter, orderBy: $orderBy, limit: 5) {
+						products {
+							id
+							name
+							price
+							user_id
+						}
+						page_info {
+							limit
+							offset
+							total
+						}
 					}
 				}
 			`,
-			operationName: "GetUser",
+			operationName: "Products",
 			variables: gin.H{
-				"getUserReq": gin.H{
-					"id": user.ID,
-				},
+				"filter":  gin.H{"user_id": user.ID, "min_price": 50},
+				"orderBy": gin.H{"field": "PRICE", "direction": "DESC"},
 			},
 			mock: func(service *mocks.MockService) {
-				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				minPrice := int64(50)
+				req := requests.ListProductsRequest{
+					Filter: requests.ProductFilter{UserID: &user.ID, MinPrice: &minPrice},
+					Order:  requests.ProductOrder{Field: requests.ProductOrderPrice, Direction: requests.OrderDesc},
+					Limit:  5,
+				}
 
 				service.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					ListProducts(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(&user, nil)
+					Return(list, nil)
 			},
 			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
-				helpers.GraphUserMatchTest(t, "data.GetUser", *rec.Body, user)
+				helpers.GraphProductMatchTest(t, "data.products.products.0", *rec.Body, product)
 			},
 		},
-	}
-
-	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			service := mocks.NewMockService(ctrl)
-			testCase.mock(service)
-
-			req := helpers.NewGraphQLRequestTest(testCase.operationName, testCase.query, testCase.variables)
-			data, err := json.Marshal(req)
-			require.NoError(t, err)
-
-			server := newGinTestServer(t, service)
-			rec := httptest.NewRecorder()
-			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
-			require.NoError(t, err)
-			request.Header.Set("Content-Type", "application/json")
-
-			server.Engine.ServeHTTP(rec, request)
-			testCase.checkResponse(t, *rec)
-		})
-	}
-}
-
-func TestQueryGetUserProducts(t *testing.T) {
-	user := helpers.NewUserTest()
-
-	testCases := []struct {
-		name          string
-		query         string
-		operationName string
-		variables     map[string]any
-		mock          func(service *mocks.MockService) *responses.Products
-		checkResponse func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products)
-	}{
 		{
-			name: "get user products successfully",
+			name: "filter by user global id",
 			query: `
-				query GetUser($getUserReq: UriID!) {
-					GetUser(input: $getUserReq) {
-						id
-						name
-						email
-						created_at
-						products(input: {first: 5}) {
-							edges {
-								cursor
-								node {
-								  id
-								  name
-								  price
-								  user_id
-								  created_at
-								}
-							}
-							page_info {
-								start_cursor
-								end_cursor
-								has_next_page
-							}
+				query Products($filter: ProductFilter) {
+					products(filter: $filter, limit: 5) {
+						products {
+							id
+							name
+							price
+							user_id
 						}
 					}
 				}
 			`,
-			operationName: "GetUser",
+			operationName: "Products",
 			variables: gin.H{
-				"getUserReq": gin.H{
-					"id": user.ID,
-				},
+				"filter": gin.H{"user_id": helpers.EncodeGlobalID("User", user.ID)},
 			},
-			mock: func(service *mocks.MockService) *responses.Products {
-				first := 5
-				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
-				getUserProductsArg := helpers.NewGetUserProductsRequestTest(user.ID, &first, nil)
-				products := helpers.NewProductsTest(5, user.ID)
-
-				service.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
-					Times(1).
-					Return(&user, nil)
+			mock: func(service *mocks.MockService) {
+				req := requests.ListProductsRequest{
+					Filter: requests.ProductFilter{UserID: &user.ID},
+					Limit:  5,
+				}
 
 				service.EXPECT().
-					GetUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
+					ListProducts(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(products, nil)
-
-				return products
+					Return(list, nil)
 			},
-			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
-				helpers.GraphUserMatchTest(t, "data.GetUser", *rec.Body, user)
-				helpers.GraphProductsMatchTest(t, "data.GetUser.products", *rec.Body, expectedProducts)
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphProductMatchTest(t, "data.products.products.0", *rec.Body, product)
 			},
 		},
 		{
-			name: "get user products complexity limit more than 100",
+			name: "filter by product global id as user",
 			query: `
-				query GetUser($getUserReq: UriID!) {
-					GetUser(input: $getUserReq) {
-						id
-						name
-						email
-						created_at
-						products(input: {first: 100}) {
-							edges {
-								cursor
-								node {
-								  id
-								  name
-								  price
-								  user_id
-								  created_at
-								}
-							}
-							page_info {
-								start_cursor
-								end_cursor
-								has_next_page
-							}
+				query Products($filter: ProductFilter) {
+					products(filter: $filter, limit: 5) {
+						products {
+							id
 						}
 					}
 				}
 			`,
-			operationName: "GetUser",
+			operationName: "Products",
 			variables: gin.H{
-				"getUserReq": gin.H{
-					"id": user.ID,
-				},
+				"filter": gin.H{"user_id": helpers.EncodeGlobalID("Product", product.ID)},
 			},
-			mock: func(service *mocks.MockService) *responses.Products {
-				first := 10
-				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
-				getUserProductsArg := helpers.NewGetUserProductsRequestTest(user.ID, &first, nil)
-
+			mock: func(service *mocks.MockService) {
 				service.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					ListProducts(gomock.Any(), gomock.Any()).
 					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrBadRequest))
+			},
+		},
+		{
+			name: "prices converted together",
+			query: `
+				query Products {
+					products(limit: 5) {
+						products {
+							price
+							euro: price(currency: "EUR")
+						}
+					}
+				}
+			`,
+			operationName: "Products",
+			mock: func(service *mocks.MockService) {
+				prices := []responses.Money{{Amount: 100, Currency: "USD"}, {Amount: 250, Currency: "USD"}, {Amount: 90, Currency: "EUR"}}
+				var products []*responses.Product
+				for i, price := range prices {
+					products = append(products, &responses.Product{ID: int64(i + 1), Price: price})
+				}
 
 				service.EXPECT().
-					GetUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
-					Times(0)
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(helpers.ProductListResponse(products, 5, 0, 3), nil)
 
-				return &responses.Products{}
+				// prices already in euro are not converted
+				service.EXPECT().
+					ConvertPrices(gomock.Any(), gomock.Eq(prices[:2]), gomock.Eq("EUR")).
+					Times(1).
+					Return([]responses.Money{{Amount: 90, Currency: "EUR"}, {Amount: 225, Currency: "EUR"}}, nil)
 			},
-			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
-				helpers.GraphExpectComplexityLimit(t, "errors.0.extensions.code", *rec.Body)
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				var products []struct {
+					Price responses.Money `json:"price"`
+					Euro  responses.Money `json:"euro"`
+				}
+				helpers.GraphDecodeTest(t, "data.products.products", *rec.Body, &products)
+
+				require.Len(t, products, 3)
+				require.Equal(t, responses.Money{Amount: 250, Currency: "USD"}, products[1].Price)
+				require.Equal(t, responses.Money{Amount: 90, Currency: "EUR"}, products[0].Euro)
+				require.Equal(t, responses.Money{Amount: 225, Currency: "EUR"}, products[1].Euro)
+				require.Equal(t, responses.Money{Amount: 90, Currency: "EUR"}, products[2].Euro)
 			},
 		},
 		{
-			name: "user products child complexity limit more than 12",
+			name: "no exchange rate for the requested currency",
 			query: `
-				query GetUser($getUserReq: UriID!) {
-					GetUser(input: $getUserReq) {
-						id
-						name
-						email
-						created_at
-						products(input: {first: 5}) {
-							edges {
-								cursor
-								node {
-									id
+				query Products {
+					products(limit: 5) {
+						products {
+							price(currency: "SEK")
+						}
+					}
+				}
+			`,
+			operationName: "Products",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(list, nil)
+
+				service.EXPECT().
+					ConvertPrices(gomock.Any(), gomock.Any(), gomock.Eq("SEK")).
+					Times(1).
+					Return(nil, services.ValidationError("no exchange rate from USD to SEK"))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrValidation))
+			},
+		},
+		{
+			name: "users of listed products are batched",
+			query: `
+				query Products {
+					products {
+						products {
+							id
+							user {
+								name
+							}
+						}
+					}
+				}
+			`,
+			operationName: "Products",
+			mock: func(service *mocks.MockService) {
+				other := helpers.NewUserTest()
+				other.ID = 2
+				products := []*responses.Product{
+					{ID: 1, UserID: user.ID},
+					{ID: 2, UserID: other.ID},
+					{ID: 3, UserID: user.ID},
+				}
+
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(helpers.ProductListResponse(products, 10, 0, 3), nil)
+
+				service.EXPECT().
+					GetBatchUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, req requests.GetBatchUsersRequest) ([]*responses.User, error) {
+						// both users in a single call, each only once
+						if len(req.IDs) != 2 {
+							return nil, fmt.Errorf("expected 2 user ids, got %v", req.IDs)
+						}
+						return []*responses.User{&user, &other}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				body := rec.Body.String()
+				require.NotContains(t, body, "errors")
+				require.Equal(t, 3, strings.Count(body, `"user":`))
+			},
+		},
+		{
+			name: "categories and tags batched",
+			query: `
+				query Products {
+					products(limit: 5) {
+						products {
+							categories {
+								name
+								parent {
 									name
-									price
-									user_id
-									created_at
-									user {
-										name
-									}
 								}
 							}
-							page_info {
-								start_cursor
-								end_cursor
-								has_next_page
+							tags {
+								name
 							}
 						}
 					}
 				}
 			`,
-			operationName: "GetUser",
+			operationName: "Products",
+			mock: func(service *mocks.MockService) {
+				products := []*responses.Product{{ID: 1}, {ID: 2}}
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(helpers.ProductListResponse(products, 5, 0, 2), nil)
+
+				parentID := int64(10)
+				categories := map[int64][]*responses.Category{
+					1: {{ID: 11, Name: "Boots", ParentID: &parentID}},
+					2: {{ID: 12, Name: "Sandals", ParentID: &parentID}},
+				}
+				service.EXPECT().
+					GetBatchProductCategories(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, req requests.GetBatchProductCategoriesRequest) ([][]*responses.Category, error) {
+						// both products in a single call
+						if len(req.ProductIDs) != 2 {
+							return nil, fmt.Errorf("expected 2 product ids, got %v", req.ProductIDs)
+						}
+						result := make([][]*responses.Category, len(req.ProductIDs))
+						for i, id := range req.ProductIDs {
+							result[i] = categories[id]
+						}
+						return result, nil
+					})
+
+				// both categories share the parent, it is fetched once
+				service.EXPECT().
+					GetBatchCategories(gomock.Any(), gomock.Eq(requests.GetBatchCategoriesRequest{IDs: []int64{parentID}})).
+					Times(1).
+					Return([]*responses.Category{{ID: parentID, Name: "Shoes"}}, nil)
+
+				tags := map[int64][]*responses.Tag{
+					1: {{ID: 1, Name: "sale"}},
+					2: {},
+				}
+				service.EXPECT().
+					GetBatchProductTags(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, req requests.GetBatchProductTagsRequest) ([][]*responses.Tag, error) {
+						if len(req.ProductIDs) != 2 {
+							return nil, fmt.Errorf("expected 2 product ids, got %v", req.ProductIDs)
+						}
+						result := make([][]*responses.Tag, len(req.ProductIDs))
+						for i, id := range req.ProductIDs {
+							result[i] = tags[id]
+						}
+						return result, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				body := rec.Body.String()
+				require.NotContains(t, body, "errors")
+				require.Equal(t, 2, strings.Count(body, `"parent":{"name":"Shoes"}`))
+				require.Contains(t, body, `"tags":[{"name":"sale"}]`)
+				require.Contains(t, body, `"tags":[]`)
+			},
+		},
+		{
+			name: "invalid limit",
+			query: `
+				query Products {
+					products(limit: 0) {
+						products {
+							id
+						}
+					}
+				}
+			`,
+			operationName: "Products",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ValidationError("limit must be between 1 and %d", services.MaxListLimit))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrValidation))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			req := helpers.NewGraphQLRequestTest(testCase.operationName, testCase.query, testCase.variables)
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, *rec)
+		})
+	}
+}
+
+func TestQuerySearchProducts(t *testing.T) {
+	user := helpers.NewUserTest()
+	results := helpers.NewSearchProductsTest(2, user.ID)
+
+	testCases := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]any
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec httptest.ResponseRecorder)
+	}{
+		{
+			name: "search products successfully",
+			query: `
+				query SearchProducts($query: String!, $after: String) {
+					searchProducts(query: $query, first: 2, after: $after) {
+						edges {
+							cursor
+							rank
+							highlight
+							node {
+								id
+								name
+							}
+						}
+						page_info {
+							end_cursor
+							has_next_page
+						}
+					}
+				}
+			`,
+			operationName: "SearchProducts",
 			variables: gin.H{
-				"getUserReq": gin.H{
-					"id": user.ID,
-				},
+				"query": "product",
+				"after": "cursor",
 			},
-			mock: func(service *mocks.MockService) *responses.Products {
-				first := 5
-				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
-				getUserProductsArg := helpers.NewGetUserProductsRequestTest(user.ID, &first, nil)
+			mock: func(service *mocks.MockService) {
+				first, after := 2, "cursor"
+				req := requests.SearchProductsRequest{Query: "product", First: &first, After: &after}
 
 				service.EXPECT().
-					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
-					Times(0)
-
+					SearchProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(results, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.RequireSearchProductsMatchTest(t, "data.searchProducts", *rec.Body, *results)
+			},
+		},
+		{
+			name: "empty query",
+			query: `
+				query SearchProducts {
+					searchProducts(query: " ") {
+						edges {
+							cursor
+						}
+					}
+				}
+			`,
+			operationName: "SearchProducts",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Eq(requests.SearchProductsRequest{Query: " "})).
+					Times(1).
+					Return(nil, services.ValidationError("search query must not be empty"))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrValidation))
+			},
+		},
+		{
+			name: "search products complexity limit",
+			query: `
+				query SearchProducts {
+					searchProducts(query: "product", first: 1000) {
+						edges {
+							node {
+								id
+								name
+							}
+						}
+					}
+				}
+			`,
+			operationName: "SearchProducts",
+			mock: func(service *mocks.MockService) {
 				service.EXPECT().
-					GetUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
+					SearchProducts(gomock.Any(), gomock.Any()).
 					Times(0)
-
-				return &responses.Products{}
 			},
-			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
 				helpers.GraphExpectComplexityLimit(t, "errors.0.extensions.code", *rec.Body)
 			},
 		},
@@ -398,7 +748,7 @@ func TestQueryGetUserProducts(t *testing.T) {
 			defer ctrl.Finish()
 
 			service := mocks.NewMockService(ctrl)
-			products := testCase.mock(service)
+			testCase.mock(service)
 
 			req := helpers.NewGraphQLRequestTest(testCase.operationName, testCase.query, testCase.variables)
 			data, err := json.Marshal(req)
@@ -411,7 +761,1656 @@ func TestQueryGetUserProducts(t *testing.T) {
 			request.Header.Set("Content-Type", "application/json")
 
 			server.Engine.ServeHTTP(rec, request)
-			testCase.checkResponse(t, *rec, *products)
+			testCase.checkResponse(t, *rec)
 		})
 	}
 }
+
+func TestQueryNode(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+	productID := helpers.EncodeGlobalID("Product", product.ID)
+	userID := helpers.EncodeGlobalID("User", user.ID)
+
+	nodeQuery := `
+		query Node($id: ID!) {
+			node(id: $id) {
+				id
+				__typename
+				... on Product {
+					name
+					price
+					user_id
+				}
+				... on User {
+					name
+					email
+				}
+			}
+		}
+	`
+
+	testCases := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]any
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec httptest.ResponseRecorder)
+	}{
+		{
+			name:          "product node",
+			query:         nodeQuery,
+			operationName: "Node",
+			variables:     gin.H{"id": productID},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetBatchProducts(gomock.Any(), gomock.Eq(requests.GetBatchProductsRequest{IDs: []int64{product.ID}})).
+					Times(1).
+					Return([]*responses.Product{&product}, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphProductMatchTest(t, "data.node", *rec.Body, product)
+			},
+		},
+		{
+			name:          "user node",
+			query:         nodeQuery,
+			operationName: "Node",
+			variables:     gin.H{"id": userID},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetBatchUsers(gomock.Any(), gomock.Eq(requests.GetBatchUsersRequest{IDs: []int64{user.ID}})).
+					Times(1).
+					Return([]*responses.User{&user}, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphUserMatchTest(t, "data.node", *rec.Body, user)
+			},
+		},
+		{
+			name:          "node not found",
+			query:         nodeQuery,
+			operationName: "Node",
+			variables:     gin.H{"id": productID},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetBatchProducts(gomock.Any(), gomock.Eq(requests.GetBatchProductsRequest{IDs: []int64{product.ID}})).
+					Times(1).
+					Return([]*responses.Product{}, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				require.JSONEq(t, `{"data":{"node":null}}`, rec.Body.String())
+			},
+		},
+		{
+			name:          "unknown node type",
+			query:         nodeQuery,
+			operationName: "Node",
+			variables:     gin.H{"id": helpers.EncodeGlobalID("Order", 1)},
+			mock:          func(service *mocks.MockService) {},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				require.JSONEq(t, `{"data":{"node":null}}`, rec.Body.String())
+			},
+		},
+		{
+			name:          "invalid id",
+			query:         nodeQuery,
+			operationName: "Node",
+			variables:     gin.H{"id": "42"},
+			mock:          func(service *mocks.MockService) {},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrBadRequest))
+			},
+		},
+		{
+			name: "nodes",
+			query: `
+				query Nodes($ids: [ID!]!) {
+					nodes(ids: $ids) {
+						id
+						... on Product {
+							name
+							price
+							user_id
+						}
+					}
+				}
+			`,
+			operationName: "Nodes",
+			variables:     gin.H{"ids": []string{productID, helpers.EncodeGlobalID("Product", 2)}},
+			mock: func(service *mocks.MockService) {
+				// the products are fetched in one batch
+				service.EXPECT().
+					GetBatchProducts(gomock.Any(), gomock.Eq(requests.GetBatchProductsRequest{IDs: []int64{product.ID, 2}})).
+					Times(1).
+					Return([]*responses.Product{&product}, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphProductMatchTest(t, "data.nodes.0", *rec.Body, product)
+				require.Contains(t, rec.Body.String(), `null]`)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			req := helpers.NewGraphQLRequestTest(testCase.operationName, testCase.query, testCase.variables)
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, *rec)
+		})
+	}
+}
+
+func TestQueryGetUser(t *testing.T) {
+	user := helpers.NewUserTest()
+
+	testCases := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]any
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec httptest.ResponseRecorder)
+	}{
+		{
+			name: "get user successfully",
+			query: `
+				query GetUser($getUserReq: UriID!) {
+					GetUser(input: $getUserReq) {
+						id
+						name
+						email
+						created_at
+					}
+				}
+			`,
+			operationName: "GetUser",
+			variables: gin.H{
+				"getUserReq": gin.H{
+					"id": user.ID,
+				},
+			},
+			mock: func(service *mocks.MockService) {
+				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+
+				service.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					Times(1).
+					Return(&user, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphUserMatchTest(t, "data.GetUser", *rec.Body, user)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			req := helpers.NewGraphQLRequestTest(testCase.operationName, testCase.query, testCase.variables)
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, *rec)
+		})
+	}
+}
+
+func TestQueryGetUserProducts(t *testing.T) {
+	user := helpers.NewUserTest()
+
+	testCases := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]any
+		mock          func(service *mocks.MockService) *responses.Products
+		checkResponse func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products)
+	}{
+		{
+			name: "get user products successfully",
+			query: `
+				query GetUser($getUserReq: UriID!) {
+					GetUser(input: $getUserReq) {
+						id
+						name
+						email
+						created_at
+						products(input: {first: 5}) {
+							edges {
+								cursor
+								node {
+								  id
+								  name
+								  price
+								  user_id
+								  created_at
+								}
+							}
+							page_info {
+								start_cursor
+								end_cursor
+								has_next_page
+							}
+						}
+					}
+				}
+			`,
+			operationName: "GetUser",
+			variables: gin.H{
+				"getUserReq": gin.H{
+					"id": user.ID,
+				},
+			},
+			mock: func(service *mocks.MockService) *responses.Products {
+				first := 5
+				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				getUserProductsArg := helpers.NewGetBatchUserProductsRequestTest([]int64{user.ID}, &first, nil)
+				products := helpers.NewProductsTest(5, user.ID)
+
+				service.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					Times(1).
+					Return(&user, nil)
+
+				service.EXPECT().
+					GetBatchUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
+					Times(1).
+					Return([]*responses.Products{products}, nil)
+
+				return products
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
+				helpers.GraphUserMatchTest(t, "data.GetUser", *rec.Body, user)
+				helpers.GraphProductsMatchTest(t, "data.GetUser.products", *rec.Body, expectedProducts)
+			},
+		},
+		{
+			name: "get user products backward",
+			query: `
+				query GetUser($getUserReq: UriID!, $before: String) {
+					GetUser(input: $getUserReq) {
+						id
+						products(input: {last: 3, before: $before}) {
+							edges {
+								cursor
+							}
+							page_info {
+								has_next_page
+								has_previous_page
+							}
+						}
+					}
+				}
+			`,
+			operationName: "GetUser",
+			variables: gin.H{
+				"getUserReq": gin.H{
+					"id": user.ID,
+				},
+				"before": "cursor",
+			},
+			mock: func(service *mocks.MockService) *responses.Products {
+				last, before := 3, "cursor"
+				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				getUserProductsArg := requests.GetBatchUserProductsRequest{UserIDs: []int64{user.ID}, Last: &last, Before: &before}
+				products := helpers.NewProductsTest(3, user.ID)
+
+				service.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					Times(1).
+					Return(&user, nil)
+
+				service.EXPECT().
+					GetBatchUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
+					Times(1).
+					Return([]*responses.Products{products}, nil)
+
+				return products
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
+				helpers.GraphProductsMatchTest(t, "data.GetUser.products", *rec.Body, expectedProducts)
+			},
+		},
+		{
+			name: "get user products invalid cursor",
+			query: `
+				query GetUser($getUserReq: UriID!) {
+					GetUser(input: $getUserReq) {
+						id
+						products(input: {first: 2, after: "forged"}) {
+							edges {
+								cursor
+							}
+						}
+					}
+				}
+			`,
+			operationName: "GetUser",
+			variables: gin.H{
+				"getUserReq": gin.H{
+					"id": user.ID,
+				},
+			},
+			mock: func(service *mocks.MockService) *responses.Products {
+				first, after := 2, "forged"
+				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				getUserProductsArg := helpers.NewGetBatchUserProductsRequestTest([]int64{user.ID}, &first, &after)
+
+				service.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					Times(1).
+					Return(&user, nil)
+
+				service.EXPECT().
+					GetBatchUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
+					Times(1).
+					Return(nil, services.BadRequestError("invalid cursor"))
+
+				return &responses.Products{}
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrBadRequest))
+			},
+		},
+		{
+			name: "get user products complexity limit more than 100",
+			query: `
+				query GetUser($getUserReq: UriID!) {
+					GetUser(input: $getUserReq) {
+						id
+						name
+						email
+						created_at
+						products(input: {first: 100}) {
+							edges {
+								cursor
+								node {
+								  id
+								  name
+								  price
+								  user_id
+								  created_at
+								}
+							}
+							page_info {
+								start_cursor
+								end_cursor
+								has_next_page
+							}
+						}
+					}
+				}
+			`,
+			operationName: "GetUser",
+			variables: gin.H{
+				"getUserReq": gin.H{
+					"id": user.ID,
+				},
+			},
+			mock: func(service *mocks.MockService) *responses.Products {
+				first := 10
+				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				getUserProductsArg := helpers.NewGetBatchUserProductsRequestTest([]int64{user.ID}, &first, nil)
+
+				service.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					Times(0)
+
+				service.EXPECT().
+					GetBatchUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
+					Times(0)
+
+				return &responses.Products{}
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
+				helpers.GraphExpectComplexityLimit(t, "errors.0.extensions.code", *rec.Body)
+			},
+		},
+		{
+			name: "user products child complexity limit more than 12",
+			query: `
+				query GetUser($getUserReq: UriID!) {
+					GetUser(input: $getUserReq) {
+						id
+						name
+						email
+						created_at
+						products(input: {first: 5}) {
+							edges {
+								cursor
+								node {
+									id
+									name
+									price
+									user_id
+									created_at
+									user {
+										name
+									}
+								}
+							}
+							page_info {
+								start_cursor
+								end_cursor
+								has_next_page
+							}
+						}
+					}
+				}
+			`,
+			operationName: "GetUser",
+			variables: gin.H{
+				"getUserReq": gin.H{
+					"id": user.ID,
+				},
+			},
+			mock: func(service *mocks.MockService) *responses.Products {
+				first := 5
+				getUserArg := helpers.NewBindUriIDRequestTest(user.ID)
+				getUserProductsArg := helpers.NewGetBatchUserProductsRequestTest([]int64{user.ID}, &first, nil)
+
+				service.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(getUserArg)).
+					Times(0)
+
+				service.EXPECT().
+					GetBatchUserProducts(gomock.Any(), gomock.Eq(getUserProductsArg)).
+					Times(0)
+
+				return &responses.Products{}
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder, expectedProducts responses.Products) {
+				helpers.GraphExpectComplexityLimit(t, "errors.0.extensions.code", *rec.Body)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			products := testCase.mock(service)
+
+			req := helpers.NewGraphQLRequestTest(testCase.operationName, testCase.query, testCase.variables)
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, *rec, *products)
+		})
+	}
+}
+
+func TestMutationUpdateProduct(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+	query := `
+		mutation UpdateProduct($input: UpdateProduct!) {
+			UpdateProduct(input: $input) {
+				id
+				name
+				price
+				user_id
+				version
+				updated_at
+			}
+		}
+	`
+
+	testCases := []struct {
+		name          string
+		variables     map[string]any
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec httptest.ResponseRecorder)
+	}{
+		{
+			name: "update product successfully",
+			variables: gin.H{
+				"input": gin.H{"id": product.ID, "name": product.Name, "price": product.Price.Amount},
+			},
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphProductMatchTest(t, "data.UpdateProduct", *rec.Body, product)
+			},
+		},
+		{
+			name: "expected version is passed to the service",
+			variables: gin.H{
+				"input": gin.H{"id": product.ID, "name": product.Name, "price": product.Price.Amount, "expectedVersion": 1},
+			},
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+				req.ExpectedVersion = &product.Version
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphProductMatchTest(t, "data.UpdateProduct", *rec.Body, product)
+			},
+		},
+		{
+			name: "precondition failed on a stale version",
+			variables: gin.H{
+				"input": gin.H{"id": product.ID, "name": product.Name, "price": product.Price.Amount, "expectedVersion": 1},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.PreconditionFailedError("product with id %d is at version 2, expected version 1", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrPreconditionFailed))
+			},
+		},
+		{
+			name: "product of another user",
+			variables: gin.H{
+				"input": gin.H{"id": product.ID, "name": product.Name, "price": product.Price.Amount},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ForbiddenError("cannot update product with id %d, it belongs to another user", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrForbidden))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			req := helpers.NewGraphQLRequestTest("UpdateProduct", query, testCase.variables)
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, *rec)
+		})
+	}
+}
+
+func TestMutationPatchProduct(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+	query := `
+		mutation PatchProduct($input: PatchProduct!) {
+			patchProduct(input: $input) {
+				id
+				name
+				price
+				user_id
+				version
+			}
+		}
+	`
+
+	testCases := []struct {
+		name          string
+		variables     map[string]any
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec httptest.ResponseRecorder)
+	}{
+		{
+			name: "only given fields are patched",
+			variables: gin.H{
+				"input": gin.H{"id": product.ID, "price": product.Price.Amount, "expectedVersion": 1},
+			},
+			mock: func(service *mocks.MockService) {
+				req := requests.PatchProductRequest{ID: product.ID, Price: &product.Price.Amount, ExpectedVersion: &product.Version}
+
+				service.EXPECT().
+					PatchProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphProductMatchTest(t, "data.patchProduct", *rec.Body, product)
+			},
+		},
+		{
+			name: "validation error",
+			variables: gin.H{
+				"input": gin.H{"id": product.ID, "name": ""},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					PatchProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ValidationError("name is required"))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrValidation))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			req := helpers.NewGraphQLRequestTest("PatchProduct", query, testCase.variables)
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, *rec)
+		})
+	}
+}
+
+func TestMutationBulkProducts(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+	query := `
+		mutation BulkProducts($create: [NewProduct!]!, $delete: BulkDeleteProducts!) {
+			bulkCreateProducts(input: $create) {
+				results {
+					index
+					product { name }
+					error { code message }
+				}
+				succeeded
+				failed
+				rolled_back
+			}
+			bulkDeleteProducts(input: $delete, atomic: false) {
+				results {
+					index
+					deleted { product_id permanent }
+				}
+				succeeded
+			}
+		}
+	`
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	createReq := requests.BulkCreateProductsRequest{
+		Products: []requests.CreateProductRequest{{UserID: user.ID, Name: product.Name, Price: product.Price.Amount}},
+		Atomic:   true,
+	}
+	service.EXPECT().
+		BulkCreateProducts(gomock.Any(), gomock.Eq(createReq)).
+		Times(1).
+		Return(&responses.BulkProductsResult{
+			Results: []*responses.BulkProductResult{
+				{Index: 0, Error: &responses.BulkError{Code: string(services.ErrForeignKeyViolation), Message: "user not found"}},
+			},
+			Failed:     1,
+			RolledBack: true,
+		}, nil)
+
+	deleteReq := requests.BulkDeleteProductsRequest{IDs: []int64{product.ID}, Permanent: true}
+	service.EXPECT().
+		BulkDeleteProducts(gomock.Any(), gomock.Eq(deleteReq)).
+		Times(1).
+		Return(&responses.BulkProductsResult{
+			Results: []*responses.BulkProductResult{
+				{Index: 0, Deleted: &responses.DeletedProduct{Deleted: true, Permanent: true, ProductID: product.ID}},
+			},
+			Succeeded: 1,
+		}, nil)
+
+	variables := gin.H{
+		"create": []gin.H{{"user_id": user.ID, "name": product.Name, "price": product.Price.Amount}},
+		"delete": gin.H{"ids": []int64{product.ID}, "permanent": true},
+	}
+	req := helpers.NewGraphQLRequestTest("BulkProducts", query, variables)
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	server := newGinTestServer(t, service)
+	rec := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+
+	server.Engine.ServeHTTP(rec, request)
+
+	created := helpers.GraphBulkResultTest(t, "data.bulkCreateProducts", *rec.Body)
+	require.True(t, created.RolledBack)
+	require.Equal(t, 1, created.Failed)
+	require.Nil(t, created.Results[0].Product)
+	require.Equal(t, string(services.ErrForeignKeyViolation), created.Results[0].Error.Code)
+
+	deleted := helpers.GraphBulkResultTest(t, "data.bulkDeleteProducts", *rec.Body)
+	require.Equal(t, 1, deleted.Succeeded)
+	require.Equal(t, product.ID, deleted.Results[0].Deleted.ProductID)
+	require.True(t, deleted.Results[0].Deleted.Permanent)
+}
+
+func TestMutationCreateUserEmailTaken(t *testing.T) {
+	query := `
+		mutation CreateUser($input: NewUser!) {
+			CreateUser(input: $input) {
+				id
+				email
+			}
+		}
+	`
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	req := requests.CreateUserRequest{Name: "royyan", Email: "ROY@gmail.com"}
+	service.EXPECT().
+		CreateUser(gomock.Any(), gomock.Eq(req)).
+		Times(1).
+		Return(nil, services.ConflictError("user with email %q already exists", req.Email))
+
+	data, err := json.Marshal(helpers.NewGraphQLRequestTest("CreateUser", query, gin.H{
+		"input": gin.H{"name": req.Name, "email": req.Email},
+	}))
+	require.NoError(t, err)
+
+	server := newGinTestServer(t, service)
+	rec := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	authenticateStaff(service, request)
+	request.Header.Set("Content-Type", "application/json")
+
+	server.Engine.ServeHTTP(rec, request)
+	helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrConflict))
+}
+
+func TestMutationDeleteUser(t *testing.T) {
+	user := helpers.NewUserTest()
+	heir := int64(2)
+	query := `
+		mutation DeleteUser($input: DeleteUser!) {
+			deleteUser(input: $input) {
+				deleted
+				user_id
+				policy
+				products
+				reassigned_to
+			}
+		}
+	`
+
+	testCases := []struct {
+		name          string
+		variables     map[string]any
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec httptest.ResponseRecorder)
+	}{
+		{
+			name: "products reassigned",
+			variables: gin.H{
+				"input": gin.H{"id": user.ID, "policy": "REASSIGN", "reassign_to": heir},
+			},
+			mock: func(service *mocks.MockService) {
+				req := requests.DeleteUserRequest{ID: user.ID, Policy: requests.UserDeleteReassign, ReassignTo: &heir}
+
+				service.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.DeletedUser{Deleted: true, UserID: user.ID, Policy: requests.UserDeleteReassign, Products: 2, ReassignedTo: &heir}, nil)
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				var deleted struct {
+					Deleted      bool   `json:"deleted"`
+					UserID       int64  `json:"user_id"`
+					Policy       string `json:"policy"`
+					Products     int64  `json:"products"`
+					ReassignedTo int64  `json:"reassigned_to"`
+				}
+				helpers.GraphDecodeTest(t, "data.deleteUser", *rec.Body, &deleted)
+
+				require.True(t, deleted.Deleted)
+				require.Equal(t, user.ID, deleted.UserID)
+				require.Equal(t, "REASSIGN", deleted.Policy)
+				require.Equal(t, int64(2), deleted.Products)
+				require.Equal(t, heir, deleted.ReassignedTo)
+			},
+		},
+		{
+			name: "user still owns products",
+			variables: gin.H{
+				"input": gin.H{"id": user.ID},
+			},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Eq(requests.DeleteUserRequest{ID: user.ID})).
+					Times(1).
+					Return(nil, services.ConflictError("user with id %d still owns 2 products", user.ID))
+			},
+			checkResponse: func(t *testing.T, rec httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrConflict))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			req := helpers.NewGraphQLRequestTest("DeleteUser", query, testCase.variables)
+			data, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, *rec)
+		})
+	}
+}
+
+func TestMutationSetExchangeRate(t *testing.T) {
+	query := `
+		mutation SetExchangeRate($input: SetExchangeRate!) {
+			setExchangeRate(input: $input) {
+				base
+				quote
+				rate
+			}
+		}
+	`
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	req := requests.SetExchangeRateRequest{Base: "USD", Quote: "EUR", Rate: "0.92"}
+	service.EXPECT().
+		SetExchangeRate(gomock.Any(), gomock.Eq(req)).
+		Times(1).
+		Return(&responses.ExchangeRate{Base: "USD", Quote: "EUR", Rate: "0.92", UpdatedAt: time.Now()}, nil)
+
+	data, err := json.Marshal(helpers.NewGraphQLRequestTest("SetExchangeRate", query, gin.H{
+		"input": gin.H{"base": req.Base, "quote": req.Quote, "rate": req.Rate},
+	}))
+	require.NoError(t, err)
+
+	server := newGinTestServer(t, service)
+	rec := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	authenticateStaff(service, request)
+	request.Header.Set("Content-Type", "application/json")
+
+	server.Engine.ServeHTTP(rec, request)
+
+	var rate responses.ExchangeRate
+	helpers.GraphDecodeTest(t, "data.setExchangeRate", *rec.Body, &rate)
+	require.Equal(t, "0.92", rate.Rate)
+}
+
+func TestMutationSetProductTags(t *testing.T) {
+	query := `
+		mutation SetProductTags($input: SetProductTags!) {
+			setProductTags(input: $input) {
+				id
+				name
+			}
+		}
+	`
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := mocks.NewMockService(ctrl)
+	req := requests.SetProductTagsRequest{ProductID: 1, Tags: []string{"Sale", "new"}}
+	service.EXPECT().
+		SetProductTags(gomock.Any(), gomock.Eq(req)).
+		Times(1).
+		Return([]*responses.Tag{{ID: 2, Name: "new"}, {ID: 1, Name: "sale"}}, nil)
+
+	data, err := json.Marshal(helpers.NewGraphQLRequestTest("SetProductTags", query, gin.H{
+		"input": gin.H{"product_id": req.ProductID, "tags": req.Tags},
+	}))
+	require.NoError(t, err)
+
+	server := newGinTestServer(t, service)
+	rec := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	authenticateStaff(service, request)
+	request.Header.Set("Content-Type", "application/json")
+
+	server.Engine.ServeHTTP(rec, request)
+
+	var tags []responses.Tag
+	helpers.GraphDecodeTest(t, "data.setProductTags", *rec.Body, &tags)
+	require.Len(t, tags, 2)
+	require.Equal(t, "sale", tags[1].Name)
+}
+
+func TestMutationUpdateOrderStatus(t *testing.T) {
+	query := `
+		mutation UpdateOrderStatus($input: UpdateOrderStatus!) {
+			updateOrderStatus(input: $input) {
+				id
+				status
+				total
+				items {
+					product_name
+					total
+				}
+			}
+		}
+	`
+
+	testCases := []struct {
+		name          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "order paid",
+			mock: func(service *mocks.MockService) {
+				req := requests.UpdateOrderStatusRequest{ID: 1, Status: requests.OrderPaid}
+				service.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Order{
+						ID:     1,
+						Status: requests.OrderPaid,
+						Total:  responses.Money{Amount: 200, Currency: "USD"},
+						Items: []*responses.OrderItem{{
+							ID:          1,
+							ProductName: "product",
+							UnitPrice:   responses.Money{Amount: 100, Currency: "USD"},
+							Quantity:    2,
+							Total:       responses.Money{Amount: 200, Currency: "USD"},
+						}},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var order struct {
+					Status string          `json:"status"`
+					Total  responses.Money `json:"total"`
+				}
+				helpers.GraphDecodeTest(t, "data.updateOrderStatus", *rec.Body, &order)
+				require.Equal(t, "PAID", order.Status)
+				require.Equal(t, int64(200), order.Total.Amount)
+			},
+		},
+		{
+			name: "transition not allowed",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("order with id 1 cannot move from shipped to paid"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrConflict))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			data, err := json.Marshal(helpers.NewGraphQLRequestTest("UpdateOrderStatus", query, gin.H{
+				"input": gin.H{"id": 1, "status": "PAID"},
+			}))
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestMutationStartPayment(t *testing.T) {
+	query := `
+		mutation StartPayment($input: StartPayment!) {
+			startPayment(input: $input) {
+				id
+				order_id
+				status
+				amount
+				client_secret
+			}
+		}
+	`
+
+	orderID := int64(1)
+	testCases := []struct {
+		name          string
+		anonymous     bool
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "payment started",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					StartPayment(gomock.Any(), gomock.Eq(requests.StartPaymentRequest{OrderID: &orderID})).
+					Times(1).
+					Return(&responses.Payment{
+						ID:           1,
+						OrderID:      &orderID,
+						Status:       responses.PaymentPending,
+						Amount:       responses.Money{Amount: 200, Currency: "USD"},
+						ClientSecret: "pi_fake_1_secret",
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var payment struct {
+					Status       string `json:"status"`
+					ClientSecret string `json:"client_secret"`
+				}
+				helpers.GraphDecodeTest(t, "data.startPayment", *rec.Body, &payment)
+				require.Equal(t, "PENDING", payment.Status)
+				require.Equal(t, "pi_fake_1_secret", payment.ClientSecret)
+			},
+		},
+		{
+			name: "order already paid",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					StartPayment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("order with id 1 is paid, only pending orders can be paid"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrConflict))
+			},
+		},
+		{
+			name:      "anonymous",
+			anonymous: true,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					StartPayment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrUnauthorized))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			data, err := json.Marshal(helpers.NewGraphQLRequestTest("StartPayment", query, gin.H{
+				"input": gin.H{"order_id": 1},
+			}))
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			if !testCase.anonymous {
+				authenticateCustomer(service, request)
+			}
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGraphAuthentication(t *testing.T) {
+	query := `
+		query GetProduct($getProductReq: UriID!) {
+			GetProduct(input: $getProductReq) {
+				id
+			}
+		}
+	`
+
+	user := &responses.User{ID: 1, Name: "royyan", Email: "royyan@gmail.com"}
+	testCases := []struct {
+		name          string
+		authorization string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "user reaches the service",
+			authorization: "Bearer access",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("access")).
+					Times(1).
+					Return(user, nil)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
+						got, ok := auth.UserFrom(ctx)
+						require.True(t, ok)
+						require.Equal(t, user.ID, got.ID)
+						return &responses.Product{ID: 1, UserID: user.ID}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.NotContains(t, rec.Body.String(), `"errors"`)
+			},
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer invalid",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("invalid")).
+					Times(1).
+					Return(nil, services.UnauthorizedError("invalid access token"))
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrUnauthorized))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			data, err := json.Marshal(helpers.NewGraphQLRequestTest("GetProduct", query, gin.H{
+				"getProductReq": gin.H{"id": 1},
+			}))
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", testCase.authorization)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGraphHasRole(t *testing.T) {
+	query := `
+		mutation AssignRole($input: UserRole!) {
+			assignRole(input: $input)
+		}
+	`
+
+	viewer := &responses.User{ID: 2, Roles: []requests.Role{requests.RoleViewer}}
+	testCases := []struct {
+		name          string
+		authorization string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "admins assign roles",
+			authorization: "Bearer staff",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("staff")).
+					Times(1).
+					Return(staff, nil)
+				req := requests.UserRoleRequest{UserID: 1, Role: requests.RoleEditor}
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return([]requests.Role{requests.RoleEditor}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var roles []string
+				helpers.GraphDecodeTest(t, "data.assignRole", *rec.Body, &roles)
+				require.Equal(t, []string{"EDITOR"}, roles)
+			},
+		},
+		{
+			name:          "viewers cannot assign roles",
+			authorization: "Bearer viewer",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("viewer")).
+					Times(1).
+					Return(viewer, nil)
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrForbidden))
+			},
+		},
+		{
+			name: "anonymous",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrUnauthorized))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			data, err := json.Marshal(helpers.NewGraphQLRequestTest("AssignRole", query, gin.H{
+				"input": gin.H{"user_id": 1, "role": "EDITOR"},
+			}))
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGraphHasPermission(t *testing.T) {
+	query := `
+		mutation AssignRole($input: UserRole!) {
+			assignRole(input: $input)
+		}
+	`
+
+	// support is an admin whose roles, in the role_permissions table, only
+	// grant reading users
+	support := &responses.User{
+		ID:          2,
+		Roles:       []requests.Role{requests.RoleAdmin},
+		Permissions: []string{auth.PermissionReadUsers},
+	}
+	testCases := []struct {
+		name          string
+		authorization string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "users with the permission assign roles",
+			authorization: "Bearer staff",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("staff")).
+					Times(1).
+					Return(staff, nil)
+				req := requests.UserRoleRequest{UserID: 1, Role: requests.RoleEditor}
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return([]requests.Role{requests.RoleEditor}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var roles []string
+				helpers.GraphDecodeTest(t, "data.assignRole", *rec.Body, &roles)
+				require.Equal(t, []string{"EDITOR"}, roles)
+			},
+		},
+		{
+			name:          "roles without the permission cannot assign roles",
+			authorization: "Bearer support",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("support")).
+					Times(1).
+					Return(support, nil)
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrForbidden))
+			},
+		},
+		{
+			name: "anonymous",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrUnauthorized))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			data, err := json.Marshal(helpers.NewGraphQLRequestTest("AssignRole", query, gin.H{
+				"input": gin.H{"user_id": 1, "role": "EDITOR"},
+			}))
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGraphHasScope(t *testing.T) {
+	key := &responses.APIKey{
+		ID:     1,
+		UserID: staff.ID,
+		Scopes: []requests.Scope{requests.ScopeProductsRead},
+	}
+
+	testCases := []struct {
+		name          string
+		operationName string
+		query         string
+		header        http.Header
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "read within scope",
+			operationName: "GetProduct",
+			query: `
+				query GetProduct($input: UriID!) {
+					GetProduct(input: $input) {
+						name
+					}
+				}
+			`,
+			header: http.Header{"X-Api-Key": {"sk_0123456789ab_secret"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Eq("sk_0123456789ab_secret")).
+					Times(1).
+					Return(staff, key, nil)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(&responses.Product{ID: 1, Name: "scoped"}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var product responses.Product
+				helpers.GraphDecodeTest(t, "data.GetProduct", *rec.Body, &product)
+				require.Equal(t, "scoped", product.Name)
+			},
+		},
+		{
+			name:          "write without scope",
+			operationName: "DeleteProduct",
+			query: `
+				mutation DeleteProduct($input: UriID!) {
+					DeleteProduct(input: $input) {
+						deleted
+					}
+				}
+			`,
+			header: http.Header{"Authorization": {"ApiKey sk_0123456789ab_secret"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Eq("sk_0123456789ab_secret")).
+					Times(1).
+					Return(staff, key, nil)
+				service.EXPECT().
+					DeleteProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrForbidden))
+			},
+		},
+		{
+			name:          "product node within scope",
+			operationName: "Node",
+			query: `
+				query Node {
+					node(id: "` + helpers.EncodeGlobalID("Product", 1) + `") {
+						id
+					}
+				}
+			`,
+			header: http.Header{"X-Api-Key": {"sk_0123456789ab_secret"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Eq("sk_0123456789ab_secret")).
+					Times(1).
+					Return(staff, key, nil)
+				service.EXPECT().
+					GetBatchProducts(gomock.Any(), gomock.Eq(requests.GetBatchProductsRequest{IDs: []int64{1}})).
+					Times(1).
+					Return([]*responses.Product{{ID: 1, Name: "scoped"}}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var node struct{ ID string }
+				helpers.GraphDecodeTest(t, "data.node", *rec.Body, &node)
+				require.Equal(t, helpers.EncodeGlobalID("Product", 1), node.ID)
+			},
+		},
+		{
+			name:          "user node without scope",
+			operationName: "Nodes",
+			query: `
+				query Nodes {
+					nodes(ids: ["` + helpers.EncodeGlobalID("Product", 1) + `", "` + helpers.EncodeGlobalID("User", 1) + `"]) {
+						id
+					}
+				}
+			`,
+			header: http.Header{"X-Api-Key": {"sk_0123456789ab_secret"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Eq("sk_0123456789ab_secret")).
+					Times(1).
+					Return(staff, key, nil)
+				// the product may already be batched when the user is refused
+				service.EXPECT().
+					GetBatchProducts(gomock.Any(), gomock.Any()).
+					AnyTimes().
+					Return([]*responses.Product{}, nil)
+				service.EXPECT().
+					GetBatchUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrForbidden))
+			},
+		},
+		{
+			name:          "invalid key",
+			operationName: "GetProduct",
+			query: `
+				query GetProduct($input: UriID!) {
+					GetProduct(input: $input) {
+						name
+					}
+				}
+			`,
+			header: http.Header{"X-Api-Key": {"sk_0123456789ab_guessed"}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AuthenticateAPIKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, nil, services.UnauthorizedError("invalid API key"))
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				helpers.GraphExpectErrorCode(t, "errors.0.extensions.code", *rec.Body, string(services.ErrUnauthorized))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			data, err := json.Marshal(helpers.NewGraphQLRequestTest(testCase.operationName, testCase.query, gin.H{
+				"input": gin.H{"id": 1},
+			}))
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/graph", bytes.NewBuffer(data))
+			require.NoError(t, err)
+			request.Header = testCase.header
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+// TestGraphScopedFields keeps API keys from reaching fields nobody gave a
+// scope: every query and mutation must be marked with @hasScope, except node
+// and nodes whose resolvers check the scope of each id.
+func TestGraphScopedFields(t *testing.T) {
+	schema := generated.NewExecutableSchema(graphconfig.GraphConfig(nil)).Schema()
+
+	for _, root := range []string{"Query", "Mutation"} {
+		for _, field := range schema.Types[root].Fields {
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			if root == "Query" && (field.Name == "node" || field.Name == "nodes") {
+				continue
+			}
+
+			require.NotNil(t, field.Directives.ForName("hasScope"), "%s.%s has no @hasScope", root, field.Name)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) GetStock(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	stock, err := gs.Service.GetStock(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"stock": stock,
+	}
+
+	resp := helpers.SuccessResponse("get stock successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) AdjustStock(c *gin.Context) {
+	var req requests.AdjustStockRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.ProductID = uri.ID
+	stock, err := gs.Service.AdjustStock(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"stock": stock,
+	}
+
+	resp := helpers.SuccessResponse("adjust stock successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) ListStockAdjustments(c *gin.Context) {
+	var req requests.ListStockAdjustmentsRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.ProductID = uri.ID
+	adjustments, err := gs.Service.ListStockAdjustments(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"adjustments": adjustments,
+	}
+
+	resp := helpers.SuccessResponse("list stock adjustments successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) CreateReservation(c *gin.Context) {
+	var req requests.CreateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	reservation, err := gs.Service.CreateReservation(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"reservation": reservation,
+	}
+
+	resp := helpers.SuccessResponse("reservation created successfully", data)
+	c.JSON(201, resp)
+}
+
+func (gs *GinServer) GetReservation(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	reservation, err := gs.Service.GetReservation(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"reservation": reservation,
+	}
+
+	resp := helpers.SuccessResponse("get reservation successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) ReleaseReservation(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	reservation, err := gs.Service.ReleaseReservation(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"reservation": reservation,
+	}
+
+	resp := helpers.SuccessResponse("release reservation successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) CommitReservation(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	reservation, err := gs.Service.CommitReservation(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"reservation": reservation,
+	}
+
+	resp := helpers.SuccessResponse("commit reservation successfully", data)
+	c.JSON(200, resp)
+}
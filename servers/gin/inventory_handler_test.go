@@ -0,0 +1,227 @@
+package ginserver
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustStock(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "stock adjusted successfully",
+			body: `{"delta":10,"reason":"received","note":"delivery"}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.AdjustStockRequest{ProductID: 1, Delta: 10, Reason: requests.StockReceived, Note: "delivery"}
+				service.EXPECT().
+					AdjustStock(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Stock{ProductID: 1, OnHand: 10, Available: 10}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"on_hand":10`)
+			},
+		},
+		{
+			name: "unknown reason",
+			body: `{"delta":10,"reason":"stolen"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AdjustStock(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "not enough stock",
+			body: `{"delta":-10,"reason":"lost"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AdjustStock(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("product with id 1 has 3 units available, 10 requested"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/products/1/stock/adjustments", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestCreateReservation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "reservation created successfully",
+			body: `{"product_id":1,"quantity":2}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.CreateReservationRequest{ProductID: 1, Quantity: 2}
+				service.EXPECT().
+					CreateReservation(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Reservation{
+						ID:        1,
+						ProductID: 1,
+						Quantity:  2,
+						Status:    responses.ReservationActive,
+						ExpiresAt: time.Now().Add(time.Minute),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Contains(t, rec.Body.String(), `"status":"active"`)
+			},
+		},
+		{
+			name: "quantity not given",
+			body: `{"product_id":1}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateReservation(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "not enough stock",
+			body: `{"product_id":1,"quantity":20}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateReservation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("product with id 1 has 3 units available, 20 requested"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/reservations", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateCustomer(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestCommitReservation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "reservation committed successfully",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CommitReservation(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(&responses.Reservation{ID: 1, ProductID: 1, Quantity: 2, Status: responses.ReservationCommitted}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"status":"committed"`)
+			},
+		},
+		{
+			name: "reservation already released",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CommitReservation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("reservation with id 1 is released"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			name: "reservation not found",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CommitReservation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NotFoundError("reservation with id 1 not found"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/reservations/1/commit", nil)
+			require.NoError(t, err)
+			authenticateCustomer(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
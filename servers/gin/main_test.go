@@ -1,16 +1,25 @@
 package ginserver
 
 import (
+	"io"
+	"net/http"
 	"os"
+	"sqlc-rest-api/auth"
 	"sqlc-rest-api/config"
 	graphconfig "sqlc-rest-api/graph/config"
 	"sqlc-rest-api/graph/generated"
+	"sqlc-rest-api/graph/loaders"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
 	"sqlc-rest-api/services"
 	"testing"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,6 +29,15 @@ func newGinTestServer(t *testing.T, service services.Service) *GinServer {
 	))
 
 	env := config.Environment{ComplexityLimit: 100}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	presenter := graphconfig.ErrorPresenter(logger)
+	graph.SetErrorPresenter(presenter)
+	graph.AroundOperations(graphconfig.AuthMiddleware(service, presenter))
+	graph.AroundOperations(loaders.Middleware(service, loaders.Config{
+		Wait:     env.DataloaderWait,
+		MaxBatch: env.DataloaderMaxBatch,
+	}))
 	graph.Use(extension.FixedComplexityLimit(env.ComplexityLimit))
 	server, err := NewGinServer(service, env, graph)
 	require.NoError(t, err)
@@ -31,3 +49,47 @@ func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
 }
+
+// staff is who tests of staff routes and fields are made by, an admin whose
+// roles grant every permission.
+var staff = &responses.User{
+	ID:    1000,
+	Name:  "staff",
+	Roles: []requests.Role{requests.RoleAdmin},
+	Permissions: []string{
+		auth.PermissionReadProducts,
+		auth.PermissionManageProducts,
+		auth.PermissionManageCatalog,
+		auth.PermissionReadInventory,
+		auth.PermissionManageInventory,
+		auth.PermissionReadOrders,
+		auth.PermissionManageOrders,
+		auth.PermissionReadPayments,
+		auth.PermissionManagePayments,
+		auth.PermissionReadUsers,
+		auth.PermissionManageUsers,
+		auth.PermissionManageRoles,
+	},
+}
+
+// authenticateStaff makes request as staff.
+func authenticateStaff(service *mocks.MockService, request *http.Request) {
+	request.Header.Set("Authorization", "Bearer staff")
+	service.EXPECT().
+		Authenticate(gomock.Any(), gomock.Eq("staff")).
+		AnyTimes().
+		Return(staff, nil)
+}
+
+// customer is who tests of routes open to every user are made by, a user
+// without roles.
+var customer = &responses.User{ID: 2000, Name: "customer"}
+
+// authenticateCustomer makes request as customer.
+func authenticateCustomer(service *mocks.MockService, request *http.Request) {
+	request.Header.Set("Authorization", "Bearer customer")
+	service.EXPECT().
+		Authenticate(gomock.Any(), gomock.Eq("customer")).
+		AnyTimes().
+		Return(customer, nil)
+}
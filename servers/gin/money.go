@@ -0,0 +1,51 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+// convertPrices converts the prices of products into the currency of the
+// currency query parameter, they are left alone when it is missing. It returns
+// the requested currency and false when a response was already written.
+func (gs *GinServer) convertPrices(c *gin.Context, products ...*responses.Product) (string, bool) {
+	var req requests.CurrencyRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return "", false
+	}
+
+	if req.Currency == "" {
+		return "", true
+	}
+
+	prices := make([]responses.Money, len(products))
+	for i, product := range products {
+		prices[i] = product.Price
+	}
+
+	converted, err := gs.Service.ConvertPrices(c, prices, req.Currency)
+	if err != nil {
+		serviceError(c, err)
+		return "", false
+	}
+
+	for i, product := range products {
+		product.Price = converted[i]
+	}
+
+	return req.Currency, true
+}
+
+func productNodes(products *responses.Products) []*responses.Product {
+	nodes := make([]*responses.Product, len(products.Edges))
+	for i, edge := range products.Edges {
+		nodes[i] = edge.Node
+	}
+
+	return nodes
+}
@@ -0,0 +1,120 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) CreateOrder(c *gin.Context) {
+	var req requests.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	order, err := gs.Service.CreateOrder(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"order": order,
+	}
+
+	resp := helpers.SuccessResponse("order created successfully", data)
+	c.JSON(201, resp)
+}
+
+func (gs *GinServer) GetOrder(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	order, err := gs.Service.GetOrder(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"order": order,
+	}
+
+	resp := helpers.SuccessResponse("get order successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) UpdateOrderStatus(c *gin.Context) {
+	var req requests.UpdateOrderStatusRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.ID = uri.ID
+	order, err := gs.Service.UpdateOrderStatus(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"order": order,
+	}
+
+	resp := helpers.SuccessResponse("update order status successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) GetUserOrders(c *gin.Context) {
+	var req requests.GetUserOrdersRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.UserID = uri.ID
+	orders, err := gs.Service.GetUserOrders(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"orders": orders,
+	}
+
+	resp := helpers.SuccessResponse("list user orders successfully", data)
+	c.JSON(200, resp)
+}
@@ -0,0 +1,365 @@
+package ginserver
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		anonymous     bool
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "anonymous",
+			body:      `{"user_id":1,"items":[{"product_id":2,"quantity":3}]}`,
+			anonymous: true,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name: "order created successfully",
+			body: `{"user_id":1,"items":[{"product_id":2,"quantity":3}]}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.CreateOrderRequest{
+					UserID: 1,
+					Items:  []requests.OrderItemRequest{{ProductID: 2, Quantity: 3}},
+				}
+				service.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Order{
+						ID:        1,
+						UserID:    1,
+						Status:    requests.OrderPending,
+						Total:     responses.Money{Amount: 300, Currency: "USD"},
+						Items:     []*responses.OrderItem{},
+						CreatedAt: time.Now(),
+						UpdatedAt: time.Now(),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Contains(t, rec.Body.String(), `"status":"pending"`)
+			},
+		},
+		{
+			name: "user not given",
+			body: `{"items":[{"product_id":2,"quantity":3}]}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.CreateOrderRequest{
+					Items: []requests.OrderItemRequest{{ProductID: 2, Quantity: 3}},
+				}
+				service.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Order{ID: 1, UserID: 1, Status: requests.OrderPending, Items: []*responses.OrderItem{}}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+			},
+		},
+		{
+			name: "order for another user",
+			body: `{"user_id":2,"items":[{"product_id":2,"quantity":3}]}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ForbiddenError("permission orders:manage required to order for user with id 2"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name: "items not given",
+			body: `{"user_id":1,"items":[]}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "quantity not given",
+			body: `{"user_id":1,"items":[{"product_id":2}]}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "product not found",
+			body: `{"user_id":1,"items":[{"product_id":99,"quantity":1}]}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NewError(services.ErrForeignKeyViolation, "product with id 99 not found"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/orders", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			if !testCase.anonymous {
+				authenticateCustomer(service, request)
+			}
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		anonymous     bool
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "owner gets the order",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetOrder(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(&responses.Order{ID: 1, UserID: customer.ID, Status: requests.OrderPending, Items: []*responses.OrderItem{}}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"user_id":2000`)
+			},
+		},
+		{
+			name: "order of another user",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetOrder(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(nil, services.ForbiddenError("cannot access order with id 1, it belongs to another user"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:      "anonymous",
+			anonymous: true,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetOrder(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/orders/1", nil)
+			require.NoError(t, err)
+			if !testCase.anonymous {
+				authenticateCustomer(service, request)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "order shipped successfully",
+			body: `{"status":"shipped"}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.UpdateOrderStatusRequest{ID: 1, Status: requests.OrderShipped}
+				service.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Order{ID: 1, Status: requests.OrderShipped, Items: []*responses.OrderItem{}}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"status":"shipped"`)
+			},
+		},
+		{
+			name: "unknown status",
+			body: `{"status":"lost"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "transition not allowed",
+			body: `{"status":"paid"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateOrderStatus(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("order with id 1 cannot move from cancelled to paid"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPut, "/orders/1/status", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGetUserOrders(t *testing.T) {
+	testCases := []struct {
+		name          string
+		url           string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "orders listed successfully",
+			url:  "/users/1/orders?first=2",
+			mock: func(service *mocks.MockService) {
+				first := 2
+				req := requests.GetUserOrdersRequest{UserID: 1, First: &first}
+				service.EXPECT().
+					GetUserOrders(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.Orders{
+						Edges:    []*responses.OrderEdge{{Cursor: "cursor", Node: &responses.Order{ID: 3, UserID: 1}}},
+						PageInfo: &responses.PageInfo{StartCursor: "cursor", EndCursor: "cursor"},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"end_cursor":"cursor"`)
+			},
+		},
+		{
+			name: "invalid page size",
+			url:  "/users/1/orders?first=0",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetUserOrders(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "user not found",
+			url:  "/users/99/orders",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetUserOrders(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NotFoundError("user with id 99 not found"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, testCase.url, nil)
+			require.NoError(t, err)
+			authenticateCustomer(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package ginserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxPatchBody caps the size of patch documents, they patch a single product
+// or user.
+const maxPatchBody = 1 << 20
+
+// bindPatch applies the patch document in the request body to current and
+// decodes the result into patched, which must pass its binding rules. It
+// writes the error response itself and reports whether the handler can go on.
+func bindPatch(c *gin.Context, current any, patched any) bool {
+	doc, err := json.Marshal(current)
+	if err != nil {
+		serviceError(c, err)
+		return false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchBody))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"message": fmt.Sprintf("patch documents are limited to %d bytes", tooLarge.Limit),
+		})
+		return false
+	}
+	if err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return false
+	}
+
+	var result []byte
+	switch c.ContentType() {
+	case helpers.MergePatchContentType:
+		result, err = helpers.ApplyMergePatch(doc, body)
+	case helpers.JSONPatchContentType:
+		result, err = helpers.ApplyJSONPatch(doc, body)
+	default:
+		c.Header("Accept-Patch", helpers.MergePatchContentType+", "+helpers.JSONPatchContentType)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"message": fmt.Sprintf("unsupported patch content type %q", c.ContentType()),
+		})
+		return false
+	}
+	if err != nil {
+		serviceError(c, patchError(err))
+		return false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(result))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return false
+	}
+
+	if err := binding.Validator.ValidateStruct(patched); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return false
+	}
+
+	return true
+}
+
+// patchError classifies the errors of applying a patch document the way RFC
+// 5789 suggests: malformed documents are bad requests, documents that don't
+// fit the resource are unprocessable and failed tests conflict with its state.
+func patchError(err error) error {
+	code := services.ErrBadRequest
+	switch {
+	case errors.Is(err, helpers.ErrPatchTestFailed):
+		code = services.ErrConflict
+	case errors.Is(err, helpers.ErrPatchPath):
+		code = services.ErrValidation
+	}
+
+	return &services.Error{Code: code, Message: err.Error(), Err: err}
+}
+
+// patchVersion returns the version a patch must be applied to. The document
+// was patched against the stored version, so that one is expected unless the
+// If-Match header asks for another, which fails right away.
+func patchVersion(c *gin.Context, resource string, id int64, version int64) (*int64, bool) {
+	expected, err := ifMatchVersion(c)
+	if err != nil {
+		serviceError(c, err)
+		return nil, false
+	}
+
+	if expected != nil && *expected != version {
+		serviceError(c, services.PreconditionFailedError("%s with id %d is at version %d, expected version %d", resource, id, version, *expected))
+		return nil, false
+	}
+
+	return &version, true
+}
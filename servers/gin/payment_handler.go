@@ -0,0 +1,150 @@
+package ginserver
+
+import (
+	"encoding/json"
+	"io"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBody caps the size of webhook deliveries, events are small.
+const maxWebhookBody = 64 << 10
+
+func (gs *GinServer) StartPayment(c *gin.Context) {
+	var req requests.StartPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	payment, err := gs.Service.StartPayment(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"payment": payment,
+	}
+
+	resp := helpers.SuccessResponse("payment started successfully", data)
+	c.JSON(201, resp)
+}
+
+func (gs *GinServer) GetPayment(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	payment, err := gs.Service.GetPayment(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"payment": payment,
+	}
+
+	resp := helpers.SuccessResponse("get payment successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) ConfirmPayment(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	payment, err := gs.Service.ConfirmPayment(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"payment": payment,
+	}
+
+	resp := helpers.SuccessResponse("payment confirmed successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) RefundPayment(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	payment, err := gs.Service.RefundPayment(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"payment": payment,
+	}
+
+	resp := helpers.SuccessResponse("payment refunded successfully", data)
+	c.JSON(200, resp)
+}
+
+// PaymentWebhook receives the events of the payment provider. Deliveries are
+// signed with Env.PaymentWebhookSecret, see payments.SignatureHeader, and may
+// be repeated: events that don't change the payment are acknowledged as well.
+func (gs *GinServer) PaymentWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBody))
+	if err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	secret := []byte(gs.Env.PaymentWebhookSecret)
+	header := c.GetHeader(payments.SignatureHeader)
+	if err := payments.Verify(secret, body, header, time.Now(), gs.Env.PaymentWebhookTolerance); err != nil {
+		c.JSON(401, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	var event payments.Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	payment, err := gs.Service.HandlePaymentEvent(c, event)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"payment": payment,
+	}
+
+	resp := helpers.SuccessResponse("payment event handled successfully", data)
+	c.JSON(200, resp)
+}
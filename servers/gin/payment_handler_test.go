@@ -0,0 +1,267 @@
+package ginserver
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartPayment(t *testing.T) {
+	orderID := int64(1)
+	testCases := []struct {
+		name          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "payment started successfully",
+			body: `{"order_id":1}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					StartPayment(gomock.Any(), gomock.Eq(requests.StartPaymentRequest{OrderID: &orderID})).
+					Times(1).
+					Return(&responses.Payment{
+						ID:           1,
+						OrderID:      &orderID,
+						Provider:     "fake",
+						IntentID:     "pi_fake_1",
+						Status:       responses.PaymentPending,
+						Amount:       responses.Money{Amount: 300, Currency: "USD"},
+						ClientSecret: "pi_fake_1_secret",
+						CreatedAt:    time.Now(),
+						UpdatedAt:    time.Now(),
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Contains(t, rec.Body.String(), `"client_secret":"pi_fake_1_secret"`)
+			},
+		},
+		{
+			name: "invalid order id",
+			body: `{"order_id":0}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					StartPayment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "provider failed",
+			body: `{"order_id":1}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					StartPayment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NewError(services.ErrPaymentProvider, "payment provider: unavailable"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadGateway, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/payments", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateCustomer(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestConfirmPayment(t *testing.T) {
+	testCases := []struct {
+		name          string
+		id            string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "payment confirmed successfully",
+			id:   "1",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ConfirmPayment(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return(&responses.Payment{ID: 1, Status: responses.PaymentSucceeded}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"status":"succeeded"`)
+			},
+		},
+		{
+			name: "payment failed already",
+			id:   "1",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ConfirmPayment(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("payment with id 1 is failed"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			name: "invalid id",
+			id:   "abc",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ConfirmPayment(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/payments/"+testCase.id+"/confirm", nil)
+			require.NoError(t, err)
+			authenticateCustomer(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	secret := []byte("whsec_test")
+	body := `{"id":"evt_1","intent_id":"pi_fake_1","status":"succeeded"}`
+	event := payments.Event{ID: "evt_1", IntentID: "pi_fake_1", Status: payments.StatusSucceeded}
+
+	testCases := []struct {
+		name          string
+		secret        string
+		signature     string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "event handled successfully",
+			secret:    string(secret),
+			signature: payments.Sign(secret, []byte(body), time.Now()),
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					HandlePaymentEvent(gomock.Any(), gomock.Eq(event)).
+					Times(1).
+					Return(&responses.Payment{ID: 1, Status: responses.PaymentSucceeded}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:      "signed with another secret",
+			secret:    string(secret),
+			signature: payments.Sign([]byte("whsec_other"), []byte(body), time.Now()),
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					HandlePaymentEvent(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name:      "replayed delivery",
+			secret:    string(secret),
+			signature: payments.Sign(secret, []byte(body), time.Now().Add(-time.Hour)),
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					HandlePaymentEvent(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name:      "no secret configured",
+			signature: payments.Sign(nil, []byte(body), time.Now()),
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					HandlePaymentEvent(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name:      "unknown intent",
+			secret:    string(secret),
+			signature: payments.Sign(secret, []byte(body), time.Now()),
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					HandlePaymentEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NotFoundError(`payment with intent id "pi_fake_1" not found`))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+			server.Env.PaymentWebhookSecret = testCase.secret
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/payments/webhook", bytes.NewBufferString(body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set(payments.SignatureHeader, testCase.signature)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
@@ -18,11 +18,10 @@ func (gs *GinServer) CreateProduct(c *gin.Context) {
 
 	product, err := gs.Service.CreateProduct(c, req)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		serviceError(c, err)
 		return
 	}
+	setETag(c, productETag(product))
 
 	data := gin.H{
 		"product": product,
@@ -33,7 +32,7 @@ func (gs *GinServer) CreateProduct(c *gin.Context) {
 }
 
 func (gs *GinServer) DeleteProduct(c *gin.Context) {
-	var req requests.BindUriID
+	var req requests.DeleteProductRequest
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(400, gin.H{
 			"message": err.Error(),
@@ -41,14 +40,19 @@ func (gs *GinServer) DeleteProduct(c *gin.Context) {
 		return
 	}
 
-	deletedProduct, err := gs.Service.DeleteProduct(c, req)
-	if err != nil {
-		c.JSON(500, gin.H{
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
 			"message": err.Error(),
 		})
 		return
 	}
 
+	deletedProduct, err := gs.Service.DeleteProduct(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
 	data := gin.H{
 		"product": deletedProduct,
 	}
@@ -57,7 +61,88 @@ func (gs *GinServer) DeleteProduct(c *gin.Context) {
 	c.JSON(200, resp)
 }
 
-func (gs *GinServer) GetProduct(c *gin.Context) {
+func (gs *GinServer) BulkCreateProducts(c *gin.Context) {
+	var opts requests.BulkOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	var req requests.BulkCreateProductsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+	req.Atomic = opts.Atomic
+
+	result, err := gs.Service.BulkCreateProducts(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	bulkResponse(c, 201, "products created successfully", result)
+}
+
+func (gs *GinServer) BulkPatchProducts(c *gin.Context) {
+	var opts requests.BulkOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	var req requests.BulkPatchProductsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+	req.Atomic = opts.Atomic
+
+	result, err := gs.Service.BulkPatchProducts(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	bulkResponse(c, 200, "patch products successfully", result)
+}
+
+func (gs *GinServer) BulkDeleteProducts(c *gin.Context) {
+	var opts requests.BulkOptions
+	if err := c.ShouldBindQuery(&opts); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	var req requests.BulkDeleteProductsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+	req.Atomic = opts.Atomic
+
+	result, err := gs.Service.BulkDeleteProducts(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	bulkResponse(c, 200, "products deleted successfully", result)
+}
+
+func (gs *GinServer) RestoreProduct(c *gin.Context) {
 	var req requests.BindUriID
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(400, gin.H{
@@ -66,14 +151,78 @@ func (gs *GinServer) GetProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := gs.Service.GetProduct(c, req)
+	product, err := gs.Service.RestoreProduct(c, req)
 	if err != nil {
-		c.JSON(500, gin.H{
+		serviceError(c, err)
+		return
+	}
+	setETag(c, productETag(product))
+
+	data := gin.H{
+		"product": product,
+	}
+
+	resp := helpers.SuccessResponse("restore product successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) ListDeletedProducts(c *gin.Context) {
+	var req requests.ListDeletedProductsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
 			"message": err.Error(),
 		})
 		return
 	}
 
+	list, err := gs.Service.ListDeletedProducts(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	if _, ok := gs.convertPrices(c, list.Products...); !ok {
+		return
+	}
+
+	data := gin.H{
+		"products":  list.Products,
+		"page_info": list.PageInfo,
+	}
+
+	resp := helpers.SuccessResponse("list deleted products successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) GetProduct(c *gin.Context) {
+	var req requests.BindUriID
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	product, err := gs.Service.GetProduct(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	currency, ok := gs.convertPrices(c, product)
+	if !ok {
+		return
+	}
+
+	// converted prices change with the exchange rates, the version does not
+	// identify them
+	if currency == "" {
+		if notModified(c, productETag(product)) {
+			return
+		}
+		setETag(c, productETag(product))
+	}
+
 	data := gin.H{
 		"product": product,
 	}
@@ -82,6 +231,61 @@ func (gs *GinServer) GetProduct(c *gin.Context) {
 	c.JSON(200, resp)
 }
 
+func (gs *GinServer) ListProducts(c *gin.Context) {
+	var req requests.ListProductsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	list, err := gs.Service.ListProducts(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	if _, ok := gs.convertPrices(c, list.Products...); !ok {
+		return
+	}
+
+	data := gin.H{
+		"products":  list.Products,
+		"page_info": list.PageInfo,
+	}
+
+	resp := helpers.SuccessResponse("list products successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) SearchProducts(c *gin.Context) {
+	var req requests.SearchProductsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	products, err := gs.Service.SearchProducts(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	if _, ok := gs.convertPrices(c, productNodes(products)...); !ok {
+		return
+	}
+
+	data := gin.H{
+		"products": products,
+	}
+
+	resp := helpers.SuccessResponse("search products successfully", data)
+	c.JSON(200, resp)
+}
+
 func (gs *GinServer) GetUserProducts(c *gin.Context) {
 	var req requests.GetUserProductsRequest
 	var uri requests.BindUriID
@@ -103,9 +307,11 @@ func (gs *GinServer) GetUserProducts(c *gin.Context) {
 	req.UserID = uri.ID
 	products, err := gs.Service.GetUserProducts(c, req)
 	if err != nil {
-		c.JSON(500, gin.H{
-			"message": err.Error(),
-		})
+		serviceError(c, err)
+		return
+	}
+
+	if _, ok := gs.convertPrices(c, productNodes(products)...); !ok {
 		return
 	}
 
@@ -135,19 +341,84 @@ func (gs *GinServer) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
 	req.ID = uri.ID
+	req.ExpectedVersion = expectedVersion
 	prod, err := gs.Service.UpdateProduct(c, req)
 	if err != nil {
-		c.JSON(500, gin.H{
+		serviceError(c, err)
+		return
+	}
+	setETag(c, productETag(prod))
+
+	data := gin.H{
+		"product": prod,
+	}
+
+	resp := helpers.SuccessResponse("update product successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) PatchProduct(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
 			"message": err.Error(),
 		})
 		return
 	}
 
+	product, err := gs.Service.GetProduct(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	version, ok := patchVersion(c, "product", product.ID, product.Version)
+	if !ok {
+		return
+	}
+
+	current := requests.ProductDocument{
+		Name:     product.Name,
+		Price:    product.Price.Amount,
+		Currency: product.Price.Currency,
+	}
+	var patched requests.ProductDocument
+	if !bindPatch(c, current, &patched) {
+		return
+	}
+
+	req := requests.PatchProductRequest{
+		ID:              product.ID,
+		ExpectedVersion: version,
+	}
+	if patched.Name != current.Name {
+		req.Name = &patched.Name
+	}
+	if patched.Price != current.Price {
+		req.Price = &patched.Price
+	}
+	if patched.Currency != current.Currency {
+		req.Currency = &patched.Currency
+	}
+
+	prod, err := gs.Service.PatchProduct(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	setETag(c, productETag(prod))
+
 	data := gin.H{
 		"product": prod,
 	}
 
-	resp := helpers.SuccessResponse("update product successfully", data)
+	resp := helpers.SuccessResponse("patch product successfully", data)
 	c.JSON(200, resp)
 }
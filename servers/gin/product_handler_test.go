@@ -8,10 +8,15 @@ import (
 	"net/http/httptest"
 	"sqlc-rest-api/helpers"
 	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"strings"
 	"testing"
+	"time"
 
 	"sqlc-rest-api/mocks"
 
+	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -54,6 +59,20 @@ func TestCreateProduct(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
+		{
+			name: "user does not exist",
+			req:  helpers.NewCreateProductRequestTest(&user, &product),
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewCreateProductRequestTest(&user, &product)
+				service.EXPECT().
+					CreateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, services.NewError(services.ErrForeignKeyViolation, "user with id %d not found", user.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
 		{
 			name: "internal server error",
 			req:  helpers.NewCreateProductRequestTest(&user, &product),
@@ -62,10 +81,12 @@ func TestCreateProduct(t *testing.T) {
 				service.EXPECT().
 					CreateProduct(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(nil, fmt.Errorf("internal server error"))
+					Return(nil, fmt.Errorf(`pq: relation "products" does not exist`))
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, rec.Code)
+				require.NotContains(t, rec.Body.String(), "products")
+				require.Contains(t, rec.Body.String(), services.InternalErrorMessage)
 			},
 		},
 	}
@@ -93,37 +114,96 @@ func TestCreateProduct(t *testing.T) {
 	}
 }
 
-func TestDeleteProduct(t *testing.T) {
+func TestBulkCreateProducts(t *testing.T) {
 	user := helpers.NewUserTest()
 	product := helpers.NewProductTest(user)
+	items := []requests.CreateProductRequest{
+		helpers.NewCreateProductRequestTest(&user, &product),
+		helpers.NewCreateProductRequestTest(&user, &product),
+	}
+
+	created := &responses.BulkProductsResult{
+		Results:   []*responses.BulkProductResult{{Index: 0, Product: &product}, {Index: 1, Product: &product}},
+		Succeeded: 2,
+	}
+	partial := &responses.BulkProductsResult{
+		Results: []*responses.BulkProductResult{
+			{Index: 0, Product: &product},
+			{Index: 1, Error: &responses.BulkError{Code: string(services.ErrForeignKeyViolation), Message: "user with id 1 not found"}},
+		},
+		Succeeded: 1,
+		Failed:    1,
+	}
+	rolledBack := &responses.BulkProductsResult{
+		Results:    []*responses.BulkProductResult{{Index: 0}, partial.Results[1]},
+		Failed:     1,
+		RolledBack: true,
+	}
 
 	testCases := []struct {
 		name          string
-		productID     int64
+		query         string
+		body          any
 		mock          func(service *mocks.MockService)
 		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
 	}{
 		{
-			name:      "product deleted successfully",
-			productID: product.ID,
+			name: "products created atomically by default",
+			body: gin.H{"products": items},
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(product.ID)
+				req := requests.BulkCreateProductsRequest{Products: items, Atomic: true}
 				service.EXPECT().
-					DeleteProduct(gomock.Any(), gomock.Eq(req)).
+					BulkCreateProducts(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(helpers.NewProductDeletedTest(product.ID), nil)
+					Return(created, nil)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, http.StatusCreated, rec.Code)
+				result := requireBulkResult(t, rec, true)
+				require.Equal(t, 2, result.Succeeded)
+				require.Equal(t, product.ID, result.Results[1].Product.ID)
 			},
 		},
 		{
-			name:      "validation error because given id lower than one",
-			productID: 0,
+			name:  "partial success",
+			query: "?atomic=false",
+			body:  gin.H{"products": items},
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(0)
+				req := requests.BulkCreateProductsRequest{Products: items}
 				service.EXPECT().
-					DeleteProduct(gomock.Any(), gomock.Eq(req)).
+					BulkCreateProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(partial, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusMultiStatus, rec.Code)
+				result := requireBulkResult(t, rec, false)
+				require.Equal(t, 1, result.Results[1].Index)
+				require.Equal(t, string(services.ErrForeignKeyViolation), result.Results[1].Error.Code)
+			},
+		},
+		{
+			name: "rolled back",
+			body: gin.H{"products": items},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					BulkCreateProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(rolledBack, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+				result := requireBulkResult(t, rec, false)
+				require.True(t, result.RolledBack)
+				require.Nil(t, result.Results[0].Product)
+			},
+		},
+		{
+			name: "no products given",
+			body: gin.H{"products": []requests.CreateProductRequest{}},
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					BulkCreateProducts(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -131,32 +211,29 @@ func TestDeleteProduct(t *testing.T) {
 			},
 		},
 		{
-			name:      "product not found",
-			productID: product.ID,
+			name:  "invalid atomic",
+			query: "?atomic=maybe",
+			body:  gin.H{"products": items},
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(product.ID)
 				service.EXPECT().
-					DeleteProduct(gomock.Any(), gomock.Eq(req)).
-					Times(1).
-					Return(nil, fmt.Errorf("product not found"))
+					BulkCreateProducts(gomock.Any(), gomock.Any()).
+					Times(0)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				// for now when product not found just return 500 error code
-				require.Equal(t, http.StatusInternalServerError, rec.Code)
+				require.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
 		{
-			name:      "internal server error",
-			productID: product.ID,
+			name: "too many products",
+			body: gin.H{"products": items},
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(product.ID)
 				service.EXPECT().
-					DeleteProduct(gomock.Any(), gomock.Eq(req)).
+					BulkCreateProducts(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil, fmt.Errorf("internal server error"))
+					Return(nil, services.ValidationError("at most %d items are allowed", services.MaxBulkItems))
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, rec.Code)
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 			},
 		},
 	}
@@ -167,13 +244,16 @@ func TestDeleteProduct(t *testing.T) {
 			defer ctrl.Finish()
 
 			service := mocks.NewMockService(ctrl)
-			server := newGinTestServer(t, service)
-
 			testCase.mock(service)
-			url := fmt.Sprintf("/products/%d", testCase.productID)
+
+			data, err := json.Marshal(testCase.body)
+			require.NoError(t, err)
+
+			server := newGinTestServer(t, service)
 			rec := httptest.NewRecorder()
-			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			request, err := http.NewRequest(http.MethodPost, "/products/bulk"+testCase.query, bytes.NewBuffer(data))
 			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
 
 			server.Engine.ServeHTTP(rec, request)
 			testCase.checkResponse(t, rec)
@@ -181,38 +261,45 @@ func TestDeleteProduct(t *testing.T) {
 	}
 }
 
-func TestGetProduct(t *testing.T) {
+func TestBulkPatchProducts(t *testing.T) {
 	user := helpers.NewUserTest()
 	product := helpers.NewProductTest(user)
+	name := "patched"
 
 	testCases := []struct {
 		name          string
-		productID     int64
+		body          string
 		mock          func(service *mocks.MockService)
 		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
 	}{
 		{
-			name:      "get product successfully",
-			productID: product.ID,
+			name: "products patched successfully",
+			body: fmt.Sprintf(`{"products":[{"id":%d,"name":"patched","expected_version":%d}]}`, product.ID, product.Version),
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(product.ID)
+				req := requests.BulkPatchProductsRequest{
+					Products: []requests.PatchProductRequest{{ID: product.ID, Name: &name, ExpectedVersion: &product.Version}},
+					Atomic:   true,
+				}
 				service.EXPECT().
-					GetProduct(gomock.Any(), gomock.Eq(req)).
+					BulkPatchProducts(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(&product, nil)
+					Return(&responses.BulkProductsResult{
+						Results:   []*responses.BulkProductResult{{Index: 0, Product: &product}},
+						Succeeded: 1,
+					}, nil)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, rec.Code)
-				helpers.RequireProductMatchTest(t, rec.Body, product)
+				result := requireBulkResult(t, rec, true)
+				require.Equal(t, product.ID, result.Results[0].Product.ID)
 			},
 		},
 		{
-			name:      "validation error because given id lower than one",
-			productID: 0,
+			name: "invalid body",
+			body: `{"products":[{"id":"one"}]}`,
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(0)
 				service.EXPECT().
-					GetProduct(gomock.Any(), gomock.Eq(req)).
+					BulkPatchProducts(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -220,32 +307,83 @@ func TestGetProduct(t *testing.T) {
 			},
 		},
 		{
-			name:      "product not found",
-			productID: product.ID,
+			name: "internal server error",
+			body: fmt.Sprintf(`{"products":[{"id":%d,"name":"patched"}]}`, product.ID),
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(product.ID)
 				service.EXPECT().
-					GetProduct(gomock.Any(), gomock.Eq(req)).
+					BulkPatchProducts(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(nil, fmt.Errorf("product not found"))
+					Return(nil, fmt.Errorf("internal server error"))
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				// for now when product not found just return 500 error code
 				require.Equal(t, http.StatusInternalServerError, rec.Code)
 			},
 		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			testCase.mock(service)
+
+			server := newGinTestServer(t, service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPatch, "/products/bulk", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestBulkDeleteProducts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
 		{
-			name:      "internal server error",
-			productID: product.ID,
+			name:  "products deleted permanently one by one",
+			query: "?atomic=false",
+			body:  `{"ids":[1,2],"permanent":true}`,
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewBindUriIDRequestTest(product.ID)
+				req := requests.BulkDeleteProductsRequest{IDs: []int64{1, 2}, Permanent: true}
 				service.EXPECT().
-					GetProduct(gomock.Any(), gomock.Eq(req)).
+					BulkDeleteProducts(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(nil, fmt.Errorf("internal server error"))
+					Return(&responses.BulkProductsResult{
+						Results: []*responses.BulkProductResult{
+							{Index: 0, Deleted: &responses.DeletedProduct{Deleted: true, Permanent: true, ProductID: 1}},
+							{Index: 1, Error: &responses.BulkError{Code: string(services.ErrNotFound), Message: "product with id 2 not found"}},
+						},
+						Succeeded: 1,
+						Failed:    1,
+					}, nil)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusInternalServerError, rec.Code)
+				require.Equal(t, http.StatusMultiStatus, rec.Code)
+				result := requireBulkResult(t, rec, false)
+				require.Equal(t, int64(1), result.Results[0].Deleted.ProductID)
+				require.Equal(t, string(services.ErrNotFound), result.Results[1].Error.Code)
+			},
+		},
+		{
+			name: "no ids given",
+			body: `{"ids":[]}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					BulkDeleteProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
 	}
@@ -256,13 +394,13 @@ func TestGetProduct(t *testing.T) {
 			defer ctrl.Finish()
 
 			service := mocks.NewMockService(ctrl)
-			server := newGinTestServer(t, service)
-
 			testCase.mock(service)
-			url := fmt.Sprintf("/products/%d", testCase.productID)
+
+			server := newGinTestServer(t, service)
 			rec := httptest.NewRecorder()
-			request, err := http.NewRequest(http.MethodGet, url, nil)
+			request, err := http.NewRequest(http.MethodDelete, "/products/bulk"+testCase.query, bytes.NewBufferString(testCase.body))
 			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
 
 			server.Engine.ServeHTTP(rec, request)
 			testCase.checkResponse(t, rec)
@@ -270,43 +408,67 @@ func TestGetProduct(t *testing.T) {
 	}
 }
 
-func TestUpdateProduct(t *testing.T) {
+func requireBulkResult(t *testing.T, rec *httptest.ResponseRecorder, success bool) *responses.BulkProductsResult {
+	var resp struct {
+		Success bool                          `json:"success"`
+		Data    *responses.BulkProductsResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, success, resp.Success)
+	require.NotNil(t, resp.Data)
+
+	return resp.Data
+}
+
+func TestDeleteProduct(t *testing.T) {
 	user := helpers.NewUserTest()
 	product := helpers.NewProductTest(user)
 
 	testCases := []struct {
 		name          string
 		productID     int64
-		req           requests.UpdateProductRequest
+		query         string
 		mock          func(service *mocks.MockService)
 		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
 	}{
 		{
-			name:      "product updated successfully",
+			name:      "product deleted successfully",
 			productID: product.ID,
-			req:       helpers.NewUpdateProductRequestTest(&product),
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewUpdateProductRequestTest(&product)
-
+				req := requests.DeleteProductRequest{ID: product.ID}
 				service.EXPECT().
-					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					DeleteProduct(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(&product, nil)
+					Return(helpers.NewProductDeletedTest(product.ID), nil)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, rec.Code)
-				helpers.RequireProductMatchTest(t, rec.Body, product)
 			},
 		},
 		{
-			name:      "validation error product id given lower than 1",
-			productID: 0,
-			req:       helpers.NewUpdateProductRequestTest(nil),
+			name:      "product deleted permanently",
+			productID: product.ID,
+			query:     "permanent=true",
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewUpdateProductRequestTest(nil)
-
+				req := requests.DeleteProductRequest{ID: product.ID, Permanent: true}
+				deleted := helpers.NewProductDeletedTest(product.ID)
+				deleted.Permanent = true
 				service.EXPECT().
-					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					DeleteProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(deleted, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:      "validation error because permanent is not a boolean",
+			productID: product.ID,
+			query:     "permanent=maybe",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					DeleteProduct(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
@@ -314,46 +476,53 @@ func TestUpdateProduct(t *testing.T) {
 			},
 		},
 		{
-			name:      "validation error product name not given",
-			productID: product.ID,
-			req:       helpers.NewUpdateProductRequestTest(nil),
+			name:      "validation error because given id lower than one",
+			productID: 0,
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewUpdateProductRequestTest(nil)
-
+				req := requests.DeleteProductRequest{ID: 0}
 				service.EXPECT().
-					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					DeleteProduct(gomock.Any(), gomock.Eq(req)).
 					Times(0)
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, rec.Code)
 			},
 		},
+		{
+			name:      "product of another user",
+			productID: product.ID,
+			mock: func(service *mocks.MockService) {
+				req := requests.DeleteProductRequest{ID: product.ID}
+				service.EXPECT().
+					DeleteProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, services.ForbiddenError("cannot delete product with id %d, it belongs to another user", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
 		{
 			name:      "product not found",
 			productID: product.ID,
-			req:       helpers.NewUpdateProductRequestTest(&product),
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewUpdateProductRequestTest(&product)
-
+				req := requests.DeleteProductRequest{ID: product.ID}
 				service.EXPECT().
-					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					DeleteProduct(gomock.Any(), gomock.Eq(req)).
 					Times(1).
-					Return(nil, fmt.Errorf("product not found"))
+					Return(nil, services.NotFoundError("product with id %d not found", product.ID))
 			},
 			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
-				// for now when error not found just return error code 500
-				require.Equal(t, http.StatusInternalServerError, rec.Code)
+				require.Equal(t, http.StatusNotFound, rec.Code)
 			},
 		},
 		{
 			name:      "internal server error",
 			productID: product.ID,
-			req:       helpers.NewUpdateProductRequestTest(&product),
 			mock: func(service *mocks.MockService) {
-				req := helpers.NewUpdateProductRequestTest(&product)
-
+				req := requests.DeleteProductRequest{ID: product.ID}
 				service.EXPECT().
-					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					DeleteProduct(gomock.Any(), gomock.Eq(req)).
 					Times(1).
 					Return(nil, fmt.Errorf("internal server error"))
 			},
@@ -372,14 +541,1179 @@ func TestUpdateProduct(t *testing.T) {
 			server := newGinTestServer(t, service)
 
 			testCase.mock(service)
-			url := fmt.Sprintf("/products/%d", testCase.productID)
+			url := fmt.Sprintf("/products/%d?%s", testCase.productID, testCase.query)
 			rec := httptest.NewRecorder()
-
-			data, err := json.Marshal(testCase.req)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
-			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(data))
-			request.Header.Set("Content-Type", "application/json")
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestRestoreProduct(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+
+	testCases := []struct {
+		name          string
+		productID     int64
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "product restored successfully",
+			productID: product.ID,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				helpers.RequireProductMatchTest(t, rec.Body, product)
+			},
+		},
+		{
+			name:      "validation error because given id lower than one",
+			productID: 0,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:      "product not in the trash",
+			productID: product.ID,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, services.NotFoundError("deleted product with id %d not found", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:      "internal server error",
+			productID: product.ID,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					RestoreProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, fmt.Errorf("internal server error"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/products/%d/restore", testCase.productID)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGetProduct(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+
+	testCases := []struct {
+		name          string
+		productID     int64
+		query         string
+		ifNoneMatch   string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "get product successfully",
+			productID: product.ID,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, `"1"`, rec.Header().Get("ETag"))
+				helpers.RequireProductMatchTest(t, rec.Body, product)
+			},
+		},
+		{
+			name:        "not modified when the etag matches",
+			productID:   product.ID,
+			ifNoneMatch: `W/"1"`,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotModified, rec.Code)
+				require.Equal(t, `"1"`, rec.Header().Get("ETag"))
+				require.Empty(t, rec.Body.Bytes())
+			},
+		},
+		{
+			name:        "stale etag gets the product",
+			productID:   product.ID,
+			ifNoneMatch: `"0"`,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				helpers.RequireProductMatchTest(t, rec.Body, product)
+			},
+		},
+		{
+			name:        "stock change invalidates the etag",
+			productID:   product.ID,
+			ifNoneMatch: `"1-10-0"`,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				returned := product
+				returned.Stock = &responses.Stock{ProductID: product.ID, OnHand: 10, Reserved: 2, Available: 8}
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&returned, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				// the version is the same, the reserved stock is not
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, `"1-10-2"`, rec.Header().Get("ETag"))
+			},
+		},
+		{
+			name:        "price converted into the requested currency",
+			productID:   product.ID,
+			query:       "currency=EUR",
+			ifNoneMatch: `"1"`,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				returned := product
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&returned, nil)
+
+				service.EXPECT().
+					ConvertPrices(gomock.Any(), gomock.Eq([]responses.Money{product.Price}), gomock.Eq("EUR")).
+					Times(1).
+					Return([]responses.Money{{Amount: 92, Currency: "EUR"}}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				// converted prices depend on the exchange rates, the etag
+				// does not apply
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Empty(t, rec.Header().Get("ETag"))
+
+				expected := product
+				expected.Price = responses.Money{Amount: 92, Currency: "EUR"}
+				helpers.RequireProductMatchTest(t, rec.Body, expected)
+			},
+		},
+		{
+			name:      "no exchange rate for the requested currency",
+			productID: product.ID,
+			query:     "currency=SEK",
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+
+				service.EXPECT().
+					ConvertPrices(gomock.Any(), gomock.Any(), gomock.Eq("SEK")).
+					Times(1).
+					Return(nil, services.ValidationError("no exchange rate from USD to SEK"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			name:      "validation error because given id lower than one",
+			productID: 0,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(0)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:      "product not found",
+			productID: product.ID,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, services.NotFoundError("product with id %d not found", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:      "internal server error",
+			productID: product.ID,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewBindUriIDRequestTest(product.ID)
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, fmt.Errorf("internal server error"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/products/%d?%s", testCase.productID, testCase.query)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			if testCase.ifNoneMatch != "" {
+				request.Header.Set("If-None-Match", testCase.ifNoneMatch)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestUpdateProduct(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+
+	testCases := []struct {
+		name          string
+		productID     int64
+		req           requests.UpdateProductRequest
+		ifMatch       string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "product updated successfully",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, `"1"`, rec.Header().Get("ETag"))
+				helpers.RequireProductMatchTest(t, rec.Body, product)
+			},
+		},
+		{
+			name:      "if-match is passed as the expected version",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			ifMatch:   `"1"`,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+				req.ExpectedVersion = &product.Version
+
+				updated := product
+				updated.Version++
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&updated, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, `"2"`, rec.Header().Get("ETag"))
+			},
+		},
+		{
+			name:      "stock part of the etag is ignored by if-match",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			ifMatch:   `"1-10-2"`,
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+				req.ExpectedVersion = &product.Version
+
+				updated := product
+				updated.Version++
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&updated, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:      "any version matches a wildcard if-match",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			ifMatch:   "*",
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:      "precondition failed on a stale version",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			ifMatch:   `"1"`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.PreconditionFailedError("product with id %d is at version 2, expected version 1", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			},
+		},
+		{
+			name:      "precondition failed on a weak if-match",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			ifMatch:   `W/"1"`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			},
+		},
+		{
+			name:      "precondition failed on an unknown etag",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			ifMatch:   `"abc"`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			},
+		},
+		{
+			name:      "validation error product id given lower than 1",
+			productID: 0,
+			req:       helpers.NewUpdateProductRequestTest(nil),
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(nil)
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:      "validation error product name not given",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(nil),
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(nil)
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:      "product not found",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, services.NotFoundError("product with id %d not found", product.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:      "internal server error",
+			productID: product.ID,
+			req:       helpers.NewUpdateProductRequestTest(&product),
+			mock: func(service *mocks.MockService) {
+				req := helpers.NewUpdateProductRequestTest(&product)
+
+				service.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(nil, fmt.Errorf("internal server error"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/products/%d", testCase.productID)
+			rec := httptest.NewRecorder()
+
+			data, err := json.Marshal(testCase.req)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(data))
+			request.Header.Set("Content-Type", "application/json")
+			require.NoError(t, err)
+			if testCase.ifMatch != "" {
+				request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestPatchProduct(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+
+	getProduct := func(service *mocks.MockService) {
+		service.EXPECT().
+			GetProduct(gomock.Any(), gomock.Eq(helpers.NewBindUriIDRequestTest(product.ID))).
+			Times(1).
+			Return(&product, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		productID     int64
+		contentType   string
+		ifMatch       string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "merge patch only sends changed fields",
+			productID:   product.ID,
+			contentType: helpers.MergePatchContentType,
+			body:        `{"name":"patched","price":100}`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+
+				name := "patched"
+				req := requests.PatchProductRequest{ID: product.ID, Name: &name, ExpectedVersion: &product.Version}
+				patched := product
+				patched.Name = name
+				patched.Version++
+				service.EXPECT().
+					PatchProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&patched, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, `"2"`, rec.Header().Get("ETag"))
+			},
+		},
+		{
+			name:        "json patch",
+			productID:   product.ID,
+			contentType: helpers.JSONPatchContentType,
+			ifMatch:     `"1"`,
+			body:        `[{"op":"test","path":"/name","value":"Test Product"},{"op":"replace","path":"/price","value":250}]`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+
+				price := int64(250)
+				req := requests.PatchProductRequest{ID: product.ID, Price: &price, ExpectedVersion: &product.Version}
+				service.EXPECT().
+					PatchProduct(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&product, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:        "json patch test failed",
+			productID:   product.ID,
+			contentType: helpers.JSONPatchContentType,
+			body:        `[{"op":"test","path":"/name","value":"Other"},{"op":"replace","path":"/price","value":250}]`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+		{
+			name:        "json patch path not found",
+			productID:   product.ID,
+			contentType: helpers.JSONPatchContentType,
+			body:        `[{"op":"remove","path":"/tags/0"}]`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			name:        "malformed patch document",
+			productID:   product.ID,
+			contentType: helpers.JSONPatchContentType,
+			body:        `{"op":"replace"}`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:        "patch document too large",
+			productID:   product.ID,
+			contentType: helpers.MergePatchContentType,
+			body:        `{"name":"` + strings.Repeat("a", maxPatchBody) + `"}`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+			},
+		},
+		{
+			name:        "validation error when the patch removes the name",
+			productID:   product.ID,
+			contentType: helpers.MergePatchContentType,
+			body:        `{"name":null}`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:        "validation error on unknown fields",
+			productID:   product.ID,
+			contentType: helpers.MergePatchContentType,
+			body:        `{"user_id":2}`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:        "unsupported content type",
+			productID:   product.ID,
+			contentType: "application/json",
+			body:        `{"name":"patched"}`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+				require.Contains(t, rec.Header().Get("Accept-Patch"), helpers.MergePatchContentType)
+			},
+		},
+		{
+			name:        "precondition failed on a stale if-match",
+			productID:   product.ID,
+			contentType: helpers.MergePatchContentType,
+			ifMatch:     `"0"`,
+			body:        `{"name":"patched"}`,
+			mock: func(service *mocks.MockService) {
+				getProduct(service)
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			},
+		},
+		{
+			name:        "product not found",
+			productID:   product.ID,
+			contentType: helpers.MergePatchContentType,
+			body:        `{"name":"patched"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NotFoundError("product with id %d not found", product.ID))
+				service.EXPECT().PatchProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:        "validation error because given id lower than one",
+			productID:   0,
+			contentType: helpers.MergePatchContentType,
+			body:        `{"name":"patched"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/products/%d", testCase.productID)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", testCase.contentType)
+			if testCase.ifMatch != "" {
+				request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestListProducts(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+	list := helpers.ProductListResponse([]*responses.Product{&product}, 5, 0, 1)
+
+	testCases := []struct {
+		name          string
+		query         string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "list products successfully",
+			query: "user_id=1&name=Test&order_by=price&direction=desc&limit=5",
+			mock: func(service *mocks.MockService) {
+				name := "Test"
+				req := requests.ListProductsRequest{
+					Filter: requests.ProductFilter{UserID: &user.ID, Name: &name},
+					Order:  requests.ProductOrder{Field: requests.ProductOrderPrice, Direction: requests.OrderDesc},
+					Limit:  5,
+				}
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(list, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				helpers.RequireProductListMatchTest(t, rec.Body, *list)
+			},
+		},
+		{
+			name:  "defaults applied",
+			query: "",
+			mock: func(service *mocks.MockService) {
+				req := requests.ListProductsRequest{
+					Order: requests.ProductOrder{Field: requests.ProductOrderID, Direction: requests.OrderAsc},
+					Limit: 10,
+				}
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(list, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "validation error unknown order field",
+			query: "order_by=user_id",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "validation error limit too large",
+			query: "limit=101",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "invalid price range",
+			query: "min_price=200&max_price=100",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ValidationError("min_price must not be greater than max_price"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+		{
+			name:  "internal server error",
+			query: "",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("internal server error"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/products?"+testCase.query, nil)
+			require.NoError(t, err)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestListDeletedProducts(t *testing.T) {
+	user := helpers.NewUserTest()
+	product := helpers.NewProductTest(user)
+	deletedAt := time.Now()
+	product.DeletedAt = &deletedAt
+	list := helpers.ProductListResponse([]*responses.Product{&product}, 5, 0, 1)
+
+	testCases := []struct {
+		name          string
+		query         string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "list deleted products successfully",
+			query: "user_id=1&limit=5",
+			mock: func(service *mocks.MockService) {
+				req := requests.ListDeletedProductsRequest{UserID: &user.ID, Limit: 5}
+				service.EXPECT().
+					ListDeletedProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(list, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				helpers.RequireProductListMatchTest(t, rec.Body, *list)
+			},
+		},
+		{
+			name:  "defaults applied",
+			query: "",
+			mock: func(service *mocks.MockService) {
+				req := requests.ListDeletedProductsRequest{Limit: 10}
+				service.EXPECT().
+					ListDeletedProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(list, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "validation error limit too large",
+			query: "limit=101",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListDeletedProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "internal server error",
+			query: "",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListDeletedProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("internal server error"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/products/trash?"+testCase.query, nil)
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	user := helpers.NewUserTest()
+	results := helpers.NewSearchProductsTest(2, user.ID)
+
+	testCases := []struct {
+		name          string
+		query         string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "search products successfully",
+			query: "q=product&first=2&after=cursor",
+			mock: func(service *mocks.MockService) {
+				first, after := 2, "cursor"
+				req := requests.SearchProductsRequest{Query: "product", First: &first, After: &after}
+				service.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(results, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				helpers.RequireSearchProductsMatchTest(t, "data.products", *rec.Body, *results)
+			},
+		},
+		{
+			name:  "validation error missing query",
+			query: "first=2",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "invalid cursor",
+			query: "q=product&after=forged",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.BadRequestError("invalid cursor"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "internal server error",
+			query: "q=product",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					SearchProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("internal server error"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/products/search?"+testCase.query, nil)
+			require.NoError(t, err)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestGetUserProducts(t *testing.T) {
+	user := helpers.NewUserTest()
+	products := helpers.NewProductsTest(2, user.ID)
+
+	testCases := []struct {
+		name          string
+		query         string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "get user products successfully",
+			query: "first=2&after=cursor",
+			mock: func(service *mocks.MockService) {
+				first, after := 2, "cursor"
+				req := helpers.NewGetUserProductsRequestTest(user.ID, &first, &after)
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(products, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "get user products backward",
+			query: "last=2&before=cursor",
+			mock: func(service *mocks.MockService) {
+				last, before := 2, "cursor"
+				req := requests.GetUserProductsRequest{UserID: user.ID, Last: &last, Before: &before}
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(products, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "get user products filtered",
+			query: "first=2&category_id=3&tag=sale",
+			mock: func(service *mocks.MockService) {
+				first, categoryID, tag := 2, int64(3), "sale"
+				req := requests.GetUserProductsRequest{UserID: user.ID, First: &first, CategoryID: &categoryID, Tag: &tag}
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(products, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "validation error category_id lower than one",
+			query: "category_id=0",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "prices converted together",
+			query: "first=2&currency=JPY",
+			mock: func(service *mocks.MockService) {
+				first := 2
+				req := helpers.NewGetUserProductsRequestTest(user.ID, &first, nil)
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(helpers.NewProductsTest(2, user.ID), nil)
+
+				prices := []responses.Money{products.Edges[0].Node.Price, products.Edges[1].Node.Price}
+				service.EXPECT().
+					ConvertPrices(gomock.Any(), gomock.Eq(prices), gomock.Eq("JPY")).
+					Times(1).
+					Return([]responses.Money{{Amount: 150, Currency: "JPY"}, {Amount: 150, Currency: "JPY"}}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"price":{"amount":150,"currency":"JPY"}`)
+			},
+		},
+		{
+			name:  "validation error first lower than one",
+			query: "first=0",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "invalid cursor",
+			query: "after=forged",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.BadRequestError("invalid cursor"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "first combined with last",
+			query: "first=2&last=2",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					GetUserProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ValidationError("first and after cannot be combined with last and before"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/user/%d/products?%s", user.ID, testCase.query)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
 			server.Engine.ServeHTTP(rec, request)
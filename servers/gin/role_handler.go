@@ -0,0 +1,95 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) ListRoles(c *gin.Context) {
+	roles, err := gs.Service.ListRoles(c)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"roles": roles,
+	}
+
+	resp := helpers.SuccessResponse("list roles successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) GetUserRoles(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	roles, err := gs.Service.GetUserRoles(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"user_id": uri.ID,
+		"roles":   roles,
+	}
+
+	resp := helpers.SuccessResponse("get user roles successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) AssignRole(c *gin.Context) {
+	var req requests.UserRoleRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	roles, err := gs.Service.AssignRole(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"user_id": req.UserID,
+		"roles":   roles,
+	}
+
+	resp := helpers.SuccessResponse("role assigned successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) RevokeRole(c *gin.Context) {
+	var req requests.UserRoleRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	roles, err := gs.Service.RevokeRole(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"user_id": req.UserID,
+		"roles":   roles,
+	}
+
+	resp := helpers.SuccessResponse("role revoked successfully", data)
+	c.JSON(200, resp)
+}
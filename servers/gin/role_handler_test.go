@@ -0,0 +1,226 @@
+package ginserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignRole(t *testing.T) {
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "role assigned successfully",
+			method: http.MethodPut,
+			path:   "/users/1/roles/editor",
+			mock: func(service *mocks.MockService) {
+				req := requests.UserRoleRequest{UserID: 1, Role: requests.RoleEditor}
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return([]requests.Role{requests.RoleViewer, requests.RoleEditor}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"roles":["viewer","editor"]`)
+			},
+		},
+		{
+			name:   "unknown role",
+			method: http.MethodPut,
+			path:   "/users/1/roles/owner",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:   "user not found",
+			method: http.MethodPut,
+			path:   "/users/99/roles/viewer",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.NotFoundError("user with id 99 not found"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, rec.Code)
+			},
+		},
+		{
+			name:   "role revoked successfully",
+			method: http.MethodDelete,
+			path:   "/users/1/roles/editor",
+			mock: func(service *mocks.MockService) {
+				req := requests.UserRoleRequest{UserID: 1, Role: requests.RoleEditor}
+				service.EXPECT().
+					RevokeRole(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return([]requests.Role{}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"roles":[]`)
+			},
+		},
+		{
+			name:   "last admin",
+			method: http.MethodDelete,
+			path:   "/users/1/roles/admin",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					RevokeRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("cannot revoke the admin role of the last admin"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(testCase.method, testCase.path, nil)
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	viewer := &responses.User{
+		ID:          2,
+		Roles:       []requests.Role{requests.RoleViewer},
+		Permissions: []string{auth.PermissionReadProducts, auth.PermissionReadInventory, auth.PermissionReadOrders, auth.PermissionReadPayments, auth.PermissionReadUsers},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "anonymous",
+			method: http.MethodGet,
+			path:   "/roles",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListRoles(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+			},
+		},
+		{
+			name:          "viewers read",
+			method:        http.MethodGet,
+			path:          "/users/1/roles",
+			authorization: "Bearer viewer",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("viewer")).
+					Times(1).
+					Return(viewer, nil)
+				service.EXPECT().
+					GetUserRoles(gomock.Any(), gomock.Eq(requests.BindUriID{ID: 1})).
+					Times(1).
+					Return([]requests.Role{requests.RoleEditor}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Contains(t, rec.Body.String(), `"roles":["editor"]`)
+			},
+		},
+		{
+			name:          "viewers cannot change the catalog",
+			method:        http.MethodDelete,
+			path:          "/categories/1",
+			authorization: "Bearer viewer",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("viewer")).
+					Times(1).
+					Return(viewer, nil)
+				service.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+		{
+			name:          "viewers cannot assign roles",
+			method:        http.MethodPut,
+			path:          "/users/2/roles/admin",
+			authorization: "Bearer viewer",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					Authenticate(gomock.Any(), gomock.Eq("viewer")).
+					Times(1).
+					Return(viewer, nil)
+				service.EXPECT().
+					AssignRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(testCase.method, testCase.path, nil)
+			require.NoError(t, err)
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
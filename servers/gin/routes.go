@@ -1,19 +1,109 @@
 package ginserver
 
 import (
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/requests"
+
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gin-gonic/gin"
 )
 
 func (gs *GinServer) setupRoutes() {
-	gs.Engine.POST("/products", gs.CreateProduct)
-	gs.Engine.DELETE("/products/:id", gs.DeleteProduct)
-	gs.Engine.GET("/products/:id", gs.GetProduct)
-	gs.Engine.PUT("/products/:id", gs.UpdateProduct)
-
-	gs.Engine.POST("/users", gs.CreateUser)
-	gs.Engine.GET("/users/:id", gs.GetUser)
-	gs.Engine.GET("/user/:id/products", gs.GetUserProducts)
+	// /graph authenticates with graphconfig.AuthMiddleware instead, so
+	// GraphQL clients get GraphQL errors
+	api := gs.Engine.Group("/", gs.authenticate)
+
+	// API keys are limited to the scopes they were created with, requests
+	// made without one have every scope. Orders, reservations and payments
+	// belong to users, anonymous requests cannot reach them.
+	products := api.Group("/", gs.requireScope(requests.ScopeProductsRead, requests.ScopeProductsWrite))
+	orders := api.Group("/", gs.requireUser, gs.requireScope(requests.ScopeOrdersRead, requests.ScopeOrdersWrite))
+	users := api.Group("/", gs.requireScope(requests.ScopeUsersRead, requests.ScopeUsersWrite))
+
+	// staff routes are grouped by the permission their roles must grant
+	readProducts := products.Group("/", gs.requirePermission(auth.PermissionReadProducts))
+	manageCatalog := products.Group("/", gs.requirePermission(auth.PermissionManageCatalog))
+	readInventory := products.Group("/", gs.requirePermission(auth.PermissionReadInventory))
+	manageInventory := products.Group("/", gs.requirePermission(auth.PermissionManageInventory))
+	manageOrders := orders.Group("/", gs.requirePermission(auth.PermissionManageOrders))
+	managePayments := orders.Group("/", gs.requirePermission(auth.PermissionManagePayments))
+	readUsers := users.Group("/", gs.requirePermission(auth.PermissionReadUsers))
+	manageUsers := users.Group("/", gs.requirePermission(auth.PermissionManageUsers))
+	manageRoles := users.Group("/", gs.requirePermission(auth.PermissionManageRoles))
+
+	api.POST("/auth/register", gs.Register)
+	api.POST("/auth/login", gs.Login)
+	api.POST("/auth/refresh", gs.RefreshSession)
+	api.POST("/auth/logout", gs.Logout)
+	api.GET("/auth/me", gs.requireUser, gs.Me)
+
+	// API keys manage keys of the caller, the service refuses requests made
+	// with a key
+	api.GET("/api-keys", gs.requireUser, gs.ListAPIKeys)
+	api.POST("/api-keys", gs.requireUser, gs.CreateAPIKey)
+	api.GET("/api-keys/:id", gs.requireUser, gs.GetAPIKey)
+	api.PUT("/api-keys/:id", gs.requireUser, gs.UpdateAPIKey)
+	api.DELETE("/api-keys/:id", gs.requireUser, gs.DeleteAPIKey)
+
+	products.GET("/products", gs.ListProducts)
+	products.POST("/products", gs.CreateProduct)
+	products.GET("/products/search", gs.SearchProducts)
+	readProducts.GET("/products/trash", gs.ListDeletedProducts)
+	products.POST("/products/bulk", gs.BulkCreateProducts)
+	products.PATCH("/products/bulk", gs.BulkPatchProducts)
+	products.DELETE("/products/bulk", gs.BulkDeleteProducts)
+	products.DELETE("/products/:id", gs.DeleteProduct)
+	products.GET("/products/:id", gs.GetProduct)
+	products.PUT("/products/:id", gs.UpdateProduct)
+	products.PATCH("/products/:id", gs.PatchProduct)
+	products.POST("/products/:id/restore", gs.RestoreProduct)
+	manageCatalog.PUT("/products/:id/categories", gs.SetProductCategories)
+	manageCatalog.PUT("/products/:id/tags", gs.SetProductTags)
+	products.GET("/products/:id/stock", gs.GetStock)
+	readInventory.GET("/products/:id/stock/adjustments", gs.ListStockAdjustments)
+	manageInventory.POST("/products/:id/stock/adjustments", gs.AdjustStock)
+
+	orders.POST("/reservations", gs.CreateReservation)
+	orders.GET("/reservations/:id", gs.GetReservation)
+	orders.POST("/reservations/:id/release", gs.ReleaseReservation)
+	orders.POST("/reservations/:id/commit", gs.CommitReservation)
+
+	orders.POST("/orders", gs.CreateOrder)
+	orders.GET("/orders/:id", gs.GetOrder)
+	manageOrders.PUT("/orders/:id/status", gs.UpdateOrderStatus)
+
+	orders.POST("/payments", gs.StartPayment)
+	api.POST("/payments/webhook", gs.PaymentWebhook)
+	orders.GET("/payments/:id", gs.GetPayment)
+	orders.POST("/payments/:id/confirm", gs.ConfirmPayment)
+	managePayments.POST("/payments/:id/refund", gs.RefundPayment)
+
+	readUsers.GET("/users", gs.ListUsers)
+	manageUsers.POST("/users", gs.CreateUser)
+	users.GET("/users/:id", gs.GetUser)
+	manageUsers.PUT("/users/:id", gs.UpdateUser)
+	manageUsers.PATCH("/users/:id", gs.PatchUser)
+	manageUsers.DELETE("/users/:id", gs.DeleteUser)
+	orders.GET("/users/:id/orders", gs.GetUserOrders)
+	products.GET("/user/:id/products", gs.GetUserProducts)
+
+	readUsers.GET("/roles", gs.ListRoles)
+	readUsers.GET("/users/:id/roles", gs.GetUserRoles)
+	manageRoles.PUT("/users/:id/roles/:role", gs.AssignRole)
+	manageRoles.DELETE("/users/:id/roles/:role", gs.RevokeRole)
+
+	products.GET("/categories", gs.ListCategories)
+	manageCatalog.POST("/categories", gs.CreateCategory)
+	products.GET("/categories/:id", gs.GetCategory)
+	manageCatalog.PUT("/categories/:id", gs.UpdateCategory)
+	manageCatalog.DELETE("/categories/:id", gs.DeleteCategory)
+
+	products.GET("/tags", gs.ListTags)
+	manageCatalog.POST("/tags", gs.CreateTag)
+	manageCatalog.DELETE("/tags/:id", gs.DeleteTag)
+
+	products.GET("/exchange-rates", gs.ListExchangeRates)
+	manageCatalog.PUT("/exchange-rates/:base/:quote", gs.SetExchangeRate)
 
 	gs.Engine.GET("/playground", gs.graphPlayground())
 	gs.Engine.POST("/graph", gs.graphQuery())
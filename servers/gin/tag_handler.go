@@ -0,0 +1,101 @@
+package ginserver
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (gs *GinServer) CreateTag(c *gin.Context) {
+	var req requests.CreateTagRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	tag, err := gs.Service.CreateTag(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"tag": tag,
+	}
+
+	resp := helpers.SuccessResponse("tag created successfully", data)
+	c.JSON(201, resp)
+}
+
+func (gs *GinServer) ListTags(c *gin.Context) {
+	tags, err := gs.Service.ListTags(c)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"tags": tags,
+	}
+
+	resp := helpers.SuccessResponse("list tags successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) DeleteTag(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := gs.Service.DeleteTag(c, uri); err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"tag_id": uri.ID,
+	}
+
+	resp := helpers.SuccessResponse("tag deleted successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) SetProductTags(c *gin.Context) {
+	var req requests.SetProductTagsRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	req.ProductID = uri.ID
+	tags, err := gs.Service.SetProductTags(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"tags": tags,
+	}
+
+	resp := helpers.SuccessResponse("set product tags successfully", data)
+	c.JSON(200, resp)
+}
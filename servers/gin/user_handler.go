@@ -18,11 +18,10 @@ func (gs *GinServer) CreateUser(c *gin.Context) {
 
 	user, err := gs.Service.CreateUser(c, req)
 	if err != nil {
-		c.JSON(400, gin.H{
-			"message": err.Error(),
-		})
+		serviceError(c, err)
 		return
 	}
+	setETag(c, etag(user.Version))
 
 	data := gin.H{
 		"user": user,
@@ -43,16 +42,165 @@ func (gs *GinServer) GetUser(c *gin.Context) {
 
 	user, err := gs.Service.GetUser(c, uri)
 	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	if notModified(c, etag(user.Version)) {
+		return
+	}
+	setETag(c, etag(user.Version))
+
+	data := gin.H{
+		"user": user,
+	}
+
+	resp := helpers.SuccessResponse("get user successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) ListUsers(c *gin.Context) {
+	var req requests.ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	users, err := gs.Service.ListUsers(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"users": users,
+	}
+
+	resp := helpers.SuccessResponse("list users successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) UpdateUser(c *gin.Context) {
+	var req requests.UpdateUserRequest
+	var uri requests.BindUriID
+
+	if err := c.ShouldBindUri(&uri); err != nil {
 		c.JSON(400, gin.H{
 			"message": err.Error(),
 		})
 		return
 	}
 
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	req.ID = uri.ID
+	req.ExpectedVersion = expectedVersion
+	user, err := gs.Service.UpdateUser(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	setETag(c, etag(user.Version))
+
 	data := gin.H{
 		"user": user,
 	}
 
-	resp := helpers.SuccessResponse("get user successfully", data)
+	resp := helpers.SuccessResponse("update user successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) DeleteUser(c *gin.Context) {
+	var req requests.DeleteUserRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	deletedUser, err := gs.Service.DeleteUser(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"user": deletedUser,
+	}
+
+	resp := helpers.SuccessResponse("user deleted successfully", data)
+	c.JSON(200, resp)
+}
+
+func (gs *GinServer) PatchUser(c *gin.Context) {
+	var uri requests.BindUriID
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(400, gin.H{
+			"message": err.Error(),
+		})
+		return
+	}
+
+	user, err := gs.Service.GetUser(c, uri)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+
+	version, ok := patchVersion(c, "user", user.ID, user.Version)
+	if !ok {
+		return
+	}
+
+	current := requests.UserDocument{Name: user.Name, Email: user.Email}
+	var patched requests.UserDocument
+	if !bindPatch(c, current, &patched) {
+		return
+	}
+
+	req := requests.PatchUserRequest{
+		ID:              user.ID,
+		ExpectedVersion: version,
+	}
+	if patched.Name != current.Name {
+		req.Name = &patched.Name
+	}
+	if patched.Email != current.Email {
+		req.Email = &patched.Email
+	}
+
+	updated, err := gs.Service.PatchUser(c, req)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	setETag(c, etag(updated.Version))
+
+	data := gin.H{
+		"user": updated,
+	}
+
+	resp := helpers.SuccessResponse("patch user successfully", data)
 	c.JSON(200, resp)
 }
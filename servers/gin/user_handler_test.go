@@ -0,0 +1,432 @@
+package ginserver
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/mocks"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUser(t *testing.T) {
+	user := helpers.NewUserTest()
+
+	testCases := []struct {
+		name          string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "user created successfully",
+			body: `{"name":"royyan","email":"roy@gmail.com"}`,
+			mock: func(service *mocks.MockService) {
+				req := requests.CreateUserRequest{Name: "royyan", Email: "roy@gmail.com"}
+				service.EXPECT().
+					CreateUser(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&user, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Equal(t, `"1"`, rec.Header().Get("ETag"))
+			},
+		},
+		{
+			name: "email taken",
+			body: `{"name":"royyan","email":"ROY@gmail.com"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("user with email %q already exists", "ROY@gmail.com"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+				require.Contains(t, rec.Body.String(), string(services.ErrConflict))
+			},
+		},
+		{
+			name: "invalid email",
+			body: `{"name":"royyan","email":"abc"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ValidationError("email %q is not a valid address", "abc"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/users", bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	user := helpers.NewUserTest()
+
+	getUser := func(service *mocks.MockService) {
+		service.EXPECT().
+			GetUser(gomock.Any(), gomock.Eq(helpers.NewBindUriIDRequestTest(user.ID))).
+			Times(1).
+			Return(&user, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		contentType   string
+		body          string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "merge patch",
+			contentType: helpers.MergePatchContentType,
+			body:        `{"email":"new@gmail.com"}`,
+			mock: func(service *mocks.MockService) {
+				getUser(service)
+
+				email := "new@gmail.com"
+				req := requests.PatchUserRequest{ID: user.ID, Email: &email, ExpectedVersion: &user.Version}
+				service.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&user, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, `"1"`, rec.Header().Get("ETag"))
+			},
+		},
+		{
+			name:        "json patch",
+			contentType: helpers.JSONPatchContentType,
+			body:        `[{"op":"replace","path":"/name","value":"roy"}]`,
+			mock: func(service *mocks.MockService) {
+				getUser(service)
+
+				name := "roy"
+				req := requests.PatchUserRequest{ID: user.ID, Name: &name, ExpectedVersion: &user.Version}
+				service.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&user, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:        "validation error when the patch removes the email",
+			contentType: helpers.JSONPatchContentType,
+			body:        `[{"op":"remove","path":"/email"}]`,
+			mock: func(service *mocks.MockService) {
+				getUser(service)
+				service.EXPECT().PatchUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:        "internal server error",
+			contentType: helpers.MergePatchContentType,
+			body:        `{"name":"roy"}`,
+			mock: func(service *mocks.MockService) {
+				getUser(service)
+				service.EXPECT().
+					PatchUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, fmt.Errorf("internal server error"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/users/%d", user.ID)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", testCase.contentType)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	user := helpers.NewUserTest()
+
+	testCases := []struct {
+		name          string
+		body          string
+		ifMatch       string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "user updated successfully",
+			body:    `{"name":"updated","email":"updated@gmail.com"}`,
+			ifMatch: `"1"`,
+			mock: func(service *mocks.MockService) {
+				req := requests.UpdateUserRequest{ID: user.ID, Name: "updated", Email: "updated@gmail.com", ExpectedVersion: &user.Version}
+				updated := user
+				updated.Version = 2
+				service.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&updated, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+				require.Equal(t, `"2"`, rec.Header().Get("ETag"))
+			},
+		},
+		{
+			name: "validation error email not given",
+			body: `{"name":"updated"}`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:    "stale version",
+			body:    `{"name":"updated","email":"updated@gmail.com"}`,
+			ifMatch: `"5"`,
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.PreconditionFailedError("user with id %d is at version 1, expected version 5", user.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/users/%d", user.ID)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewBufferString(testCase.body))
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+			request.Header.Set("Content-Type", "application/json")
+			if testCase.ifMatch != "" {
+				request.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	user := helpers.NewUserTest()
+	heir := int64(2)
+
+	testCases := []struct {
+		name          string
+		query         string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "user deleted with the configured policy",
+			mock: func(service *mocks.MockService) {
+				req := requests.DeleteUserRequest{ID: user.ID}
+				service.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.DeletedUser{Deleted: true, UserID: user.ID, Policy: requests.UserDeleteRestrict}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "products reassigned",
+			query: "?policy=reassign&reassign_to=2",
+			mock: func(service *mocks.MockService) {
+				req := requests.DeleteUserRequest{ID: user.ID, Policy: requests.UserDeleteReassign, ReassignTo: &heir}
+				service.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(&responses.DeletedUser{Deleted: true, UserID: user.ID, Policy: requests.UserDeleteReassign, Products: 3, ReassignedTo: &heir}, nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "unknown policy",
+			query: "?policy=orphan",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name: "user still owns products",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.ConflictError("user with id %d still owns 3 products", user.ID))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			url := fmt.Sprintf("/users/%d%s", user.ID, testCase.query)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	user := helpers.NewUserTest()
+	first := 1
+
+	testCases := []struct {
+		name          string
+		query         string
+		mock          func(service *mocks.MockService)
+		checkResponse func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "list users successfully",
+			query: "?first=1",
+			mock: func(service *mocks.MockService) {
+				req := requests.ListUsersRequest{First: &first}
+				service.EXPECT().
+					ListUsers(gomock.Any(), gomock.Eq(req)).
+					Times(1).
+					Return(helpers.UsersResponse([]*responses.User{&user}, true, false), nil)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name:  "validation error because first lower than one",
+			query: "?first=0",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListUsers(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+		{
+			name:  "invalid cursor",
+			query: "?after=garbage",
+			mock: func(service *mocks.MockService) {
+				service.EXPECT().
+					ListUsers(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, services.BadRequestError("invalid cursor"))
+			},
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			server := newGinTestServer(t, service)
+
+			testCase.mock(service)
+			rec := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/users"+testCase.query, nil)
+			require.NoError(t, err)
+			authenticateStaff(service, request)
+
+			server.Engine.ServeHTTP(rec, request)
+			testCase.checkResponse(t, rec)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"strings"
+	"time"
+)
+
+// apiKeyTouchInterval is how stale last_used_at gets before a request
+// updates it, so busy keys do not write on every request.
+const apiKeyTouchInterval = time.Minute
+
+// keyOwner returns the caller of ctx, which manages its own API keys. Keys
+// cannot manage keys, or a leaked key could mint keys that outlive it.
+func keyOwner(ctx context.Context) (*responses.User, error) {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := auth.ScopesFrom(ctx); ok {
+		return nil, ForbiddenError("API keys cannot manage API keys")
+	}
+
+	return caller, nil
+}
+
+// validateAPIKey returns the trimmed name and the stored scopes of a key.
+func validateAPIKey(name string, scopes []requests.Scope) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", ValidationError("name is required")
+	}
+
+	stored, err := validateScopes(scopes)
+	if err != nil {
+		return "", "", err
+	}
+
+	return name, stored, nil
+}
+
+// validateScopes returns scopes the way they are stored: deduplicated, in the
+// order of requests.Scopes and separated by spaces.
+func validateScopes(scopes []requests.Scope) (string, error) {
+	if len(scopes) == 0 {
+		return "", ValidationError("at least one scope is required")
+	}
+
+	has := make(map[requests.Scope]bool)
+	for _, scope := range scopes {
+		if !scope.Valid() {
+			return "", ValidationError("unknown scope %q", scope)
+		}
+		has[scope] = true
+	}
+
+	var names []string
+	for _, scope := range requests.Scopes {
+		if has[scope] {
+			names = append(names, string(scope))
+		}
+	}
+
+	return strings.Join(names, " "), nil
+}
+
+func apiKeyExpiry(expiresAt *time.Time, now time.Time) (sql.NullTime, error) {
+	if expiresAt == nil {
+		return sql.NullTime{}, nil
+	}
+
+	if !expiresAt.After(now) {
+		return sql.NullTime{}, ValidationError("expires_at must be in the future")
+	}
+
+	return sql.NullTime{Time: expiresAt.UTC(), Valid: true}, nil
+}
+
+// apiKeyPrefix returns the prefix of key, keys that are not shaped like API
+// keys are invalid.
+func apiKeyPrefix(key string) (string, error) {
+	prefix, ok := auth.APIKeyPrefix(key)
+	if !ok {
+		return "", invalidAPIKeyError()
+	}
+
+	return prefix, nil
+}
+
+// checkAPIKey compares key with the hash of the stored key found by its
+// prefix and refuses expired keys.
+func checkAPIKey(key, hash string, expiresAt sql.NullTime, now time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(auth.HashAPIKey(key)), []byte(hash)) != 1 {
+		return invalidAPIKeyError()
+	}
+
+	if expiresAt.Valid && !expiresAt.Time.After(now) {
+		return UnauthorizedError("API key has expired")
+	}
+
+	return nil
+}
+
+// staleAPIKey reports whether the last use of a key is worth recording.
+func staleAPIKey(lastUsedAt sql.NullTime, now time.Time) bool {
+	return !lastUsedAt.Valid || now.Sub(lastUsedAt.Time) >= apiKeyTouchInterval
+}
+
+func invalidAPIKeyError() error {
+	return UnauthorizedError("invalid API key")
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/responses"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// dummyPasswordHash is checked against when the user of a login has no
+// password, so unknown emails take as long as wrong passwords.
+const dummyPasswordHash = "$2a$10$mThmA1DxW6acT87MOIhOMONI0PzasQIQzRlpQhkGxGJeWud1P5lmW"
+
+var errNoTokenSecret = NewError(ErrInternal, "no token secret is configured")
+
+func validatePassword(password string) error {
+	if len(password) < auth.MinPasswordLength || len(password) > auth.MaxPasswordLength {
+		return ValidationError("password must be between %d and %d bytes long", auth.MinPasswordLength, auth.MaxPasswordLength)
+	}
+
+	return nil
+}
+
+// checkPassword compares password with the hash of a user, users without a
+// password cannot log in.
+func checkPassword(hash sql.NullString, password string) error {
+	if !hash.Valid {
+		_ = auth.CheckPassword(dummyPasswordHash, password)
+		return invalidCredentialsError()
+	}
+
+	err := auth.CheckPassword(hash.String, password)
+	if errors.Is(err, auth.ErrPasswordMismatch) {
+		return invalidCredentialsError()
+	}
+
+	return err
+}
+
+// loginEmail returns email the way normalize stores it, leaving out the
+// checks so users keep logging in when the email policy changes.
+func loginEmail(email string) string {
+	return norm.NFC.String(strings.TrimSpace(email))
+}
+
+// refreshToken is a new refresh token, only its hash is stored.
+type refreshToken struct {
+	token     string
+	hash      string
+	expiresAt time.Time
+}
+
+func newRefreshToken(tokens auth.Tokens, now time.Time) (refreshToken, error) {
+	if len(tokens.Secret) == 0 {
+		return refreshToken{}, errNoTokenSecret
+	}
+
+	token, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return refreshToken{}, err
+	}
+
+	return refreshToken{token: token, hash: hash, expiresAt: tokens.RefreshExpiry(now)}, nil
+}
+
+// newSession signs an access token of user and pairs it with refresh.
+func newSession(tokens auth.Tokens, user *responses.User, refresh refreshToken, now time.Time) (*responses.Session, error) {
+	access, expiresAt, err := tokens.Access(user.ID, now)
+	if errors.Is(err, auth.ErrNoSecret) {
+		return nil, errNoTokenSecret
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &responses.Session{
+		User:             user,
+		TokenType:        auth.TokenType,
+		AccessToken:      access,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refresh.token,
+		RefreshExpiresAt: refresh.expiresAt,
+	}, nil
+}
+
+// accessTokenUser verifies an access token and returns the id of its user.
+func accessTokenUser(tokens auth.Tokens, token string, now time.Time) (int64, error) {
+	claims, err := tokens.Verify(token, now)
+	switch {
+	case errors.Is(err, auth.ErrNoSecret):
+		return 0, errNoTokenSecret
+	case errors.Is(err, auth.ErrExpiredToken):
+		return 0, UnauthorizedError("access token has expired")
+	case err != nil:
+		return 0, UnauthorizedError("invalid access token")
+	}
+
+	id, err := claims.UserID()
+	if err != nil {
+		return 0, UnauthorizedError("invalid access token")
+	}
+
+	return id, nil
+}
+
+func invalidCredentialsError() error {
+	return UnauthorizedError("invalid email or password")
+}
+
+func invalidRefreshTokenError() error {
+	return UnauthorizedError("invalid refresh token")
+}
+
+func userGoneError() error {
+	return UnauthorizedError("the user of the token no longer exists")
+}
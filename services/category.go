@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	maxCategoryNameLength = 255
+	maxTagLength          = 50
+)
+
+const (
+	// categoriesNameIndex makes the names of siblings unique, ignoring case.
+	categoriesNameIndex = "categories_parent_name_key"
+	tagsNameIndex       = "tags_name_key"
+	// tagsNameColumn is how sqlite names the unique index on tags.name in
+	// its errors, it only names indexes on expressions.
+	tagsNameColumn = "tags.name"
+)
+
+func normalizeCategoryName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ValidationError("name is required")
+	}
+
+	if utf8.RuneCountInString(name) > maxCategoryNameLength {
+		return "", ValidationError("name must be at most %d characters long", maxCategoryNameLength)
+	}
+
+	return name, nil
+}
+
+// normalizeTag returns tag in the form it is stored in: trimmed and in lower
+// case.
+func normalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", ValidationError("tag must not be empty")
+	}
+
+	if utf8.RuneCountInString(tag) > maxTagLength {
+		return "", ValidationError("tag %q must be at most %d characters long", tag, maxTagLength)
+	}
+
+	return tag, nil
+}
+
+// normalizeTags normalizes every tag and drops the repeated ones, the first
+// occurrence keeps its position.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := []string{}
+	for _, tag := range tags {
+		tag, err := normalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized, nil
+}
+
+func checkCategoryParent(parentID *int64) error {
+	if parentID != nil && *parentID < 1 {
+		return ValidationError("parent_id must be at least 1")
+	}
+
+	return nil
+}
+
+// normalizeCategoryIDs drops the repeated ids, the first occurrence keeps its
+// position.
+func normalizeCategoryIDs(ids []int64) ([]int64, error) {
+	seen := make(map[int64]bool, len(ids))
+	normalized := []int64{}
+	for _, id := range ids {
+		if id < 1 {
+			return nil, ValidationError("category ids must be at least 1")
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			normalized = append(normalized, id)
+		}
+	}
+
+	return normalized, nil
+}
+
+// missingCategory returns an id of ids that is not in found.
+func missingCategory(ids []int64, found []*responses.Category) (int64, bool) {
+	byID := make(map[int64]bool, len(found))
+	for _, category := range found {
+		byID[category.ID] = true
+	}
+
+	for _, id := range ids {
+		if !byID[id] {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// checkCategoryMove refuses to move category id under a parent whose
+// ancestors, the parent included, contain the category itself.
+func checkCategoryMove(id int64, parentAncestors []int64) error {
+	for _, ancestor := range parentAncestors {
+		if ancestor == id {
+			return ValidationError("category with id %d cannot be moved into its own subtree", id)
+		}
+	}
+
+	return nil
+}
+
+func validateSetProductCategories(req requests.SetProductCategoriesRequest) (requests.SetProductCategoriesRequest, error) {
+	ids, err := normalizeCategoryIDs(req.CategoryIDs)
+	req.CategoryIDs = ids
+	return req, err
+}
+
+func validateSetProductTags(req requests.SetProductTagsRequest) (requests.SetProductTagsRequest, error) {
+	tags, err := normalizeTags(req.Tags)
+	req.Tags = tags
+	return req, err
+}
+
+func parentNotFoundError(id int64) error {
+	return NewError(ErrForeignKeyViolation, "parent category with id %d not found", id)
+}
+
+func categoryNotFoundError(id int64) error {
+	return NewError(ErrForeignKeyViolation, "category with id %d not found", id)
+}
+
+func categoryNameTakenError(name string) error {
+	return ConflictError("category %q already exists at this level", name)
+}
+
+func tagTakenError(name string) error {
+	return ConflictError("tag %q already exists", name)
+}
+
+func hasChildrenError(id int64, children int64) error {
+	return ConflictError("category with id %d still has %d subcategories", id, children)
+}
+
+// isUniqueViolation reports whether err violates the unique index or
+// constraint named index.
+func isUniqueViolation(err error, index string) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqUniqueViolation && pqErr.Constraint == index
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique && strings.Contains(sqliteErr.Error(), index)
+	}
+
+	return false
+}
+
+// categoryError reports violations of the sibling name index as
+// categoryNameTakenError, other errors are classified by dbError.
+func categoryError(err error, name string, id int64) error {
+	if isUniqueViolation(err, categoriesNameIndex) {
+		return categoryNameTakenError(name)
+	}
+
+	return dbError(err, "category", id)
+}
+
+func tagError(err error, name string) error {
+	if isUniqueViolation(err, tagsNameIndex) || isUniqueViolation(err, tagsNameColumn) {
+		return tagTakenError(name)
+	}
+
+	return dbError(err, "tag", 0)
+}
+
+// groupByProduct returns the items of every product id in order. row splits
+// a fetched row into its product id and item.
+func groupByProduct[T, R any](productIDs []int64, rows []T, row func(T) (int64, R)) [][]R {
+	byProduct := make(map[int64][]R)
+	for _, r := range rows {
+		id, item := row(r)
+		byProduct[id] = append(byProduct[id], item)
+	}
+
+	groups := make([][]R, len(productIDs))
+	for i, id := range productIDs {
+		groups[i] = byProduct[id]
+		if groups[i] == nil {
+			groups[i] = []R{}
+		}
+	}
+
+	return groups
+}
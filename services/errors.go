@@ -0,0 +1,160 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+type ErrorCode string
+
+const (
+	ErrBadRequest          ErrorCode = "BAD_REQUEST"
+	ErrNotFound            ErrorCode = "NOT_FOUND"
+	ErrValidation          ErrorCode = "VALIDATION_FAILED"
+	ErrConflict            ErrorCode = "CONFLICT"
+	ErrPreconditionFailed  ErrorCode = "PRECONDITION_FAILED"
+	ErrForeignKeyViolation ErrorCode = "FOREIGN_KEY_VIOLATION"
+	ErrUnauthorized        ErrorCode = "UNAUTHORIZED"
+	ErrForbidden           ErrorCode = "FORBIDDEN"
+	ErrPaymentProvider     ErrorCode = "PAYMENT_PROVIDER_ERROR"
+	ErrInternal            ErrorCode = "INTERNAL"
+)
+
+// InternalErrorMessage is what clients are shown instead of the message of an
+// ErrInternal error, which may carry SQL and driver details.
+const InternalErrorMessage = "internal server error"
+
+// Error is the error type returned by every Service implementation. Servers
+// use its Code to choose a transport specific status instead of matching on
+// the message.
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return string(e.Code)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NewError(code ErrorCode, format string, args ...any) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func BadRequestError(format string, args ...any) *Error {
+	return NewError(ErrBadRequest, format, args...)
+}
+
+func NotFoundError(format string, args ...any) *Error {
+	return NewError(ErrNotFound, format, args...)
+}
+
+func ValidationError(format string, args ...any) *Error {
+	return NewError(ErrValidation, format, args...)
+}
+
+func ConflictError(format string, args ...any) *Error {
+	return NewError(ErrConflict, format, args...)
+}
+
+func PreconditionFailedError(format string, args ...any) *Error {
+	return NewError(ErrPreconditionFailed, format, args...)
+}
+
+func UnauthorizedError(format string, args ...any) *Error {
+	return NewError(ErrUnauthorized, format, args...)
+}
+
+func ForbiddenError(format string, args ...any) *Error {
+	return NewError(ErrForbidden, format, args...)
+}
+
+// ErrorCodeOf returns the code of the first *Error in err's chain, errors that
+// were never classified are reported as ErrInternal.
+func ErrorCodeOf(err error) ErrorCode {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	return ErrInternal
+}
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+	pqNotNullViolation    = "23502"
+	pqCheckViolation      = "23514"
+	pqDataExceptionClass  = "22"
+)
+
+// dbError classifies an error returned by the postgres or sqlite repositories.
+// sql.ErrNoRows is reported as not found using the given resource name and id.
+func dbError(err error, resource string, id int64) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Error{
+			Code:    ErrNotFound,
+			Message: fmt.Sprintf("%s with id %d not found", resource, id),
+			Err:     err,
+		}
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == pqUniqueViolation,
+			pqErr.Code == pqSerializationFailure,
+			pqErr.Code == pqDeadlockDetected:
+			return &Error{Code: ErrConflict, Message: pqErr.Message, Err: err}
+		case pqErr.Code == pqForeignKeyViolation:
+			return &Error{Code: ErrForeignKeyViolation, Message: pqErr.Message, Err: err}
+		case pqErr.Code == pqNotNullViolation,
+			pqErr.Code == pqCheckViolation,
+			pqErr.Code.Class() == pqDataExceptionClass:
+			return &Error{Code: ErrValidation, Message: pqErr.Message, Err: err}
+		}
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return &Error{Code: ErrConflict, Message: sqliteErr.Error(), Err: err}
+		case sqlite3.ErrConstraintForeignKey:
+			return &Error{Code: ErrForeignKeyViolation, Message: sqliteErr.Error(), Err: err}
+		case sqlite3.ErrConstraintNotNull, sqlite3.ErrConstraintCheck:
+			return &Error{Code: ErrValidation, Message: sqliteErr.Error(), Err: err}
+		}
+	}
+
+	return &Error{Code: ErrInternal, Err: err}
+}
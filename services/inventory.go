@@ -0,0 +1,175 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultReservationTTL is how long a reservation holds its units when
+	// neither the request nor the service chose a TTL.
+	DefaultReservationTTL = 15 * time.Minute
+	// MaxReservationTTL caps the TTL a request can ask for.
+	MaxReservationTTL = 24 * time.Hour
+
+	DefaultStockAdjustmentsLimit = 50
+	MaxStockAdjustmentsLimit     = 100
+
+	maxStockNoteLength = 255
+)
+
+var stockReasons = map[requests.StockReason]bool{
+	requests.StockReceived:   true,
+	requests.StockReturned:   true,
+	requests.StockSold:       true,
+	requests.StockDamaged:    true,
+	requests.StockLost:       true,
+	requests.StockCorrection: true,
+}
+
+func validateAdjustStock(req requests.AdjustStockRequest) (requests.AdjustStockRequest, error) {
+	if req.Delta == 0 {
+		return req, ValidationError("delta must not be zero")
+	}
+
+	req.Reason = requests.StockReason(strings.ToLower(string(req.Reason)))
+	if !stockReasons[req.Reason] {
+		return req, ValidationError("reason must be one of received, returned, sold, damaged, lost or correction")
+	}
+
+	req.Note = strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(req.Note) > maxStockNoteLength {
+		return req, ValidationError("note must be at most %d characters long", maxStockNoteLength)
+	}
+
+	return req, nil
+}
+
+func normalizeListStockAdjustments(req requests.ListStockAdjustmentsRequest) (requests.ListStockAdjustmentsRequest, error) {
+	if req.Limit == 0 {
+		req.Limit = DefaultStockAdjustmentsLimit
+	}
+
+	if req.Limit < 1 || req.Limit > MaxStockAdjustmentsLimit {
+		return req, ValidationError("limit must be between 1 and %d", MaxStockAdjustmentsLimit)
+	}
+
+	return req, nil
+}
+
+// reservationExpiry validates req and returns when the reservation expires
+// if it is created at now. ttl is the TTL of the service, zero means
+// DefaultReservationTTL.
+func reservationExpiry(req requests.CreateReservationRequest, ttl time.Duration, now time.Time) (time.Time, error) {
+	if req.Quantity < 1 {
+		return time.Time{}, ValidationError("quantity must be at least 1")
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+
+	if req.TTLSeconds != nil {
+		maxSeconds := int64(MaxReservationTTL / time.Second)
+		if *req.TTLSeconds < 1 || *req.TTLSeconds > maxSeconds {
+			return time.Time{}, ValidationError("ttl_seconds must be between 1 and %d", maxSeconds)
+		}
+		ttl = time.Duration(*req.TTLSeconds) * time.Second
+	}
+
+	return now.Add(ttl), nil
+}
+
+// adjustedOnHand returns the units on hand once delta is applied, stock
+// that is reserved cannot be removed.
+func adjustedOnHand(productID, onHand, reserved, delta int64) (int64, error) {
+	if onHand+delta < reserved {
+		return onHand, insufficientStockError(productID, onHand-reserved, -delta)
+	}
+
+	return onHand + delta, nil
+}
+
+func checkAvailable(productID, onHand, reserved, quantity int64) error {
+	if onHand-reserved < quantity {
+		return insufficientStockError(productID, onHand-reserved, quantity)
+	}
+
+	return nil
+}
+
+// closedStock returns the stock once a reservation of quantity units moves
+// to status. Its units are no longer reserved, committed units also leave
+// the stock on hand.
+func closedStock(onHand, reserved, quantity int64, status responses.ReservationStatus) (int64, int64) {
+	if status == responses.ReservationCommitted {
+		onHand -= quantity
+	}
+
+	return onHand, reserved - quantity
+}
+
+// checkCommit refuses to commit a reservation that expired but was not swept
+// yet.
+func checkCommit(reservation *responses.Reservation, status responses.ReservationStatus, now time.Time) error {
+	if status == responses.ReservationCommitted && !reservation.ExpiresAt.After(now) {
+		return ConflictError("reservation with id %d has expired", reservation.ID)
+	}
+
+	return nil
+}
+
+// commitNote is the note of the stock adjustment a committed reservation
+// makes.
+func commitNote(reservationID int64) string {
+	return fmt.Sprintf("reservation %d", reservationID)
+}
+
+// reservedByProduct sums the units of reservations by product. The product
+// ids are sorted so inventory rows are always locked in the same order.
+func reservedByProduct(reservations []*responses.Reservation) ([]int64, map[int64]int64) {
+	quantities := make(map[int64]int64)
+	for _, reservation := range reservations {
+		quantities[reservation.ProductID] += reservation.Quantity
+	}
+
+	productIDs := make([]int64, 0, len(quantities))
+	for id := range quantities {
+		productIDs = append(productIDs, id)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	return productIDs, quantities
+}
+
+// stocksByProduct returns the stock of every product id in order, products
+// that never had stock get the zero Stock.
+func stocksByProduct(productIDs []int64, stocks []*responses.Stock) []*responses.Stock {
+	byProduct := make(map[int64]*responses.Stock, len(stocks))
+	for _, stock := range stocks {
+		byProduct[stock.ProductID] = stock
+	}
+
+	results := make([]*responses.Stock, len(productIDs))
+	for i, id := range productIDs {
+		results[i] = byProduct[id]
+		if results[i] == nil {
+			results[i] = &responses.Stock{ProductID: id}
+		}
+	}
+
+	return results
+}
+
+func insufficientStockError(productID, available, requested int64) error {
+	return ConflictError("product with id %d has %d units available, %d requested", productID, available, requested)
+}
+
+func reservationClosedError(reservation *responses.Reservation) error {
+	return ConflictError("reservation with id %d is %s", reservation.ID, reservation.Status)
+}
@@ -0,0 +1,2535 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/db/postgres/repositories"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryService keeps everything in process memory. It is meant for local
+// development and tests where running postgres is not wanted, data is lost
+// when the process exits.
+type MemoryService struct {
+	mu             sync.RWMutex
+	products       map[int64]repositories.Product
+	users          map[int64]repositories.User
+	rates          map[[2]string]repositories.ExchangeRate
+	categories     map[int64]repositories.Category
+	tags           map[int64]repositories.Tag
+	lastProductID  int64
+	lastUserID     int64
+	lastCategoryID int64
+	lastTagID      int64
+
+	// productCategories and productTags hold the ids of the categories
+	// and tags of every product.
+	productCategories map[int64]map[int64]bool
+	productTags       map[int64]map[int64]bool
+
+	// inventory is keyed by product id, stockAdjustments are in the order
+	// they were made.
+	inventory             map[int64]repositories.Inventory
+	stockAdjustments      []repositories.StockAdjustment
+	reservations          map[int64]repositories.Reservation
+	lastStockAdjustmentID int64
+	lastReservationID     int64
+
+	// orderItems are in the order they were created.
+	orders          map[int64]repositories.Order
+	orderItems      []repositories.OrderItem
+	lastOrderID     int64
+	lastOrderItemID int64
+
+	payments      map[int64]repositories.Payment
+	lastPaymentID int64
+
+	// claimed holds the payments being captured or refunded, a payment is
+	// claimed before the provider is called so it is captured or refunded
+	// once.
+	claimed map[int64]bool
+
+	// refreshTokens are keyed by id like the other tables, logins look
+	// them up by hash.
+	refreshTokens      map[int64]repositories.RefreshToken
+	lastRefreshTokenID int64
+
+	// userRoles holds the roles of every user, the roles themselves and
+	// their permissions are memoryRoles.
+	userRoles map[int64]map[requests.Role]bool
+
+	// apiKeys are keyed by id, requests look them up by prefix.
+	apiKeys      map[int64]repositories.APIKey
+	lastAPIKeyID int64
+
+	Options
+}
+
+func NewMemoryService() *MemoryService {
+	return &MemoryService{
+		products: make(map[int64]repositories.Product),
+		users:    make(map[int64]repositories.User),
+		rates:    make(map[[2]string]repositories.ExchangeRate),
+
+		categories:        make(map[int64]repositories.Category),
+		tags:              make(map[int64]repositories.Tag),
+		productCategories: make(map[int64]map[int64]bool),
+		productTags:       make(map[int64]map[int64]bool),
+
+		inventory:    make(map[int64]repositories.Inventory),
+		reservations: make(map[int64]repositories.Reservation),
+
+		orders:   make(map[int64]repositories.Order),
+		payments: make(map[int64]repositories.Payment),
+
+		claimed: make(map[int64]bool),
+
+		refreshTokens: make(map[int64]repositories.RefreshToken),
+		userRoles:     make(map[int64]map[requests.Role]bool),
+		apiKeys:       make(map[int64]repositories.APIKey),
+	}
+}
+
+func (m *MemoryService) CreateProduct(ctx context.Context, req requests.CreateProductRequest) (*responses.Product, error) {
+	currency, err := m.Currencies.currency(req.Currency)
+	if err != nil {
+		return &responses.Product{}, err
+	}
+
+	userID, err := newProductOwner(ctx, m.Policy, req.UserID)
+	if err != nil {
+		return &responses.Product{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[userID]; !ok {
+		return &responses.Product{}, NewError(ErrForeignKeyViolation, "user with id %d not found", userID)
+	}
+
+	m.lastProductID++
+	prod := repositories.Product{
+		ID:        m.lastProductID,
+		Name:      req.Name,
+		Price:     req.Price,
+		Currency:  currency,
+		UserID:    userID,
+		Version:   1,
+		CreatedAt: now(),
+	}
+	prod.UpdatedAt = prod.CreatedAt
+	m.products[prod.ID] = prod
+
+	product := helpers.ProductResponse(prod)
+	product.Stock = m.stockOf(prod.ID)
+	return product, nil
+}
+
+func (m *MemoryService) DeleteProduct(ctx context.Context, req requests.DeleteProductRequest) (*responses.DeletedProduct, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// permanent deletes also empty the trash, soft deletes only see live
+	// products
+	prod, ok := m.products[req.ID]
+	if ok {
+		if err := authorizeProduct(ctx, m.Policy, ActionDelete, prod.ID, prod.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !ok || (!req.Permanent && prod.DeletedAt.Valid) {
+		return nil, NotFoundError("product with id %d not found", req.ID)
+	}
+
+	if req.Permanent {
+		m.deleteProduct(req.ID)
+	} else {
+		prod.DeletedAt = now()
+		m.products[prod.ID] = prod
+	}
+
+	return &responses.DeletedProduct{
+		Deleted:   true,
+		Permanent: req.Permanent,
+		ProductID: req.ID,
+	}, nil
+}
+
+func (m *MemoryService) RestoreProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prod, ok := m.products[req.ID]
+	if ok {
+		if err := authorizeProduct(ctx, m.Policy, ActionUpdate, prod.ID, prod.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !ok || !prod.DeletedAt.Valid {
+		return nil, NotFoundError("deleted product with id %d not found", req.ID)
+	}
+
+	prod.DeletedAt = sql.NullTime{}
+	prod.Version++
+	prod.UpdatedAt = now()
+	m.products[prod.ID] = prod
+
+	product := helpers.ProductResponse(prod)
+	product.Stock = m.stockOf(prod.ID)
+	return product, nil
+}
+
+func (m *MemoryService) ListDeletedProducts(ctx context.Context, req requests.ListDeletedProductsRequest) (*responses.ProductList, error) {
+	req, err := normalizeListDeletedProducts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var deleted []repositories.Product
+	for _, prod := range m.products {
+		if prod.DeletedAt.Valid && (req.UserID == nil || prod.UserID == *req.UserID) {
+			deleted = append(deleted, prod)
+		}
+	}
+
+	sort.Slice(deleted, func(i, j int) bool {
+		a, b := deleted[i], deleted[j]
+		if !a.DeletedAt.Time.Equal(b.DeletedAt.Time) {
+			return a.DeletedAt.Time.After(b.DeletedAt.Time)
+		}
+
+		return a.ID > b.ID
+	})
+
+	start, end := offsetBounds(len(deleted), req.Limit, req.Offset)
+	return helpers.ProductListResponse(deleted[start:end], req.Limit, req.Offset, int64(len(deleted))), nil
+}
+
+func (m *MemoryService) PurgeDeletedProducts(ctx context.Context, req requests.PurgeDeletedProductsRequest) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var purged int64
+	for id, prod := range m.products {
+		if prod.DeletedAt.Valid && prod.DeletedAt.Time.Before(req.DeletedBefore) {
+			m.deleteProduct(id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (m *MemoryService) GetProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	prod, ok := m.liveProduct(req.ID)
+	if !ok {
+		return &responses.Product{}, NotFoundError("product with id %d not found", req.ID)
+	}
+
+	product := helpers.ProductResponse(prod)
+	product.Stock = m.stockOf(prod.ID)
+	return product, nil
+}
+
+func (m *MemoryService) GetBatchProducts(ctx context.Context, req requests.GetBatchProductsRequest) ([]*responses.Product, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	products := []*responses.Product{}
+	for _, id := range req.IDs {
+		if prod, ok := m.liveProduct(id); ok {
+			products = append(products, helpers.ProductResponse(prod))
+		}
+	}
+
+	return products, nil
+}
+
+func (m *MemoryService) ListProducts(ctx context.Context, req requests.ListProductsRequest) (*responses.ProductList, error) {
+	req, err := normalizeListProducts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matched []repositories.Product
+	for _, prod := range m.products {
+		if !prod.DeletedAt.Valid && matchProductFilter(prod, req.Filter) {
+			matched = append(matched, prod)
+		}
+	}
+
+	desc := req.Order.Direction == requests.OrderDesc
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if desc {
+			a, b = b, a
+		}
+
+		switch req.Order.Field {
+		case requests.ProductOrderName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		case requests.ProductOrderPrice:
+			if a.Price != b.Price {
+				return a.Price < b.Price
+			}
+		case requests.ProductOrderCreatedAt:
+			if !a.CreatedAt.Time.Equal(b.CreatedAt.Time) {
+				return a.CreatedAt.Time.Before(b.CreatedAt.Time)
+			}
+		}
+
+		return a.ID < b.ID
+	})
+
+	start, end := offsetBounds(len(matched), req.Limit, req.Offset)
+	return helpers.ProductListResponse(matched[start:end], req.Limit, req.Offset, int64(len(matched))), nil
+}
+
+// offsetBounds returns the slice bounds of an offset page over n items.
+func offsetBounds(n, limit, offset int) (int, int) {
+	start := offset
+	if start > n {
+		start = n
+	}
+
+	end := start + limit
+	if end > n {
+		end = n
+	}
+
+	return start, end
+}
+
+func matchProductFilter(prod repositories.Product, f requests.ProductFilter) bool {
+	if f.UserID != nil && prod.UserID != *f.UserID {
+		return false
+	}
+
+	if f.Name != nil && !strings.Contains(strings.ToLower(prod.Name), strings.ToLower(*f.Name)) {
+		return false
+	}
+
+	if f.MinPrice != nil && prod.Price < *f.MinPrice {
+		return false
+	}
+
+	if f.MaxPrice != nil && prod.Price > *f.MaxPrice {
+		return false
+	}
+
+	if f.CreatedAfter != nil && prod.CreatedAt.Time.Before(*f.CreatedAfter) {
+		return false
+	}
+
+	if f.CreatedBefore != nil && !prod.CreatedAt.Time.Before(*f.CreatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+func (m *MemoryService) SearchProducts(ctx context.Context, req requests.SearchProductsRequest) (*responses.Products, error) {
+	search, err := newProductSearch(req, m.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var hits []searchHit
+	for _, prod := range m.products {
+		if prod.DeletedAt.Valid {
+			continue
+		}
+
+		if hit, ok := search.match(helpers.ProductResponse(prod)); ok {
+			hits = append(hits, hit)
+		}
+	}
+
+	return search.response(search.page(hits)), nil
+}
+
+func (m *MemoryService) GetUserProducts(ctx context.Context, req requests.GetUserProductsRequest) (*responses.Products, error) {
+	page, err := newUserProductsPage(req, m.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.users[req.UserID]; !ok {
+		return nil, NotFoundError("user with id %d not found", req.UserID)
+	}
+
+	return m.userProducts(req.UserID, page), nil
+}
+
+func (m *MemoryService) GetBatchUserProducts(ctx context.Context, req requests.GetBatchUserProductsRequest) ([]*responses.Products, error) {
+	page, err := newBatchUserProductsPage(req, m.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pages := make([]*responses.Products, len(req.UserIDs))
+	for i, id := range req.UserIDs {
+		pages[i] = m.userProducts(id, page)
+	}
+
+	return pages, nil
+}
+
+// userProducts returns one page of the products of a user, m.mu must be held.
+func (m *MemoryService) userProducts(userID int64, page userProductsPage) *responses.Products {
+	// same ordering as the GetUserProducts query: newest first with the id
+	// breaking ties
+	match := m.productFilter(page)
+
+	var owned []repositories.Product
+	for _, prod := range m.products {
+		if prod.UserID == userID && !prod.DeletedAt.Valid && match(prod.ID) {
+			owned = append(owned, prod)
+		}
+	}
+
+	sort.Slice(owned, func(i, j int) bool {
+		return newerThan(owned[i], owned[j].CreatedAt.Time, owned[j].ID)
+	})
+
+	start, end := 0, len(owned)
+	if c := page.cursor; c != nil && page.backward {
+		end = sort.Search(len(owned), func(i int) bool {
+			return !newerThan(owned[i], c.CreatedAt, c.ID)
+		})
+	} else if c != nil {
+		start = sort.Search(len(owned), func(i int) bool {
+			return olderThan(owned[i], c.CreatedAt, c.ID)
+		})
+	}
+
+	if page.backward {
+		if end-page.size > start {
+			start = end - page.size
+		}
+	} else if start+page.size < end {
+		end = start + page.size
+	}
+
+	results := owned[start:end]
+	hnp := len(results) > 0 && end < len(owned)
+	hpp := len(results) > 0 && start > 0
+
+	return helpers.ProductsResponse(results, hnp, hpp)
+}
+
+// liveProduct returns the product with the given id unless it is in the
+// trash, m.mu must be held.
+func (m *MemoryService) liveProduct(id int64) (repositories.Product, bool) {
+	prod, ok := m.products[id]
+	if !ok || prod.DeletedAt.Valid {
+		return repositories.Product{}, false
+	}
+
+	return prod, true
+}
+
+// newerThan reports whether prod comes before the (createdAt, id) position in
+// the newest first ordering.
+func newerThan(prod repositories.Product, createdAt time.Time, id int64) bool {
+	if prod.CreatedAt.Time.Equal(createdAt) {
+		return prod.ID > id
+	}
+
+	return prod.CreatedAt.Time.After(createdAt)
+}
+
+// olderThan reports whether prod comes after the (createdAt, id) position in
+// the newest first ordering.
+func olderThan(prod repositories.Product, createdAt time.Time, id int64) bool {
+	if prod.CreatedAt.Time.Equal(createdAt) {
+		return prod.ID < id
+	}
+
+	return prod.CreatedAt.Time.Before(createdAt)
+}
+
+func (m *MemoryService) UpdateProduct(ctx context.Context, req requests.UpdateProductRequest) (*responses.Product, error) {
+	if err := checkUpdatedCurrency(req.Currency); err != nil {
+		return &responses.Product{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prod, ok := m.liveProduct(req.ID)
+	if !ok {
+		return &responses.Product{}, NotFoundError("product with id %d not found", req.ID)
+	}
+
+	if err := authorizeProduct(ctx, m.Policy, ActionUpdate, prod.ID, prod.UserID); err != nil {
+		return &responses.Product{}, err
+	}
+
+	if err := checkVersion("product", prod.ID, req.ExpectedVersion, prod.Version); err != nil {
+		return &responses.Product{}, err
+	}
+
+	prod.Name = req.Name
+	prod.Price = req.Price
+	prod.Currency = updatedCurrency(req.Currency, prod.Currency)
+	prod.Version++
+	prod.UpdatedAt = now()
+	m.products[prod.ID] = prod
+
+	product := helpers.ProductResponse(prod)
+	product.Stock = m.stockOf(prod.ID)
+	return product, nil
+}
+
+func (m *MemoryService) PatchProduct(ctx context.Context, req requests.PatchProductRequest) (*responses.Product, error) {
+	if err := validateProductPatch(req); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prod, err := m.patchProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	product := helpers.ProductResponse(prod)
+	product.Stock = m.stockOf(prod.ID)
+	return product, nil
+}
+
+// patchProduct must be called with m.mu held.
+func (m *MemoryService) patchProduct(ctx context.Context, req requests.PatchProductRequest) (repositories.Product, error) {
+	prod, ok := m.liveProduct(req.ID)
+	if !ok {
+		return prod, NotFoundError("product with id %d not found", req.ID)
+	}
+
+	if err := authorizeProduct(ctx, m.Policy, ActionUpdate, prod.ID, prod.UserID); err != nil {
+		return prod, err
+	}
+
+	if err := checkVersion("product", prod.ID, req.ExpectedVersion, prod.Version); err != nil {
+		return prod, err
+	}
+
+	if req.Name != nil {
+		prod.Name = *req.Name
+	}
+	if req.Price != nil {
+		prod.Price = *req.Price
+	}
+	if req.Currency != nil {
+		prod.Currency = *req.Currency
+	}
+	prod.Version++
+	prod.UpdatedAt = now()
+	m.products[prod.ID] = prod
+
+	return prod, nil
+}
+
+func (m *MemoryService) BulkCreateProducts(ctx context.Context, req requests.BulkCreateProductsRequest) (*responses.BulkProductsResult, error) {
+	if err := checkBulkSize(len(req.Products)); err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := newBulkResult(len(req.Products))
+	validateNewProducts(result, req.Products)
+	products := authorizeNewProducts(ctx, m.Policy, caller, result, req.Products)
+
+	found := make(map[int64]bool)
+	for _, id := range bulkUserIDs(result, products) {
+		_, found[id] = m.users[id]
+	}
+	requireUsers(result, products, found)
+
+	if req.Atomic && bulkFailed(result) {
+		return finishBulk(result, true), nil
+	}
+
+	createdAt := now()
+	for _, i := range bulkPending(result) {
+		m.lastProductID++
+		prod := repositories.Product{
+			ID:        m.lastProductID,
+			Name:      products[i].Name,
+			Price:     products[i].Price,
+			Currency:  m.Currencies.orDefault(products[i].Currency),
+			UserID:    products[i].UserID,
+			Version:   1,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+		m.products[prod.ID] = prod
+		result.Results[i].Product = helpers.ProductResponse(prod)
+	}
+
+	return finishBulk(result, req.Atomic), nil
+}
+
+func (m *MemoryService) BulkPatchProducts(ctx context.Context, req requests.BulkPatchProductsRequest) (*responses.BulkProductsResult, error) {
+	if err := checkBulkSize(len(req.Products)); err != nil {
+		return nil, err
+	}
+
+	if _, err := callerOf(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := newBulkResult(len(req.Products))
+	validatePatches(result, req.Products)
+
+	if req.Atomic && bulkFailed(result) {
+		return finishBulk(result, true), nil
+	}
+
+	// the products as they were before the first patch, restored when an
+	// atomic request fails
+	original := make(map[int64]repositories.Product)
+	for _, i := range bulkPending(result) {
+		if prod, ok := m.products[req.Products[i].ID]; ok {
+			if _, saved := original[prod.ID]; !saved {
+				original[prod.ID] = prod
+			}
+		}
+
+		prod, err := m.patchProduct(ctx, req.Products[i])
+		if err != nil {
+			bulkFail(result, i, err)
+			if req.Atomic {
+				break
+			}
+			continue
+		}
+		result.Results[i].Product = helpers.ProductResponse(prod)
+	}
+
+	if req.Atomic && bulkFailed(result) {
+		for id, prod := range original {
+			m.products[id] = prod
+		}
+	}
+
+	return finishBulk(result, req.Atomic), nil
+}
+
+func (m *MemoryService) BulkDeleteProducts(ctx context.Context, req requests.BulkDeleteProductsRequest) (*responses.BulkProductsResult, error) {
+	if err := checkBulkSize(len(req.IDs)); err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := newBulkResult(len(req.IDs))
+	validateDeletes(result, req.IDs)
+
+	owners := make(map[int64]int64)
+	for _, i := range bulkPending(result) {
+		if prod, ok := m.products[req.IDs[i]]; ok {
+			owners[prod.ID] = prod.UserID
+		}
+	}
+	authorizeDeletes(ctx, m.Policy, caller, result, req.IDs, owners)
+
+	// same rules as DeleteProduct
+	var deleted []int64
+	for _, i := range bulkPending(result) {
+		prod, ok := m.products[req.IDs[i]]
+		if ok && (req.Permanent || !prod.DeletedAt.Valid) {
+			deleted = append(deleted, prod.ID)
+		}
+	}
+	bulkDeleted(result, req.IDs, deleted, req.Permanent)
+
+	if req.Atomic && bulkFailed(result) {
+		return finishBulk(result, true), nil
+	}
+
+	deletedAt := now()
+	for _, id := range deleted {
+		if req.Permanent {
+			m.deleteProduct(id)
+			continue
+		}
+
+		prod := m.products[id]
+		prod.DeletedAt = deletedAt
+		m.products[id] = prod
+	}
+
+	return finishBulk(result, req.Atomic), nil
+}
+
+func (m *MemoryService) CreateUser(ctx context.Context, req requests.CreateUserRequest) (*responses.User, error) {
+	email, err := m.Emails.normalize(req.Email)
+	if err != nil {
+		return &responses.User{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.emailTaken(email, 0) {
+		return &responses.User{}, emailTakenError(email)
+	}
+
+	return helpers.UserResponse(m.addUser(req.Name, email, sql.NullString{})), nil
+}
+
+func (m *MemoryService) GetUser(ctx context.Context, req requests.BindUriID) (*responses.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[req.ID]
+	if !ok {
+		return &responses.User{}, NotFoundError("user with id %d not found", req.ID)
+	}
+
+	return helpers.UserResponse(user), nil
+}
+
+func (m *MemoryService) UpdateUser(ctx context.Context, req requests.UpdateUserRequest) (*responses.User, error) {
+	email, err := m.Emails.normalize(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[req.ID]
+	if !ok {
+		return nil, NotFoundError("user with id %d not found", req.ID)
+	}
+
+	if err := checkVersion("user", user.ID, req.ExpectedVersion, user.Version); err != nil {
+		return nil, err
+	}
+
+	if m.emailTaken(email, user.ID) {
+		return nil, emailTakenError(email)
+	}
+
+	user.Name = req.Name
+	user.Email = email
+	user.Version++
+	user.UpdatedAt = now()
+	m.users[user.ID] = user
+
+	return helpers.UserResponse(user), nil
+}
+
+func (m *MemoryService) DeleteUser(ctx context.Context, req requests.DeleteUserRequest) (*responses.DeletedUser, error) {
+	policy, reassignTo, err := m.UserDeletion.resolve(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[req.ID]; !ok {
+		return nil, NotFoundError("user with id %d not found", req.ID)
+	}
+
+	// orders are never deleted or reassigned with the user
+	var orders int64
+	for _, order := range m.orders {
+		if order.UserID == req.ID {
+			orders++
+		}
+	}
+	if orders > 0 {
+		return nil, hasOrdersError(req.ID, orders)
+	}
+
+	if policy == requests.UserDeleteReassign {
+		if _, ok := m.users[reassignTo]; !ok {
+			return nil, reassignUserError(reassignTo)
+		}
+	}
+
+	// trashed products belong to the user as well
+	var owned []repositories.Product
+	for _, prod := range m.products {
+		if prod.UserID == req.ID {
+			owned = append(owned, prod)
+		}
+	}
+
+	deleted := &responses.DeletedUser{
+		Deleted:  true,
+		UserID:   req.ID,
+		Policy:   policy,
+		Products: int64(len(owned)),
+	}
+
+	switch policy {
+	case requests.UserDeleteCascade:
+		for _, prod := range owned {
+			m.deleteProduct(prod.ID)
+		}
+	case requests.UserDeleteReassign:
+		updatedAt := now()
+		for _, prod := range owned {
+			prod.UserID = reassignTo
+			prod.Version++
+			prod.UpdatedAt = updatedAt
+			m.products[prod.ID] = prod
+		}
+		deleted.ReassignedTo = &reassignTo
+	default:
+		if len(owned) > 0 {
+			return nil, ownsProductsError(req.ID, deleted.Products)
+		}
+	}
+
+	delete(m.users, req.ID)
+	delete(m.userRoles, req.ID)
+	for id, token := range m.refreshTokens {
+		if token.UserID == req.ID {
+			delete(m.refreshTokens, id)
+		}
+	}
+	for id, key := range m.apiKeys {
+		if key.UserID == req.ID {
+			delete(m.apiKeys, id)
+		}
+	}
+	for id, reservation := range m.reservations {
+		if reservation.UserID.Valid && reservation.UserID.Int64 == req.ID {
+			reservation.UserID = sql.NullInt64{}
+			m.reservations[id] = reservation
+		}
+	}
+	for id, payment := range m.payments {
+		if payment.UserID.Valid && payment.UserID.Int64 == req.ID {
+			payment.UserID = sql.NullInt64{}
+			m.payments[id] = payment
+		}
+	}
+
+	return deleted, nil
+}
+
+func (m *MemoryService) ListUsers(ctx context.Context, req requests.ListUsersRequest) (*responses.Users, error) {
+	page, err := newUsersPage(req, m.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var users []repositories.User
+	for _, user := range m.users {
+		if page.after == nil || user.ID > *page.after {
+			users = append(users, user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	hasNextPage := len(users) > page.size
+	if hasNextPage {
+		users = users[:page.size]
+	}
+
+	return helpers.UsersResponse(users, hasNextPage, page.after != nil), nil
+}
+
+func (m *MemoryService) PatchUser(ctx context.Context, req requests.PatchUserRequest) (*responses.User, error) {
+	req, err := m.Emails.normalizePatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[req.ID]
+	if !ok {
+		return nil, NotFoundError("user with id %d not found", req.ID)
+	}
+
+	if err := checkVersion("user", user.ID, req.ExpectedVersion, user.Version); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+	if req.Email != nil {
+		if m.emailTaken(*req.Email, user.ID) {
+			return nil, emailTakenError(*req.Email)
+		}
+		user.Email = *req.Email
+	}
+	user.Version++
+	user.UpdatedAt = now()
+	m.users[user.ID] = user
+
+	return helpers.UserResponse(user), nil
+}
+
+func (m *MemoryService) GetBatchUsers(ctx context.Context, req requests.GetBatchUsersRequest) ([]*responses.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	users := []*responses.User{}
+	for _, id := range req.IDs {
+		if user, ok := m.users[id]; ok {
+			users = append(users, helpers.UserResponse(user))
+		}
+	}
+
+	return users, nil
+}
+
+func (m *MemoryService) SetExchangeRate(ctx context.Context, req requests.SetExchangeRateRequest) (*responses.ExchangeRate, error) {
+	if err := checkExchangeRate(req); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rate := repositories.ExchangeRate{
+		Base:      req.Base,
+		Quote:     req.Quote,
+		Rate:      req.Rate,
+		UpdatedAt: now().Time,
+	}
+	m.rates[[2]string{rate.Base, rate.Quote}] = rate
+
+	return helpers.ExchangeRateResponse(rate), nil
+}
+
+func (m *MemoryService) ListExchangeRates(ctx context.Context) ([]*responses.ExchangeRate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.exchangeRates(), nil
+}
+
+// exchangeRates returns the rates in base and quote order, m.mu must be held.
+func (m *MemoryService) exchangeRates() []*responses.ExchangeRate {
+	rates := make([]repositories.ExchangeRate, 0, len(m.rates))
+	for _, rate := range m.rates {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Base != rates[j].Base {
+			return rates[i].Base < rates[j].Base
+		}
+		return rates[i].Quote < rates[j].Quote
+	})
+
+	return helpers.ExchangeRateSliceResponse(rates)
+}
+
+func (m *MemoryService) ConvertPrices(ctx context.Context, prices []responses.Money, currency string) ([]responses.Money, error) {
+	rates, err := m.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Currencies.convertPrices(rates, prices, currency)
+}
+
+func (m *MemoryService) CreateCategory(ctx context.Context, req requests.CreateCategoryRequest) (*responses.Category, error) {
+	name, err := normalizeCategoryName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCategoryParent(req.ParentID); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if req.ParentID != nil {
+		if _, ok := m.categories[*req.ParentID]; !ok {
+			return nil, parentNotFoundError(*req.ParentID)
+		}
+	}
+
+	parentID := nullInt64(req.ParentID)
+	if m.categoryNameTaken(name, parentID, 0) {
+		return nil, categoryNameTakenError(name)
+	}
+
+	m.lastCategoryID++
+	category := repositories.Category{
+		ID:        m.lastCategoryID,
+		Name:      name,
+		ParentID:  parentID,
+		CreatedAt: now().Time,
+	}
+	category.UpdatedAt = category.CreatedAt
+	m.categories[category.ID] = category
+
+	return helpers.CategoryResponse(category), nil
+}
+
+func (m *MemoryService) GetCategory(ctx context.Context, req requests.BindUriID) (*responses.Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	category, ok := m.categories[req.ID]
+	if !ok {
+		return nil, NotFoundError("category with id %d not found", req.ID)
+	}
+
+	return helpers.CategoryResponse(category), nil
+}
+
+func (m *MemoryService) GetBatchCategories(ctx context.Context, req requests.GetBatchCategoriesRequest) ([]*responses.Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var categories []repositories.Category
+	for _, id := range req.IDs {
+		if category, ok := m.categories[id]; ok {
+			categories = append(categories, category)
+		}
+	}
+
+	return helpers.CategorySliceResponse(categories), nil
+}
+
+func (m *MemoryService) ListCategories(ctx context.Context, req requests.ListCategoriesRequest) ([]*responses.Category, error) {
+	if err := checkCategoryParent(req.ParentID); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if req.ParentID != nil {
+		if _, ok := m.categories[*req.ParentID]; !ok {
+			return nil, NotFoundError("category with id %d not found", *req.ParentID)
+		}
+	}
+
+	parentID := nullInt64(req.ParentID)
+	var children []repositories.Category
+	for _, category := range m.categories {
+		if category.ParentID == parentID {
+			children = append(children, category)
+		}
+	}
+	sortCategories(children)
+
+	return helpers.CategorySliceResponse(children), nil
+}
+
+func (m *MemoryService) UpdateCategory(ctx context.Context, req requests.UpdateCategoryRequest) (*responses.Category, error) {
+	name, err := normalizeCategoryName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCategoryParent(req.ParentID); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	category, ok := m.categories[req.ID]
+	if !ok {
+		return nil, NotFoundError("category with id %d not found", req.ID)
+	}
+
+	if req.ParentID != nil {
+		if _, ok := m.categories[*req.ParentID]; !ok {
+			return nil, parentNotFoundError(*req.ParentID)
+		}
+
+		if err := checkCategoryMove(category.ID, m.categoryAncestors(*req.ParentID)); err != nil {
+			return nil, err
+		}
+	}
+
+	parentID := nullInt64(req.ParentID)
+	if m.categoryNameTaken(name, parentID, category.ID) {
+		return nil, categoryNameTakenError(name)
+	}
+
+	category.Name = name
+	category.ParentID = parentID
+	category.UpdatedAt = now().Time
+	m.categories[category.ID] = category
+
+	return helpers.CategoryResponse(category), nil
+}
+
+func (m *MemoryService) DeleteCategory(ctx context.Context, req requests.BindUriID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var children int64
+	for _, category := range m.categories {
+		if category.ParentID.Valid && category.ParentID.Int64 == req.ID {
+			children++
+		}
+	}
+
+	if children > 0 {
+		return hasChildrenError(req.ID, children)
+	}
+
+	if _, ok := m.categories[req.ID]; !ok {
+		return NotFoundError("category with id %d not found", req.ID)
+	}
+
+	delete(m.categories, req.ID)
+	for _, ids := range m.productCategories {
+		delete(ids, req.ID)
+	}
+
+	return nil
+}
+
+func (m *MemoryService) CreateTag(ctx context.Context, req requests.CreateTagRequest) (*responses.Tag, error) {
+	name, err := normalizeTag(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tagByName(name); ok {
+		return nil, tagTakenError(name)
+	}
+
+	return helpers.TagResponse(m.createTag(name)), nil
+}
+
+func (m *MemoryService) ListTags(ctx context.Context) ([]*responses.Tag, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tags := make([]repositories.Tag, 0, len(m.tags))
+	for _, tag := range m.tags {
+		tags = append(tags, tag)
+	}
+	sortTags(tags)
+
+	return helpers.TagSliceResponse(tags), nil
+}
+
+func (m *MemoryService) DeleteTag(ctx context.Context, req requests.BindUriID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tags[req.ID]; !ok {
+		return NotFoundError("tag with id %d not found", req.ID)
+	}
+
+	delete(m.tags, req.ID)
+	for _, ids := range m.productTags {
+		delete(ids, req.ID)
+	}
+
+	return nil
+}
+
+func (m *MemoryService) SetProductCategories(ctx context.Context, req requests.SetProductCategoriesRequest) ([]*responses.Category, error) {
+	req, err := validateSetProductCategories(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.liveProduct(req.ProductID); !ok {
+		return nil, NotFoundError("product with id %d not found", req.ProductID)
+	}
+
+	ids := make(map[int64]bool, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		if _, ok := m.categories[id]; !ok {
+			return nil, categoryNotFoundError(id)
+		}
+		ids[id] = true
+	}
+	m.productCategories[req.ProductID] = ids
+
+	return m.categoriesOf(req.ProductID), nil
+}
+
+func (m *MemoryService) SetProductTags(ctx context.Context, req requests.SetProductTagsRequest) ([]*responses.Tag, error) {
+	req, err := validateSetProductTags(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.liveProduct(req.ProductID); !ok {
+		return nil, NotFoundError("product with id %d not found", req.ProductID)
+	}
+
+	ids := make(map[int64]bool, len(req.Tags))
+	for _, name := range req.Tags {
+		tag, ok := m.tagByName(name)
+		if !ok {
+			tag = m.createTag(name)
+		}
+		ids[tag.ID] = true
+	}
+	m.productTags[req.ProductID] = ids
+
+	return m.tagsOf(req.ProductID), nil
+}
+
+func (m *MemoryService) GetBatchProductCategories(ctx context.Context, req requests.GetBatchProductCategoriesRequest) ([][]*responses.Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	categories := make([][]*responses.Category, len(req.ProductIDs))
+	for i, id := range req.ProductIDs {
+		categories[i] = m.categoriesOf(id)
+	}
+
+	return categories, nil
+}
+
+func (m *MemoryService) GetBatchProductTags(ctx context.Context, req requests.GetBatchProductTagsRequest) ([][]*responses.Tag, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tags := make([][]*responses.Tag, len(req.ProductIDs))
+	for i, id := range req.ProductIDs {
+		tags[i] = m.tagsOf(id)
+	}
+
+	return tags, nil
+}
+
+func (m *MemoryService) GetStock(ctx context.Context, req requests.BindUriID) (*responses.Stock, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.liveProduct(req.ID); !ok {
+		return nil, NotFoundError("product with id %d not found", req.ID)
+	}
+
+	return m.stockOf(req.ID), nil
+}
+
+func (m *MemoryService) GetBatchStock(ctx context.Context, req requests.GetBatchStockRequest) ([]*responses.Stock, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stocks := make([]*responses.Stock, len(req.ProductIDs))
+	for i, id := range req.ProductIDs {
+		stocks[i] = m.stockOf(id)
+	}
+
+	return stocks, nil
+}
+
+func (m *MemoryService) AdjustStock(ctx context.Context, req requests.AdjustStockRequest) (*responses.Stock, error) {
+	req, err := validateAdjustStock(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.liveProduct(req.ProductID); !ok {
+		return nil, NotFoundError("product with id %d not found", req.ProductID)
+	}
+
+	inventory := m.inventory[req.ProductID]
+	onHand, err := adjustedOnHand(req.ProductID, inventory.OnHand, inventory.Reserved, req.Delta)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory = m.setInventory(req.ProductID, onHand, inventory.Reserved)
+	m.addStockAdjustment(req.ProductID, req.Delta, req.Reason, req.Note)
+
+	return helpers.StockResponse(inventory), nil
+}
+
+func (m *MemoryService) ListStockAdjustments(ctx context.Context, req requests.ListStockAdjustmentsRequest) ([]*responses.StockAdjustment, error) {
+	req, err := normalizeListStockAdjustments(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.liveProduct(req.ProductID); !ok {
+		return nil, NotFoundError("product with id %d not found", req.ProductID)
+	}
+
+	adjustments := []*responses.StockAdjustment{}
+	for i := len(m.stockAdjustments) - 1; i >= 0 && len(adjustments) < req.Limit; i-- {
+		if adjustment := m.stockAdjustments[i]; adjustment.ProductID == req.ProductID {
+			adjustments = append(adjustments, helpers.StockAdjustmentResponse(adjustment))
+		}
+	}
+
+	return adjustments, nil
+}
+
+func (m *MemoryService) CreateReservation(ctx context.Context, req requests.CreateReservationRequest) (*responses.Reservation, error) {
+	expiresAt, err := reservationExpiry(req, m.ReservationTTL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.liveProduct(req.ProductID); !ok {
+		return nil, NotFoundError("product with id %d not found", req.ProductID)
+	}
+
+	if _, ok := m.users[caller.ID]; !ok {
+		return nil, NewError(ErrForeignKeyViolation, "user with id %d not found", caller.ID)
+	}
+
+	inventory := m.inventory[req.ProductID]
+	if err := checkAvailable(req.ProductID, inventory.OnHand, inventory.Reserved, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	m.setInventory(req.ProductID, inventory.OnHand, inventory.Reserved+req.Quantity)
+
+	m.lastReservationID++
+	reservation := repositories.Reservation{
+		ID:        m.lastReservationID,
+		ProductID: req.ProductID,
+		UserID:    sql.NullInt64{Int64: caller.ID, Valid: true},
+		Quantity:  req.Quantity,
+		Status:    string(responses.ReservationActive),
+		ExpiresAt: expiresAt.UTC().Truncate(time.Microsecond),
+		CreatedAt: now().Time,
+	}
+	reservation.UpdatedAt = reservation.CreatedAt
+	m.reservations[reservation.ID] = reservation
+
+	return helpers.ReservationResponse(reservation), nil
+}
+
+func (m *MemoryService) GetReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reservation, ok := m.reservations[req.ID]
+	if !ok {
+		return nil, NotFoundError("reservation with id %d not found", req.ID)
+	}
+
+	if err := authorizeOrderRead(ctx, "reservation", reservation.ID, reservation.UserID.Int64); err != nil {
+		return nil, err
+	}
+
+	return helpers.ReservationResponse(reservation), nil
+}
+
+func (m *MemoryService) ReleaseReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	return m.closeReservation(ctx, req.ID, responses.ReservationReleased)
+}
+
+func (m *MemoryService) CommitReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	return m.closeReservation(ctx, req.ID, responses.ReservationCommitted)
+}
+
+func (m *MemoryService) closeReservation(ctx context.Context, id int64, status responses.ReservationStatus) (*responses.Reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservation, ok := m.reservations[id]
+	if !ok {
+		return nil, NotFoundError("reservation with id %d not found", id)
+	}
+
+	if err := authorizeOrder(ctx, "reservation", id, reservation.UserID.Int64); err != nil {
+		return nil, err
+	}
+
+	if reservation.Status != string(responses.ReservationActive) {
+		return nil, reservationClosedError(helpers.ReservationResponse(reservation))
+	}
+
+	if err := checkCommit(helpers.ReservationResponse(reservation), status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	reservation.Status = string(status)
+	reservation.UpdatedAt = now().Time
+	m.reservations[id] = reservation
+
+	inventory := m.inventory[reservation.ProductID]
+	onHand, reserved := closedStock(inventory.OnHand, inventory.Reserved, reservation.Quantity, status)
+	m.setInventory(reservation.ProductID, onHand, reserved)
+	if status == responses.ReservationCommitted {
+		m.addStockAdjustment(reservation.ProductID, -reservation.Quantity, requests.StockSold, commitNote(id))
+	}
+
+	return helpers.ReservationResponse(reservation), nil
+}
+
+func (m *MemoryService) ReleaseExpiredReservations(ctx context.Context, req requests.ReleaseExpiredReservationsRequest) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var released int64
+	for id, reservation := range m.reservations {
+		if reservation.Status != string(responses.ReservationActive) || !reservation.ExpiresAt.Before(req.ExpiredBefore) {
+			continue
+		}
+
+		reservation.Status = string(responses.ReservationExpired)
+		reservation.UpdatedAt = now().Time
+		m.reservations[id] = reservation
+
+		inventory := m.inventory[reservation.ProductID]
+		m.setInventory(reservation.ProductID, inventory.OnHand, inventory.Reserved-reservation.Quantity)
+		released++
+	}
+
+	return released, nil
+}
+
+func (m *MemoryService) CreateOrder(ctx context.Context, req requests.CreateOrderRequest) (*responses.Order, error) {
+	if err := validateCreateOrder(req); err != nil {
+		return nil, err
+	}
+
+	userID, err := newOrderOwner(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	req.UserID = userID
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[req.UserID]; !ok {
+		return nil, NewError(ErrForeignKeyViolation, "user with id %d not found", req.UserID)
+	}
+
+	products := make([]*responses.Product, len(req.Items))
+	for i, item := range req.Items {
+		prod, ok := m.products[item.ProductID]
+		if !ok || prod.DeletedAt.Valid {
+			return nil, productNotOrderableError(item.ProductID)
+		}
+		products[i] = helpers.ProductResponse(prod)
+	}
+
+	currency, lines, total, err := m.Currencies.priceOrder(req, products, m.exchangeRates())
+	if err != nil {
+		return nil, err
+	}
+
+	m.lastOrderID++
+	order := repositories.Order{
+		ID:        m.lastOrderID,
+		UserID:    req.UserID,
+		Status:    string(requests.OrderPending),
+		Currency:  currency,
+		Total:     total,
+		CreatedAt: now().Time,
+	}
+	order.UpdatedAt = order.CreatedAt
+	m.orders[order.ID] = order
+
+	for _, line := range lines {
+		m.lastOrderItemID++
+		m.orderItems = append(m.orderItems, repositories.OrderItem{
+			ID:          m.lastOrderItemID,
+			OrderID:     order.ID,
+			ProductID:   sql.NullInt64{Int64: line.productID, Valid: true},
+			ProductName: line.productName,
+			UnitPrice:   line.unitPrice,
+			Quantity:    line.quantity,
+		})
+	}
+
+	return helpers.OrderResponse(order, m.orderItems), nil
+}
+
+func (m *MemoryService) GetOrder(ctx context.Context, req requests.BindUriID) (*responses.Order, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	order, ok := m.orders[req.ID]
+	if !ok {
+		return nil, NotFoundError("order with id %d not found", req.ID)
+	}
+
+	if err := authorizeOrderRead(ctx, "order", order.ID, order.UserID); err != nil {
+		return nil, err
+	}
+
+	return helpers.OrderResponse(order, m.orderItems), nil
+}
+
+func (m *MemoryService) UpdateOrderStatus(ctx context.Context, req requests.UpdateOrderStatusRequest) (*responses.Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	order, ok := m.orders[req.ID]
+	if !ok {
+		return nil, NotFoundError("order with id %d not found", req.ID)
+	}
+
+	if err := checkOrderTransition(order.ID, requests.OrderStatus(order.Status), req.Status); err != nil {
+		return nil, err
+	}
+
+	order.Status = string(req.Status)
+	order.UpdatedAt = now().Time
+	m.orders[order.ID] = order
+
+	return helpers.OrderResponse(order, m.orderItems), nil
+}
+
+func (m *MemoryService) GetUserOrders(ctx context.Context, req requests.GetUserOrdersRequest) (*responses.Orders, error) {
+	page, err := newOrdersPage(req, m.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeUserOrders(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.users[req.UserID]; !ok {
+		return nil, NotFoundError("user with id %d not found", req.UserID)
+	}
+
+	var orders []repositories.Order
+	for _, order := range m.orders {
+		if order.UserID == req.UserID && (page.after == nil || order.ID < *page.after) {
+			orders = append(orders, order)
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ID > orders[j].ID })
+
+	hasNextPage := len(orders) > page.size
+	if hasNextPage {
+		orders = orders[:page.size]
+	}
+
+	return helpers.OrdersResponse(orders, m.orderItems, hasNextPage, page.after != nil), nil
+}
+
+func (m *MemoryService) StartPayment(ctx context.Context, req requests.StartPaymentRequest) (*responses.Payment, error) {
+	if err := validateStartPayment(req); err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	amount, payerID, err := m.paymentAmount(caller, req)
+	m.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	intent, err := createIntent(ctx, m.Payments, amount, paymentReference(req))
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the order may have been paid while the intent was created
+	if req.OrderID != nil && m.orderPaymentExists(*req.OrderID) {
+		return nil, orderPaymentExistsError(*req.OrderID)
+	}
+
+	m.lastPaymentID++
+	payment := repositories.Payment{
+		ID:        m.lastPaymentID,
+		OrderID:   nullInt64(req.OrderID),
+		ProductID: nullInt64(req.ProductID),
+		UserID:    sql.NullInt64{Int64: payerID, Valid: true},
+		Provider:  m.Payments.Name(),
+		IntentID:  intent.ID,
+		Status:    string(responses.PaymentPending),
+		Amount:    amount.Amount,
+		Currency:  amount.Currency,
+		CreatedAt: now().Time,
+	}
+	payment.UpdatedAt = payment.CreatedAt
+	m.payments[payment.ID] = payment
+
+	res := helpers.PaymentResponse(payment)
+	res.ClientSecret = intent.ClientSecret
+	return res, nil
+}
+
+func (m *MemoryService) GetPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	payment, err := m.payment(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOrderRead(ctx, "payment", payment.ID, payment.UserID.Int64); err != nil {
+		return nil, err
+	}
+
+	return helpers.PaymentResponse(payment), nil
+}
+
+func (m *MemoryService) payment(id int64) (repositories.Payment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	payment, ok := m.payments[id]
+	if !ok {
+		return repositories.Payment{}, NotFoundError("payment with id %d not found", id)
+	}
+
+	return payment, nil
+}
+
+func (m *MemoryService) ConfirmPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, claimed, err := m.claimPayment(caller, req.ID)
+	if !claimed {
+		return payment, err
+	}
+	defer m.releasePayment(payment.ID)
+
+	status, err := capturePayment(ctx, m.Payments, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.settlePayment(payment.ID, status), nil
+}
+
+// claimPayment marks a pending payment as being confirmed so concurrent
+// confirms do not capture it twice, claimed payments must be released with
+// releasePayment. Like checkConfirm, succeeded payments are returned without
+// being claimed.
+func (m *MemoryService) claimPayment(caller *responses.User, id int64) (*responses.Payment, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.payments[id]
+	if !ok {
+		return nil, false, NotFoundError("payment with id %d not found", id)
+	}
+
+	if err := checkOrderOwner(caller, "payment", id, stored.UserID.Int64); err != nil {
+		return nil, false, err
+	}
+
+	payment := helpers.PaymentResponse(stored)
+	capture, err := checkConfirm(payment)
+	if !capture {
+		return payment, false, err
+	}
+
+	if m.claimed[id] {
+		return nil, false, paymentConfirmingError(id)
+	}
+
+	// orders cancelled since the payment started are not charged
+	if payment.OrderID != nil {
+		order := m.orders[*payment.OrderID]
+		if status := requests.OrderStatus(order.Status); status != requests.OrderPending {
+			return nil, false, orderNotPayableError(order.ID, status)
+		}
+	}
+
+	m.claimed[id] = true
+	return payment, true, nil
+}
+
+func (m *MemoryService) releasePayment(id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.claimed, id)
+}
+
+func (m *MemoryService) RefundPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	payment, err := m.claimRefund(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer m.releasePayment(payment.ID)
+
+	status, err := refundPayment(ctx, m.Payments, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.settlePayment(payment.ID, status), nil
+}
+
+// claimRefund marks a succeeded payment as being refunded so concurrent
+// refunds do not refund it twice, like claimPayment.
+func (m *MemoryService) claimRefund(id int64) (*responses.Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.payments[id]
+	if !ok {
+		return nil, NotFoundError("payment with id %d not found", id)
+	}
+
+	payment := helpers.PaymentResponse(stored)
+	if err := checkRefund(payment); err != nil {
+		return nil, err
+	}
+
+	if m.claimed[id] {
+		return nil, paymentRefundingError(id)
+	}
+
+	m.claimed[id] = true
+	return payment, nil
+}
+
+func (m *MemoryService) HandlePaymentEvent(ctx context.Context, event payments.Event) (*responses.Payment, error) {
+	status := responses.PaymentStatus(event.Status)
+	if err := checkPaymentStatus(status); err != nil {
+		return nil, err
+	}
+
+	if m.Payments == nil {
+		return nil, errNoPaymentProvider
+	}
+
+	m.mu.RLock()
+	var id int64
+	for _, payment := range m.payments {
+		if payment.Provider == m.Payments.Name() && payment.IntentID == event.IntentID {
+			id = payment.ID
+		}
+	}
+	m.mu.RUnlock()
+
+	if id == 0 {
+		return nil, paymentIntentNotFoundError(event.IntentID)
+	}
+
+	return m.settlePayment(id, status), nil
+}
+
+func (m *MemoryService) Register(ctx context.Context, req requests.RegisterRequest) (*responses.Session, error) {
+	email, err := m.Emails.normalize(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePassword(req.Password); err != nil {
+		return nil, err
+	}
+
+	issuedAt := time.Now()
+	refresh, err := newRefreshToken(m.Tokens, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.emailTaken(email, 0) {
+		return nil, emailTakenError(email)
+	}
+
+	user := m.addUser(req.Name, email, nullString(&hash))
+	m.addRefreshToken(user.ID, refresh)
+
+	return newSession(m.Tokens, helpers.UserResponse(user), refresh, issuedAt)
+}
+
+func (m *MemoryService) Login(ctx context.Context, req requests.LoginRequest) (*responses.Session, error) {
+	issuedAt := time.Now()
+	refresh, err := newRefreshToken(m.Tokens, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	email := loginEmail(req.Email)
+	m.mu.RLock()
+	var user repositories.User
+	for _, u := range m.users {
+		if sameEmail(u.Email, email) {
+			user = u
+		}
+	}
+	m.mu.RUnlock()
+
+	if err := checkPassword(user.PasswordHash, req.Password); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[user.ID]; !ok {
+		return nil, invalidCredentialsError()
+	}
+	m.addRefreshToken(user.ID, refresh)
+
+	return newSession(m.Tokens, m.withRoles(helpers.UserResponse(user)), refresh, issuedAt)
+}
+
+func (m *MemoryService) RefreshSession(ctx context.Context, req requests.RefreshTokenRequest) (*responses.Session, error) {
+	issuedAt := time.Now()
+	refresh, err := newRefreshToken(m.Tokens, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.refreshToken(auth.HashRefreshToken(req.RefreshToken))
+	if !ok || !token.ExpiresAt.After(issuedAt) {
+		return nil, invalidRefreshTokenError()
+	}
+
+	// a token used twice was stolen, every session of the user ends
+	if token.RevokedAt.Valid {
+		m.revokeRefreshTokens(token.UserID)
+		return nil, invalidRefreshTokenError()
+	}
+
+	token.RevokedAt = now()
+	m.refreshTokens[token.ID] = token
+
+	user, ok := m.users[token.UserID]
+	if !ok {
+		return nil, NotFoundError("user with id %d not found", token.UserID)
+	}
+	m.addRefreshToken(user.ID, refresh)
+
+	return newSession(m.Tokens, m.withRoles(helpers.UserResponse(user)), refresh, issuedAt)
+}
+
+func (m *MemoryService) Logout(ctx context.Context, req requests.RefreshTokenRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	token, ok := m.refreshToken(auth.HashRefreshToken(req.RefreshToken))
+	if ok && !token.RevokedAt.Valid {
+		token.RevokedAt = now()
+		m.refreshTokens[token.ID] = token
+	}
+
+	return nil
+}
+
+func (m *MemoryService) Authenticate(ctx context.Context, token string) (*responses.User, error) {
+	id, err := accessTokenUser(m.Tokens, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil, userGoneError()
+	}
+
+	return m.withRoles(helpers.UserResponse(user)), nil
+}
+
+func (m *MemoryService) ListRoles(ctx context.Context) ([]*responses.Role, error) {
+	roles := make([]*responses.Role, len(memoryRoles))
+	for i, role := range memoryRoles {
+		roles[i] = &responses.Role{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: append([]string{}, role.Permissions...),
+		}
+	}
+
+	return roles, nil
+}
+
+func (m *MemoryService) GetUserRoles(ctx context.Context, req requests.BindUriID) ([]requests.Role, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.users[req.ID]; !ok {
+		return nil, NotFoundError("user with id %d not found", req.ID)
+	}
+
+	roles, _ := memoryUserRoles(m.userRoles[req.ID])
+	return roles, nil
+}
+
+func (m *MemoryService) AssignRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error) {
+	if err := validateRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[req.UserID]; !ok {
+		return nil, NotFoundError("user with id %d not found", req.UserID)
+	}
+
+	if m.userRoles[req.UserID] == nil {
+		m.userRoles[req.UserID] = make(map[requests.Role]bool)
+	}
+	m.userRoles[req.UserID][req.Role] = true
+
+	roles, _ := memoryUserRoles(m.userRoles[req.UserID])
+	return roles, nil
+}
+
+func (m *MemoryService) RevokeRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error) {
+	if err := validateRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[req.UserID]; !ok {
+		return nil, NotFoundError("user with id %d not found", req.UserID)
+	}
+
+	if req.Role == requests.RoleAdmin && m.userRoles[req.UserID][req.Role] {
+		admins := 0
+		for _, roles := range m.userRoles {
+			if roles[requests.RoleAdmin] {
+				admins++
+			}
+		}
+		if admins == 1 {
+			return nil, lastAdminError()
+		}
+	}
+	delete(m.userRoles[req.UserID], req.Role)
+
+	roles, _ := memoryUserRoles(m.userRoles[req.UserID])
+	return roles, nil
+}
+
+func (m *MemoryService) CreateAPIKey(ctx context.Context, req requests.CreateAPIKeyRequest) (*responses.NewAPIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, scopes, err := validateAPIKey(req.Name, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := apiKeyExpiry(req.ExpiresAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[caller.ID]; !ok {
+		return nil, NotFoundError("user with id %d not found", caller.ID)
+	}
+
+	m.lastAPIKeyID++
+	created := repositories.APIKey{
+		ID:        m.lastAPIKeyID,
+		UserID:    caller.ID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedAt: now().Time,
+	}
+	m.apiKeys[created.ID] = created
+
+	return &responses.NewAPIKey{APIKey: helpers.APIKeyResponse(created), Key: key}, nil
+}
+
+func (m *MemoryService) ListAPIKeys(ctx context.Context) ([]*responses.APIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var keys []repositories.APIKey
+	for _, key := range m.apiKeys {
+		if key.UserID == caller.ID {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	return helpers.APIKeySliceResponse(keys), nil
+}
+
+func (m *MemoryService) GetAPIKey(ctx context.Context, req requests.BindUriID) (*responses.APIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, err := m.userAPIKey(req.ID, caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.APIKeyResponse(key), nil
+}
+
+func (m *MemoryService) UpdateAPIKey(ctx context.Context, req requests.UpdateAPIKeyRequest) (*responses.APIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, scopes, err := validateAPIKey(req.Name, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, err := m.userAPIKey(req.ID, caller.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Name = name
+	key.Scopes = scopes
+	m.apiKeys[key.ID] = key
+
+	return helpers.APIKeyResponse(key), nil
+}
+
+func (m *MemoryService) DeleteAPIKey(ctx context.Context, req requests.BindUriID) error {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.userAPIKey(req.ID, caller.ID); err != nil {
+		return err
+	}
+
+	delete(m.apiKeys, req.ID)
+	return nil
+}
+
+func (m *MemoryService) AuthenticateAPIKey(ctx context.Context, key string) (*responses.User, *responses.APIKey, error) {
+	prefix, err := apiKeyPrefix(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var stored repositories.APIKey
+	var found bool
+	for _, k := range m.apiKeys {
+		if k.Prefix == prefix {
+			stored, found = k, true
+			break
+		}
+	}
+	if !found {
+		return nil, nil, invalidAPIKeyError()
+	}
+
+	used := now()
+	if err := checkAPIKey(key, stored.KeyHash, stored.ExpiresAt, used.Time); err != nil {
+		return nil, nil, err
+	}
+
+	if staleAPIKey(stored.LastUsedAt, used.Time) {
+		stored.LastUsedAt = used
+		m.apiKeys[stored.ID] = stored
+	}
+
+	user, ok := m.users[stored.UserID]
+	if !ok {
+		return nil, nil, userGoneError()
+	}
+
+	return m.withRoles(helpers.UserResponse(user)), helpers.APIKeyResponse(stored), nil
+}
+
+// withRoles sets the roles of user and the permissions they grant, m.mu must
+// be held.
+func (m *MemoryService) withRoles(user *responses.User) *responses.User {
+	user.Roles, user.Permissions = memoryUserRoles(m.userRoles[user.ID])
+	return user
+}
+
+// categoryNameTaken reports whether a sibling of category id under parentID
+// is named name, ignoring case. m.mu must be held.
+func (m *MemoryService) categoryNameTaken(name string, parentID sql.NullInt64, id int64) bool {
+	for _, category := range m.categories {
+		if category.ID != id && category.ParentID == parentID && strings.EqualFold(category.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// categoryAncestors returns id and the ids of its ancestors, m.mu must be
+// held.
+func (m *MemoryService) categoryAncestors(id int64) []int64 {
+	var ancestors []int64
+	for {
+		category, ok := m.categories[id]
+		if !ok {
+			return ancestors
+		}
+
+		ancestors = append(ancestors, category.ID)
+		if !category.ParentID.Valid {
+			return ancestors
+		}
+		id = category.ParentID.Int64
+	}
+}
+
+// productFilter returns whether a product passes the category and tag filters
+// of page, m.mu must be held.
+func (m *MemoryService) productFilter(page userProductsPage) func(productID int64) bool {
+	var subtree map[int64]bool
+	if page.categoryID != nil {
+		subtree = map[int64]bool{*page.categoryID: true}
+		for grew := true; grew; {
+			grew = false
+			for _, category := range m.categories {
+				if category.ParentID.Valid && subtree[category.ParentID.Int64] && !subtree[category.ID] {
+					subtree[category.ID] = true
+					grew = true
+				}
+			}
+		}
+	}
+
+	tag, tagged := repositories.Tag{}, true
+	if page.tag != nil {
+		tag, tagged = m.tagByName(*page.tag)
+	}
+
+	return func(productID int64) bool {
+		if page.tag != nil && (!tagged || !m.productTags[productID][tag.ID]) {
+			return false
+		}
+
+		if subtree == nil {
+			return true
+		}
+
+		for id := range m.productCategories[productID] {
+			if subtree[id] {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// categoriesOf returns the categories of a product in the order of the
+// GetBatchProductCategories query, m.mu must be held.
+func (m *MemoryService) categoriesOf(productID int64) []*responses.Category {
+	var categories []repositories.Category
+	for id := range m.productCategories[productID] {
+		categories = append(categories, m.categories[id])
+	}
+	sortCategories(categories)
+
+	return helpers.CategorySliceResponse(categories)
+}
+
+// tagsOf returns the tags of a product by name, m.mu must be held.
+func (m *MemoryService) tagsOf(productID int64) []*responses.Tag {
+	var tags []repositories.Tag
+	for id := range m.productTags[productID] {
+		tags = append(tags, m.tags[id])
+	}
+	sortTags(tags)
+
+	return helpers.TagSliceResponse(tags)
+}
+
+// tagByName requires m.mu to be held.
+func (m *MemoryService) tagByName(name string) (repositories.Tag, bool) {
+	for _, tag := range m.tags {
+		if tag.Name == name {
+			return tag, true
+		}
+	}
+
+	return repositories.Tag{}, false
+}
+
+// createTag requires m.mu to be held for writing.
+func (m *MemoryService) createTag(name string) repositories.Tag {
+	m.lastTagID++
+	tag := repositories.Tag{ID: m.lastTagID, Name: name, CreatedAt: now().Time}
+	m.tags[tag.ID] = tag
+
+	return tag
+}
+
+// deleteProduct removes a product for good together with its categories and
+// tags, like the cascading foreign keys do. m.mu must be held for writing.
+func (m *MemoryService) deleteProduct(id int64) {
+	delete(m.products, id)
+	delete(m.productCategories, id)
+	delete(m.productTags, id)
+	delete(m.inventory, id)
+
+	for reservationID, reservation := range m.reservations {
+		if reservation.ProductID == id {
+			delete(m.reservations, reservationID)
+		}
+	}
+
+	adjustments := m.stockAdjustments[:0]
+	for _, adjustment := range m.stockAdjustments {
+		if adjustment.ProductID != id {
+			adjustments = append(adjustments, adjustment)
+		}
+	}
+	m.stockAdjustments = adjustments
+
+	for i, item := range m.orderItems {
+		if item.ProductID.Valid && item.ProductID.Int64 == id {
+			m.orderItems[i].ProductID = sql.NullInt64{}
+		}
+	}
+
+	for paymentID, payment := range m.payments {
+		if payment.ProductID.Valid && payment.ProductID.Int64 == id {
+			payment.ProductID = sql.NullInt64{}
+			m.payments[paymentID] = payment
+		}
+	}
+}
+
+// paymentAmount returns what the target of req costs and the user paying it:
+// the owner of the order, or caller for products. Only pending orders and live
+// products can be paid. m.mu must be held.
+func (m *MemoryService) paymentAmount(caller *responses.User, req requests.StartPaymentRequest) (responses.Money, int64, error) {
+	if req.OrderID == nil {
+		prod, ok := m.products[*req.ProductID]
+		if !ok || prod.DeletedAt.Valid {
+			return responses.Money{}, 0, productNotOrderableError(*req.ProductID)
+		}
+
+		if _, ok := m.users[caller.ID]; !ok {
+			return responses.Money{}, 0, NewError(ErrForeignKeyViolation, "user with id %d not found", caller.ID)
+		}
+
+		return responses.Money{Amount: prod.Price, Currency: prod.Currency}, caller.ID, nil
+	}
+
+	order, ok := m.orders[*req.OrderID]
+	if !ok {
+		return responses.Money{}, 0, NewError(ErrForeignKeyViolation, "order with id %d not found", *req.OrderID)
+	}
+
+	if err := checkOrderOwner(caller, "order", order.ID, order.UserID); err != nil {
+		return responses.Money{}, 0, err
+	}
+
+	if status := requests.OrderStatus(order.Status); status != requests.OrderPending {
+		return responses.Money{}, 0, orderNotPayableError(order.ID, status)
+	}
+
+	if m.orderPaymentExists(order.ID) {
+		return responses.Money{}, 0, orderPaymentExistsError(order.ID)
+	}
+
+	return responses.Money{Amount: order.Total, Currency: order.Currency}, order.UserID, nil
+}
+
+// orderPaymentExists reports whether an order has a payment that did not
+// fail, like the unique index on payments.order_id. m.mu must be held.
+func (m *MemoryService) orderPaymentExists(orderID int64) bool {
+	for _, payment := range m.payments {
+		if payment.OrderID.Valid && payment.OrderID.Int64 == orderID && payment.Status != string(responses.PaymentFailed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// settlePayment moves payment id to status. Repeated and stale updates leave
+// the payment as it is, orders are paid once their payment succeeds.
+func (m *MemoryService) settlePayment(id int64, status responses.PaymentStatus) *responses.Payment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	payment := m.payments[id]
+	if !canMovePayment(responses.PaymentStatus(payment.Status), status) {
+		return helpers.PaymentResponse(payment)
+	}
+
+	payment.Status = string(status)
+	payment.UpdatedAt = now().Time
+	m.payments[id] = payment
+
+	if status == responses.PaymentSucceeded && payment.OrderID.Valid {
+		order := m.orders[payment.OrderID.Int64]
+		if order.Status == string(requests.OrderPending) {
+			order.Status = string(requests.OrderPaid)
+			order.UpdatedAt = payment.UpdatedAt
+			m.orders[order.ID] = order
+		}
+	}
+
+	return helpers.PaymentResponse(payment)
+}
+
+// stockOf returns the stock of a product, m.mu must be held.
+func (m *MemoryService) stockOf(productID int64) *responses.Stock {
+	inventory, ok := m.inventory[productID]
+	if !ok {
+		return &responses.Stock{ProductID: productID}
+	}
+
+	return helpers.StockResponse(inventory)
+}
+
+// setInventory stores the stock of a product, m.mu must be held.
+func (m *MemoryService) setInventory(productID, onHand, reserved int64) repositories.Inventory {
+	inventory := repositories.Inventory{
+		ProductID: productID,
+		OnHand:    onHand,
+		Reserved:  reserved,
+		UpdatedAt: now().Time,
+	}
+	m.inventory[productID] = inventory
+
+	return inventory
+}
+
+func (m *MemoryService) addStockAdjustment(productID, delta int64, reason requests.StockReason, note string) {
+	m.lastStockAdjustmentID++
+	m.stockAdjustments = append(m.stockAdjustments, repositories.StockAdjustment{
+		ID:        m.lastStockAdjustmentID,
+		ProductID: productID,
+		Delta:     delta,
+		Reason:    string(reason),
+		Note:      note,
+		CreatedAt: now().Time,
+	})
+}
+
+func sortCategories(categories []repositories.Category) {
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := strings.ToLower(categories[i].Name), strings.ToLower(categories[j].Name)
+		if a != b {
+			return a < b
+		}
+		return categories[i].ID < categories[j].ID
+	})
+}
+
+func sortTags(tags []repositories.Tag) {
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+}
+
+// emailTaken reports whether a user other than id has email, it requires
+// m.mu to be held.
+// addUser creates a user, m.mu must be held for writing.
+func (m *MemoryService) addUser(name, email string, passwordHash sql.NullString) repositories.User {
+	m.lastUserID++
+	user := repositories.User{
+		ID:           m.lastUserID,
+		Name:         name,
+		Email:        email,
+		Version:      1,
+		CreatedAt:    now(),
+		PasswordHash: passwordHash,
+	}
+	user.UpdatedAt = user.CreatedAt
+	m.users[user.ID] = user
+
+	return user
+}
+
+// refreshToken returns the refresh token stored under hash, m.mu must be
+// held.
+func (m *MemoryService) refreshToken(hash string) (repositories.RefreshToken, bool) {
+	for _, token := range m.refreshTokens {
+		if token.TokenHash == hash {
+			return token, true
+		}
+	}
+
+	return repositories.RefreshToken{}, false
+}
+
+// addRefreshToken stores the hash of refresh, m.mu must be held for writing.
+func (m *MemoryService) addRefreshToken(userID int64, refresh refreshToken) {
+	m.lastRefreshTokenID++
+	m.refreshTokens[m.lastRefreshTokenID] = repositories.RefreshToken{
+		ID:        m.lastRefreshTokenID,
+		UserID:    userID,
+		TokenHash: refresh.hash,
+		ExpiresAt: refresh.expiresAt,
+		CreatedAt: now().Time,
+	}
+}
+
+// revokeRefreshTokens ends every session of a user, m.mu must be held for
+// writing.
+func (m *MemoryService) revokeRefreshTokens(userID int64) {
+	revokedAt := now()
+	for id, token := range m.refreshTokens {
+		if token.UserID == userID && !token.RevokedAt.Valid {
+			token.RevokedAt = revokedAt
+			m.refreshTokens[id] = token
+		}
+	}
+}
+
+// userAPIKey returns the API key id of userID, keys of other users are not
+// found. m.mu must be held.
+func (m *MemoryService) userAPIKey(id, userID int64) (repositories.APIKey, error) {
+	key, ok := m.apiKeys[id]
+	if !ok || key.UserID != userID {
+		return repositories.APIKey{}, NotFoundError("API key with id %d not found", id)
+	}
+
+	return key, nil
+}
+
+func (m *MemoryService) emailTaken(email string, id int64) bool {
+	for _, user := range m.users {
+		if user.ID != id && sameEmail(user.Email, email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// now mimics a postgres TIMESTAMPTZ default: UTC with microsecond precision
+// and without the monotonic clock reading, so cursors round trip.
+func now() sql.NullTime {
+	return sql.NullTime{
+		Valid: true,
+		Time:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
@@ -0,0 +1,164 @@
+package services_test
+
+import (
+	"context"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"sqlc-rest-api/services/servicetest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryService(t *testing.T) {
+	servicetest.Run(t, func(t *testing.T) services.Service {
+		service := services.NewMemoryService()
+		service.Payments = payments.NewFake()
+		service.Tokens = auth.Tokens{Secret: []byte("secret")}
+		return service
+	})
+}
+
+func TestUserDeletionDefault(t *testing.T) {
+	require.Error(t, services.UserDeletion{Policy: "orphan"}.Validate())
+
+	service := services.NewMemoryService()
+	user, err := service.CreateUser(context.Background(), requests.CreateUserRequest{Name: "royyan", Email: "royyan@gmail.com"})
+	require.NoError(t, err)
+	heir, err := service.CreateUser(context.Background(), requests.CreateUserRequest{Name: "heir", Email: "heir@gmail.com"})
+	require.NoError(t, err)
+
+	product, err := service.CreateProduct(auth.WithUser(context.Background(), user), requests.CreateProductRequest{UserID: user.ID, Name: "product", Price: 100})
+	require.NoError(t, err)
+
+	// requests without a policy use the configured one
+	service.UserDeletion = services.UserDeletion{Policy: requests.UserDeleteReassign, ReassignTo: heir.ID}
+	require.NoError(t, service.UserDeletion.Validate())
+
+	deleted, err := service.DeleteUser(context.Background(), requests.DeleteUserRequest{ID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, requests.UserDeleteReassign, deleted.Policy)
+	require.Equal(t, heir.ID, *deleted.ReassignedTo)
+
+	got, err := service.GetProduct(context.Background(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, heir.ID, got.UserID)
+
+	// the configured heir cannot be deleted with the reassign policy
+	_, err = service.DeleteUser(context.Background(), requests.DeleteUserRequest{ID: heir.ID})
+	require.Equal(t, services.ErrValidation, services.ErrorCodeOf(err))
+}
+
+func TestDisposableEmailDomains(t *testing.T) {
+	service := services.NewMemoryService()
+	service.Emails = services.NewEmailPolicy([]string{" Mailinator.com", "", "yopmail.com"})
+
+	for _, email := range []string{"royyan@mailinator.com", "royyan@eu.MAILINATOR.com", "royyan@yopmail.com"} {
+		_, err := service.CreateUser(context.Background(), requests.CreateUserRequest{Name: "royyan", Email: email})
+		require.Equal(t, services.ErrValidation, services.ErrorCodeOf(err), email)
+	}
+
+	user, err := service.CreateUser(context.Background(), requests.CreateUserRequest{Name: "royyan", Email: "royyan@notmailinator.com"})
+	require.NoError(t, err)
+	require.Equal(t, "royyan@notmailinator.com", user.Email)
+}
+
+func TestCurrencies(t *testing.T) {
+	require.Error(t, services.Currencies{Default: "XYZ"}.Validate())
+	require.Error(t, services.Currencies{Rounding: "nearest"}.Validate())
+
+	ctx := context.Background()
+	service := services.NewMemoryService()
+	user, err := service.CreateUser(ctx, requests.CreateUserRequest{Name: "royyan", Email: "royyan@gmail.com"})
+	require.NoError(t, err)
+
+	// products without a currency get the configured default
+	service.Currencies = services.Currencies{Default: "EUR"}
+	product, err := service.CreateProduct(auth.WithUser(ctx, user), requests.CreateProductRequest{UserID: user.ID, Name: "product", Price: 100})
+	require.NoError(t, err)
+	require.Equal(t, "EUR", product.Price.Currency)
+
+	_, err = service.SetExchangeRate(ctx, requests.SetExchangeRateRequest{Base: "EUR", Quote: "USD", Rate: "0.5"})
+	require.NoError(t, err)
+
+	// 0.5 and -0.5 cents, and 1.5 cents
+	prices := []responses.Money{{Amount: 1, Currency: "EUR"}, {Amount: -1, Currency: "EUR"}, {Amount: 3, Currency: "EUR"}}
+	testCases := []struct {
+		rounding services.Rounding
+		expected []int64
+	}{
+		{services.RoundHalfEven, []int64{0, 0, 2}},
+		{services.RoundHalfUp, []int64{1, -1, 2}},
+		{services.RoundDown, []int64{0, 0, 1}},
+		{services.RoundUp, []int64{1, -1, 2}},
+	}
+
+	for _, testCase := range testCases {
+		service.Currencies.Rounding = testCase.rounding
+		converted, err := service.ConvertPrices(ctx, prices, "USD")
+		require.NoError(t, err)
+		for i, expected := range testCase.expected {
+			require.Equal(t, responses.Money{Amount: expected, Currency: "USD"}, converted[i], testCase.rounding)
+		}
+	}
+}
+
+func TestProductPolicy(t *testing.T) {
+	ctx := context.Background()
+	service := services.NewMemoryService()
+	user, err := service.CreateUser(ctx, requests.CreateUserRequest{Name: "royyan", Email: "royyan@gmail.com"})
+	require.NoError(t, err)
+	ctx = auth.WithUser(ctx, user)
+
+	// rules are added next to the owner policy
+	service.Policy = services.Policies{
+		services.OwnerPolicy{},
+		services.PolicyFunc(func(ctx context.Context, caller *responses.User, action services.Action, resource services.Resource) error {
+			if action == services.ActionDelete {
+				return services.ForbiddenError("products are never deleted")
+			}
+			return nil
+		}),
+	}
+
+	product, err := service.CreateProduct(ctx, requests.CreateProductRequest{UserID: user.ID, Name: "product", Price: 100})
+	require.NoError(t, err)
+
+	_, err = service.DeleteProduct(ctx, requests.DeleteProductRequest{ID: product.ID})
+	require.Equal(t, services.ErrForbidden, services.ErrorCodeOf(err))
+
+	_, err = service.DeleteProduct(auth.WithUser(ctx, &responses.User{ID: user.ID + 1, Permissions: []string{auth.PermissionManageProducts}}), requests.DeleteProductRequest{ID: product.ID})
+	require.Equal(t, services.ErrForbidden, services.ErrorCodeOf(err))
+
+	_, err = service.UpdateProduct(ctx, requests.UpdateProductRequest{ID: product.ID, Name: "updated", Price: 200})
+	require.NoError(t, err)
+}
+
+func TestLastAdmin(t *testing.T) {
+	ctx := context.Background()
+	service := services.NewMemoryService()
+	first, err := service.CreateUser(ctx, requests.CreateUserRequest{Name: "first", Email: "first@gmail.com"})
+	require.NoError(t, err)
+	second, err := service.CreateUser(ctx, requests.CreateUserRequest{Name: "second", Email: "second@gmail.com"})
+	require.NoError(t, err)
+
+	for _, user := range []*responses.User{first, second} {
+		_, err = service.AssignRole(ctx, requests.UserRoleRequest{UserID: user.ID, Role: requests.RoleAdmin})
+		require.NoError(t, err)
+	}
+
+	roles, err := service.RevokeRole(ctx, requests.UserRoleRequest{UserID: first.ID, Role: requests.RoleAdmin})
+	require.NoError(t, err)
+	require.Empty(t, roles)
+
+	// someone has to be left to assign roles
+	_, err = service.RevokeRole(ctx, requests.UserRoleRequest{UserID: second.ID, Role: requests.RoleAdmin})
+	require.Equal(t, services.ErrConflict, services.ErrorCodeOf(err))
+
+	roles, err = service.GetUserRoles(ctx, requests.BindUriID{ID: second.ID})
+	require.NoError(t, err)
+	require.Equal(t, []requests.Role{requests.RoleAdmin}, roles)
+}
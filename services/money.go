@@ -0,0 +1,277 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// DefaultCurrency prices products created without a currency unless
+// Currencies.Default says otherwise.
+const DefaultCurrency = "USD"
+
+// Rounding decides how converted prices that fall between two minor units are
+// rounded.
+type Rounding string
+
+const (
+	// RoundHalfEven rounds halves to the even neighbour, so rounding errors
+	// don't add up over many conversions.
+	RoundHalfEven Rounding = "half_even"
+	RoundHalfUp   Rounding = "half_up"
+	// RoundDown truncates towards zero and RoundUp away from it.
+	RoundDown Rounding = "down"
+	RoundUp   Rounding = "up"
+)
+
+// Currencies configures the currency of new products and the conversion of
+// prices into other currencies. The zero value uses DefaultCurrency and
+// RoundHalfEven.
+type Currencies struct {
+	Default  string
+	Rounding Rounding
+}
+
+// Validate reports configurations prices could never be converted with.
+func (c Currencies) Validate() error {
+	if c.Default != "" {
+		if _, ok := responses.MinorUnits(c.Default); !ok {
+			return fmt.Errorf("unknown default currency %q", c.Default)
+		}
+	}
+
+	switch c.Rounding {
+	case "", RoundHalfEven, RoundHalfUp, RoundDown, RoundUp:
+		return nil
+	default:
+		return fmt.Errorf("unknown rounding %q", c.Rounding)
+	}
+}
+
+func (c Currencies) defaultCurrency() string {
+	if c.Default == "" {
+		return DefaultCurrency
+	}
+
+	return c.Default
+}
+
+// orDefault returns currency, the default currency when it is empty.
+func (c Currencies) orDefault(currency string) string {
+	if currency == "" {
+		return c.defaultCurrency()
+	}
+
+	return currency
+}
+
+// currency checks the currency of a new product, empty means the default
+// currency.
+func (c Currencies) currency(currency string) (string, error) {
+	if currency == "" {
+		return c.defaultCurrency(), nil
+	}
+
+	return currency, checkCurrency(currency)
+}
+
+// checkCurrency accepts the ISO 4217 codes responses.MinorUnits knows, in
+// upper case.
+func checkCurrency(currency string) error {
+	if _, ok := responses.MinorUnits(currency); !ok {
+		return ValidationError("unknown currency %q", currency)
+	}
+
+	return nil
+}
+
+// checkUpdatedCurrency checks the currency of an update, empty keeps the
+// stored one.
+func checkUpdatedCurrency(currency string) error {
+	if currency == "" {
+		return nil
+	}
+
+	return checkCurrency(currency)
+}
+
+func updatedCurrency(requested, stored string) string {
+	if requested == "" {
+		return stored
+	}
+
+	return requested
+}
+
+var decimalRate = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// parseRate accepts positive decimal numbers, fractions like 1/3 can't be
+// stored.
+func parseRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !decimalRate.MatchString(rate) || !ok || r.Sign() <= 0 {
+		return nil, ValidationError("rate must be a positive decimal number, got %q", rate)
+	}
+
+	return r, nil
+}
+
+func checkExchangeRate(req requests.SetExchangeRateRequest) error {
+	if err := checkCurrency(req.Base); err != nil {
+		return err
+	}
+
+	if err := checkCurrency(req.Quote); err != nil {
+		return err
+	}
+
+	if req.Base == req.Quote {
+		return ValidationError("cannot set the rate of %s to itself", req.Base)
+	}
+
+	_, err := parseRate(req.Rate)
+	return err
+}
+
+// rateTable looks up the rate between two currencies. Pairs without a rate of
+// their own use the inverse rate or go through the default currency.
+type rateTable struct {
+	rates map[[2]string]*big.Rat
+	pivot string
+}
+
+func newRateTable(rates []*responses.ExchangeRate, pivot string) (*rateTable, error) {
+	table := &rateTable{rates: make(map[[2]string]*big.Rat, len(rates)), pivot: pivot}
+	for _, rate := range rates {
+		r, err := parseRate(rate.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("exchange rate %s/%s: %w", rate.Base, rate.Quote, err)
+		}
+		table.rates[[2]string{rate.Base, rate.Quote}] = r
+	}
+
+	return table, nil
+}
+
+func (t *rateTable) direct(from, to string) (*big.Rat, bool) {
+	if r, ok := t.rates[[2]string{from, to}]; ok {
+		return r, true
+	}
+
+	if r, ok := t.rates[[2]string{to, from}]; ok {
+		return new(big.Rat).Inv(r), true
+	}
+
+	return nil, false
+}
+
+func (t *rateTable) rate(from, to string) (*big.Rat, error) {
+	if r, ok := t.direct(from, to); ok {
+		return r, nil
+	}
+
+	if from != t.pivot && to != t.pivot {
+		first, ok := t.direct(from, t.pivot)
+		if ok {
+			if second, ok := t.direct(t.pivot, to); ok {
+				return new(big.Rat).Mul(first, second), nil
+			}
+		}
+	}
+
+	return nil, ValidationError("no exchange rate from %s to %s", from, to)
+}
+
+// convert returns price in currency, rounded to its minor unit.
+func (c Currencies) convert(table *rateTable, price responses.Money, currency string) (responses.Money, error) {
+	if price.Currency == currency {
+		return price, nil
+	}
+
+	rate, err := table.rate(price.Currency, currency)
+	if err != nil {
+		return responses.Money{}, err
+	}
+
+	from, _ := responses.MinorUnits(price.Currency)
+	to, _ := responses.MinorUnits(currency)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to-from))), nil))
+
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(price.Amount), rate)
+	if to > from {
+		amount.Mul(amount, scale)
+	} else {
+		amount.Quo(amount, scale)
+	}
+
+	rounded := c.round(amount)
+	if !rounded.IsInt64() {
+		return responses.Money{}, ValidationError("%s does not fit a price in %s", price, currency)
+	}
+
+	return responses.Money{Amount: rounded.Int64(), Currency: currency}, nil
+}
+
+// round rounds r to an integer with the configured rounding.
+func (c Currencies) round(r *big.Rat) *big.Int {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return q
+	}
+
+	// away is q moved one unit away from zero, where the remainder points
+	away := new(big.Int).Add(q, big.NewInt(int64(rem.Sign())))
+
+	// compare the remainder with half a unit: 2|rem| against the denominator
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(r.Denom())
+
+	switch c.Rounding {
+	case RoundDown:
+		return q
+	case RoundUp:
+		return away
+	case RoundHalfUp:
+		if cmp >= 0 {
+			return away
+		}
+		return q
+	default:
+		if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+			return away
+		}
+		return q
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// convertPrices converts every price into currency with the given rates.
+func (c Currencies) convertPrices(rates []*responses.ExchangeRate, prices []responses.Money, currency string) ([]responses.Money, error) {
+	if err := checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	table, err := newRateTable(rates, c.defaultCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]responses.Money, len(prices))
+	for i, price := range prices {
+		if converted[i], err = c.convert(table, price, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+)
+
+func nullInt64(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
+
+func nullFloat64(v *float64) sql.NullFloat64 {
+	if v == nil {
+		return sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: *v, Valid: true}
+}
+
+func nullString(v *string) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: *v, Valid: true}
+}
+
+func nullTime(v *time.Time) sql.NullTime {
+	if v == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *v, Valid: true}
+}
+
+// nullable is used for sqlite parameters sqlc could not infer a type for, a
+// nil pointer becomes SQL NULL.
+func nullable[T any](v *T) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	return *v
+}
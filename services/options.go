@@ -0,0 +1,41 @@
+package services
+
+import (
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/payments"
+	"time"
+)
+
+// Options are the settings shared by every Service implementation, they are
+// embedded so each service can be configured the same way.
+type Options struct {
+	// MaxPageSize caps first and last of GetUserProducts, zero means
+	// DefaultMaxPageSize.
+	MaxPageSize int
+
+	// UserDeletion is used by DeleteUser when the request has no policy.
+	UserDeletion UserDeletion
+
+	// Emails validates and normalizes the emails of created and updated
+	// users.
+	Emails EmailPolicy
+
+	// Currencies sets the currency of new products and converts prices.
+	Currencies Currencies
+
+	// ReservationTTL is how long reservations hold stock unless the request
+	// asks otherwise, zero means DefaultReservationTTL.
+	ReservationTTL time.Duration
+
+	// Payments is the gateway payments are made with, starting a payment
+	// fails without one.
+	Payments payments.Provider
+
+	// Tokens signs the access tokens of logged in users, logging in fails
+	// without a secret.
+	Tokens auth.Tokens
+
+	// Policy decides who may create, update and delete products, nil means
+	// OwnerPolicy.
+	Policy Policy
+}
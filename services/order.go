@@ -0,0 +1,138 @@
+package services
+
+import (
+	"math"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// MaxOrderItems caps the number of items of an order.
+const MaxOrderItems = 100
+
+// orderTransitions lists the statuses every status can move to, shipped and
+// cancelled orders are final.
+var orderTransitions = map[requests.OrderStatus][]requests.OrderStatus{
+	requests.OrderPending: {requests.OrderPaid, requests.OrderCancelled},
+	requests.OrderPaid:    {requests.OrderShipped, requests.OrderCancelled},
+}
+
+func validateCreateOrder(req requests.CreateOrderRequest) error {
+	if req.UserID < 0 {
+		return ValidationError("user_id must be at least 1")
+	}
+
+	if len(req.Items) < 1 || len(req.Items) > MaxOrderItems {
+		return ValidationError("an order must have between 1 and %d items", MaxOrderItems)
+	}
+
+	seen := make(map[int64]bool, len(req.Items))
+	for _, item := range req.Items {
+		if item.ProductID < 1 {
+			return ValidationError("product ids must be at least 1")
+		}
+
+		if item.Quantity < 1 {
+			return ValidationError("quantity must be at least 1")
+		}
+
+		if seen[item.ProductID] {
+			return ValidationError("product with id %d is ordered more than once", item.ProductID)
+		}
+		seen[item.ProductID] = true
+	}
+
+	if req.Currency != "" {
+		return checkCurrency(req.Currency)
+	}
+
+	return nil
+}
+
+// orderLine is an item of a new order priced in the currency of the order.
+type orderLine struct {
+	productID   int64
+	productName string
+	unitPrice   int64
+	quantity    int64
+}
+
+// priceOrder snapshots the prices of products, which are in the order of the
+// items of req, and returns the currency, lines and total of the order.
+func (c Currencies) priceOrder(req requests.CreateOrderRequest, products []*responses.Product, rates []*responses.ExchangeRate) (string, []orderLine, int64, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = products[0].Price.Currency
+	}
+
+	prices := make([]responses.Money, len(products))
+	for i, product := range products {
+		if product.Price.Amount < 0 {
+			return "", nil, 0, ValidationError("product with id %d has a negative price", product.ID)
+		}
+		prices[i] = product.Price
+	}
+
+	converted, err := c.convertPrices(rates, prices, currency)
+	if err != nil {
+		return "", nil, 0, err
+	}
+
+	lines := make([]orderLine, len(products))
+	var total int64
+	for i, product := range products {
+		lines[i] = orderLine{
+			productID:   product.ID,
+			productName: product.Name,
+			unitPrice:   converted[i].Amount,
+			quantity:    req.Items[i].Quantity,
+		}
+
+		amount, ok := multiply(lines[i].unitPrice, lines[i].quantity)
+		if !ok || amount > math.MaxInt64-total {
+			return "", nil, 0, ValidationError("order total does not fit in %s", currency)
+		}
+		total += amount
+	}
+
+	return currency, lines, total, nil
+}
+
+// multiply returns a*b for non-negative a and positive b and whether it did
+// not overflow.
+func multiply(a, b int64) (int64, bool) {
+	if a > math.MaxInt64/b {
+		return 0, false
+	}
+
+	return a * b, true
+}
+
+// checkOrderTransition refuses transitions the order state machine does not
+// have, moving an order to the status it is in included.
+func checkOrderTransition(id int64, from, to requests.OrderStatus) error {
+	switch to {
+	case requests.OrderPending, requests.OrderPaid, requests.OrderShipped, requests.OrderCancelled:
+	default:
+		return ValidationError("status must be one of pending, paid, shipped or cancelled")
+	}
+
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+
+	return ConflictError("order with id %d cannot move from %s to %s", id, from, to)
+}
+
+func productNotOrderableError(id int64) error {
+	return NewError(ErrForeignKeyViolation, "product with id %d not found", id)
+}
+
+func hasOrdersError(id int64, orders int64) error {
+	return ConflictError("user with id %d still has %d orders", id, orders)
+}
+
+func orderStatusChangedError(id int64, from requests.OrderStatus) error {
+	return ConflictError("order with id %d is no longer %s", id, from)
+}
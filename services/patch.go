@@ -0,0 +1,35 @@
+package services
+
+import "sqlc-rest-api/requests"
+
+// validateProductPatch applies the rules of an update to the fields the patch
+// sets.
+func validateProductPatch(req requests.PatchProductRequest) error {
+	if req.Name != nil && *req.Name == "" {
+		return ValidationError("name is required")
+	}
+
+	if req.Price != nil && *req.Price == 0 {
+		return ValidationError("price is required")
+	}
+
+	if req.Currency != nil {
+		return checkCurrency(*req.Currency)
+	}
+
+	return nil
+}
+
+// validateUserPatch applies the rules of a new user to the fields the patch
+// sets.
+func validateUserPatch(req requests.PatchUserRequest) error {
+	if req.Name != nil && *req.Name == "" {
+		return ValidationError("name is required")
+	}
+
+	if req.Email != nil && *req.Email == "" {
+		return ValidationError("email is required")
+	}
+
+	return nil
+}
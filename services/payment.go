@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+const (
+	// paymentsOrderIndex lets an order have one payment that did not fail.
+	paymentsOrderIndex = "payments_order_id_key"
+	// paymentsOrderColumn is how sqlite names paymentsOrderIndex in its
+	// errors.
+	paymentsOrderColumn = "payments.order_id"
+)
+
+// paymentTransitions lists the statuses every status can move to, failed and
+// refunded payments are final.
+var paymentTransitions = map[responses.PaymentStatus][]responses.PaymentStatus{
+	responses.PaymentPending:   {responses.PaymentSucceeded, responses.PaymentFailed},
+	responses.PaymentSucceeded: {responses.PaymentRefunded},
+}
+
+var errNoPaymentProvider = NewError(ErrInternal, "no payment provider is configured")
+
+func validateStartPayment(req requests.StartPaymentRequest) error {
+	if (req.OrderID == nil) == (req.ProductID == nil) {
+		return ValidationError("exactly one of order_id and product_id is required")
+	}
+
+	if req.OrderID != nil && *req.OrderID < 1 {
+		return ValidationError("order_id must be at least 1")
+	}
+
+	if req.ProductID != nil && *req.ProductID < 1 {
+		return ValidationError("product_id must be at least 1")
+	}
+
+	return nil
+}
+
+// canMovePayment reports whether a payment can move from one status to the
+// other.
+func canMovePayment(from, to responses.PaymentStatus) bool {
+	for _, next := range paymentTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkPaymentStatus(status responses.PaymentStatus) error {
+	switch status {
+	case responses.PaymentPending, responses.PaymentSucceeded, responses.PaymentFailed, responses.PaymentRefunded:
+		return nil
+	}
+
+	return ValidationError("status must be one of pending, succeeded, failed or refunded")
+}
+
+// checkConfirm refuses to confirm payments that already failed or were
+// refunded. Confirming a succeeded payment is a no-op, the bool reports
+// whether the provider still has to capture it.
+func checkConfirm(payment *responses.Payment) (bool, error) {
+	switch payment.Status {
+	case responses.PaymentPending:
+		return true, nil
+	case responses.PaymentSucceeded:
+		return false, nil
+	}
+
+	return false, paymentClosedError(payment)
+}
+
+func checkRefund(payment *responses.Payment) error {
+	if payment.Status != responses.PaymentSucceeded {
+		return ConflictError("payment with id %d is %s, only succeeded payments can be refunded", payment.ID, payment.Status)
+	}
+
+	return nil
+}
+
+// createIntent asks provider for an intent paying amount for the payment
+// target described by reference.
+func createIntent(ctx context.Context, provider payments.Provider, amount responses.Money, reference string) (payments.Intent, error) {
+	if provider == nil {
+		return payments.Intent{}, errNoPaymentProvider
+	}
+
+	intent, err := provider.CreateIntent(ctx, payments.IntentParams{
+		Amount:    amount.Amount,
+		Currency:  amount.Currency,
+		Reference: reference,
+	})
+
+	return intent, providerError(err)
+}
+
+func capturePayment(ctx context.Context, provider payments.Provider, payment *responses.Payment) (responses.PaymentStatus, error) {
+	if err := checkProvider(provider, payment); err != nil {
+		return "", err
+	}
+
+	intent, err := provider.Capture(ctx, payment.IntentID)
+	if err != nil {
+		return "", providerError(err)
+	}
+
+	return responses.PaymentStatus(intent.Status), nil
+}
+
+// refundPayment gives back the whole amount of payment.
+func refundPayment(ctx context.Context, provider payments.Provider, payment *responses.Payment) (responses.PaymentStatus, error) {
+	if err := checkProvider(provider, payment); err != nil {
+		return "", err
+	}
+
+	intent, err := provider.Refund(ctx, payment.IntentID, payment.Amount.Amount)
+	if err != nil {
+		return "", providerError(err)
+	}
+
+	return responses.PaymentStatus(intent.Status), nil
+}
+
+// checkProvider refuses payments made through another provider than the one
+// of the service, their intents are unknown to it.
+func checkProvider(provider payments.Provider, payment *responses.Payment) error {
+	if provider == nil {
+		return errNoPaymentProvider
+	}
+
+	if provider.Name() != payment.Provider {
+		return ConflictError("payment with id %d was made with provider %s", payment.ID, payment.Provider)
+	}
+
+	return nil
+}
+
+func paymentReference(req requests.StartPaymentRequest) string {
+	if req.OrderID != nil {
+		return fmt.Sprintf("order %d", *req.OrderID)
+	}
+
+	return fmt.Sprintf("product %d", *req.ProductID)
+}
+
+func providerError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{
+		Code:    ErrPaymentProvider,
+		Message: fmt.Sprintf("payment provider: %v", err),
+		Err:     err,
+	}
+}
+
+func orderNotPayableError(id int64, status requests.OrderStatus) error {
+	return ConflictError("order with id %d is %s, only pending orders can be paid", id, status)
+}
+
+func orderPaymentExistsError(id int64) error {
+	return ConflictError("order with id %d already has a payment", id)
+}
+
+func paymentClosedError(payment *responses.Payment) error {
+	return ConflictError("payment with id %d is %s", payment.ID, payment.Status)
+}
+
+func paymentConfirmingError(id int64) error {
+	return ConflictError("payment with id %d is being confirmed", id)
+}
+
+func paymentRefundingError(id int64) error {
+	return ConflictError("payment with id %d is being refunded", id)
+}
+
+func paymentStatusChangedError(id int64, from responses.PaymentStatus) error {
+	return ConflictError("payment with id %d is no longer %s", id, from)
+}
+
+func paymentIntentNotFoundError(intentID string) error {
+	return NotFoundError("payment with intent id %q not found", intentID)
+}
+
+// paymentError reports a second live payment of an order as
+// orderPaymentExistsError, other errors are classified by dbError.
+func paymentError(err error, orderID int64) error {
+	if isUniqueViolation(err, paymentsOrderIndex) || isUniqueViolation(err, paymentsOrderColumn) {
+		return orderPaymentExistsError(orderID)
+	}
+
+	return dbError(err, "payment", 0)
+}
@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"context"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider counts captures and refunds, they are slowed down so
+// concurrent calls overlap.
+type countingProvider struct {
+	*payments.Fake
+	captures atomic.Int64
+	refunds  atomic.Int64
+}
+
+func (p *countingProvider) Capture(ctx context.Context, intentID string) (payments.Intent, error) {
+	p.captures.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return p.Fake.Capture(ctx, intentID)
+}
+
+func (p *countingProvider) Refund(ctx context.Context, intentID string, amount int64) (payments.Intent, error) {
+	p.refunds.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return p.Fake.Refund(ctx, intentID, amount)
+}
+
+var paymentFactories = map[string]func(t *testing.T, provider payments.Provider) services.Service{
+	"memory": func(t *testing.T, provider payments.Provider) services.Service {
+		service := services.NewMemoryService()
+		service.Payments = provider
+		return service
+	},
+	"sqlite": func(t *testing.T, provider payments.Provider) services.Service {
+		service := newSqliteService(t)
+		service.Payments = provider
+		return service
+	},
+}
+
+// startPayment starts paying for a product as a new user and acts as it.
+func startPayment(t *testing.T, service services.Service) (context.Context, *responses.Payment) {
+	user, err := service.CreateUser(context.Background(), requests.CreateUserRequest{Name: "royyan", Email: "royyan@gmail.com"})
+	require.NoError(t, err)
+	ctx := auth.WithUser(context.Background(), user)
+
+	product, err := service.CreateProduct(ctx, requests.CreateProductRequest{UserID: user.ID, Name: "product", Price: 100})
+	require.NoError(t, err)
+
+	payment, err := service.StartPayment(ctx, requests.StartPaymentRequest{ProductID: &product.ID})
+	require.NoError(t, err)
+
+	return ctx, payment
+}
+
+// concurrently calls fn n times at once and requires the calls that lost the
+// race to have seen the payment settled or to have been refused while it was
+// settling.
+func concurrently(t *testing.T, n int, fn func() error) {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = fn()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			require.Equal(t, services.ErrConflict, services.ErrorCodeOf(err), err)
+		}
+	}
+}
+
+func TestConfirmPaymentConcurrently(t *testing.T) {
+	for name, factory := range paymentFactories {
+		t.Run(name, func(t *testing.T) {
+			provider := &countingProvider{Fake: payments.NewFake()}
+			service := factory(t, provider)
+			ctx, payment := startPayment(t, service)
+
+			concurrently(t, 5, func() error {
+				_, err := service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+				return err
+			})
+			require.EqualValues(t, 1, provider.captures.Load())
+
+			confirmed, err := service.GetPayment(ctx, requests.BindUriID{ID: payment.ID})
+			require.NoError(t, err)
+			require.Equal(t, responses.PaymentSucceeded, confirmed.Status)
+		})
+	}
+}
+
+func TestRefundPaymentConcurrently(t *testing.T) {
+	for name, factory := range paymentFactories {
+		t.Run(name, func(t *testing.T) {
+			provider := &countingProvider{Fake: payments.NewFake()}
+			service := factory(t, provider)
+			ctx, payment := startPayment(t, service)
+
+			_, err := service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+			require.NoError(t, err)
+
+			concurrently(t, 5, func() error {
+				_, err := service.RefundPayment(ctx, requests.BindUriID{ID: payment.ID})
+				return err
+			})
+			require.EqualValues(t, 1, provider.refunds.Load())
+
+			refunded, err := service.GetPayment(ctx, requests.BindUriID{ID: payment.ID})
+			require.NoError(t, err)
+			require.Equal(t, responses.PaymentRefunded, refunded.Status)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/responses"
+)
+
+// Action is what the caller of a service method does to a Resource.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Resource is what a Policy rules on, OwnerID is the id of the user the
+// resource belongs to. Created resources have no ID yet.
+type Resource struct {
+	Type    string
+	ID      int64
+	OwnerID int64
+}
+
+// Policy decides whether caller may apply action to resource, a nil error
+// allows it. Refusals are usually a ForbiddenError. caller is never nil,
+// anonymous callers are refused before any policy runs. Policies run while
+// the service holds its transaction or lock and must not call back into it.
+type Policy interface {
+	Authorize(ctx context.Context, caller *responses.User, action Action, resource Resource) error
+}
+
+// PolicyFunc lets an ordinary function be used as a Policy.
+type PolicyFunc func(ctx context.Context, caller *responses.User, action Action, resource Resource) error
+
+func (f PolicyFunc) Authorize(ctx context.Context, caller *responses.User, action Action, resource Resource) error {
+	return f(ctx, caller, action, resource)
+}
+
+// Policies allows what every one of its policies allows, rules are added by
+// putting them next to OwnerPolicy.
+type Policies []Policy
+
+func (p Policies) Authorize(ctx context.Context, caller *responses.User, action Action, resource Resource) error {
+	for _, policy := range p {
+		if err := policy.Authorize(ctx, caller, action, resource); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OwnerPolicy lets users act on the resources they own and staff allowed to
+// manage products on all of them. It is the policy of services without one.
+type OwnerPolicy struct{}
+
+func (OwnerPolicy) Authorize(ctx context.Context, caller *responses.User, action Action, resource Resource) error {
+	if caller.HasPermission(auth.PermissionManageProducts) || caller.ID == resource.OwnerID {
+		return nil
+	}
+
+	if action == ActionCreate {
+		return ForbiddenError("cannot create a %s for user with id %d", resource.Type, resource.OwnerID)
+	}
+
+	return ForbiddenError("cannot %s %s with id %d, it belongs to another user", action, resource.Type, resource.ID)
+}
+
+// callerOf returns the user the service is called by, see auth.WithUser.
+func callerOf(ctx context.Context) (*responses.User, error) {
+	caller, ok := auth.UserFrom(ctx)
+	if !ok {
+		return nil, UnauthorizedError("authentication required")
+	}
+
+	return caller, nil
+}
+
+// authorize asks policy, or OwnerPolicy when it is nil, whether caller may
+// apply action to resource.
+func authorize(ctx context.Context, policy Policy, caller *responses.User, action Action, resource Resource) error {
+	if policy == nil {
+		policy = OwnerPolicy{}
+	}
+
+	return policy.Authorize(ctx, caller, action, resource)
+}
+
+// authorizeProduct resolves the caller of ctx and asks policy whether it may
+// apply action to the product id owned by ownerID.
+func authorizeProduct(ctx context.Context, policy Policy, action Action, id, ownerID int64) error {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	return authorize(ctx, policy, caller, action, productResource(id, ownerID))
+}
+
+// newProductOwner resolves the caller of ctx and returns the owner of the
+// product it creates for userID, see productOwner.
+func newProductOwner(ctx context.Context, policy Policy, userID int64) (int64, error) {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ownerID := productOwner(caller, userID)
+	return ownerID, authorize(ctx, policy, caller, ActionCreate, productResource(0, ownerID))
+}
+
+// productOwner returns the user a product created by caller belongs to: the
+// caller itself, staff allowed to manage products may create them for any
+// user.
+func productOwner(caller *responses.User, userID int64) int64 {
+	if caller.HasPermission(auth.PermissionManageProducts) {
+		return userID
+	}
+
+	return caller.ID
+}
+
+func productResource(id, ownerID int64) Resource {
+	return Resource{Type: "product", ID: id, OwnerID: ownerID}
+}
+
+// newOrderOwner resolves the caller of ctx and returns the user the order it
+// creates for userID belongs to: the caller itself when userID is 0, staff
+// allowed to manage orders may order for any user. Other callers ordering for
+// someone else are refused.
+func newOrderOwner(ctx context.Context, userID int64) (int64, error) {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if userID == 0 || userID == caller.ID {
+		return caller.ID, nil
+	}
+
+	if !caller.HasPermission(auth.PermissionManageOrders) {
+		return 0, ForbiddenError("permission %s required to order for user with id %d", auth.PermissionManageOrders, userID)
+	}
+
+	return userID, nil
+}
+
+// authorizeOrder resolves the caller of ctx and refuses it unless it owns the
+// resource, an order, reservation or payment, or may manage orders. Resources
+// without an owner have an owner id of 0.
+func authorizeOrder(ctx context.Context, resource string, id, ownerID int64) error {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	return checkOrderOwner(caller, resource, id, ownerID)
+}
+
+// orderReadPermissions are the permissions letting staff read the resources
+// of every user without changing them.
+var orderReadPermissions = map[string]string{
+	"order":       auth.PermissionReadOrders,
+	"reservation": auth.PermissionReadOrders,
+	"payment":     auth.PermissionReadPayments,
+}
+
+// authorizeOrderRead is authorizeOrder for reads, it also lets staff allowed
+// to read the resources of every user through.
+func authorizeOrderRead(ctx context.Context, resource string, id, ownerID int64) error {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	if caller.HasPermission(orderReadPermissions[resource]) {
+		return nil
+	}
+
+	return checkOrderOwner(caller, resource, id, ownerID)
+}
+
+// authorizeUserOrders resolves the caller of ctx and refuses it unless it is
+// the user with userID or may read or manage orders.
+func authorizeUserOrders(ctx context.Context, userID int64) error {
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	if caller.HasPermission(auth.PermissionReadOrders) || caller.HasPermission(auth.PermissionManageOrders) || caller.ID == userID {
+		return nil
+	}
+
+	return ForbiddenError("cannot list the orders of user with id %d", userID)
+}
+
+func checkOrderOwner(caller *responses.User, resource string, id, ownerID int64) error {
+	if caller.HasPermission(auth.PermissionManageOrders) || caller.ID == ownerID {
+		return nil
+	}
+
+	return ForbiddenError("cannot access %s with id %d, it belongs to another user", resource, id)
+}
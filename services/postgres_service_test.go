@@ -0,0 +1,44 @@
+package services_test
+
+import (
+	"fmt"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/config"
+	"sqlc-rest-api/db/drivers"
+	"sqlc-rest-api/db/postgres/repositories"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/services"
+	"sqlc-rest-api/services/servicetest"
+	"testing"
+	"time"
+)
+
+// TestPostgresService runs against the database configured in app.env with
+// the migrations already applied, it is skipped when postgres is unreachable.
+func TestPostgresService(t *testing.T) {
+	env, err := config.LoadEnv("../", "app")
+	if err != nil {
+		t.Skip("app.env not found:", err)
+	}
+
+	db, err := drivers.NewPostgres(env).Connect()
+	if err != nil {
+		t.Skip("postgres not available:", err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		t.Skip("postgres not available:", err)
+	}
+
+	servicetest.Run(t, func(t *testing.T) services.Service {
+		// the database outlives the test run, intent ids must not repeat
+		provider := payments.NewFake()
+		provider.Prefix = fmt.Sprintf("pi_test_%d_", time.Now().UnixNano())
+
+		service := services.NewPostgresService(db, repositories.New())
+		service.Payments = provider
+		service.Tokens = auth.Tokens{Secret: []byte("secret")}
+		return service
+	})
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// MaxBulkItems caps the number of items of a single bulk request.
+const MaxBulkItems = 1000
+
+// errBulkRolledBack makes WithTx roll back an atomic bulk request once one of
+// its items failed, the item errors are already in the result.
+var errBulkRolledBack = errors.New("bulk request rolled back")
+
+func checkBulkSize(n int) error {
+	if n == 0 {
+		return ValidationError("at least one item is required")
+	}
+
+	if n > MaxBulkItems {
+		return ValidationError("at most %d items are allowed, got %d", MaxBulkItems, n)
+	}
+
+	return nil
+}
+
+func newBulkResult(n int) *responses.BulkProductsResult {
+	result := &responses.BulkProductsResult{
+		Results: make([]*responses.BulkProductResult, n),
+	}
+	for i := range result.Results {
+		result.Results[i] = &responses.BulkProductResult{Index: i}
+	}
+
+	return result
+}
+
+func bulkFail(result *responses.BulkProductsResult, i int, err error) {
+	result.Results[i].Error = &responses.BulkError{
+		Code:    string(ErrorCodeOf(err)),
+		Message: err.Error(),
+	}
+}
+
+// bulkPending returns the indexes of the items that did not fail yet.
+func bulkPending(result *responses.BulkProductsResult) []int {
+	var pending []int
+	for i, item := range result.Results {
+		if item.Error == nil {
+			pending = append(pending, i)
+		}
+	}
+
+	return pending
+}
+
+func bulkFailed(result *responses.BulkProductsResult) bool {
+	return len(bulkPending(result)) < len(result.Results)
+}
+
+// finishBulk counts the outcomes. Atomic requests with a failed item are
+// rolled back, so the items that went through report nothing.
+func finishBulk(result *responses.BulkProductsResult, atomic bool) *responses.BulkProductsResult {
+	result.RolledBack = atomic && bulkFailed(result)
+	result.Succeeded, result.Failed = 0, 0
+	for _, item := range result.Results {
+		if result.RolledBack {
+			item.Product, item.Deleted = nil, nil
+		}
+
+		if item.Error != nil {
+			result.Failed++
+		} else if !result.RolledBack {
+			result.Succeeded++
+		}
+	}
+
+	return result
+}
+
+// validateNewProducts applies the binding rules of CreateProductRequest to
+// every item, they are not run on the items of a bulk request.
+func validateNewProducts(result *responses.BulkProductsResult, products []requests.CreateProductRequest) {
+	for i, req := range products {
+		switch {
+		case req.UserID < 1:
+			bulkFail(result, i, ValidationError("user_id must be at least 1"))
+		case req.Price < 1:
+			bulkFail(result, i, ValidationError("price must be at least 1"))
+		case req.Name == "":
+			bulkFail(result, i, ValidationError("name is required"))
+		case req.Currency != "":
+			if err := checkCurrency(req.Currency); err != nil {
+				bulkFail(result, i, err)
+			}
+		}
+	}
+}
+
+// requireUsers fails the pending items whose user is not in found.
+func requireUsers(result *responses.BulkProductsResult, products []requests.CreateProductRequest, found map[int64]bool) {
+	for _, i := range bulkPending(result) {
+		if !found[products[i].UserID] {
+			bulkFail(result, i, NewError(ErrForeignKeyViolation, "user with id %d not found", products[i].UserID))
+		}
+	}
+}
+
+// authorizeNewProducts returns products with the owners productOwner picks
+// for caller and fails the pending items policy refuses.
+func authorizeNewProducts(ctx context.Context, policy Policy, caller *responses.User, result *responses.BulkProductsResult, products []requests.CreateProductRequest) []requests.CreateProductRequest {
+	owned := make([]requests.CreateProductRequest, len(products))
+	copy(owned, products)
+	for _, i := range bulkPending(result) {
+		owned[i].UserID = productOwner(caller, owned[i].UserID)
+		if err := authorize(ctx, policy, caller, ActionCreate, productResource(0, owned[i].UserID)); err != nil {
+			bulkFail(result, i, err)
+		}
+	}
+
+	return owned
+}
+
+// bulkUserIDs returns the distinct users of the pending items.
+func bulkUserIDs(result *responses.BulkProductsResult, products []requests.CreateProductRequest) []int64 {
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, i := range bulkPending(result) {
+		if id := products[i].UserID; !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+func validatePatches(result *responses.BulkProductsResult, products []requests.PatchProductRequest) {
+	for i, req := range products {
+		if req.ID < 1 {
+			bulkFail(result, i, ValidationError("id must be at least 1"))
+			continue
+		}
+
+		if err := validateProductPatch(req); err != nil {
+			bulkFail(result, i, err)
+		}
+	}
+}
+
+// validateDeletes fails invalid and repeated ids, a product can only be
+// deleted once.
+func validateDeletes(result *responses.BulkProductsResult, ids []int64) {
+	seen := make(map[int64]bool)
+	for i, id := range ids {
+		switch {
+		case id < 1:
+			bulkFail(result, i, ValidationError("id must be at least 1"))
+		case seen[id]:
+			bulkFail(result, i, ValidationError("product with id %d is listed more than once", id))
+		}
+		seen[id] = true
+	}
+}
+
+// authorizeDeletes fails the pending items policy refuses to let caller
+// delete, owners maps the ids of stored products to their owner. Unknown ids
+// are left to bulkDeleted.
+func authorizeDeletes(ctx context.Context, policy Policy, caller *responses.User, result *responses.BulkProductsResult, ids []int64, owners map[int64]int64) {
+	for _, i := range bulkPending(result) {
+		ownerID, ok := owners[ids[i]]
+		if !ok {
+			continue
+		}
+
+		if err := authorize(ctx, policy, caller, ActionDelete, productResource(ids[i], ownerID)); err != nil {
+			bulkFail(result, i, err)
+		}
+	}
+}
+
+// bulkDeleteIDs returns the ids of the pending items.
+func bulkDeleteIDs(result *responses.BulkProductsResult, ids []int64) []int64 {
+	var pending []int64
+	for _, i := range bulkPending(result) {
+		pending = append(pending, ids[i])
+	}
+
+	return pending
+}
+
+// bulkDeleted reports the pending items whose id is in deleted as deleted and
+// fails the others as not found.
+func bulkDeleted(result *responses.BulkProductsResult, ids []int64, deleted []int64, permanent bool) {
+	found := make(map[int64]bool, len(deleted))
+	for _, id := range deleted {
+		found[id] = true
+	}
+
+	for _, i := range bulkPending(result) {
+		if !found[ids[i]] {
+			bulkFail(result, i, NotFoundError("product with id %d not found", ids[i]))
+			continue
+		}
+
+		result.Results[i].Deleted = &responses.DeletedProduct{
+			Deleted:   true,
+			Permanent: permanent,
+			ProductID: ids[i],
+		}
+	}
+}
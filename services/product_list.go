@@ -0,0 +1,73 @@
+package services
+
+import (
+	"sqlc-rest-api/requests"
+	"strings"
+)
+
+const (
+	DefaultListLimit = 10
+	MaxListLimit     = 100
+)
+
+// normalizeListProducts fills in the defaults the REST binding would apply and
+// validates what the GraphQL layer can't, so every Service lists the same way.
+// The limit has no default here, both transports default it to
+// DefaultListLimit, so a limit of 0 is refused instead of silently listing a
+// default page.
+func normalizeListProducts(req requests.ListProductsRequest) (requests.ListProductsRequest, error) {
+	if req.Limit < 1 || req.Limit > MaxListLimit {
+		return req, ValidationError("limit must be between 1 and %d", MaxListLimit)
+	}
+
+	if req.Offset < 0 {
+		return req, ValidationError("offset must not be negative")
+	}
+
+	if req.Order.Field == "" {
+		req.Order.Field = requests.ProductOrderID
+	}
+
+	switch req.Order.Field {
+	case requests.ProductOrderID, requests.ProductOrderName, requests.ProductOrderPrice, requests.ProductOrderCreatedAt:
+	default:
+		return req, ValidationError("cannot order products by %q", req.Order.Field)
+	}
+
+	if req.Order.Direction == "" {
+		req.Order.Direction = requests.OrderAsc
+	}
+
+	if req.Order.Direction != requests.OrderAsc && req.Order.Direction != requests.OrderDesc {
+		return req, ValidationError("order direction must be asc or desc")
+	}
+
+	f := req.Filter
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return req, ValidationError("min_price must not be greater than max_price")
+	}
+
+	return req, nil
+}
+
+// normalizeListDeletedProducts is normalizeListProducts for the trash, which
+// is always ordered by deletion time, most recent first.
+func normalizeListDeletedProducts(req requests.ListDeletedProductsRequest) (requests.ListDeletedProductsRequest, error) {
+	if req.Limit < 1 || req.Limit > MaxListLimit {
+		return req, ValidationError("limit must be between 1 and %d", MaxListLimit)
+	}
+
+	if req.Offset < 0 {
+		return req, ValidationError("offset must not be negative")
+	}
+
+	return req, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes s match literally inside a LIKE pattern, the queries
+// declare \ as its escape character.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
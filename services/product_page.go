@@ -0,0 +1,143 @@
+package services
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"time"
+)
+
+const (
+	DefaultPageSize    = 5
+	DefaultMaxPageSize = 50
+)
+
+// userProductsPage is a validated GetUserProductsRequest. Products are always
+// returned newest first, backward pages are fetched oldest first and reversed.
+// categoryID and tag are the optional filters, tag is normalized.
+type userProductsPage struct {
+	size       int
+	backward   bool
+	cursor     *helpers.Cursor
+	categoryID *int64
+	tag        *string
+}
+
+func newUserProductsPage(req requests.GetUserProductsRequest, maxPageSize int) (userProductsPage, error) {
+	if maxPageSize < 1 {
+		maxPageSize = DefaultMaxPageSize
+	}
+
+	page := userProductsPage{
+		size:       DefaultPageSize,
+		backward:   req.Last != nil || req.Before != nil,
+		categoryID: req.CategoryID,
+	}
+
+	if page.categoryID != nil && *page.categoryID < 1 {
+		return page, ValidationError("category_id must be at least 1")
+	}
+
+	if req.Tag != nil {
+		tag, err := normalizeTag(*req.Tag)
+		if err != nil {
+			return page, err
+		}
+		page.tag = &tag
+	}
+
+	if page.backward && (req.First != nil || req.After != nil) {
+		return page, ValidationError("first and after cannot be combined with last and before")
+	}
+
+	size, cursor := req.First, req.After
+	if page.backward {
+		size, cursor = req.Last, req.Before
+	}
+
+	if size != nil {
+		page.size = *size
+	}
+
+	if page.size < 1 || page.size > maxPageSize {
+		return page, ValidationError("page size must be between 1 and %d", maxPageSize)
+	}
+
+	if cursor != nil {
+		c, err := helpers.DecodeCursor(*cursor)
+		if err != nil {
+			return page, &Error{Code: ErrBadRequest, Message: "invalid cursor", Err: err}
+		}
+		page.cursor = &c
+	}
+
+	return page, nil
+}
+
+func newBatchUserProductsPage(req requests.GetBatchUserProductsRequest, maxPageSize int) (userProductsPage, error) {
+	return newUserProductsPage(requests.GetUserProductsRequest{
+		First:      req.First,
+		After:      req.After,
+		Last:       req.Last,
+		Before:     req.Before,
+		CategoryID: req.CategoryID,
+		Tag:        req.Tag,
+	}, maxPageSize)
+}
+
+// splitUserProductsPages turns the products of several users, fetched with one
+// extra product per user, into one page per user id. beyondCursor holds the
+// users that have products on the other side of the cursor.
+func splitUserProductsPages(userIDs []int64, page userProductsPage, products []*responses.Product, beyondCursor []int64) []*responses.Products {
+	byUser := make(map[int64][]*responses.Product)
+	for _, product := range products {
+		byUser[product.UserID] = append(byUser[product.UserID], product)
+	}
+
+	beyond := make(map[int64]bool)
+	for _, id := range beyondCursor {
+		beyond[id] = true
+	}
+
+	pages := make([]*responses.Products, len(userIDs))
+	for i, id := range userIDs {
+		results := byUser[id]
+		more := len(results) > page.size
+		if more {
+			results = results[:page.size]
+		}
+
+		hnp, hpp := more, beyond[id]
+		if page.backward {
+			results = append([]*responses.Product(nil), results...)
+			reverse(results)
+			hnp, hpp = beyond[id], more
+		}
+
+		pages[i] = helpers.ProductsResponse(results, hnp && len(results) > 0, hpp && len(results) > 0)
+	}
+
+	return pages
+}
+
+func (p userProductsPage) cursorTime() *time.Time {
+	if p.cursor == nil {
+		return nil
+	}
+
+	return &p.cursor.CreatedAt
+}
+
+func (p userProductsPage) cursorID() *int64 {
+	if p.cursor == nil {
+		return nil
+	}
+
+	return &p.cursor.ID
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
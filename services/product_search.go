@@ -0,0 +1,294 @@
+package services
+
+import (
+	"sort"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxSearchQueryLength = 200
+
+	// WordSimilarityThreshold is the pg_trgm default of the <% operator, the
+	// query must be at least this similar to a name to match on trigrams alone.
+	WordSimilarityThreshold = 0.6
+)
+
+// productSearch is a validated SearchProductsRequest. Results are ordered by
+// rank, highest first, with the id breaking ties.
+type productSearch struct {
+	query  string
+	terms  []string
+	size   int
+	cursor *helpers.SearchCursor
+}
+
+func newProductSearch(req requests.SearchProductsRequest, maxPageSize int) (productSearch, error) {
+	if maxPageSize < 1 {
+		maxPageSize = DefaultMaxPageSize
+	}
+
+	search := productSearch{
+		query: strings.TrimSpace(req.Query),
+		size:  DefaultPageSize,
+	}
+	search.terms = searchWords(search.query)
+
+	if search.query == "" {
+		return search, ValidationError("search query must not be empty")
+	}
+
+	if utf8.RuneCountInString(search.query) > MaxSearchQueryLength {
+		return search, ValidationError("search query must be at most %d characters", MaxSearchQueryLength)
+	}
+
+	if req.First != nil {
+		search.size = *req.First
+	}
+
+	if search.size < 1 || search.size > maxPageSize {
+		return search, ValidationError("page size must be between 1 and %d", maxPageSize)
+	}
+
+	if req.After != nil {
+		c, err := helpers.DecodeSearchCursor(*req.After)
+		if err != nil {
+			return search, &Error{Code: ErrBadRequest, Message: "invalid cursor", Err: err}
+		}
+		search.cursor = &c
+	}
+
+	return search, nil
+}
+
+func (s productSearch) cursorRank() *float64 {
+	if s.cursor == nil {
+		return nil
+	}
+
+	return &s.cursor.Rank
+}
+
+func (s productSearch) cursorID() *int64 {
+	if s.cursor == nil {
+		return nil
+	}
+
+	return &s.cursor.ID
+}
+
+// searchHit is a product matching a search together with its relevance.
+type searchHit struct {
+	product   *responses.Product
+	rank      float64
+	highlight string
+}
+
+// match ranks a product for the backends without full-text search, closely
+// following the postgres SearchProducts query: a name containing every query
+// term as a word prefix stands in for the tsquery match, trigram similarity
+// tolerates typos.
+func (s productSearch) match(prod *responses.Product) (searchHit, bool) {
+	words := searchWords(prod.Name)
+	if len(s.terms) < 1 || len(words) < 1 {
+		return searchHit{}, false
+	}
+
+	var found int
+	var similarity float64
+	for _, term := range s.terms {
+		termTrigrams := trigrams(term)
+
+		var best float64
+		var prefix bool
+		for _, word := range words {
+			prefix = prefix || strings.HasPrefix(word, term)
+			if sim := trigramSimilarity(termTrigrams, trigrams(word)); sim > best {
+				best = sim
+			}
+		}
+
+		if prefix {
+			found++
+		}
+		similarity += best
+	}
+	similarity /= float64(len(s.terms))
+
+	if found < len(s.terms) && similarity < WordSimilarityThreshold {
+		return searchHit{}, false
+	}
+
+	return searchHit{
+		product:   prod,
+		rank:      float64(found)/float64(len(s.terms)) + similarity,
+		highlight: highlight(prod.Name, s.terms),
+	}, true
+}
+
+// page orders hits by relevance and keeps the ones after the cursor, with one
+// extra hit when there is another page.
+func (s productSearch) page(hits []searchHit) []searchHit {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].rank != hits[j].rank {
+			return hits[i].rank > hits[j].rank
+		}
+
+		return hits[i].product.ID < hits[j].product.ID
+	})
+
+	if c := s.cursor; c != nil {
+		start := sort.Search(len(hits), func(i int) bool {
+			hit := hits[i]
+			return hit.rank < c.Rank || (hit.rank == c.Rank && hit.product.ID > c.ID)
+		})
+		hits = hits[start:]
+	}
+
+	if len(hits) > s.size+1 {
+		hits = hits[:s.size+1]
+	}
+
+	return hits
+}
+
+// response turns hits, fetched with one extra hit, into a connection. Search
+// cursors only go forward so there is a previous page whenever a cursor was
+// given.
+func (s productSearch) response(hits []searchHit) *responses.Products {
+	more := len(hits) > s.size
+	if more {
+		hits = hits[:s.size]
+	}
+
+	if len(hits) < 1 {
+		return helpers.ProductsResponse([]*responses.Product{}, false, false)
+	}
+
+	edges := make([]*responses.ProductEdge, len(hits))
+	for i := range hits {
+		hit := hits[i]
+		edges[i] = &responses.ProductEdge{
+			Cursor:    helpers.EncodeSearchCursor(hit.rank, hit.product.ID),
+			Node:      hit.product,
+			Rank:      &hit.rank,
+			Highlight: &hit.highlight,
+		}
+	}
+
+	sc := edges[0].Cursor
+	ec := edges[len(edges)-1].Cursor
+
+	return &responses.Products{
+		Edges:    edges,
+		PageInfo: helpers.NewPageInfo(sc, ec, more, s.cursor != nil),
+	}
+}
+
+// candidateTrigrams are the parts of the query a product name must contain to
+// be worth ranking: the inner trigrams of every term, or the term itself when
+// it is too short to have any.
+func (s productSearch) candidateTrigrams() []string {
+	var candidates []string
+	for _, term := range s.terms {
+		r := []rune(term)
+		if len(r) < 3 {
+			candidates = append(candidates, term)
+			continue
+		}
+
+		for i := 0; i+3 <= len(r); i++ {
+			candidates = append(candidates, string(r[i:i+3]))
+		}
+	}
+
+	return candidates
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// searchWords splits s into lower case words, dropping duplicates.
+func searchWords(s string) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !isWordRune(r) }) {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+// trigrams returns the trigrams of a word the way pg_trgm does, padded with
+// two spaces in front and one behind.
+func trigrams(word string) map[string]bool {
+	r := []rune("  " + word + " ")
+	set := make(map[string]bool, len(r))
+	for i := 0; i+3 <= len(r); i++ {
+		set[string(r[i:i+3])] = true
+	}
+
+	return set
+}
+
+func trigramSimilarity(a, b map[string]bool) float64 {
+	var shared int
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// highlightEscaper escapes what the postgres SearchProducts query escapes in
+// names before ts_headline adds its tags, so the marks are the only markup.
+var highlightEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// highlight wraps the words of name starting with one of terms in <mark>
+// tags, like ts_headline in the postgres query. The rest of the name is HTML
+// escaped.
+func highlight(name string, terms []string) string {
+	var b strings.Builder
+	writeWord := func(word string) {
+		lower := strings.ToLower(word)
+		for _, term := range terms {
+			if strings.HasPrefix(lower, term) {
+				b.WriteString("<mark>" + word + "</mark>")
+				return
+			}
+		}
+		b.WriteString(word)
+	}
+
+	start := -1
+	for i, r := range name {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			writeWord(name[start:i])
+			start = -1
+		}
+		b.WriteString(highlightEscaper.Replace(string(r)))
+	}
+
+	if start >= 0 {
+		writeWord(name[start:])
+	}
+
+	return b.String()
+}
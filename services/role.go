@@ -0,0 +1,104 @@
+package services
+
+import (
+	"sort"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+)
+
+// memoryRoles mirrors the roles and grants the migrations seed, in the order
+// of their ids.
+var memoryRoles = []responses.Role{
+	{
+		Name:        requests.RoleViewer,
+		Description: "reads everything staff can read",
+		Permissions: []string{
+			auth.PermissionReadProducts,
+			auth.PermissionReadInventory,
+			auth.PermissionReadOrders,
+			auth.PermissionReadPayments,
+			auth.PermissionReadUsers,
+		},
+	},
+	{
+		Name:        requests.RoleEditor,
+		Description: "manages products, the catalog, stock, orders and payments",
+		Permissions: []string{
+			auth.PermissionReadProducts,
+			auth.PermissionManageProducts,
+			auth.PermissionManageCatalog,
+			auth.PermissionReadInventory,
+			auth.PermissionManageInventory,
+			auth.PermissionReadOrders,
+			auth.PermissionManageOrders,
+			auth.PermissionReadPayments,
+			auth.PermissionManagePayments,
+			auth.PermissionReadUsers,
+		},
+	},
+	{
+		Name:        requests.RoleAdmin,
+		Description: "manages users and their roles",
+		Permissions: []string{
+			auth.PermissionReadProducts,
+			auth.PermissionManageProducts,
+			auth.PermissionManageCatalog,
+			auth.PermissionReadInventory,
+			auth.PermissionManageInventory,
+			auth.PermissionReadOrders,
+			auth.PermissionManageOrders,
+			auth.PermissionReadPayments,
+			auth.PermissionManagePayments,
+			auth.PermissionReadUsers,
+			auth.PermissionManageUsers,
+			auth.PermissionManageRoles,
+		},
+	},
+}
+
+func validateRole(role requests.Role) error {
+	if !role.Valid() {
+		return ValidationError("unknown role %q", role)
+	}
+
+	return nil
+}
+
+func roleNames(names []string) []requests.Role {
+	roles := make([]requests.Role, len(names))
+	for i, name := range names {
+		roles[i] = requests.Role(name)
+	}
+
+	return roles
+}
+
+// memoryUserRoles returns the roles of has in the order of memoryRoles and
+// the permissions they grant, sorted like the SQL services sort them.
+func memoryUserRoles(has map[requests.Role]bool) ([]requests.Role, []string) {
+	roles := []requests.Role{}
+	granted := make(map[string]bool)
+	for _, role := range memoryRoles {
+		if !has[role.Name] {
+			continue
+		}
+
+		roles = append(roles, role.Name)
+		for _, permission := range role.Permissions {
+			granted[permission] = true
+		}
+	}
+
+	permissions := make([]string, 0, len(granted))
+	for permission := range granted {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+
+	return roles, permissions
+}
+
+func lastAdminError() error {
+	return ConflictError("cannot revoke the admin role of the last admin")
+}
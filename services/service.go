@@ -2,16 +2,91 @@ package services
 
 import (
 	"context"
+	"sqlc-rest-api/payments"
 	"sqlc-rest-api/requests"
 	"sqlc-rest-api/responses"
 )
 
 type Service interface {
 	CreateProduct(ctx context.Context, req requests.CreateProductRequest) (*responses.Product, error)
-	DeleteProduct(ctx context.Context, req requests.BindUriID) (*responses.DeletedProduct, error)
+	DeleteProduct(ctx context.Context, req requests.DeleteProductRequest) (*responses.DeletedProduct, error)
+	RestoreProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error)
+	ListDeletedProducts(ctx context.Context, req requests.ListDeletedProductsRequest) (*responses.ProductList, error)
+	PurgeDeletedProducts(ctx context.Context, req requests.PurgeDeletedProductsRequest) (int64, error)
 	GetProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error)
+	GetBatchProducts(ctx context.Context, req requests.GetBatchProductsRequest) ([]*responses.Product, error)
+	ListProducts(ctx context.Context, req requests.ListProductsRequest) (*responses.ProductList, error)
+	SearchProducts(ctx context.Context, req requests.SearchProductsRequest) (*responses.Products, error)
 	UpdateProduct(ctx context.Context, req requests.UpdateProductRequest) (*responses.Product, error)
+	PatchProduct(ctx context.Context, req requests.PatchProductRequest) (*responses.Product, error)
+	BulkCreateProducts(ctx context.Context, req requests.BulkCreateProductsRequest) (*responses.BulkProductsResult, error)
+	BulkPatchProducts(ctx context.Context, req requests.BulkPatchProductsRequest) (*responses.BulkProductsResult, error)
+	BulkDeleteProducts(ctx context.Context, req requests.BulkDeleteProductsRequest) (*responses.BulkProductsResult, error)
 	CreateUser(ctx context.Context, req requests.CreateUserRequest) (*responses.User, error)
 	GetUser(ctx context.Context, req requests.BindUriID) (*responses.User, error)
+	UpdateUser(ctx context.Context, req requests.UpdateUserRequest) (*responses.User, error)
+	PatchUser(ctx context.Context, req requests.PatchUserRequest) (*responses.User, error)
+	DeleteUser(ctx context.Context, req requests.DeleteUserRequest) (*responses.DeletedUser, error)
+	ListUsers(ctx context.Context, req requests.ListUsersRequest) (*responses.Users, error)
 	GetUserProducts(ctx context.Context, req requests.GetUserProductsRequest) (*responses.Products, error)
+	GetBatchUsers(ctx context.Context, req requests.GetBatchUsersRequest) ([]*responses.User, error)
+	GetBatchUserProducts(ctx context.Context, req requests.GetBatchUserProductsRequest) ([]*responses.Products, error)
+	SetExchangeRate(ctx context.Context, req requests.SetExchangeRateRequest) (*responses.ExchangeRate, error)
+	ListExchangeRates(ctx context.Context) ([]*responses.ExchangeRate, error)
+	ConvertPrices(ctx context.Context, prices []responses.Money, currency string) ([]responses.Money, error)
+	CreateCategory(ctx context.Context, req requests.CreateCategoryRequest) (*responses.Category, error)
+	GetCategory(ctx context.Context, req requests.BindUriID) (*responses.Category, error)
+	GetBatchCategories(ctx context.Context, req requests.GetBatchCategoriesRequest) ([]*responses.Category, error)
+	ListCategories(ctx context.Context, req requests.ListCategoriesRequest) ([]*responses.Category, error)
+	UpdateCategory(ctx context.Context, req requests.UpdateCategoryRequest) (*responses.Category, error)
+	DeleteCategory(ctx context.Context, req requests.BindUriID) error
+	CreateTag(ctx context.Context, req requests.CreateTagRequest) (*responses.Tag, error)
+	ListTags(ctx context.Context) ([]*responses.Tag, error)
+	DeleteTag(ctx context.Context, req requests.BindUriID) error
+	SetProductCategories(ctx context.Context, req requests.SetProductCategoriesRequest) ([]*responses.Category, error)
+	SetProductTags(ctx context.Context, req requests.SetProductTagsRequest) ([]*responses.Tag, error)
+	GetBatchProductCategories(ctx context.Context, req requests.GetBatchProductCategoriesRequest) ([][]*responses.Category, error)
+	GetBatchProductTags(ctx context.Context, req requests.GetBatchProductTagsRequest) ([][]*responses.Tag, error)
+	GetStock(ctx context.Context, req requests.BindUriID) (*responses.Stock, error)
+	GetBatchStock(ctx context.Context, req requests.GetBatchStockRequest) ([]*responses.Stock, error)
+	AdjustStock(ctx context.Context, req requests.AdjustStockRequest) (*responses.Stock, error)
+	ListStockAdjustments(ctx context.Context, req requests.ListStockAdjustmentsRequest) ([]*responses.StockAdjustment, error)
+	CreateReservation(ctx context.Context, req requests.CreateReservationRequest) (*responses.Reservation, error)
+	GetReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error)
+	ReleaseReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error)
+	CommitReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error)
+	ReleaseExpiredReservations(ctx context.Context, req requests.ReleaseExpiredReservationsRequest) (int64, error)
+	CreateOrder(ctx context.Context, req requests.CreateOrderRequest) (*responses.Order, error)
+	GetOrder(ctx context.Context, req requests.BindUriID) (*responses.Order, error)
+	UpdateOrderStatus(ctx context.Context, req requests.UpdateOrderStatusRequest) (*responses.Order, error)
+	GetUserOrders(ctx context.Context, req requests.GetUserOrdersRequest) (*responses.Orders, error)
+	StartPayment(ctx context.Context, req requests.StartPaymentRequest) (*responses.Payment, error)
+	GetPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error)
+	ConfirmPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error)
+	RefundPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error)
+	HandlePaymentEvent(ctx context.Context, event payments.Event) (*responses.Payment, error)
+	Register(ctx context.Context, req requests.RegisterRequest) (*responses.Session, error)
+	Login(ctx context.Context, req requests.LoginRequest) (*responses.Session, error)
+	RefreshSession(ctx context.Context, req requests.RefreshTokenRequest) (*responses.Session, error)
+	Logout(ctx context.Context, req requests.RefreshTokenRequest) error
+	// Authenticate returns the user an access token was issued to, with its
+	// roles and permissions.
+	Authenticate(ctx context.Context, token string) (*responses.User, error)
+	ListRoles(ctx context.Context) ([]*responses.Role, error)
+	// GetUserRoles, AssignRole and RevokeRole return the roles the user has
+	// afterwards.
+	GetUserRoles(ctx context.Context, req requests.BindUriID) ([]requests.Role, error)
+	AssignRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error)
+	RevokeRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error)
+	// CreateAPIKey, ListAPIKeys, GetAPIKey, UpdateAPIKey and DeleteAPIKey
+	// manage the API keys of the caller, the key itself is only returned
+	// once by CreateAPIKey.
+	CreateAPIKey(ctx context.Context, req requests.CreateAPIKeyRequest) (*responses.NewAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*responses.APIKey, error)
+	GetAPIKey(ctx context.Context, req requests.BindUriID) (*responses.APIKey, error)
+	UpdateAPIKey(ctx context.Context, req requests.UpdateAPIKeyRequest) (*responses.APIKey, error)
+	DeleteAPIKey(ctx context.Context, req requests.BindUriID) error
+	// AuthenticateAPIKey returns the user an API key acts for, with its roles
+	// and permissions, and the key with its scopes.
+	AuthenticateAPIKey(ctx context.Context, key string) (*responses.User, *responses.APIKey, error)
 }
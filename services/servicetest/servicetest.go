@@ -0,0 +1,3334 @@
+// Package servicetest is a contract test suite every services.Service
+// implementation must pass, so all storage backends behave the same behind the
+// REST and GraphQL servers.
+package servicetest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"sqlc-rest-api/services"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns the service under test. Tests never assume the storage is
+// empty, every test creates the users and products it works with.
+type Factory func(t *testing.T) services.Service
+
+// missingID is an id no test ever creates.
+const missingID = int64(1) << 40
+
+// admin is who the tests call the service as, admins act on the products and
+// orders of every user. The ownership policy is tested with the users owning
+// products and orders.
+var admin = &responses.User{
+	ID:          missingID,
+	Name:        "admin",
+	Roles:       []requests.Role{requests.RoleAdmin},
+	Permissions: []string{auth.PermissionManageProducts, auth.PermissionManageOrders},
+}
+
+func adminContext() context.Context {
+	return auth.WithUser(context.Background(), admin)
+}
+
+func userContext(user *responses.User) context.Context {
+	return auth.WithUser(context.Background(), user)
+}
+
+func Run(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, service services.Service)
+	}{
+		{"create and get user", testCreateGetUser},
+		{"get user not found", testGetUserNotFound},
+		{"create and get product", testCreateGetProduct},
+		{"create product unknown user", testCreateProductUnknownUser},
+		{"get product not found", testGetProductNotFound},
+		{"update product", testUpdateProduct},
+		{"update product not found", testUpdateProductNotFound},
+		{"update product expected version", testUpdateProductExpectedVersion},
+		{"restore product bumps version", testRestoreProductBumpsVersion},
+		{"product mutations return stock", testProductMutationsReturnStock},
+		{"patch product", testPatchProduct},
+		{"patch product invalid", testPatchProductInvalid},
+		{"product currency", testProductCurrency},
+		{"product currency invalid", testProductCurrencyInvalid},
+		{"exchange rates", testExchangeRates},
+		{"convert prices", testConvertPrices},
+		{"patch user", testPatchUser},
+		{"update user", testUpdateUser},
+		{"user email normalized", testUserEmailNormalized},
+		{"user email taken", testUserEmailTaken},
+		{"user email invalid", testUserEmailInvalid},
+		{"delete user restrict", testDeleteUserRestrict},
+		{"delete user cascade", testDeleteUserCascade},
+		{"delete user reassign", testDeleteUserReassign},
+		{"delete user invalid", testDeleteUserInvalid},
+		{"list users", testListUsers},
+		{"bulk create products", testBulkCreateProducts},
+		{"bulk create products atomic", testBulkCreateProductsAtomic},
+		{"bulk patch products", testBulkPatchProducts},
+		{"bulk delete products", testBulkDeleteProducts},
+		{"bulk products invalid", testBulkProductsInvalid},
+		{"delete product", testDeleteProduct},
+		{"delete product permanent", testDeleteProductPermanent},
+		{"delete product not found", testDeleteProductNotFound},
+		{"restore product", testRestoreProduct},
+		{"list deleted products", testListDeletedProducts},
+		{"purge deleted products", testPurgeDeletedProducts},
+		{"user products unknown user", testUserProductsUnknownUser},
+		{"user products empty", testUserProductsEmpty},
+		{"user products pagination", testUserProductsPagination},
+		{"user products exact page", testUserProductsExactPage},
+		{"user products only owner", testUserProductsOnlyOwner},
+		{"user products backward", testUserProductsBackward},
+		{"user products same created_at", testUserProductsSameCreatedAt},
+		{"user products invalid cursor", testUserProductsInvalidCursor},
+		{"user products invalid page", testUserProductsInvalidPage},
+		{"batch users", testBatchUsers},
+		{"batch products", testBatchProducts},
+		{"batch user products", testBatchUserProducts},
+		{"batch user products backward", testBatchUserProductsBackward},
+		{"list products filters", testListProductsFilters},
+		{"list products order", testListProductsOrder},
+		{"list products offset pagination", testListProductsOffset},
+		{"list products invalid", testListProductsInvalid},
+		{"search products", testSearchProducts},
+		{"search products typo", testSearchProductsTypo},
+		{"search products pagination", testSearchProductsPagination},
+		{"search products invalid", testSearchProductsInvalid},
+		{"category tree", testCategoryTree},
+		{"category invalid", testCategoryInvalid},
+		{"tags", testTags},
+		{"product categories and tags", testProductCategoriesAndTags},
+		{"user products filters", testUserProductsFilters},
+		{"adjust stock", testAdjustStock},
+		{"adjust stock invalid", testAdjustStockInvalid},
+		{"reservations", testReservations},
+		{"release expired reservations", testReleaseExpiredReservations},
+		{"reservations never oversell", testReservationsNeverOversell},
+		{"concurrent writers", testConcurrentWriters},
+		{"create order", testCreateOrder},
+		{"create order converts prices", testCreateOrderConvertsPrices},
+		{"create order invalid", testCreateOrderInvalid},
+		{"order status transitions", testOrderStatusTransitions},
+		{"user orders", testUserOrders},
+		{"delete user with orders", testDeleteUserWithOrders},
+		{"order keeps purged products", testOrderKeepsPurgedProducts},
+		{"order payment", testOrderPayment},
+		{"payment of cancelled order", testPaymentOfCancelledOrder},
+		{"declined payment", testDeclinedPayment},
+		{"refund payment", testRefundPayment},
+		{"payment events", testPaymentEvents},
+		{"start payment invalid", testStartPaymentInvalid},
+		{"register and login", testRegisterLogin},
+		{"login invalid credentials", testLoginInvalidCredentials},
+		{"register invalid", testRegisterInvalid},
+		{"refresh session rotates", testRefreshSessionRotates},
+		{"logout", testLogout},
+		{"product ownership", testProductOwnership},
+		{"bulk product ownership", testBulkProductOwnership},
+		{"order ownership", testOrderOwnership},
+		{"anonymous product changes", testAnonymousProductChanges},
+		{"list roles", testListRoles},
+		{"assign and revoke roles", testAssignRevokeRoles},
+		{"roles invalid", testRolesInvalid},
+		{"roles manage products", testRolesManageProducts},
+		{"roles read orders", testRolesReadOrders},
+		{"api keys", testAPIKeys},
+		{"api keys invalid", testAPIKeysInvalid},
+		{"api key expiry", testAPIKeyExpiry},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, factory(t))
+		})
+	}
+}
+
+func testCreateGetUser(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	got, err := service.GetUser(adminContext(), requests.BindUriID{ID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, got.ID)
+	require.Equal(t, user.Name, got.Name)
+	require.Equal(t, user.Email, got.Email)
+	require.WithinDuration(t, user.CreatedAt, got.CreatedAt, time.Millisecond)
+}
+
+func testGetUserNotFound(t *testing.T, service services.Service) {
+	_, err := service.GetUser(adminContext(), requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testCreateGetProduct(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	got, err := service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, product.ID, got.ID)
+	require.Equal(t, "product", got.Name)
+	require.Equal(t, int64(100), got.Price.Amount)
+	require.Equal(t, user.ID, got.UserID)
+	require.False(t, got.CreatedAt.IsZero())
+}
+
+func testCreateProductUnknownUser(t *testing.T, service services.Service) {
+	req := requests.CreateProductRequest{
+		UserID: missingID,
+		Name:   "product",
+		Price:  100,
+	}
+
+	_, err := service.CreateProduct(adminContext(), req)
+	requireCode(t, services.ErrForeignKeyViolation, err)
+}
+
+func testGetProductNotFound(t *testing.T, service services.Service) {
+	_, err := service.GetProduct(adminContext(), requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testUpdateProduct(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	req := requests.UpdateProductRequest{
+		ID:    product.ID,
+		Name:  "updated",
+		Price: 555,
+	}
+
+	updated, err := service.UpdateProduct(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, product.ID, updated.ID)
+	require.Equal(t, "updated", updated.Name)
+	require.Equal(t, int64(555), updated.Price.Amount)
+	require.Equal(t, user.ID, updated.UserID)
+	require.Equal(t, product.Version+1, updated.Version)
+	require.False(t, updated.UpdatedAt.Before(product.UpdatedAt))
+
+	got, err := service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, "updated", got.Name)
+	require.Equal(t, int64(555), got.Price.Amount)
+	require.Equal(t, updated.Version, got.Version)
+}
+
+func testUpdateProductExpectedVersion(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	req := requests.UpdateProductRequest{
+		ID:              product.ID,
+		Name:            "first editor",
+		Price:           200,
+		ExpectedVersion: &product.Version,
+	}
+
+	updated, err := service.UpdateProduct(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.Version)
+
+	// the second editor still holds the first version
+	req.Name = "second editor"
+	_, err = service.UpdateProduct(adminContext(), req)
+	requireCode(t, services.ErrPreconditionFailed, err)
+
+	got, err := service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, "first editor", got.Name)
+	require.Equal(t, int64(2), got.Version)
+
+	req.ExpectedVersion = &got.Version
+	updated, err = service.UpdateProduct(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, "second editor", updated.Name)
+	require.Equal(t, int64(3), updated.Version)
+
+	req.ID = missingID
+	_, err = service.UpdateProduct(adminContext(), req)
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testPatchProduct(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	name := "patched"
+	patched, err := service.PatchProduct(adminContext(), requests.PatchProductRequest{ID: product.ID, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, "patched", patched.Name)
+	require.Equal(t, product.Price, patched.Price)
+	require.Equal(t, product.Version+1, patched.Version)
+
+	price := int64(250)
+	req := requests.PatchProductRequest{ID: product.ID, Price: &price, ExpectedVersion: &patched.Version}
+	patched, err = service.PatchProduct(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, "patched", patched.Name)
+	require.Equal(t, price, patched.Price.Amount)
+
+	// req still expects the previous version
+	_, err = service.PatchProduct(adminContext(), req)
+	requireCode(t, services.ErrPreconditionFailed, err)
+
+	got, err := service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, "patched", got.Name)
+	require.Equal(t, price, got.Price.Amount)
+	require.Equal(t, patched.Version, got.Version)
+}
+
+func testPatchProductInvalid(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	empty := ""
+	_, err := service.PatchProduct(adminContext(), requests.PatchProductRequest{ID: product.ID, Name: &empty})
+	requireCode(t, services.ErrValidation, err)
+
+	zero := int64(0)
+	_, err = service.PatchProduct(adminContext(), requests.PatchProductRequest{ID: product.ID, Price: &zero})
+	requireCode(t, services.ErrValidation, err)
+
+	name := "patched"
+	_, err = service.PatchProduct(adminContext(), requests.PatchProductRequest{ID: missingID, Name: &name})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+	require.NoError(t, err)
+
+	_, err = service.PatchProduct(adminContext(), requests.PatchProductRequest{ID: product.ID, Name: &name})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testProductCurrency(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+
+	product := createProduct(t, service, user.ID, "product")
+	require.Equal(t, responses.Money{Amount: 100, Currency: services.DefaultCurrency}, product.Price)
+
+	euro, err := service.CreateProduct(ctx, requests.CreateProductRequest{
+		UserID:   user.ID,
+		Name:     "euro product",
+		Price:    250,
+		Currency: "EUR",
+	})
+	require.NoError(t, err)
+	require.Equal(t, responses.Money{Amount: 250, Currency: "EUR"}, euro.Price)
+
+	// an update without currency keeps the stored one
+	updated, err := service.UpdateProduct(ctx, requests.UpdateProductRequest{ID: euro.ID, Name: "euro product", Price: 300})
+	require.NoError(t, err)
+	require.Equal(t, responses.Money{Amount: 300, Currency: "EUR"}, updated.Price)
+
+	currency := "JPY"
+	patched, err := service.PatchProduct(ctx, requests.PatchProductRequest{ID: euro.ID, Currency: &currency})
+	require.NoError(t, err)
+	require.Equal(t, responses.Money{Amount: 300, Currency: "JPY"}, patched.Price)
+
+	got, err := service.GetProduct(ctx, requests.BindUriID{ID: euro.ID})
+	require.NoError(t, err)
+	require.Equal(t, patched.Price, got.Price)
+}
+
+func testProductCurrencyInvalid(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	for _, currency := range []string{"usd", "XXX", "EURO"} {
+		_, err := service.CreateProduct(ctx, requests.CreateProductRequest{
+			UserID:   user.ID,
+			Name:     "product",
+			Price:    100,
+			Currency: currency,
+		})
+		requireCode(t, services.ErrValidation, err)
+
+		_, err = service.UpdateProduct(ctx, requests.UpdateProductRequest{ID: product.ID, Name: "product", Price: 100, Currency: currency})
+		requireCode(t, services.ErrValidation, err)
+
+		_, err = service.PatchProduct(ctx, requests.PatchProductRequest{ID: product.ID, Currency: &currency})
+		requireCode(t, services.ErrValidation, err)
+	}
+
+	got, err := service.GetProduct(ctx, requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, product.Price, got.Price)
+	require.Equal(t, product.Version, got.Version)
+}
+
+func testExchangeRates(t *testing.T, service services.Service) {
+	ctx := adminContext()
+
+	_, err := service.SetExchangeRate(ctx, requests.SetExchangeRateRequest{Base: "GBP", Quote: "CHF", Rate: "1.5"})
+	require.NoError(t, err)
+
+	// setting a pair again replaces its rate
+	rate, err := service.SetExchangeRate(ctx, requests.SetExchangeRateRequest{Base: "GBP", Quote: "CHF", Rate: "1.125"})
+	require.NoError(t, err)
+	require.Equal(t, "GBP", rate.Base)
+	require.Equal(t, "CHF", rate.Quote)
+	require.Equal(t, "1.125", rate.Rate)
+
+	rates, err := service.ListExchangeRates(ctx)
+	require.NoError(t, err)
+
+	var found []*responses.ExchangeRate
+	for _, r := range rates {
+		if r.Base == "GBP" && r.Quote == "CHF" {
+			found = append(found, r)
+		}
+	}
+	require.Len(t, found, 1)
+	require.Equal(t, "1.125", found[0].Rate)
+
+	invalid := []requests.SetExchangeRateRequest{
+		{Base: "GBP", Quote: "GBP", Rate: "1"},
+		{Base: "gbp", Quote: "CHF", Rate: "1"},
+		{Base: "GBP", Quote: "CHF", Rate: "0"},
+		{Base: "GBP", Quote: "CHF", Rate: "-1.5"},
+		{Base: "GBP", Quote: "CHF", Rate: "1/3"},
+		{Base: "GBP", Quote: "CHF", Rate: "1e3"},
+	}
+	for _, req := range invalid {
+		_, err := service.SetExchangeRate(ctx, req)
+		requireCode(t, services.ErrValidation, err)
+	}
+}
+
+func testConvertPrices(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	setRate := func(base, quote, rate string) {
+		_, err := service.SetExchangeRate(ctx, requests.SetExchangeRateRequest{Base: base, Quote: quote, Rate: rate})
+		require.NoError(t, err)
+	}
+	setRate("USD", "EUR", "0.9")
+	setRate("USD", "JPY", "150")
+
+	prices := []responses.Money{
+		{Amount: 1000, Currency: "USD"},
+		{Amount: 900, Currency: "EUR"},
+		{Amount: 5, Currency: "USD"},
+		{Amount: 1500, Currency: "JPY"},
+	}
+
+	converted, err := service.ConvertPrices(ctx, prices, "EUR")
+	require.NoError(t, err)
+	require.Equal(t, []responses.Money{
+		{Amount: 900, Currency: "EUR"},
+		{Amount: 900, Currency: "EUR"},
+		// 4.5 cents round half to even
+		{Amount: 4, Currency: "EUR"},
+		// through USD, the inverse of USD/JPY
+		{Amount: 900, Currency: "EUR"},
+	}, converted)
+
+	converted, err = service.ConvertPrices(ctx, prices, "JPY")
+	require.NoError(t, err)
+	require.Equal(t, []responses.Money{
+		{Amount: 1500, Currency: "JPY"},
+		{Amount: 1500, Currency: "JPY"},
+		{Amount: 8, Currency: "JPY"},
+		{Amount: 1500, Currency: "JPY"},
+	}, converted)
+
+	_, err = service.ConvertPrices(ctx, prices, "SEK")
+	requireCode(t, services.ErrValidation, err)
+
+	_, err = service.ConvertPrices(ctx, prices, "sek")
+	requireCode(t, services.ErrValidation, err)
+}
+
+func testPatchUser(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	name := "patched"
+	patched, err := service.PatchUser(adminContext(), requests.PatchUserRequest{ID: user.ID, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, "patched", patched.Name)
+	require.Equal(t, user.Email, patched.Email)
+	require.Equal(t, user.Version+1, patched.Version)
+
+	email := uniqueEmail("patched")
+	req := requests.PatchUserRequest{ID: user.ID, Email: &email, ExpectedVersion: &user.Version}
+	_, err = service.PatchUser(adminContext(), req)
+	requireCode(t, services.ErrPreconditionFailed, err)
+
+	req.ExpectedVersion = &patched.Version
+	patched, err = service.PatchUser(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, "patched", patched.Name)
+	require.Equal(t, email, patched.Email)
+
+	got, err := service.GetUser(adminContext(), requests.BindUriID{ID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, email, got.Email)
+	require.Equal(t, patched.Version, got.Version)
+
+	empty := ""
+	_, err = service.PatchUser(adminContext(), requests.PatchUserRequest{ID: user.ID, Email: &empty})
+	requireCode(t, services.ErrValidation, err)
+
+	_, err = service.PatchUser(adminContext(), requests.PatchUserRequest{ID: missingID, Name: &name})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testBulkCreateProducts(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	req := requests.BulkCreateProductsRequest{
+		Products: []requests.CreateProductRequest{
+			{UserID: user.ID, Name: "first", Price: 100},
+			{UserID: missingID, Name: "no owner", Price: 100},
+			{UserID: user.ID, Name: "second", Price: 200},
+			{UserID: user.ID, Name: "", Price: 300},
+		},
+	}
+
+	result, err := service.BulkCreateProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{
+		1: services.ErrForeignKeyViolation,
+		3: services.ErrValidation,
+	})
+	require.False(t, result.RolledBack)
+
+	for _, i := range []int{0, 2} {
+		created := result.Results[i].Product
+		require.NotNil(t, created)
+		require.Equal(t, req.Products[i].Name, created.Name)
+		require.Equal(t, req.Products[i].Price, created.Price.Amount)
+		require.Equal(t, int64(1), created.Version)
+	}
+	require.Less(t, result.Results[0].Product.ID, result.Results[2].Product.ID)
+
+	requireProducts(t, listProducts(t, service, requests.ListProductsRequest{Filter: requests.ProductFilter{UserID: &user.ID}}),
+		result.Results[0].Product, result.Results[2].Product)
+}
+
+func testBulkCreateProductsAtomic(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	req := requests.BulkCreateProductsRequest{
+		Products: []requests.CreateProductRequest{
+			{UserID: user.ID, Name: "first", Price: 100},
+			{UserID: missingID, Name: "no owner", Price: 100},
+		},
+		Atomic: true,
+	}
+
+	result, err := service.BulkCreateProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{1: services.ErrForeignKeyViolation})
+	require.True(t, result.RolledBack)
+	require.Zero(t, result.Succeeded)
+	require.Nil(t, result.Results[0].Product)
+	requireProducts(t, listProducts(t, service, requests.ListProductsRequest{Filter: requests.ProductFilter{UserID: &user.ID}}))
+
+	req.Products = req.Products[:1]
+	result, err = service.BulkCreateProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, nil)
+	requireProducts(t, listProducts(t, service, requests.ListProductsRequest{Filter: requests.ProductFilter{UserID: &user.ID}}),
+		result.Results[0].Product)
+}
+
+func testBulkPatchProducts(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	first := createProduct(t, service, user.ID, "first")
+	second := createProduct(t, service, user.ID, "second")
+
+	name, price := "patched", int64(250)
+	stale := int64(5)
+	req := requests.BulkPatchProductsRequest{
+		Products: []requests.PatchProductRequest{
+			{ID: first.ID, Name: &name},
+			{ID: second.ID, Price: &price, ExpectedVersion: &stale},
+		},
+		Atomic: true,
+	}
+
+	// the failing item rolls back the patch of the first one
+	result, err := service.BulkPatchProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{1: services.ErrPreconditionFailed})
+	require.True(t, result.RolledBack)
+
+	got, err := service.GetProduct(adminContext(), requests.BindUriID{ID: first.ID})
+	require.NoError(t, err)
+	require.Equal(t, first.Name, got.Name)
+	require.Equal(t, first.Version, got.Version)
+
+	req.Atomic = false
+	result, err = service.BulkPatchProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{1: services.ErrPreconditionFailed})
+	require.False(t, result.RolledBack)
+	require.Equal(t, name, result.Results[0].Product.Name)
+	require.Equal(t, first.Version+1, result.Results[0].Product.Version)
+
+	req.Products[1].ExpectedVersion = &second.Version
+	req.Atomic = true
+	result, err = service.BulkPatchProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, nil)
+	require.Equal(t, first.Version+2, result.Results[0].Product.Version)
+	require.Equal(t, price, result.Results[1].Product.Price.Amount)
+
+	got, err = service.GetProduct(adminContext(), requests.BindUriID{ID: second.ID})
+	require.NoError(t, err)
+	require.Equal(t, price, got.Price.Amount)
+}
+
+func testBulkDeleteProducts(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	products := createProducts(t, service, user.ID, 3)
+
+	req := requests.BulkDeleteProductsRequest{
+		IDs:    []int64{products[0].ID, missingID, products[1].ID},
+		Atomic: true,
+	}
+
+	result, err := service.BulkDeleteProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{1: services.ErrNotFound})
+	require.True(t, result.RolledBack)
+	require.Len(t, listProducts(t, service, requests.ListProductsRequest{Filter: requests.ProductFilter{UserID: &user.ID}}).Products, 3)
+
+	req.Atomic = false
+	result, err = service.BulkDeleteProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{1: services.ErrNotFound})
+	require.Equal(t, products[0].ID, result.Results[0].Deleted.ProductID)
+	require.False(t, result.Results[0].Deleted.Permanent)
+	requireProducts(t, listProducts(t, service, requests.ListProductsRequest{Filter: requests.ProductFilter{UserID: &user.ID}}), products[2])
+	// deleted together, newest id first
+	requireProducts(t, listDeletedProducts(t, service, requests.ListDeletedProductsRequest{UserID: &user.ID}), products[1], products[0])
+
+	// permanent deletes also empty the trash
+	req = requests.BulkDeleteProductsRequest{IDs: []int64{products[0].ID, products[2].ID}, Permanent: true, Atomic: true}
+	result, err = service.BulkDeleteProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, nil)
+	require.True(t, result.Results[1].Deleted.Permanent)
+	requireProducts(t, listProducts(t, service, requests.ListProductsRequest{Filter: requests.ProductFilter{UserID: &user.ID}}))
+	requireProducts(t, listDeletedProducts(t, service, requests.ListDeletedProductsRequest{UserID: &user.ID}), products[1])
+}
+
+func testBulkProductsInvalid(t *testing.T, service services.Service) {
+	_, err := service.BulkCreateProducts(adminContext(), requests.BulkCreateProductsRequest{})
+	requireCode(t, services.ErrValidation, err)
+
+	_, err = service.BulkDeleteProducts(adminContext(), requests.BulkDeleteProductsRequest{IDs: make([]int64, services.MaxBulkItems+1)})
+	requireCode(t, services.ErrValidation, err)
+
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	req := requests.BulkDeleteProductsRequest{IDs: []int64{product.ID, 0, product.ID}}
+	result, err := service.BulkDeleteProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{
+		1: services.ErrValidation,
+		2: services.ErrValidation,
+	})
+
+	zero := int64(0)
+	patches := requests.BulkPatchProductsRequest{Products: []requests.PatchProductRequest{{ID: product.ID, Price: &zero}}, Atomic: true}
+	result, err = service.BulkPatchProducts(adminContext(), patches)
+	require.NoError(t, err)
+	requireBulkErrors(t, result, map[int]services.ErrorCode{0: services.ErrValidation})
+	require.True(t, result.RolledBack)
+}
+
+func testUpdateUser(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	req := requests.UpdateUserRequest{ID: user.ID, Name: "updated", Email: uniqueEmail("updated"), ExpectedVersion: &user.Version}
+	updated, err := service.UpdateUser(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, req.Name, updated.Name)
+	require.Equal(t, req.Email, updated.Email)
+	require.Equal(t, user.Version+1, updated.Version)
+
+	// req still expects the previous version
+	_, err = service.UpdateUser(adminContext(), req)
+	requireCode(t, services.ErrPreconditionFailed, err)
+
+	got, err := service.GetUser(adminContext(), requests.BindUriID{ID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, req.Email, got.Email)
+	require.Equal(t, updated.Version, got.Version)
+
+	req.ID = missingID
+	_, err = service.UpdateUser(adminContext(), req)
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testUserEmailNormalized(t *testing.T, service services.Service) {
+	local := strings.TrimSuffix(uniqueEmail("Royyan"), "@gmail.com")
+
+	// the decomposed e and acute accent are stored composed
+	req := requests.CreateUserRequest{Name: "royyan", Email: "  " + local + "e\u0301@GMail.COM "}
+	user, err := service.CreateUser(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, local+"\u00e9@gmail.com", user.Email)
+
+	got, err := service.GetUser(adminContext(), requests.BindUriID{ID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, user.Email, got.Email)
+
+	email := " " + strings.ToUpper(local) + "@Example.ORG"
+	patched, err := service.PatchUser(adminContext(), requests.PatchUserRequest{ID: user.ID, Email: &email})
+	require.NoError(t, err)
+	require.Equal(t, strings.ToUpper(local)+"@example.org", patched.Email)
+}
+
+func testUserEmailTaken(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	other := createUser(t, service)
+
+	// emails differing only in case belong to the same mailbox
+	taken := strings.ToUpper(user.Email)
+	_, err := service.CreateUser(adminContext(), requests.CreateUserRequest{Name: "copy", Email: taken})
+	requireCode(t, services.ErrConflict, err)
+	require.Contains(t, err.Error(), "already exists")
+
+	_, err = service.UpdateUser(adminContext(), requests.UpdateUserRequest{ID: other.ID, Name: other.Name, Email: taken})
+	requireCode(t, services.ErrConflict, err)
+
+	_, err = service.PatchUser(adminContext(), requests.PatchUserRequest{ID: other.ID, Email: &taken})
+	requireCode(t, services.ErrConflict, err)
+
+	got, err := service.GetUser(adminContext(), requests.BindUriID{ID: other.ID})
+	require.NoError(t, err)
+	require.Equal(t, other.Email, got.Email)
+
+	// users can keep their own email in another case
+	updated, err := service.UpdateUser(adminContext(), requests.UpdateUserRequest{ID: user.ID, Name: user.Name, Email: taken})
+	require.NoError(t, err)
+	require.Equal(t, user.Version+1, updated.Version)
+
+	// only ASCII letters are folded, SQLite can't fold the others, so every
+	// backend keeps local parts differing in another letter's case apart
+	local := "émile." + searchToken()
+	lower, err := service.CreateUser(adminContext(), requests.CreateUserRequest{Name: "lower", Email: local + "@gmail.com"})
+	require.NoError(t, err)
+	upper, err := service.CreateUser(adminContext(), requests.CreateUserRequest{Name: "upper", Email: "É" + strings.TrimPrefix(local, "é") + "@gmail.com"})
+	require.NoError(t, err)
+	require.NotEqual(t, lower.ID, upper.ID)
+
+	_, err = service.CreateUser(adminContext(), requests.CreateUserRequest{Name: "copy", Email: "éMILE." + strings.TrimPrefix(local, "émile.") + "@GMAIL.com"})
+	requireCode(t, services.ErrConflict, err)
+}
+
+func testUserEmailInvalid(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	for _, email := range []string{
+		"",
+		"   ",
+		"abc",
+		"royyan@",
+		"@gmail.com",
+		"royyan@localhost",
+		"royyan..dev@gmail.com",
+		"Royyan <royyan@gmail.com>",
+		"royyan@gmail.com, other@gmail.com",
+		strings.Repeat("a", 250) + "@gmail.com",
+	} {
+		_, err := service.CreateUser(adminContext(), requests.CreateUserRequest{Name: "royyan", Email: email})
+		requireCode(t, services.ErrValidation, err)
+
+		_, err = service.UpdateUser(adminContext(), requests.UpdateUserRequest{ID: user.ID, Name: user.Name, Email: email})
+		requireCode(t, services.ErrValidation, err)
+
+		email := email
+		_, err = service.PatchUser(adminContext(), requests.PatchUserRequest{ID: user.ID, Email: &email})
+		requireCode(t, services.ErrValidation, err)
+	}
+}
+
+func testDeleteUserRestrict(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	// products in the trash still belong to the user
+	_, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+	require.NoError(t, err)
+
+	_, err = service.DeleteUser(adminContext(), requests.DeleteUserRequest{ID: user.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	_, err = service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID, Permanent: true})
+	require.NoError(t, err)
+
+	deleted, err := service.DeleteUser(adminContext(), requests.DeleteUserRequest{ID: user.ID})
+	require.NoError(t, err)
+	require.True(t, deleted.Deleted)
+	require.Equal(t, user.ID, deleted.UserID)
+	require.Equal(t, requests.UserDeleteRestrict, deleted.Policy)
+	require.Zero(t, deleted.Products)
+
+	_, err = service.GetUser(adminContext(), requests.BindUriID{ID: user.ID})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.DeleteUser(adminContext(), requests.DeleteUserRequest{ID: user.ID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testDeleteUserCascade(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	products := createProducts(t, service, user.ID, 2)
+
+	_, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: products[0].ID})
+	require.NoError(t, err)
+
+	req := requests.DeleteUserRequest{ID: user.ID, Policy: requests.UserDeleteCascade}
+	deleted, err := service.DeleteUser(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, requests.UserDeleteCascade, deleted.Policy)
+	require.Equal(t, int64(2), deleted.Products)
+	require.Nil(t, deleted.ReassignedTo)
+
+	for _, product := range products {
+		_, err = service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID, Permanent: true})
+		requireCode(t, services.ErrNotFound, err)
+	}
+}
+
+func testDeleteUserReassign(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	heir := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	missing := missingID
+	req := requests.DeleteUserRequest{ID: user.ID, Policy: requests.UserDeleteReassign, ReassignTo: &missing}
+	_, err := service.DeleteUser(adminContext(), req)
+	requireCode(t, services.ErrForeignKeyViolation, err)
+
+	// the failed delete changed nothing
+	_, err = service.GetUser(adminContext(), requests.BindUriID{ID: user.ID})
+	require.NoError(t, err)
+
+	req.ReassignTo = &heir.ID
+	deleted, err := service.DeleteUser(adminContext(), req)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted.Products)
+	require.Equal(t, heir.ID, *deleted.ReassignedTo)
+
+	got, err := service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, heir.ID, got.UserID)
+	require.Equal(t, product.Version+1, got.Version)
+}
+
+func testDeleteUserInvalid(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	for _, req := range []requests.DeleteUserRequest{
+		{ID: user.ID, Policy: "orphan"},
+		{ID: user.ID, Policy: requests.UserDeleteReassign},
+		{ID: user.ID, Policy: requests.UserDeleteReassign, ReassignTo: &user.ID},
+		{ID: user.ID, Policy: requests.UserDeleteCascade, ReassignTo: &user.ID},
+	} {
+		_, err := service.DeleteUser(adminContext(), req)
+		requireCode(t, services.ErrValidation, err)
+	}
+
+	_, err := service.GetUser(adminContext(), requests.BindUriID{ID: user.ID})
+	require.NoError(t, err)
+}
+
+func testListUsers(t *testing.T, service services.Service) {
+	users := []*responses.User{createUser(t, service), createUser(t, service), createUser(t, service)}
+
+	// start right before the first user, other tests may have created more
+	first, after := 2, helpers.EncodeIDCursor(users[0].ID-1)
+	page, err := service.ListUsers(adminContext(), requests.ListUsersRequest{First: &first, After: &after})
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 2)
+	require.Equal(t, users[0].ID, page.Edges[0].Node.ID)
+	require.Equal(t, users[1].ID, page.Edges[1].Node.ID)
+	require.True(t, page.PageInfo.HasNextPage)
+	require.True(t, page.PageInfo.HasPreviousPage)
+
+	page, err = service.ListUsers(adminContext(), requests.ListUsersRequest{First: &first, After: &page.PageInfo.EndCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 1)
+	require.Equal(t, users[2].ID, page.Edges[0].Node.ID)
+	require.False(t, page.PageInfo.HasNextPage)
+
+	page, err = service.ListUsers(adminContext(), requests.ListUsersRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, page.Edges)
+	require.False(t, page.PageInfo.HasPreviousPage)
+
+	invalid := "garbage"
+	_, err = service.ListUsers(adminContext(), requests.ListUsersRequest{After: &invalid})
+	requireCode(t, services.ErrBadRequest, err)
+
+	zero := 0
+	_, err = service.ListUsers(adminContext(), requests.ListUsersRequest{First: &zero})
+	requireCode(t, services.ErrValidation, err)
+}
+
+func testRestoreProductBumpsVersion(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	_, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+	require.NoError(t, err)
+
+	restored, err := service.RestoreProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, product.Version+1, restored.Version)
+
+	// edits made before the product was trashed are stale
+	req := requests.UpdateProductRequest{ID: product.ID, Name: "stale", Price: 1, ExpectedVersion: &product.Version}
+	_, err = service.UpdateProduct(adminContext(), req)
+	requireCode(t, services.ErrPreconditionFailed, err)
+}
+
+// testProductMutationsReturnStock checks products are returned with the same
+// stock GetProduct loads, so their entity tags match.
+func testProductMutationsReturnStock(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+	require.Equal(t, &responses.Stock{ProductID: product.ID}, product.Stock)
+
+	adjustStock(t, service, product.ID, 10, requests.StockReceived)
+	createReservation(t, service, user, product.ID, 3)
+	requireSameStock := func(got *responses.Product) {
+		t.Helper()
+		want, err := service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+		require.NoError(t, err)
+		require.Equal(t, want.Stock, got.Stock)
+		require.Equal(t, int64(7), got.Stock.Available)
+	}
+
+	updated, err := service.UpdateProduct(adminContext(), requests.UpdateProductRequest{ID: product.ID, Name: "updated", Price: 10})
+	require.NoError(t, err)
+	requireSameStock(updated)
+
+	name := "patched"
+	patched, err := service.PatchProduct(adminContext(), requests.PatchProductRequest{ID: product.ID, Name: &name})
+	require.NoError(t, err)
+	requireSameStock(patched)
+
+	_, err = service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+	require.NoError(t, err)
+	restored, err := service.RestoreProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	requireSameStock(restored)
+}
+
+func testUpdateProductNotFound(t *testing.T, service services.Service) {
+	req := requests.UpdateProductRequest{
+		ID:    missingID,
+		Name:  "updated",
+		Price: 555,
+	}
+
+	_, err := service.UpdateProduct(adminContext(), req)
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testDeleteProduct(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "trashed product")
+
+	deleted, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+	require.NoError(t, err)
+	require.True(t, deleted.Deleted)
+	require.False(t, deleted.Permanent)
+	require.Equal(t, product.ID, deleted.ProductID)
+
+	// soft deleted products are hidden everywhere but the trash
+	_, err = service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.UpdateProduct(adminContext(), requests.UpdateProductRequest{ID: product.ID, Name: "updated", Price: 1})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+	requireCode(t, services.ErrNotFound, err)
+
+	requireProducts(t, listProducts(t, service, requests.ListProductsRequest{Filter: requests.ProductFilter{UserID: &user.ID}}))
+	require.Empty(t, getUserProducts(t, service, user.ID, 5, nil).Edges)
+
+	batch, err := service.GetBatchUserProducts(adminContext(), requests.GetBatchUserProductsRequest{UserIDs: []int64{user.ID}})
+	require.NoError(t, err)
+	require.Empty(t, batch[0].Edges)
+
+	trash := listDeletedProducts(t, service, requests.ListDeletedProductsRequest{UserID: &user.ID})
+	requireProducts(t, trash, product)
+	require.NotNil(t, trash.Products[0].DeletedAt)
+}
+
+func testDeleteProductPermanent(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	live := createProduct(t, service, user.ID, "live product")
+	trashed := createProduct(t, service, user.ID, "trashed product")
+
+	_, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: trashed.ID})
+	require.NoError(t, err)
+
+	for _, product := range []*responses.Product{live, trashed} {
+		deleted, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID, Permanent: true})
+		require.NoError(t, err)
+		require.True(t, deleted.Permanent)
+		require.Equal(t, product.ID, deleted.ProductID)
+	}
+
+	requireProducts(t, listDeletedProducts(t, service, requests.ListDeletedProductsRequest{UserID: &user.ID}))
+
+	_, err = service.RestoreProduct(adminContext(), requests.BindUriID{ID: trashed.ID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testDeleteProductNotFound(t *testing.T, service services.Service) {
+	for _, permanent := range []bool{false, true} {
+		req := requests.DeleteProductRequest{ID: missingID, Permanent: permanent}
+		_, err := service.DeleteProduct(adminContext(), req)
+		requireCode(t, services.ErrNotFound, err)
+	}
+}
+
+func testRestoreProduct(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "restored product")
+
+	// only products in the trash can be restored
+	_, err := service.RestoreProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+	require.NoError(t, err)
+
+	restored, err := service.RestoreProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, product.ID, restored.ID)
+	require.Equal(t, product.Name, restored.Name)
+	require.Nil(t, restored.DeletedAt)
+
+	got, err := service.GetProduct(adminContext(), requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, product.ID, got.ID)
+	requireProducts(t, listDeletedProducts(t, service, requests.ListDeletedProductsRequest{UserID: &user.ID}))
+
+	_, err = service.RestoreProduct(adminContext(), requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testListDeletedProducts(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	products := createProducts(t, service, user.ID, 3)
+	other := createUser(t, service)
+	createProducts(t, service, other.ID, 1)
+
+	// most recently deleted first
+	for _, product := range products {
+		_, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	req := requests.ListDeletedProductsRequest{UserID: &user.ID, Limit: 2}
+	trash := listDeletedProducts(t, service, req)
+	requireProducts(t, trash, products[2], products[1])
+	require.Equal(t, int64(3), trash.PageInfo.Total)
+
+	req.Offset = 2
+	requireProducts(t, listDeletedProducts(t, service, req), products[0])
+
+	for _, req := range []requests.ListDeletedProductsRequest{
+		{Limit: 0},
+		{Limit: services.MaxListLimit + 1},
+		{Limit: 1, Offset: -1},
+	} {
+		_, err := service.ListDeletedProducts(adminContext(), req)
+		requireCode(t, services.ErrValidation, err)
+	}
+}
+
+func testPurgeDeletedProducts(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	products := createProducts(t, service, user.ID, 3)
+	for _, product := range products[:2] {
+		_, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: product.ID})
+		require.NoError(t, err)
+	}
+
+	// nothing was deleted an hour ago
+	_, err := service.PurgeDeletedProducts(adminContext(), requests.PurgeDeletedProductsRequest{DeletedBefore: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	trash := listDeletedProducts(t, service, requests.ListDeletedProductsRequest{UserID: &user.ID})
+	require.Equal(t, int64(2), trash.PageInfo.Total)
+
+	purged, err := service.PurgeDeletedProducts(adminContext(), requests.PurgeDeletedProductsRequest{DeletedBefore: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, purged, int64(2))
+
+	trash = listDeletedProducts(t, service, requests.ListDeletedProductsRequest{UserID: &user.ID})
+	require.Zero(t, trash.PageInfo.Total)
+
+	_, err = service.RestoreProduct(adminContext(), requests.BindUriID{ID: products[0].ID})
+	requireCode(t, services.ErrNotFound, err)
+
+	// live products are never purged
+	_, err = service.GetProduct(adminContext(), requests.BindUriID{ID: products[2].ID})
+	require.NoError(t, err)
+}
+
+func testUserProductsUnknownUser(t *testing.T, service services.Service) {
+	first := 5
+	req := requests.GetUserProductsRequest{UserID: missingID, First: &first}
+
+	_, err := service.GetUserProducts(adminContext(), req)
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testUserProductsEmpty(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+
+	products := getUserProducts(t, service, user.ID, 5, nil)
+	require.Empty(t, products.Edges)
+	require.False(t, products.PageInfo.HasNextPage)
+	require.Empty(t, products.PageInfo.StartCursor)
+	require.Empty(t, products.PageInfo.EndCursor)
+}
+
+func testUserProductsPagination(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	created := createProducts(t, service, user.ID, 5)
+
+	// newest first
+	page := getUserProducts(t, service, user.ID, 2, nil)
+	requireEdges(t, page, created[4], created[3])
+	require.True(t, page.PageInfo.HasNextPage)
+	require.Equal(t, page.Edges[0].Cursor, page.PageInfo.StartCursor)
+	require.Equal(t, page.Edges[1].Cursor, page.PageInfo.EndCursor)
+
+	require.False(t, page.PageInfo.HasPreviousPage)
+
+	page = getUserProducts(t, service, user.ID, 2, &page.PageInfo.EndCursor)
+	requireEdges(t, page, created[2], created[1])
+	require.True(t, page.PageInfo.HasNextPage)
+	require.True(t, page.PageInfo.HasPreviousPage)
+
+	page = getUserProducts(t, service, user.ID, 2, &page.PageInfo.EndCursor)
+	requireEdges(t, page, created[0])
+	require.False(t, page.PageInfo.HasNextPage)
+	require.True(t, page.PageInfo.HasPreviousPage)
+}
+
+func testUserProductsBackward(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	created := createProducts(t, service, user.ID, 5)
+
+	// oldest page first, still newest first within the page
+	page := getUserProductsBefore(t, service, user.ID, 2, nil)
+	requireEdges(t, page, created[1], created[0])
+	require.False(t, page.PageInfo.HasNextPage)
+	require.True(t, page.PageInfo.HasPreviousPage)
+
+	page = getUserProductsBefore(t, service, user.ID, 2, &page.PageInfo.StartCursor)
+	requireEdges(t, page, created[3], created[2])
+	require.True(t, page.PageInfo.HasNextPage)
+	require.True(t, page.PageInfo.HasPreviousPage)
+
+	page = getUserProductsBefore(t, service, user.ID, 2, &page.PageInfo.StartCursor)
+	requireEdges(t, page, created[4])
+	require.True(t, page.PageInfo.HasNextPage)
+	require.False(t, page.PageInfo.HasPreviousPage)
+
+	// going forward again from the middle of the list
+	forward := getUserProducts(t, service, user.ID, 2, &page.PageInfo.EndCursor)
+	requireEdges(t, forward, created[3], created[2])
+}
+
+// testUserProductsSameCreatedAt creates products without waiting so several
+// of them may share created_at, paging must still return each exactly once.
+func testUserProductsSameCreatedAt(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	for i := 0; i < 10; i++ {
+		createProduct(t, service, user.ID, fmt.Sprintf("product %d", i))
+	}
+
+	seen := map[int64]bool{}
+	var after *string
+	for {
+		page := getUserProducts(t, service, user.ID, 3, after)
+		for _, edge := range page.Edges {
+			require.False(t, seen[edge.Node.ID], "product %d returned twice", edge.Node.ID)
+			seen[edge.Node.ID] = true
+		}
+
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		after = &page.PageInfo.EndCursor
+	}
+	require.Len(t, seen, 10)
+
+	var before *string
+	seen = map[int64]bool{}
+	for {
+		page := getUserProductsBefore(t, service, user.ID, 4, before)
+		for _, edge := range page.Edges {
+			require.False(t, seen[edge.Node.ID], "product %d returned twice", edge.Node.ID)
+			seen[edge.Node.ID] = true
+		}
+
+		if !page.PageInfo.HasPreviousPage {
+			break
+		}
+		before = &page.PageInfo.StartCursor
+	}
+	require.Len(t, seen, 10)
+}
+
+func testUserProductsInvalidCursor(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	created := createProducts(t, service, user.ID, 2)
+	page := getUserProducts(t, service, user.ID, 1, nil)
+	requireEdges(t, page, created[1])
+
+	// flip the last character of the signature
+	cursor := page.PageInfo.EndCursor
+	tampered := cursor[:len(cursor)-1] + "A"
+	if tampered == cursor {
+		tampered = cursor[:len(cursor)-1] + "B"
+	}
+
+	for _, cursor := range []string{"garbage", "", tampered} {
+		cursor := cursor
+		_, err := service.GetUserProducts(adminContext(), requests.GetUserProductsRequest{UserID: user.ID, After: &cursor})
+		requireCode(t, services.ErrBadRequest, err)
+
+		_, err = service.GetUserProducts(adminContext(), requests.GetUserProductsRequest{UserID: user.ID, Before: &cursor})
+		requireCode(t, services.ErrBadRequest, err)
+	}
+}
+
+func testUserProductsInvalidPage(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	zero, tooMany, one := 0, services.DefaultMaxPageSize+1, 1
+
+	for _, req := range []requests.GetUserProductsRequest{
+		{UserID: user.ID, First: &zero},
+		{UserID: user.ID, First: &tooMany},
+		{UserID: user.ID, Last: &tooMany},
+		{UserID: user.ID, First: &one, Last: &one},
+	} {
+		_, err := service.GetUserProducts(adminContext(), req)
+		requireCode(t, services.ErrValidation, err)
+	}
+}
+
+func testUserProductsExactPage(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	created := createProducts(t, service, user.ID, 3)
+
+	page := getUserProducts(t, service, user.ID, 3, nil)
+	requireEdges(t, page, created[2], created[1], created[0])
+	require.False(t, page.PageInfo.HasNextPage)
+
+	page = getUserProducts(t, service, user.ID, 3, &page.PageInfo.EndCursor)
+	require.Empty(t, page.Edges)
+	require.False(t, page.PageInfo.HasNextPage)
+}
+
+func testUserProductsOnlyOwner(t *testing.T, service services.Service) {
+	owner := createUser(t, service)
+	other := createUser(t, service)
+	created := createProducts(t, service, owner.ID, 2)
+	createProducts(t, service, other.ID, 2)
+
+	page := getUserProducts(t, service, owner.ID, 5, nil)
+	requireEdges(t, page, created[1], created[0])
+	require.False(t, page.PageInfo.HasNextPage)
+}
+
+func testBatchUsers(t *testing.T, service services.Service) {
+	first := createUser(t, service)
+	second := createUser(t, service)
+
+	req := requests.GetBatchUsersRequest{IDs: []int64{second.ID, missingID, first.ID}}
+	users, err := service.GetBatchUsers(adminContext(), req)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	ids := []int64{users[0].ID, users[1].ID}
+	require.ElementsMatch(t, []int64{first.ID, second.ID}, ids)
+
+	users, err = service.GetBatchUsers(adminContext(), requests.GetBatchUsersRequest{})
+	require.NoError(t, err)
+	require.Empty(t, users)
+}
+
+// testBatchProducts expects missing and trashed products to be left out.
+func testBatchProducts(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	products := createProducts(t, service, user.ID, 3)
+
+	_, err := service.DeleteProduct(adminContext(), requests.DeleteProductRequest{ID: products[2].ID})
+	require.NoError(t, err)
+
+	req := requests.GetBatchProductsRequest{IDs: []int64{products[1].ID, missingID, products[0].ID, products[2].ID}}
+	batch, err := service.GetBatchProducts(adminContext(), req)
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+
+	ids := []int64{batch[0].ID, batch[1].ID}
+	require.ElementsMatch(t, []int64{products[0].ID, products[1].ID}, ids)
+
+	batch, err = service.GetBatchProducts(adminContext(), requests.GetBatchProductsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, batch)
+}
+
+// testBatchUserProducts walks several users at once and expects the same
+// pages GetUserProducts returns for each of them.
+func testBatchUserProducts(t *testing.T, service services.Service) {
+	users := []*responses.User{createUser(t, service), createUser(t, service), createUser(t, service)}
+	createProducts(t, service, users[0].ID, 5)
+	createProducts(t, service, users[1].ID, 2)
+
+	userIDs := []int64{users[0].ID, users[1].ID, users[2].ID, missingID}
+	first := 2
+	req := requests.GetBatchUserProductsRequest{UserIDs: userIDs, First: &first}
+	for page := 0; page < 3; page++ {
+		pages, err := service.GetBatchUserProducts(adminContext(), req)
+		require.NoError(t, err)
+		require.Len(t, pages, len(userIDs))
+
+		for i, user := range users {
+			single := getUserProducts(t, service, user.ID, first, req.After)
+			requireSamePage(t, single, pages[i])
+		}
+		require.Empty(t, pages[3].Edges)
+
+		// continue from the first user's position, all users share the cursor
+		if !pages[0].PageInfo.HasNextPage {
+			break
+		}
+		req.After = &pages[0].PageInfo.EndCursor
+	}
+}
+
+func testBatchUserProductsBackward(t *testing.T, service services.Service) {
+	users := []*responses.User{createUser(t, service), createUser(t, service)}
+	createProducts(t, service, users[0].ID, 3)
+	createProducts(t, service, users[1].ID, 1)
+
+	last := 2
+	req := requests.GetBatchUserProductsRequest{UserIDs: []int64{users[0].ID, users[1].ID}, Last: &last}
+	pages, err := service.GetBatchUserProducts(adminContext(), req)
+	require.NoError(t, err)
+
+	for i, user := range users {
+		requireSamePage(t, getUserProductsBefore(t, service, user.ID, last, nil), pages[i])
+	}
+
+	req.Before = &pages[0].PageInfo.StartCursor
+	pages, err = service.GetBatchUserProducts(adminContext(), req)
+	require.NoError(t, err)
+	requireSamePage(t, getUserProductsBefore(t, service, users[0].ID, last, req.Before), pages[0])
+
+	invalid := "invalid"
+	req.Before = &invalid
+	_, err = service.GetBatchUserProducts(adminContext(), req)
+	requireCode(t, services.ErrBadRequest, err)
+}
+
+func testCategoryTree(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	root := createCategory(t, service, "Root "+searchToken(), nil)
+	child := createCategory(t, service, "Child", &root.ID)
+	leaf := createCategory(t, service, "Leaf", &child.ID)
+	require.Nil(t, root.ParentID)
+	require.Equal(t, root.ID, *child.ParentID)
+
+	got, err := service.GetCategory(ctx, requests.BindUriID{ID: leaf.ID})
+	require.NoError(t, err)
+	require.Equal(t, leaf.Name, got.Name)
+	require.Equal(t, child.ID, *got.ParentID)
+
+	children, err := service.ListCategories(ctx, requests.ListCategoriesRequest{ParentID: &root.ID})
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	require.Equal(t, child.ID, children[0].ID)
+
+	roots, err := service.ListCategories(ctx, requests.ListCategoriesRequest{})
+	require.NoError(t, err)
+	require.Contains(t, categoryIDs(roots), root.ID)
+	require.NotContains(t, categoryIDs(roots), child.ID)
+
+	// names are unique among siblings only
+	_, err = service.CreateCategory(ctx, requests.CreateCategoryRequest{Name: "CHILD", ParentID: &root.ID})
+	requireCode(t, services.ErrConflict, err)
+	createCategory(t, service, "Child", &child.ID)
+
+	// a category cannot end up below itself
+	_, err = service.UpdateCategory(ctx, requests.UpdateCategoryRequest{ID: root.ID, Name: root.Name, ParentID: &leaf.ID})
+	requireCode(t, services.ErrValidation, err)
+	_, err = service.UpdateCategory(ctx, requests.UpdateCategoryRequest{ID: child.ID, Name: child.Name, ParentID: &child.ID})
+	requireCode(t, services.ErrValidation, err)
+
+	moved, err := service.UpdateCategory(ctx, requests.UpdateCategoryRequest{ID: leaf.ID, Name: "Moved leaf", ParentID: &root.ID})
+	require.NoError(t, err)
+	require.Equal(t, "Moved leaf", moved.Name)
+	require.Equal(t, root.ID, *moved.ParentID)
+
+	children, err = service.ListCategories(ctx, requests.ListCategoriesRequest{ParentID: &root.ID})
+	require.NoError(t, err)
+	require.Equal(t, []int64{child.ID, leaf.ID}, categoryIDs(children))
+
+	// categories with subcategories cannot be deleted
+	err = service.DeleteCategory(ctx, requests.BindUriID{ID: root.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	require.NoError(t, service.DeleteCategory(ctx, requests.BindUriID{ID: leaf.ID}))
+	_, err = service.GetCategory(ctx, requests.BindUriID{ID: leaf.ID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testCategoryInvalid(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	category := createCategory(t, service, "Category "+searchToken(), nil)
+	missing := missingID
+	zero := int64(0)
+
+	_, err := service.CreateCategory(ctx, requests.CreateCategoryRequest{Name: "  "})
+	requireCode(t, services.ErrValidation, err)
+
+	_, err = service.CreateCategory(ctx, requests.CreateCategoryRequest{Name: "orphan", ParentID: &missing})
+	requireCode(t, services.ErrForeignKeyViolation, err)
+
+	_, err = service.CreateCategory(ctx, requests.CreateCategoryRequest{Name: "orphan", ParentID: &zero})
+	requireCode(t, services.ErrValidation, err)
+
+	_, err = service.UpdateCategory(ctx, requests.UpdateCategoryRequest{ID: category.ID, Name: "orphan", ParentID: &missing})
+	requireCode(t, services.ErrForeignKeyViolation, err)
+
+	_, err = service.UpdateCategory(ctx, requests.UpdateCategoryRequest{ID: missingID, Name: "missing"})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.ListCategories(ctx, requests.ListCategoriesRequest{ParentID: &missing})
+	requireCode(t, services.ErrNotFound, err)
+
+	err = service.DeleteCategory(ctx, requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testTags(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	name := "sale-" + searchToken()
+
+	tag, err := service.CreateTag(ctx, requests.CreateTagRequest{Name: "  " + strings.ToUpper(name) + " "})
+	require.NoError(t, err)
+	require.Equal(t, name, tag.Name)
+
+	_, err = service.CreateTag(ctx, requests.CreateTagRequest{Name: name})
+	requireCode(t, services.ErrConflict, err)
+
+	_, err = service.CreateTag(ctx, requests.CreateTagRequest{Name: " "})
+	requireCode(t, services.ErrValidation, err)
+
+	_, err = service.CreateTag(ctx, requests.CreateTagRequest{Name: strings.Repeat("a", 51)})
+	requireCode(t, services.ErrValidation, err)
+
+	tags, err := service.ListTags(ctx)
+	require.NoError(t, err)
+	require.Contains(t, tagNames(tags), name)
+
+	require.NoError(t, service.DeleteTag(ctx, requests.BindUriID{ID: tag.ID}))
+	err = service.DeleteTag(ctx, requests.BindUriID{ID: tag.ID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testProductCategoriesAndTags(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+	other := createProduct(t, service, user.ID, "other")
+	root := createCategory(t, service, "Root "+searchToken(), nil)
+	child := createCategory(t, service, "Child", &root.ID)
+	token := searchToken()
+
+	categories, err := service.SetProductCategories(ctx, requests.SetProductCategoriesRequest{
+		ProductID:   product.ID,
+		CategoryIDs: []int64{root.ID, child.ID, root.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{child.ID, root.ID}, categoryIDs(categories))
+
+	tags, err := service.SetProductTags(ctx, requests.SetProductTagsRequest{
+		ProductID: product.ID,
+		Tags:      []string{"red-" + token, "RED-" + token, "blue-" + token},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"blue-" + token, "red-" + token}, tagNames(tags))
+
+	batchCategories, err := service.GetBatchProductCategories(ctx, requests.GetBatchProductCategoriesRequest{
+		ProductIDs: []int64{other.ID, product.ID, missingID},
+	})
+	require.NoError(t, err)
+	require.Len(t, batchCategories, 3)
+	require.Empty(t, batchCategories[0])
+	require.Equal(t, []int64{child.ID, root.ID}, categoryIDs(batchCategories[1]))
+	require.Empty(t, batchCategories[2])
+
+	batchTags, err := service.GetBatchProductTags(ctx, requests.GetBatchProductTagsRequest{
+		ProductIDs: []int64{product.ID, other.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"blue-" + token, "red-" + token}, tagNames(batchTags[0]))
+	require.Empty(t, batchTags[1])
+
+	// setting replaces, existing tags are reused
+	tags, err = service.SetProductTags(ctx, requests.SetProductTagsRequest{ProductID: other.ID, Tags: []string{"red-" + token}})
+	require.NoError(t, err)
+	require.Equal(t, batchTags[0][1].ID, tags[0].ID)
+
+	categories, err = service.SetProductCategories(ctx, requests.SetProductCategoriesRequest{ProductID: product.ID, CategoryIDs: []int64{}})
+	require.NoError(t, err)
+	require.Empty(t, categories)
+
+	_, err = service.SetProductCategories(ctx, requests.SetProductCategoriesRequest{ProductID: product.ID, CategoryIDs: []int64{root.ID, missingID}})
+	requireCode(t, services.ErrForeignKeyViolation, err)
+
+	_, err = service.SetProductTags(ctx, requests.SetProductTagsRequest{ProductID: product.ID, Tags: []string{"ok", " "}})
+	requireCode(t, services.ErrValidation, err)
+
+	_, err = service.SetProductTags(ctx, requests.SetProductTagsRequest{ProductID: missingID, Tags: []string{"ok"}})
+	requireCode(t, services.ErrNotFound, err)
+
+	// permanently deleted products lose their tags
+	_, err = service.DeleteProduct(ctx, requests.DeleteProductRequest{ID: other.ID, Permanent: true})
+	require.NoError(t, err)
+	batchTags, err = service.GetBatchProductTags(ctx, requests.GetBatchProductTagsRequest{ProductIDs: []int64{other.ID}})
+	require.NoError(t, err)
+	require.Empty(t, batchTags[0])
+}
+
+func testUserProductsFilters(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	products := createProducts(t, service, user.ID, 3)
+	root := createCategory(t, service, "Root "+searchToken(), nil)
+	child := createCategory(t, service, "Child", &root.ID)
+	tag := "tag-" + searchToken()
+
+	_, err := service.SetProductCategories(ctx, requests.SetProductCategoriesRequest{ProductID: products[0].ID, CategoryIDs: []int64{child.ID}})
+	require.NoError(t, err)
+	_, err = service.SetProductCategories(ctx, requests.SetProductCategoriesRequest{ProductID: products[1].ID, CategoryIDs: []int64{root.ID}})
+	require.NoError(t, err)
+	for _, product := range []*responses.Product{products[0], products[2]} {
+		_, err = service.SetProductTags(ctx, requests.SetProductTagsRequest{ProductID: product.ID, Tags: []string{tag}})
+		require.NoError(t, err)
+	}
+
+	upperTag := strings.ToUpper(tag)
+	unknownTag := "unknown-" + tag
+	testCases := []struct {
+		name       string
+		categoryID *int64
+		tag        *string
+		expected   []*responses.Product
+	}{
+		{"category with subcategories", &root.ID, nil, []*responses.Product{products[1], products[0]}},
+		{"leaf category", &child.ID, nil, []*responses.Product{products[0]}},
+		{"tag", nil, &upperTag, []*responses.Product{products[2], products[0]}},
+		{"category and tag", &root.ID, &tag, []*responses.Product{products[0]}},
+		{"unknown tag", nil, &unknownTag, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			first := 10
+			req := requests.GetUserProductsRequest{UserID: user.ID, First: &first, CategoryID: testCase.categoryID, Tag: testCase.tag}
+			page, err := service.GetUserProducts(ctx, req)
+			require.NoError(t, err)
+			requireEdges(t, page, testCase.expected...)
+
+			batch, err := service.GetBatchUserProducts(ctx, requests.GetBatchUserProductsRequest{
+				UserIDs:    []int64{user.ID},
+				First:      &first,
+				CategoryID: testCase.categoryID,
+				Tag:        testCase.tag,
+			})
+			require.NoError(t, err)
+			requireSamePage(t, page, batch[0])
+		})
+	}
+
+	// pages only count filtered products
+	first := 1
+	req := requests.GetUserProductsRequest{UserID: user.ID, First: &first, CategoryID: &root.ID}
+	page, err := service.GetUserProducts(ctx, req)
+	require.NoError(t, err)
+	requireEdges(t, page, products[1])
+	require.True(t, page.PageInfo.HasNextPage)
+
+	req.After = &page.PageInfo.EndCursor
+	page, err = service.GetUserProducts(ctx, req)
+	require.NoError(t, err)
+	requireEdges(t, page, products[0])
+	require.False(t, page.PageInfo.HasNextPage)
+	require.True(t, page.PageInfo.HasPreviousPage)
+
+	zero, empty := int64(0), " "
+	_, err = service.GetUserProducts(ctx, requests.GetUserProductsRequest{UserID: user.ID, CategoryID: &zero})
+	requireCode(t, services.ErrValidation, err)
+	_, err = service.GetUserProducts(ctx, requests.GetUserProductsRequest{UserID: user.ID, Tag: &empty})
+	requireCode(t, services.ErrValidation, err)
+}
+
+func requireSamePage(t *testing.T, expected, actual *responses.Products) {
+	require.Len(t, actual.Edges, len(expected.Edges))
+	for i := range expected.Edges {
+		require.Equal(t, expected.Edges[i].Node.ID, actual.Edges[i].Node.ID)
+		require.Equal(t, expected.Edges[i].Cursor, actual.Edges[i].Cursor)
+	}
+	require.Equal(t, expected.PageInfo, actual.PageInfo)
+}
+
+// createCatalog creates alpha (300), beta (100) and "gamma 100%" (200) for a
+// new user and returns the user id with the products in creation order.
+func createCatalog(t *testing.T, service services.Service) (int64, []*responses.Product) {
+	user := createUser(t, service)
+	var products []*responses.Product
+	for _, p := range []struct {
+		name  string
+		price int64
+	}{{"alpha", 300}, {"beta", 100}, {"gamma 100%", 200}} {
+		req := requests.CreateProductRequest{UserID: user.ID, Name: p.name, Price: p.price}
+		product, err := service.CreateProduct(adminContext(), req)
+		require.NoError(t, err)
+		products = append(products, product)
+	}
+
+	return user.ID, products
+}
+
+func testListProductsFilters(t *testing.T, service services.Service) {
+	userID, p := createCatalog(t, service)
+	createCatalog(t, service)
+
+	name := func(s string) *string { return &s }
+	price := func(v int64) *int64 { return &v }
+	hourAgo := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name     string
+		filter   requests.ProductFilter
+		expected []*responses.Product
+	}{
+		{"owner only", requests.ProductFilter{}, p},
+		{"name case insensitive", requests.ProductFilter{Name: name("ALP")}, p[:1]},
+		{"name wildcard is literal", requests.ProductFilter{Name: name("%")}, p[2:]},
+		{"name underscore is literal", requests.ProductFilter{Name: name("a_")}, nil},
+		{"name backslash is literal", requests.ProductFilter{Name: name(`\`)}, nil},
+		{"price range", requests.ProductFilter{MinPrice: price(150), MaxPrice: price(300)}, []*responses.Product{p[0], p[2]}},
+		{"created after", requests.ProductFilter{CreatedAfter: &hourAgo}, p},
+		{"created before", requests.ProductFilter{CreatedBefore: &hourAgo}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.UserID = &userID
+			list := listProducts(t, service, requests.ListProductsRequest{Filter: tc.filter})
+			requireProducts(t, list, tc.expected...)
+			require.Equal(t, int64(len(tc.expected)), list.PageInfo.Total)
+		})
+	}
+}
+
+func testListProductsOrder(t *testing.T, service services.Service) {
+	userID, p := createCatalog(t, service)
+
+	tests := []struct {
+		order    requests.ProductOrder
+		expected []*responses.Product
+	}{
+		{requests.ProductOrder{}, []*responses.Product{p[0], p[1], p[2]}},
+		{requests.ProductOrder{Field: requests.ProductOrderID, Direction: requests.OrderDesc}, []*responses.Product{p[2], p[1], p[0]}},
+		{requests.ProductOrder{Field: requests.ProductOrderName, Direction: requests.OrderDesc}, []*responses.Product{p[2], p[1], p[0]}},
+		{requests.ProductOrder{Field: requests.ProductOrderPrice, Direction: requests.OrderAsc}, []*responses.Product{p[1], p[2], p[0]}},
+		{requests.ProductOrder{Field: requests.ProductOrderPrice, Direction: requests.OrderDesc}, []*responses.Product{p[0], p[2], p[1]}},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s %s", tc.order.Field, tc.order.Direction), func(t *testing.T) {
+			req := requests.ListProductsRequest{
+				Filter: requests.ProductFilter{UserID: &userID},
+				Order:  tc.order,
+			}
+
+			requireProducts(t, listProducts(t, service, req), tc.expected...)
+		})
+	}
+}
+
+func testListProductsOffset(t *testing.T, service services.Service) {
+	userID, p := createCatalog(t, service)
+
+	req := requests.ListProductsRequest{
+		Filter: requests.ProductFilter{UserID: &userID},
+		Limit:  2,
+		Offset: 1,
+	}
+
+	list := listProducts(t, service, req)
+	requireProducts(t, list, p[1], p[2])
+	require.Equal(t, 2, list.PageInfo.Limit)
+	require.Equal(t, 1, list.PageInfo.Offset)
+	require.Equal(t, int64(3), list.PageInfo.Total)
+
+	req.Offset = 3
+	list = listProducts(t, service, req)
+	requireProducts(t, list)
+	require.Equal(t, int64(3), list.PageInfo.Total)
+}
+
+func testListProductsInvalid(t *testing.T, service services.Service) {
+	for _, req := range []requests.ListProductsRequest{
+		{Limit: 0},
+		{Limit: services.MaxListLimit + 1},
+		{Limit: 1, Offset: -1},
+		{Limit: 1, Order: requests.ProductOrder{Field: "user_id"}},
+		{Limit: 1, Order: requests.ProductOrder{Direction: "sideways"}},
+	} {
+		_, err := service.ListProducts(adminContext(), req)
+		requireCode(t, services.ErrValidation, err)
+	}
+}
+
+func testSearchProducts(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	token := searchToken()
+
+	exact := createProduct(t, service, user.ID, token+" Keyboard")
+	mechanical := createProduct(t, service, user.ID, token+" mechanical keyboard")
+	cover := createProduct(t, service, user.ID, token+" keyboard cover")
+	createProduct(t, service, user.ID, token+" mouse")
+
+	results := searchProducts(t, service, token+" keyboard", 10, nil)
+	require.Len(t, results.Edges, 3)
+
+	var ids []int64
+	for i, edge := range results.Edges {
+		ids = append(ids, edge.Node.ID)
+		require.NotNil(t, edge.Rank)
+		require.NotNil(t, edge.Highlight)
+		if i > 0 {
+			require.LessOrEqual(t, *edge.Rank, *results.Edges[i-1].Rank)
+		}
+	}
+	require.ElementsMatch(t, []int64{exact.ID, mechanical.ID, cover.ID}, ids)
+
+	require.Equal(t, exact.ID, results.Edges[0].Node.ID)
+	require.Equal(t, "<mark>"+token+"</mark> <mark>Keyboard</mark>", *results.Edges[0].Highlight)
+	require.False(t, results.PageInfo.HasNextPage)
+	require.False(t, results.PageInfo.HasPreviousPage)
+
+	results = searchProducts(t, service, token+" headphones", 10, nil)
+	require.Empty(t, results.Edges)
+
+	// names are escaped, the marks are the only markup of a highlight
+	bold := createProduct(t, service, user.ID, token+" <b>bold</b> & co")
+	results = searchProducts(t, service, token+" bold", 10, nil)
+	require.NotEmpty(t, results.Edges)
+	require.Equal(t, bold.ID, results.Edges[0].Node.ID)
+	require.Equal(t, "<mark>"+token+"</mark> &lt;b&gt;<mark>bold</mark>&lt;/b&gt; &amp; co", *results.Edges[0].Highlight)
+}
+
+func testSearchProductsTypo(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	token := searchToken()
+	product := createProduct(t, service, user.ID, token+" mechanical keyboard")
+
+	results := searchProducts(t, service, token+" mechanicl", 10, nil)
+	require.Len(t, results.Edges, 1)
+	require.Equal(t, product.ID, results.Edges[0].Node.ID)
+}
+
+func testSearchProductsPagination(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	token := searchToken()
+
+	// equally relevant products are ordered by id
+	var products []*responses.Product
+	for i := 1; i <= 5; i++ {
+		products = append(products, createProduct(t, service, user.ID, fmt.Sprintf("%s lamp %d", token, i)))
+	}
+
+	var after *string
+	for i, expected := range [][]*responses.Product{products[:2], products[2:4], products[4:]} {
+		results := searchProducts(t, service, token+" lamp", 2, after)
+		require.Len(t, results.Edges, len(expected))
+		for j, product := range expected {
+			require.Equal(t, product.ID, results.Edges[j].Node.ID)
+		}
+
+		require.Equal(t, i < 2, results.PageInfo.HasNextPage)
+		require.Equal(t, i > 0, results.PageInfo.HasPreviousPage)
+		after = &results.PageInfo.EndCursor
+	}
+}
+
+func testSearchProductsInvalid(t *testing.T, service services.Service) {
+	zero := 0
+	tooLong := strings.Repeat("a", services.MaxSearchQueryLength+1)
+	for _, req := range []requests.SearchProductsRequest{
+		{Query: "  "},
+		{Query: tooLong},
+		{Query: "lamp", First: &zero},
+	} {
+		_, err := service.SearchProducts(adminContext(), req)
+		requireCode(t, services.ErrValidation, err)
+	}
+
+	// cursors of other connections are not search cursors
+	user := createUser(t, service)
+	createProducts(t, service, user.ID, 1)
+	products := getUserProducts(t, service, user.ID, 1, nil)
+
+	for _, cursor := range []string{"garbage", products.PageInfo.EndCursor} {
+		req := requests.SearchProductsRequest{Query: "lamp", After: &cursor}
+		_, err := service.SearchProducts(adminContext(), req)
+		requireCode(t, services.ErrBadRequest, err)
+	}
+}
+
+func searchProducts(t *testing.T, service services.Service, query string, first int, after *string) *responses.Products {
+	req := requests.SearchProductsRequest{
+		Query: query,
+		First: &first,
+		After: after,
+	}
+
+	results, err := service.SearchProducts(adminContext(), req)
+	require.NoError(t, err)
+	require.NotNil(t, results.PageInfo)
+
+	return results
+}
+
+var emailSeq int64
+
+// uniqueEmail returns an email no other user has, even on shared storage.
+func uniqueEmail(name string) string {
+	return fmt.Sprintf("%s.%s%d@gmail.com", name, searchToken(), atomic.AddInt64(&emailSeq, 1))
+}
+
+// searchToken returns a word no other test uses, so searches only see the
+// products of the running test even on shared storage.
+func testAdjustStock(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "stocked")
+
+	// products that never had stock have none
+	stock, err := service.GetStock(ctx, requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.Stock{ProductID: product.ID}, *stock)
+
+	stock = adjustStock(t, service, product.ID, 10, requests.StockReceived)
+	require.Equal(t, int64(10), stock.OnHand)
+	require.Equal(t, int64(10), stock.Available)
+
+	stock = adjustStock(t, service, product.ID, -3, requests.StockDamaged)
+	require.Equal(t, int64(7), stock.OnHand)
+
+	_, err = service.AdjustStock(ctx, requests.AdjustStockRequest{
+		ProductID: product.ID,
+		Delta:     -8,
+		Reason:    requests.StockLost,
+	})
+	requireCode(t, services.ErrConflict, err)
+
+	got, err := service.GetProduct(ctx, requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.NotNil(t, got.Stock)
+	require.Equal(t, int64(7), got.Stock.OnHand)
+
+	stocks, err := service.GetBatchStock(ctx, requests.GetBatchStockRequest{ProductIDs: []int64{missingID, product.ID}})
+	require.NoError(t, err)
+	require.Len(t, stocks, 2)
+	require.Equal(t, responses.Stock{ProductID: missingID}, *stocks[0])
+	require.Equal(t, int64(7), stocks[1].Available)
+
+	adjustments, err := service.ListStockAdjustments(ctx, requests.ListStockAdjustmentsRequest{ProductID: product.ID})
+	require.NoError(t, err)
+	require.Len(t, adjustments, 2)
+	require.Equal(t, int64(-3), adjustments[0].Delta)
+	require.Equal(t, requests.StockDamaged, adjustments[0].Reason)
+	require.Equal(t, int64(10), adjustments[1].Delta)
+
+	adjustments, err = service.ListStockAdjustments(ctx, requests.ListStockAdjustmentsRequest{ProductID: product.ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, adjustments, 1)
+}
+
+func testAdjustStockInvalid(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "stocked")
+
+	invalid := []requests.AdjustStockRequest{
+		{ProductID: product.ID, Delta: 0, Reason: requests.StockReceived},
+		{ProductID: product.ID, Delta: 1, Reason: "stolen"},
+		{ProductID: product.ID, Delta: 1, Reason: requests.StockReceived, Note: strings.Repeat("a", 256)},
+	}
+	for _, req := range invalid {
+		_, err := service.AdjustStock(ctx, req)
+		requireCode(t, services.ErrValidation, err)
+	}
+
+	_, err := service.AdjustStock(ctx, requests.AdjustStockRequest{ProductID: missingID, Delta: 1, Reason: requests.StockReceived})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.GetStock(ctx, requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.ListStockAdjustments(ctx, requests.ListStockAdjustmentsRequest{ProductID: product.ID, Limit: 101})
+	requireCode(t, services.ErrValidation, err)
+}
+
+func testReservations(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	buyer := userContext(user)
+	product := createProduct(t, service, user.ID, "reserved")
+	adjustStock(t, service, product.ID, 5, requests.StockReceived)
+
+	first := createReservation(t, service, user, product.ID, 3)
+	require.Equal(t, responses.ReservationActive, first.Status)
+	require.True(t, first.ExpiresAt.After(time.Now()))
+	requireStock(t, service, product.ID, 5, 3)
+
+	_, err := service.CreateReservation(buyer, requests.CreateReservationRequest{ProductID: product.ID, Quantity: 3})
+	requireCode(t, services.ErrConflict, err)
+
+	// reserved units cannot be removed from the stock
+	_, err = service.AdjustStock(ctx, requests.AdjustStockRequest{ProductID: product.ID, Delta: -3, Reason: requests.StockLost})
+	requireCode(t, services.ErrConflict, err)
+
+	released, err := service.ReleaseReservation(buyer, requests.BindUriID{ID: first.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.ReservationReleased, released.Status)
+	requireStock(t, service, product.ID, 5, 0)
+
+	_, err = service.ReleaseReservation(buyer, requests.BindUriID{ID: first.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	second := createReservation(t, service, user, product.ID, 2)
+	committed, err := service.CommitReservation(buyer, requests.BindUriID{ID: second.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.ReservationCommitted, committed.Status)
+	requireStock(t, service, product.ID, 3, 0)
+
+	_, err = service.CommitReservation(buyer, requests.BindUriID{ID: second.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	got, err := service.GetReservation(buyer, requests.BindUriID{ID: second.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.ReservationCommitted, got.Status)
+	require.Equal(t, int64(2), got.Quantity)
+
+	adjustments, err := service.ListStockAdjustments(ctx, requests.ListStockAdjustmentsRequest{ProductID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(-2), adjustments[0].Delta)
+	require.Equal(t, requests.StockSold, adjustments[0].Reason)
+
+	_, err = service.GetReservation(buyer, requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.ReleaseReservation(buyer, requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.CreateReservation(buyer, requests.CreateReservationRequest{ProductID: missingID, Quantity: 1})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.CreateReservation(buyer, requests.CreateReservationRequest{ProductID: product.ID, Quantity: 0})
+	requireCode(t, services.ErrValidation, err)
+
+	ttl := int64(2 * 24 * 60 * 60)
+	_, err = service.CreateReservation(buyer, requests.CreateReservationRequest{ProductID: product.ID, Quantity: 1, TTLSeconds: &ttl})
+	requireCode(t, services.ErrValidation, err)
+}
+
+func testReleaseExpiredReservations(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	buyer := userContext(user)
+	product := createProduct(t, service, user.ID, "expiring")
+	adjustStock(t, service, product.ID, 4, requests.StockReceived)
+
+	ttl := int64(60)
+	req := requests.CreateReservationRequest{ProductID: product.ID, Quantity: 1, TTLSeconds: &ttl}
+	short, err := service.CreateReservation(buyer, req)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Minute), short.ExpiresAt, 5*time.Second)
+
+	long := createReservation(t, service, user, product.ID, 2)
+	requireStock(t, service, product.ID, 4, 3)
+
+	// nothing expired yet
+	_, err = service.ReleaseExpiredReservations(ctx, requests.ReleaseExpiredReservationsRequest{ExpiredBefore: time.Now()})
+	require.NoError(t, err)
+	requireStock(t, service, product.ID, 4, 3)
+
+	// other tests may leave reservations expiring in between
+	released, err := service.ReleaseExpiredReservations(ctx, requests.ReleaseExpiredReservationsRequest{
+		ExpiredBefore: time.Now().Add(2 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, released, int64(1))
+	requireStock(t, service, product.ID, 4, 2)
+
+	got, err := service.GetReservation(buyer, requests.BindUriID{ID: short.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.ReservationExpired, got.Status)
+
+	_, err = service.CommitReservation(buyer, requests.BindUriID{ID: short.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	got, err = service.GetReservation(buyer, requests.BindUriID{ID: long.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.ReservationActive, got.Status)
+}
+
+func testReservationsNeverOversell(t *testing.T, service services.Service) {
+	user := createUser(t, service)
+	ctx := userContext(user)
+	product := createProduct(t, service, user.ID, "popular")
+	adjustStock(t, service, product.ID, 5, requests.StockReceived)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 12)
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.CreateReservation(ctx, requests.CreateReservationRequest{ProductID: product.ID, Quantity: 1})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// racing reservations wait for each other, the ones past the stock are
+	// refused because it ran out and not because they raced
+	var reserved int64
+	for err := range errs {
+		if err == nil {
+			reserved++
+			continue
+		}
+		requireCode(t, services.ErrConflict, err)
+	}
+	require.Equal(t, int64(5), reserved)
+	requireStock(t, service, product.ID, 5, 5)
+}
+
+// testConcurrentWriters makes sure writers racing each other wait for their
+// turn instead of failing.
+func testConcurrentWriters(t *testing.T, service services.Service) {
+	const writers = 50
+
+	user := createUser(t, service)
+	ctx := userContext(user)
+	product := createProduct(t, service, user.ID, "busy")
+	adjustStock(t, service, product.ID, writers, requests.StockReceived)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.CreateReservation(ctx, requests.CreateReservationRequest{ProductID: product.ID, Quantity: 1})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	requireStock(t, service, product.ID, writers, writers)
+}
+
+func testCreateOrder(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	first := createProduct(t, service, user.ID, "first")
+	second := createProduct(t, service, user.ID, "second")
+
+	order := createOrder(t, service, user.ID, first.ID, 2, second.ID, 3)
+	require.Equal(t, user.ID, order.UserID)
+	require.Equal(t, requests.OrderPending, order.Status)
+	require.Equal(t, responses.Money{Amount: 500, Currency: services.DefaultCurrency}, order.Total)
+	require.Len(t, order.Items, 2)
+	require.Equal(t, first.ID, *order.Items[0].ProductID)
+	require.Equal(t, "first", order.Items[0].ProductName)
+	require.Equal(t, responses.Money{Amount: 100, Currency: services.DefaultCurrency}, order.Items[0].UnitPrice)
+	require.Equal(t, int64(2), order.Items[0].Quantity)
+	require.Equal(t, responses.Money{Amount: 200, Currency: services.DefaultCurrency}, order.Items[0].Total)
+	require.Equal(t, responses.Money{Amount: 300, Currency: services.DefaultCurrency}, order.Items[1].Total)
+
+	// the order keeps the price and name the product had when it was placed
+	_, err := service.UpdateProduct(ctx, requests.UpdateProductRequest{ID: first.ID, Name: "renamed", Price: 999})
+	require.NoError(t, err)
+
+	got, err := service.GetOrder(ctx, requests.BindUriID{ID: order.ID})
+	require.NoError(t, err)
+	require.Equal(t, order.Total, got.Total)
+	require.Equal(t, "first", got.Items[0].ProductName)
+	require.Equal(t, int64(100), got.Items[0].UnitPrice.Amount)
+
+	_, err = service.GetOrder(ctx, requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testCreateOrderConvertsPrices(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+
+	_, err := service.SetExchangeRate(ctx, requests.SetExchangeRateRequest{Base: "SEK", Quote: "DKK", Rate: "0.5"})
+	require.NoError(t, err)
+
+	sek, err := service.CreateProduct(ctx, requests.CreateProductRequest{UserID: user.ID, Name: "sek", Price: 1000, Currency: "SEK"})
+	require.NoError(t, err)
+	dkk, err := service.CreateProduct(ctx, requests.CreateProductRequest{UserID: user.ID, Name: "dkk", Price: 300, Currency: "DKK"})
+	require.NoError(t, err)
+
+	// the first product picks the currency of the order
+	order := createOrder(t, service, user.ID, sek.ID, 1, dkk.ID, 1)
+	require.Equal(t, responses.Money{Amount: 1600, Currency: "SEK"}, order.Total)
+	require.Equal(t, responses.Money{Amount: 600, Currency: "SEK"}, order.Items[1].UnitPrice)
+
+	order, err = service.CreateOrder(ctx, requests.CreateOrderRequest{
+		UserID:   user.ID,
+		Currency: "DKK",
+		Items:    []requests.OrderItemRequest{{ProductID: sek.ID, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, responses.Money{Amount: 1000, Currency: "DKK"}, order.Total)
+}
+
+func testCreateOrderInvalid(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+	trashed := createProduct(t, service, user.ID, "trashed")
+	_, err := service.DeleteProduct(ctx, requests.DeleteProductRequest{ID: trashed.ID})
+	require.NoError(t, err)
+
+	item := func(id, quantity int64) requests.OrderItemRequest {
+		return requests.OrderItemRequest{ProductID: id, Quantity: quantity}
+	}
+
+	testCases := []struct {
+		name string
+		req  requests.CreateOrderRequest
+		code services.ErrorCode
+	}{
+		{"no items", requests.CreateOrderRequest{UserID: user.ID}, services.ErrValidation},
+		{"zero quantity", requests.CreateOrderRequest{UserID: user.ID, Items: []requests.OrderItemRequest{item(product.ID, 0)}}, services.ErrValidation},
+		{"product twice", requests.CreateOrderRequest{UserID: user.ID, Items: []requests.OrderItemRequest{item(product.ID, 1), item(product.ID, 2)}}, services.ErrValidation},
+		{"unknown currency", requests.CreateOrderRequest{UserID: user.ID, Currency: "XXX", Items: []requests.OrderItemRequest{item(product.ID, 1)}}, services.ErrValidation},
+		{"no exchange rate", requests.CreateOrderRequest{UserID: user.ID, Currency: "ISK", Items: []requests.OrderItemRequest{item(product.ID, 1)}}, services.ErrValidation},
+		{"total overflows", requests.CreateOrderRequest{UserID: user.ID, Items: []requests.OrderItemRequest{item(product.ID, math.MaxInt64/10)}}, services.ErrValidation},
+		{"missing user", requests.CreateOrderRequest{UserID: missingID, Items: []requests.OrderItemRequest{item(product.ID, 1)}}, services.ErrForeignKeyViolation},
+		{"missing product", requests.CreateOrderRequest{UserID: user.ID, Items: []requests.OrderItemRequest{item(product.ID, 1), item(missingID, 1)}}, services.ErrForeignKeyViolation},
+		{"trashed product", requests.CreateOrderRequest{UserID: user.ID, Items: []requests.OrderItemRequest{item(trashed.ID, 1)}}, services.ErrForeignKeyViolation},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := service.CreateOrder(ctx, testCase.req)
+			requireCode(t, testCase.code, err)
+		})
+	}
+
+	// failed orders leave nothing behind
+	orders, err := service.GetUserOrders(ctx, requests.GetUserOrdersRequest{UserID: user.ID})
+	require.NoError(t, err)
+	require.Empty(t, orders.Edges)
+}
+
+func testOrderStatusTransitions(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	setStatus := func(id int64, status requests.OrderStatus) (*responses.Order, error) {
+		return service.UpdateOrderStatus(ctx, requests.UpdateOrderStatusRequest{ID: id, Status: status})
+	}
+
+	order := createOrder(t, service, user.ID, product.ID, 1)
+	_, err := setStatus(order.ID, requests.OrderShipped)
+	requireCode(t, services.ErrConflict, err)
+	_, err = setStatus(order.ID, requests.OrderPending)
+	requireCode(t, services.ErrConflict, err)
+	_, err = setStatus(order.ID, "lost")
+	requireCode(t, services.ErrValidation, err)
+
+	for _, status := range []requests.OrderStatus{requests.OrderPaid, requests.OrderShipped} {
+		updated, err := setStatus(order.ID, status)
+		require.NoError(t, err)
+		require.Equal(t, status, updated.Status)
+		require.Len(t, updated.Items, 1)
+		require.False(t, updated.UpdatedAt.Before(order.UpdatedAt))
+	}
+
+	// shipped orders are final
+	_, err = setStatus(order.ID, requests.OrderCancelled)
+	requireCode(t, services.ErrConflict, err)
+
+	cancelled := createOrder(t, service, user.ID, product.ID, 1)
+	updated, err := setStatus(cancelled.ID, requests.OrderCancelled)
+	require.NoError(t, err)
+	require.Equal(t, requests.OrderCancelled, updated.Status)
+
+	_, err = setStatus(cancelled.ID, requests.OrderPaid)
+	requireCode(t, services.ErrConflict, err)
+
+	got, err := service.GetOrder(ctx, requests.BindUriID{ID: cancelled.ID})
+	require.NoError(t, err)
+	require.Equal(t, requests.OrderCancelled, got.Status)
+
+	_, err = setStatus(missingID, requests.OrderPaid)
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testUserOrders(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	other := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+
+	var orders []*responses.Order
+	for i := int64(1); i <= 3; i++ {
+		orders = append(orders, createOrder(t, service, user.ID, product.ID, i))
+	}
+	createOrder(t, service, other.ID, product.ID, 1)
+
+	// newest first
+	first := 2
+	page, err := service.GetUserOrders(ctx, requests.GetUserOrdersRequest{UserID: user.ID, First: &first})
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 2)
+	require.Equal(t, orders[2].ID, page.Edges[0].Node.ID)
+	require.Equal(t, orders[1].ID, page.Edges[1].Node.ID)
+	require.Equal(t, int64(2), page.Edges[1].Node.Items[0].Quantity)
+	require.True(t, page.PageInfo.HasNextPage)
+	require.False(t, page.PageInfo.HasPreviousPage)
+
+	page, err = service.GetUserOrders(ctx, requests.GetUserOrdersRequest{UserID: user.ID, First: &first, After: &page.PageInfo.EndCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Edges, 1)
+	require.Equal(t, orders[0].ID, page.Edges[0].Node.ID)
+	require.Len(t, page.Edges[0].Node.Items, 1)
+	require.False(t, page.PageInfo.HasNextPage)
+	require.True(t, page.PageInfo.HasPreviousPage)
+
+	_, err = service.GetUserOrders(ctx, requests.GetUserOrdersRequest{UserID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+
+	invalid := "invalid"
+	_, err = service.GetUserOrders(ctx, requests.GetUserOrdersRequest{UserID: user.ID, After: &invalid})
+	requireCode(t, services.ErrBadRequest, err)
+
+	tooMany := services.DefaultMaxPageSize + 1
+	_, err = service.GetUserOrders(ctx, requests.GetUserOrdersRequest{UserID: user.ID, First: &tooMany})
+	requireCode(t, services.ErrValidation, err)
+}
+
+func testDeleteUserWithOrders(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "product")
+	createOrder(t, service, user.ID, product.ID, 1)
+
+	for _, policy := range []requests.UserDeletePolicy{requests.UserDeleteRestrict, requests.UserDeleteCascade} {
+		_, err := service.DeleteUser(ctx, requests.DeleteUserRequest{ID: user.ID, Policy: policy})
+		requireCode(t, services.ErrConflict, err)
+	}
+
+	_, err := service.GetProduct(ctx, requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+}
+
+func testOrderKeepsPurgedProducts(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "purged")
+	order := createOrder(t, service, user.ID, product.ID, 1)
+
+	_, err := service.DeleteProduct(ctx, requests.DeleteProductRequest{ID: product.ID, Permanent: true})
+	require.NoError(t, err)
+
+	got, err := service.GetOrder(ctx, requests.BindUriID{ID: order.ID})
+	require.NoError(t, err)
+	require.Nil(t, got.Items[0].ProductID)
+	require.Equal(t, "purged", got.Items[0].ProductName)
+	require.Equal(t, order.Total, got.Total)
+}
+
+func testOrderPayment(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "paid")
+	order := createOrder(t, service, user.ID, product.ID, 2)
+
+	payment, err := service.StartPayment(ctx, requests.StartPaymentRequest{OrderID: &order.ID})
+	require.NoError(t, err)
+	require.NotZero(t, payment.ID)
+	require.Equal(t, order.ID, *payment.OrderID)
+	require.Nil(t, payment.ProductID)
+	require.Equal(t, "fake", payment.Provider)
+	require.Equal(t, responses.PaymentPending, payment.Status)
+	require.Equal(t, order.Total, payment.Amount)
+	require.NotEmpty(t, payment.IntentID)
+	require.NotEmpty(t, payment.ClientSecret)
+
+	// the client secret is only handed out once
+	got, err := service.GetPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	require.Empty(t, got.ClientSecret)
+	require.Equal(t, payment.IntentID, got.IntentID)
+
+	// an order has one live payment at a time
+	_, err = service.StartPayment(ctx, requests.StartPaymentRequest{OrderID: &order.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	confirmed, err := service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.PaymentSucceeded, confirmed.Status)
+
+	paid, err := service.GetOrder(ctx, requests.BindUriID{ID: order.ID})
+	require.NoError(t, err)
+	require.Equal(t, requests.OrderPaid, paid.Status)
+
+	// confirming twice is a no-op
+	again, err := service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.PaymentSucceeded, again.Status)
+
+	_, err = service.StartPayment(ctx, requests.StartPaymentRequest{OrderID: &order.ID})
+	requireCode(t, services.ErrConflict, err)
+}
+
+func testPaymentOfCancelledOrder(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "cancelled")
+	order := createOrder(t, service, user.ID, product.ID, 1)
+
+	payment, err := service.StartPayment(ctx, requests.StartPaymentRequest{OrderID: &order.ID})
+	require.NoError(t, err)
+
+	_, err = service.UpdateOrderStatus(ctx, requests.UpdateOrderStatusRequest{ID: order.ID, Status: requests.OrderCancelled})
+	require.NoError(t, err)
+
+	_, err = service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	got, err := service.GetPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.PaymentPending, got.Status)
+}
+
+func testDeclinedPayment(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	buyer := userContext(user)
+	product, err := service.CreateProduct(ctx, requests.CreateProductRequest{
+		UserID: user.ID,
+		Name:   "declined",
+		Price:  payments.FakeDeclinedAmount,
+	})
+	require.NoError(t, err)
+
+	payment, err := service.StartPayment(buyer, requests.StartPaymentRequest{ProductID: &product.ID})
+	require.NoError(t, err)
+	require.Equal(t, product.ID, *payment.ProductID)
+	require.Equal(t, product.Price, payment.Amount)
+
+	failed, err := service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.PaymentFailed, failed.Status)
+
+	_, err = service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	_, err = service.RefundPayment(ctx, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrConflict, err)
+}
+
+func testRefundPayment(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	buyer := userContext(user)
+	product := createProduct(t, service, user.ID, "refunded")
+
+	payment, err := service.StartPayment(buyer, requests.StartPaymentRequest{ProductID: &product.ID})
+	require.NoError(t, err)
+
+	// only succeeded payments can be refunded
+	_, err = service.RefundPayment(ctx, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrConflict, err)
+
+	_, err = service.ConfirmPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+
+	refunded, err := service.RefundPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.PaymentRefunded, refunded.Status)
+
+	_, err = service.RefundPayment(ctx, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrConflict, err)
+}
+
+func testPaymentEvents(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "webhook")
+	order := createOrder(t, service, user.ID, product.ID, 1)
+
+	payment, err := service.StartPayment(ctx, requests.StartPaymentRequest{OrderID: &order.ID})
+	require.NoError(t, err)
+
+	event := func(status payments.Status) payments.Event {
+		return payments.Event{ID: "evt_" + string(status), IntentID: payment.IntentID, Status: status}
+	}
+
+	// deliveries may repeat and arrive out of order, the payment only moves
+	// forward
+	steps := []struct {
+		status   payments.Status
+		expected responses.PaymentStatus
+	}{
+		{payments.StatusSucceeded, responses.PaymentSucceeded},
+		{payments.StatusSucceeded, responses.PaymentSucceeded},
+		{payments.StatusPending, responses.PaymentSucceeded},
+		{payments.StatusFailed, responses.PaymentSucceeded},
+		{payments.StatusRefunded, responses.PaymentRefunded},
+		{payments.StatusSucceeded, responses.PaymentRefunded},
+	}
+
+	for i, step := range steps {
+		got, err := service.HandlePaymentEvent(ctx, event(step.status))
+		require.NoError(t, err, i)
+		require.Equal(t, payment.ID, got.ID, i)
+		require.Equal(t, step.expected, got.Status, i)
+	}
+
+	paid, err := service.GetOrder(ctx, requests.BindUriID{ID: order.ID})
+	require.NoError(t, err)
+	require.Equal(t, requests.OrderPaid, paid.Status)
+
+	_, err = service.HandlePaymentEvent(ctx, payments.Event{IntentID: "pi_unknown", Status: payments.StatusSucceeded})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.HandlePaymentEvent(ctx, payments.Event{IntentID: payment.IntentID, Status: "disputed"})
+	requireCode(t, services.ErrValidation, err)
+}
+
+func testStartPaymentInvalid(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "invalid")
+	cancelled := createOrder(t, service, user.ID, product.ID, 1)
+	_, err := service.UpdateOrderStatus(ctx, requests.UpdateOrderStatusRequest{ID: cancelled.ID, Status: requests.OrderCancelled})
+	require.NoError(t, err)
+
+	missing := missingID
+	testCases := []struct {
+		name string
+		req  requests.StartPaymentRequest
+		code services.ErrorCode
+	}{
+		{"no target", requests.StartPaymentRequest{}, services.ErrValidation},
+		{"two targets", requests.StartPaymentRequest{OrderID: &cancelled.ID, ProductID: &product.ID}, services.ErrValidation},
+		{"missing order", requests.StartPaymentRequest{OrderID: &missing}, services.ErrForeignKeyViolation},
+		{"missing product", requests.StartPaymentRequest{ProductID: &missing}, services.ErrForeignKeyViolation},
+		{"cancelled order", requests.StartPaymentRequest{OrderID: &cancelled.ID}, services.ErrConflict},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := service.StartPayment(ctx, testCase.req)
+			requireCode(t, testCase.code, err)
+		})
+	}
+
+	_, err = service.GetPayment(ctx, requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testRegisterLogin(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	session := register(t, service, "royyan")
+
+	user, err := service.Authenticate(ctx, session.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, session.User.ID, user.ID)
+
+	// logins match emails the way users are looked up
+	login, err := service.Login(ctx, requests.LoginRequest{Email: " " + strings.ToUpper(session.User.Email), Password: "correct horse"})
+	require.NoError(t, err)
+	require.Equal(t, session.User.ID, login.User.ID)
+	require.NotEqual(t, session.RefreshToken, login.RefreshToken)
+
+	user, err = service.Authenticate(ctx, login.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, session.User.ID, user.ID)
+
+	_, err = service.Authenticate(ctx, login.AccessToken+"x")
+	requireCode(t, services.ErrUnauthorized, err)
+
+	_, err = service.Authenticate(ctx, "not a token")
+	requireCode(t, services.ErrUnauthorized, err)
+}
+
+func testLoginInvalidCredentials(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	session := register(t, service, "royyan")
+
+	// users created without a password cannot log in
+	user := createUser(t, service)
+
+	testCases := []struct {
+		name string
+		req  requests.LoginRequest
+	}{
+		{"wrong password", requests.LoginRequest{Email: session.User.Email, Password: "wrong horse"}},
+		{"unknown email", requests.LoginRequest{Email: uniqueEmail("unknown"), Password: "correct horse"}},
+		{"no password", requests.LoginRequest{Email: user.Email, Password: "correct horse"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := service.Login(ctx, testCase.req)
+			requireCode(t, services.ErrUnauthorized, err)
+		})
+	}
+}
+
+func testRegisterInvalid(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	session := register(t, service, "royyan")
+
+	testCases := []struct {
+		name string
+		req  requests.RegisterRequest
+		code services.ErrorCode
+	}{
+		{"short password", requests.RegisterRequest{Name: "royyan", Email: uniqueEmail("short"), Password: "short"}, services.ErrValidation},
+		{"long password", requests.RegisterRequest{Name: "royyan", Email: uniqueEmail("long"), Password: strings.Repeat("x", 73)}, services.ErrValidation},
+		{"invalid email", requests.RegisterRequest{Name: "royyan", Email: "royyan", Password: "correct horse"}, services.ErrValidation},
+		{"email taken", requests.RegisterRequest{Name: "royyan", Email: session.User.Email, Password: "correct horse"}, services.ErrConflict},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := service.Register(ctx, testCase.req)
+			requireCode(t, testCase.code, err)
+		})
+	}
+}
+
+func testRefreshSessionRotates(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	session := register(t, service, "royyan")
+	other, err := service.Login(ctx, requests.LoginRequest{Email: session.User.Email, Password: "correct horse"})
+	require.NoError(t, err)
+
+	refreshed, err := service.RefreshSession(ctx, requests.RefreshTokenRequest{RefreshToken: session.RefreshToken})
+	require.NoError(t, err)
+	require.Equal(t, session.User.ID, refreshed.User.ID)
+	require.NotEqual(t, session.RefreshToken, refreshed.RefreshToken)
+
+	user, err := service.Authenticate(ctx, refreshed.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, session.User.ID, user.ID)
+
+	// reusing a rotated token revokes every session of the user
+	_, err = service.RefreshSession(ctx, requests.RefreshTokenRequest{RefreshToken: session.RefreshToken})
+	requireCode(t, services.ErrUnauthorized, err)
+
+	_, err = service.RefreshSession(ctx, requests.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken})
+	requireCode(t, services.ErrUnauthorized, err)
+
+	_, err = service.RefreshSession(ctx, requests.RefreshTokenRequest{RefreshToken: other.RefreshToken})
+	requireCode(t, services.ErrUnauthorized, err)
+
+	_, err = service.RefreshSession(ctx, requests.RefreshTokenRequest{RefreshToken: "unknown"})
+	requireCode(t, services.ErrUnauthorized, err)
+}
+
+func testLogout(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	session := register(t, service, "royyan")
+	req := requests.RefreshTokenRequest{RefreshToken: session.RefreshToken}
+
+	require.NoError(t, service.Logout(ctx, req))
+	require.NoError(t, service.Logout(ctx, req))
+	require.NoError(t, service.Logout(ctx, requests.RefreshTokenRequest{RefreshToken: "unknown"}))
+
+	_, err := service.RefreshSession(ctx, req)
+	requireCode(t, services.ErrUnauthorized, err)
+
+	// the user can log in again
+	_, err = service.Login(ctx, requests.LoginRequest{Email: session.User.Email, Password: "correct horse"})
+	require.NoError(t, err)
+}
+
+func testProductOwnership(t *testing.T, service services.Service) {
+	owner := createUser(t, service)
+	other := createUser(t, service)
+	ctx := userContext(owner)
+
+	// users own the products they create whatever the request says
+	product, err := service.CreateProduct(ctx, requests.CreateProductRequest{UserID: other.ID, Name: "owned", Price: 100})
+	require.NoError(t, err)
+	require.Equal(t, owner.ID, product.UserID)
+
+	name := "patched"
+	update := requests.UpdateProductRequest{ID: product.ID, Name: "updated", Price: 200}
+	patch := requests.PatchProductRequest{ID: product.ID, Name: &name}
+	remove := requests.DeleteProductRequest{ID: product.ID}
+
+	otherCtx := userContext(other)
+	_, err = service.UpdateProduct(otherCtx, update)
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.PatchProduct(otherCtx, patch)
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.DeleteProduct(otherCtx, remove)
+	requireCode(t, services.ErrForbidden, err)
+
+	got, err := service.GetProduct(ctx, requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+	require.Equal(t, "owned", got.Name)
+	require.Equal(t, int64(1), got.Version)
+
+	_, err = service.UpdateProduct(ctx, update)
+	require.NoError(t, err)
+	_, err = service.PatchProduct(ctx, patch)
+	require.NoError(t, err)
+	_, err = service.DeleteProduct(ctx, remove)
+	require.NoError(t, err)
+
+	// trashed products stay owned
+	_, err = service.RestoreProduct(otherCtx, requests.BindUriID{ID: product.ID})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.DeleteProduct(otherCtx, requests.DeleteProductRequest{ID: product.ID, Permanent: true})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.RestoreProduct(ctx, requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+
+	// admins act on every product and create products for other users
+	_, err = service.UpdateProduct(adminContext(), requests.UpdateProductRequest{ID: product.ID, Name: "by admin", Price: 300})
+	require.NoError(t, err)
+	created, err := service.CreateProduct(adminContext(), requests.CreateProductRequest{UserID: other.ID, Name: "for other", Price: 100})
+	require.NoError(t, err)
+	require.Equal(t, other.ID, created.UserID)
+
+	_, err = service.DeleteProduct(otherCtx, requests.DeleteProductRequest{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testBulkProductOwnership(t *testing.T, service services.Service) {
+	owner := createUser(t, service)
+	other := createUser(t, service)
+	ctx := userContext(owner)
+	mine := createProduct(t, service, owner.ID, "mine")
+	theirs := createProduct(t, service, other.ID, "theirs")
+
+	created, err := service.BulkCreateProducts(ctx, requests.BulkCreateProductsRequest{Products: []requests.CreateProductRequest{
+		{UserID: other.ID, Name: "bulk", Price: 100},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, 1, created.Succeeded)
+	require.Equal(t, owner.ID, created.Results[0].Product.UserID)
+
+	name := "patched"
+	patched, err := service.BulkPatchProducts(ctx, requests.BulkPatchProductsRequest{Products: []requests.PatchProductRequest{
+		{ID: mine.ID, Name: &name},
+		{ID: theirs.ID, Name: &name},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, 1, patched.Succeeded)
+	require.Equal(t, string(services.ErrForbidden), patched.Results[1].Error.Code)
+
+	// atomic requests refuse every item once one is forbidden
+	deleted, err := service.BulkDeleteProducts(ctx, requests.BulkDeleteProductsRequest{IDs: []int64{mine.ID, theirs.ID}, Atomic: true})
+	require.NoError(t, err)
+	require.True(t, deleted.RolledBack)
+	require.Equal(t, string(services.ErrForbidden), deleted.Results[1].Error.Code)
+
+	deleted, err = service.BulkDeleteProducts(ctx, requests.BulkDeleteProductsRequest{IDs: []int64{mine.ID, theirs.ID}})
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted.Succeeded)
+	require.NotNil(t, deleted.Results[0].Deleted)
+	require.Equal(t, string(services.ErrForbidden), deleted.Results[1].Error.Code)
+
+	_, err = service.GetProduct(ctx, requests.BindUriID{ID: theirs.ID})
+	require.NoError(t, err)
+}
+
+func testOrderOwnership(t *testing.T, service services.Service) {
+	owner := createUser(t, service)
+	other := createUser(t, service)
+	ctx, otherCtx := userContext(owner), userContext(other)
+	product := createProduct(t, service, other.ID, "ordered")
+	adjustStock(t, service, product.ID, 2, requests.StockReceived)
+
+	// users order for themselves, only staff may order for someone else
+	_, err := service.CreateOrder(ctx, requests.CreateOrderRequest{
+		UserID: other.ID,
+		Items:  []requests.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+	})
+	requireCode(t, services.ErrForbidden, err)
+
+	order, err := service.CreateOrder(ctx, requests.CreateOrderRequest{
+		Items: []requests.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, owner.ID, order.UserID)
+
+	_, err = service.GetOrder(ctx, requests.BindUriID{ID: order.ID})
+	require.NoError(t, err)
+	_, err = service.GetOrder(otherCtx, requests.BindUriID{ID: order.ID})
+	requireCode(t, services.ErrForbidden, err)
+
+	orders, err := service.GetUserOrders(ctx, requests.GetUserOrdersRequest{UserID: owner.ID})
+	require.NoError(t, err)
+	require.Len(t, orders.Edges, 1)
+	_, err = service.GetUserOrders(otherCtx, requests.GetUserOrdersRequest{UserID: owner.ID})
+	requireCode(t, services.ErrForbidden, err)
+
+	_, err = service.StartPayment(otherCtx, requests.StartPaymentRequest{OrderID: &order.ID})
+	requireCode(t, services.ErrForbidden, err)
+	payment, err := service.StartPayment(ctx, requests.StartPaymentRequest{OrderID: &order.ID})
+	require.NoError(t, err)
+	require.Equal(t, owner.ID, *payment.UserID)
+
+	_, err = service.GetPayment(otherCtx, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.ConfirmPayment(otherCtx, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrForbidden, err)
+	got, err := service.GetPayment(ctx, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	require.Equal(t, responses.PaymentPending, got.Status)
+
+	reservation := createReservation(t, service, owner, product.ID, 1)
+	require.Equal(t, owner.ID, *reservation.UserID)
+
+	_, err = service.GetReservation(otherCtx, requests.BindUriID{ID: reservation.ID})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.ReleaseReservation(otherCtx, requests.BindUriID{ID: reservation.ID})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.CommitReservation(otherCtx, requests.BindUriID{ID: reservation.ID})
+	requireCode(t, services.ErrForbidden, err)
+	requireStock(t, service, product.ID, 2, 1)
+
+	_, err = service.CommitReservation(ctx, requests.BindUriID{ID: reservation.ID})
+	require.NoError(t, err)
+
+	// staff allowed to manage orders see the orders of every user
+	_, err = service.GetOrder(adminContext(), requests.BindUriID{ID: order.ID})
+	require.NoError(t, err)
+	_, err = service.GetUserOrders(adminContext(), requests.GetUserOrdersRequest{UserID: owner.ID})
+	require.NoError(t, err)
+
+	anonymous := context.Background()
+	_, err = service.CreateOrder(anonymous, requests.CreateOrderRequest{
+		UserID: owner.ID,
+		Items:  []requests.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+	})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.GetOrder(anonymous, requests.BindUriID{ID: order.ID})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.CreateReservation(anonymous, requests.CreateReservationRequest{ProductID: product.ID, Quantity: 1})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.GetPayment(anonymous, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrUnauthorized, err)
+}
+
+func testAnonymousProductChanges(t *testing.T, service services.Service) {
+	ctx := context.Background()
+	user := createUser(t, service)
+	product := createProduct(t, service, user.ID, "anonymous")
+	name := "anonymous"
+
+	_, err := service.CreateProduct(ctx, requests.CreateProductRequest{UserID: user.ID, Name: "anonymous", Price: 100})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.UpdateProduct(ctx, requests.UpdateProductRequest{ID: product.ID, Name: "anonymous", Price: 100})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.PatchProduct(ctx, requests.PatchProductRequest{ID: product.ID, Name: &name})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.DeleteProduct(ctx, requests.DeleteProductRequest{ID: product.ID})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.BulkCreateProducts(ctx, requests.BulkCreateProductsRequest{Products: []requests.CreateProductRequest{{UserID: user.ID, Name: "anonymous", Price: 100}}})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.BulkPatchProducts(ctx, requests.BulkPatchProductsRequest{Products: []requests.PatchProductRequest{{ID: product.ID, Name: &name}}})
+	requireCode(t, services.ErrUnauthorized, err)
+	_, err = service.BulkDeleteProducts(ctx, requests.BulkDeleteProductsRequest{IDs: []int64{product.ID}})
+	requireCode(t, services.ErrUnauthorized, err)
+
+	// reads stay public
+	_, err = service.GetProduct(ctx, requests.BindUriID{ID: product.ID})
+	require.NoError(t, err)
+}
+
+func testListRoles(t *testing.T, service services.Service) {
+	roles, err := service.ListRoles(adminContext())
+	require.NoError(t, err)
+
+	permissions := make(map[requests.Role][]string)
+	for _, role := range roles {
+		permissions[role.Name] = role.Permissions
+	}
+	require.Len(t, permissions, 3)
+
+	// viewers are support staff, they read everything and change nothing
+	require.NotEmpty(t, permissions[requests.RoleViewer])
+	for _, permission := range permissions[requests.RoleViewer] {
+		require.True(t, strings.HasSuffix(permission, ":read"), permission)
+	}
+
+	require.Contains(t, permissions[requests.RoleEditor], auth.PermissionManageProducts)
+	require.NotContains(t, permissions[requests.RoleEditor], auth.PermissionManageRoles)
+	require.Contains(t, permissions[requests.RoleAdmin], auth.PermissionManageRoles)
+
+	// every role includes the permissions of the roles below it
+	require.Subset(t, permissions[requests.RoleEditor], permissions[requests.RoleViewer])
+	require.Subset(t, permissions[requests.RoleAdmin], permissions[requests.RoleEditor])
+}
+
+func testAssignRevokeRoles(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	session := register(t, service, "royyan")
+	userID := session.User.ID
+
+	roles, err := service.GetUserRoles(ctx, requests.BindUriID{ID: userID})
+	require.NoError(t, err)
+	require.Empty(t, roles)
+
+	roles, err = service.AssignRole(ctx, requests.UserRoleRequest{UserID: userID, Role: requests.RoleEditor})
+	require.NoError(t, err)
+	require.Equal(t, []requests.Role{requests.RoleEditor}, roles)
+
+	// assigning twice changes nothing
+	roles, err = service.AssignRole(ctx, requests.UserRoleRequest{UserID: userID, Role: requests.RoleEditor})
+	require.NoError(t, err)
+	require.Equal(t, []requests.Role{requests.RoleEditor}, roles)
+
+	roles, err = service.AssignRole(ctx, requests.UserRoleRequest{UserID: userID, Role: requests.RoleViewer})
+	require.NoError(t, err)
+	require.Equal(t, []requests.Role{requests.RoleViewer, requests.RoleEditor}, roles)
+
+	user, err := service.Authenticate(ctx, session.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, roles, user.Roles)
+	require.True(t, user.HasRole(requests.RoleViewer))
+	require.True(t, user.HasRole(requests.RoleEditor))
+	require.False(t, user.HasRole(requests.RoleAdmin))
+	require.True(t, user.HasPermission(auth.PermissionManageProducts))
+	require.False(t, user.HasPermission(auth.PermissionManageUsers))
+
+	login, err := service.Login(ctx, requests.LoginRequest{Email: session.User.Email, Password: "correct horse"})
+	require.NoError(t, err)
+	require.Equal(t, user.Roles, login.User.Roles)
+	require.Equal(t, user.Permissions, login.User.Permissions)
+
+	roles, err = service.RevokeRole(ctx, requests.UserRoleRequest{UserID: userID, Role: requests.RoleEditor})
+	require.NoError(t, err)
+	require.Equal(t, []requests.Role{requests.RoleViewer}, roles)
+
+	// revoking a role the user does not have changes nothing
+	roles, err = service.RevokeRole(ctx, requests.UserRoleRequest{UserID: userID, Role: requests.RoleEditor})
+	require.NoError(t, err)
+	require.Equal(t, []requests.Role{requests.RoleViewer}, roles)
+
+	user, err = service.Authenticate(ctx, session.AccessToken)
+	require.NoError(t, err)
+	require.True(t, user.HasPermission(auth.PermissionReadProducts))
+	require.False(t, user.HasPermission(auth.PermissionManageProducts))
+}
+
+func testRolesInvalid(t *testing.T, service services.Service) {
+	ctx := adminContext()
+	user := createUser(t, service)
+
+	_, err := service.GetUserRoles(ctx, requests.BindUriID{ID: missingID})
+	requireCode(t, services.ErrNotFound, err)
+	_, err = service.AssignRole(ctx, requests.UserRoleRequest{UserID: missingID, Role: requests.RoleViewer})
+	requireCode(t, services.ErrNotFound, err)
+	_, err = service.RevokeRole(ctx, requests.UserRoleRequest{UserID: missingID, Role: requests.RoleViewer})
+	requireCode(t, services.ErrNotFound, err)
+
+	_, err = service.AssignRole(ctx, requests.UserRoleRequest{UserID: user.ID, Role: "owner"})
+	requireCode(t, services.ErrValidation, err)
+	_, err = service.RevokeRole(ctx, requests.UserRoleRequest{UserID: user.ID, Role: "owner"})
+	requireCode(t, services.ErrValidation, err)
+}
+
+func testRolesManageProducts(t *testing.T, service services.Service) {
+	owner := createUser(t, service)
+	product := createProduct(t, service, owner.ID, "owned")
+
+	update := requests.UpdateProductRequest{ID: product.ID, Name: "updated", Price: 200}
+	_, err := service.UpdateProduct(staffContext(t, service, requests.RoleViewer), update)
+	requireCode(t, services.ErrForbidden, err)
+
+	updated, err := service.UpdateProduct(staffContext(t, service, requests.RoleEditor), update)
+	require.NoError(t, err)
+	require.Equal(t, owner.ID, updated.UserID)
+	require.Equal(t, "updated", updated.Name)
+}
+
+func testRolesReadOrders(t *testing.T, service services.Service) {
+	owner := createUser(t, service)
+	ctx := userContext(owner)
+	product := createProduct(t, service, owner.ID, "ordered")
+	adjustStock(t, service, product.ID, 2, requests.StockReceived)
+
+	order, err := service.CreateOrder(ctx, requests.CreateOrderRequest{
+		UserID: owner.ID,
+		Items:  []requests.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	payment, err := service.StartPayment(ctx, requests.StartPaymentRequest{OrderID: &order.ID})
+	require.NoError(t, err)
+	reservation := createReservation(t, service, owner, product.ID, 1)
+
+	// viewers read the orders, reservations and payments of every user
+	viewer := staffContext(t, service, requests.RoleViewer)
+	_, err = service.GetOrder(viewer, requests.BindUriID{ID: order.ID})
+	require.NoError(t, err)
+	orders, err := service.GetUserOrders(viewer, requests.GetUserOrdersRequest{UserID: owner.ID})
+	require.NoError(t, err)
+	require.Len(t, orders.Edges, 1)
+	_, err = service.GetPayment(viewer, requests.BindUriID{ID: payment.ID})
+	require.NoError(t, err)
+	_, err = service.GetReservation(viewer, requests.BindUriID{ID: reservation.ID})
+	require.NoError(t, err)
+
+	// but change none of them
+	_, err = service.ConfirmPayment(viewer, requests.BindUriID{ID: payment.ID})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.ReleaseReservation(viewer, requests.BindUriID{ID: reservation.ID})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.StartPayment(viewer, requests.StartPaymentRequest{OrderID: &order.ID})
+	requireCode(t, services.ErrForbidden, err)
+	requireStock(t, service, product.ID, 2, 1)
+}
+
+// staffContext registers a user holding role and acts as it, with the
+// permissions the service loads for role.
+func staffContext(t *testing.T, service services.Service, role requests.Role) context.Context {
+	session := register(t, service, string(role))
+	_, err := service.AssignRole(adminContext(), requests.UserRoleRequest{UserID: session.User.ID, Role: role})
+	require.NoError(t, err)
+
+	user, err := service.Authenticate(adminContext(), session.AccessToken)
+	require.NoError(t, err)
+	return userContext(user)
+}
+
+func testAPIKeys(t *testing.T, service services.Service) {
+	session := register(t, service, "partner")
+	ctx := userContext(session.User)
+
+	created, err := service.CreateAPIKey(ctx, requests.CreateAPIKeyRequest{
+		Name:   " nightly import ",
+		Scopes: []requests.Scope{requests.ScopeProductsWrite, requests.ScopeProductsRead, requests.ScopeProductsWrite},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nightly import", created.Name)
+	require.Equal(t, session.User.ID, created.UserID)
+	require.Equal(t, []requests.Scope{requests.ScopeProductsRead, requests.ScopeProductsWrite}, created.Scopes)
+	require.True(t, strings.HasPrefix(created.Key, "sk_"+created.Prefix+"_"))
+	require.Nil(t, created.ExpiresAt)
+	require.Nil(t, created.LastUsedAt)
+
+	keys, err := service.ListAPIKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*responses.APIKey{created.APIKey}, keys)
+
+	user, key, err := service.AuthenticateAPIKey(context.Background(), created.Key)
+	require.NoError(t, err)
+	require.Equal(t, session.User.ID, user.ID)
+	require.Equal(t, created.ID, key.ID)
+	require.True(t, key.HasScope(requests.ScopeProductsWrite))
+	require.False(t, key.HasScope(requests.ScopeOrdersRead))
+	require.NotNil(t, key.LastUsedAt)
+
+	got, err := service.GetAPIKey(ctx, requests.BindUriID{ID: created.ID})
+	require.NoError(t, err)
+	require.NotNil(t, got.LastUsedAt)
+
+	updated, err := service.UpdateAPIKey(ctx, requests.UpdateAPIKeyRequest{
+		ID:     created.ID,
+		Name:   "read only",
+		Scopes: []requests.Scope{requests.ScopeProductsRead},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "read only", updated.Name)
+	require.Equal(t, created.Prefix, updated.Prefix)
+
+	_, key, err = service.AuthenticateAPIKey(context.Background(), created.Key)
+	require.NoError(t, err)
+	require.Equal(t, []requests.Scope{requests.ScopeProductsRead}, key.Scopes)
+
+	// the keys of other users are not found
+	other := userContext(register(t, service, "other").User)
+	keys, err = service.ListAPIKeys(other)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+	_, err = service.GetAPIKey(other, requests.BindUriID{ID: created.ID})
+	requireCode(t, services.ErrNotFound, err)
+	_, err = service.UpdateAPIKey(other, requests.UpdateAPIKeyRequest{ID: created.ID, Name: "stolen", Scopes: []requests.Scope{requests.ScopeUsersWrite}})
+	requireCode(t, services.ErrNotFound, err)
+	err = service.DeleteAPIKey(other, requests.BindUriID{ID: created.ID})
+	requireCode(t, services.ErrNotFound, err)
+
+	err = service.DeleteAPIKey(ctx, requests.BindUriID{ID: created.ID})
+	require.NoError(t, err)
+	_, _, err = service.AuthenticateAPIKey(context.Background(), created.Key)
+	requireCode(t, services.ErrUnauthorized, err)
+	err = service.DeleteAPIKey(ctx, requests.BindUriID{ID: created.ID})
+	requireCode(t, services.ErrNotFound, err)
+}
+
+func testAPIKeysInvalid(t *testing.T, service services.Service) {
+	session := register(t, service, "partner")
+	ctx := userContext(session.User)
+	scopes := []requests.Scope{requests.ScopeOrdersRead}
+
+	_, err := service.CreateAPIKey(context.Background(), requests.CreateAPIKeyRequest{Name: "anonymous", Scopes: scopes})
+	requireCode(t, services.ErrUnauthorized, err)
+
+	// a key cannot mint keys that outlive it
+	_, err = service.CreateAPIKey(auth.WithScopes(ctx, scopes), requests.CreateAPIKeyRequest{Name: "minted", Scopes: scopes})
+	requireCode(t, services.ErrForbidden, err)
+	_, err = service.ListAPIKeys(auth.WithScopes(ctx, scopes))
+	requireCode(t, services.ErrForbidden, err)
+
+	past := time.Now().Add(-time.Hour)
+	for _, req := range []requests.CreateAPIKeyRequest{
+		{Name: " ", Scopes: scopes},
+		{Name: "no scopes"},
+		{Name: "unknown scope", Scopes: []requests.Scope{"products:delete"}},
+		{Name: "expired", Scopes: scopes, ExpiresAt: &past},
+	} {
+		_, err = service.CreateAPIKey(ctx, req)
+		requireCode(t, services.ErrValidation, err)
+	}
+
+	created, err := service.CreateAPIKey(ctx, requests.CreateAPIKeyRequest{Name: "valid", Scopes: scopes})
+	require.NoError(t, err)
+	_, err = service.UpdateAPIKey(ctx, requests.UpdateAPIKeyRequest{ID: created.ID, Name: "valid", Scopes: []requests.Scope{"everything"}})
+	requireCode(t, services.ErrValidation, err)
+
+	for _, key := range []string{
+		"",
+		"not a key",
+		"sk_" + created.Prefix + "_guessed",
+		"sk_000000000000_" + strings.SplitN(created.Key, "_", 3)[2],
+		created.Key + "x",
+	} {
+		_, _, err = service.AuthenticateAPIKey(context.Background(), key)
+		requireCode(t, services.ErrUnauthorized, err)
+	}
+}
+
+func testAPIKeyExpiry(t *testing.T, service services.Service) {
+	session := register(t, service, "partner")
+	expiresAt := time.Now().Add(50 * time.Millisecond)
+
+	created, err := service.CreateAPIKey(userContext(session.User), requests.CreateAPIKeyRequest{
+		Name:      "short lived",
+		Scopes:    []requests.Scope{requests.ScopeProductsRead},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.ExpiresAt)
+	require.WithinDuration(t, expiresAt, *created.ExpiresAt, time.Millisecond)
+
+	_, _, err = service.AuthenticateAPIKey(context.Background(), created.Key)
+	require.NoError(t, err)
+
+	time.Sleep(time.Until(expiresAt) + time.Millisecond)
+	_, _, err = service.AuthenticateAPIKey(context.Background(), created.Key)
+	requireCode(t, services.ErrUnauthorized, err)
+}
+
+// register signs up a user with the password "correct horse".
+func register(t *testing.T, service services.Service, name string) *responses.Session {
+	req := requests.RegisterRequest{
+		Name:     name,
+		Email:    uniqueEmail(name),
+		Password: "correct horse",
+	}
+
+	session, err := service.Register(adminContext(), req)
+	require.NoError(t, err)
+	require.NotZero(t, session.User.ID)
+	require.Equal(t, req.Email, session.User.Email)
+	require.Equal(t, "Bearer", session.TokenType)
+	require.NotEmpty(t, session.AccessToken)
+	require.NotEmpty(t, session.RefreshToken)
+	require.True(t, session.ExpiresAt.Before(session.RefreshExpiresAt))
+
+	return session
+}
+
+func adjustStock(t *testing.T, service services.Service, productID, delta int64, reason requests.StockReason) *responses.Stock {
+	stock, err := service.AdjustStock(adminContext(), requests.AdjustStockRequest{
+		ProductID: productID,
+		Delta:     delta,
+		Reason:    reason,
+	})
+	require.NoError(t, err)
+	require.Equal(t, productID, stock.ProductID)
+
+	return stock
+}
+
+func createReservation(t *testing.T, service services.Service, user *responses.User, productID, quantity int64) *responses.Reservation {
+	reservation, err := service.CreateReservation(userContext(user), requests.CreateReservationRequest{
+		ProductID: productID,
+		Quantity:  quantity,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, reservation.ID)
+	require.Equal(t, quantity, reservation.Quantity)
+
+	return reservation
+}
+
+// createOrder orders the products of pairs of product id and quantity.
+func createOrder(t *testing.T, service services.Service, userID int64, pairs ...int64) *responses.Order {
+	req := requests.CreateOrderRequest{UserID: userID}
+	for i := 0; i < len(pairs); i += 2 {
+		req.Items = append(req.Items, requests.OrderItemRequest{ProductID: pairs[i], Quantity: pairs[i+1]})
+	}
+
+	order, err := service.CreateOrder(adminContext(), req)
+	require.NoError(t, err)
+	require.NotZero(t, order.ID)
+	require.Len(t, order.Items, len(req.Items))
+
+	return order
+}
+
+func requireStock(t *testing.T, service services.Service, productID, onHand, reserved int64) {
+	stock, err := service.GetStock(adminContext(), requests.BindUriID{ID: productID})
+	require.NoError(t, err)
+	require.Equal(t, onHand, stock.OnHand, "on hand")
+	require.Equal(t, reserved, stock.Reserved, "reserved")
+	require.Equal(t, onHand-reserved, stock.Available, "available")
+}
+
+func searchToken() string {
+	var token []byte
+	for n := time.Now().UnixNano(); n > 0; n /= 26 {
+		token = append(token, byte('a'+n%26))
+	}
+
+	return string(token)
+}
+
+// listProducts and listDeletedProducts list a default page unless req has a
+// limit.
+func listProducts(t *testing.T, service services.Service, req requests.ListProductsRequest) *responses.ProductList {
+	if req.Limit == 0 {
+		req.Limit = services.DefaultListLimit
+	}
+
+	list, err := service.ListProducts(adminContext(), req)
+	require.NoError(t, err)
+	require.NotNil(t, list.PageInfo)
+
+	return list
+}
+
+func listDeletedProducts(t *testing.T, service services.Service, req requests.ListDeletedProductsRequest) *responses.ProductList {
+	if req.Limit == 0 {
+		req.Limit = services.DefaultListLimit
+	}
+
+	list, err := service.ListDeletedProducts(adminContext(), req)
+	require.NoError(t, err)
+	require.NotNil(t, list.PageInfo)
+
+	return list
+}
+
+func requireProducts(t *testing.T, list *responses.ProductList, expected ...*responses.Product) {
+	require.Len(t, list.Products, len(expected))
+	for i, product := range expected {
+		require.Equal(t, product.ID, list.Products[i].ID)
+	}
+}
+
+func createUser(t *testing.T, service services.Service) *responses.User {
+	req := requests.CreateUserRequest{
+		Name:  "royyan",
+		Email: uniqueEmail("royyan"),
+	}
+
+	user, err := service.CreateUser(adminContext(), req)
+	require.NoError(t, err)
+	require.NotZero(t, user.ID)
+	require.Equal(t, req.Name, user.Name)
+	require.Equal(t, req.Email, user.Email)
+	require.Equal(t, int64(1), user.Version)
+	require.False(t, user.UpdatedAt.IsZero())
+
+	return user
+}
+
+func createProduct(t *testing.T, service services.Service, userID int64, name string) *responses.Product {
+	req := requests.CreateProductRequest{
+		UserID: userID,
+		Name:   name,
+		Price:  100,
+	}
+
+	product, err := service.CreateProduct(adminContext(), req)
+	require.NoError(t, err)
+	require.NotZero(t, product.ID)
+	require.Equal(t, userID, product.UserID)
+	require.Equal(t, int64(1), product.Version)
+	require.False(t, product.UpdatedAt.IsZero())
+
+	return product
+}
+
+// createProducts returns the products in creation order.
+func createProducts(t *testing.T, service services.Service, userID int64, n int) []*responses.Product {
+	products := make([]*responses.Product, n)
+	for i := 0; i < n; i++ {
+		products[i] = createProduct(t, service, userID, fmt.Sprintf("product %d", i+1))
+		// cursors are created_at based, keep timestamps apart even on
+		// backends that store milliseconds only
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	return products
+}
+
+func getUserProducts(t *testing.T, service services.Service, userID int64, first int, after *string) *responses.Products {
+	req := requests.GetUserProductsRequest{
+		UserID: userID,
+		First:  &first,
+		After:  after,
+	}
+
+	products, err := service.GetUserProducts(adminContext(), req)
+	require.NoError(t, err)
+	require.NotNil(t, products.PageInfo)
+
+	return products
+}
+
+func getUserProductsBefore(t *testing.T, service services.Service, userID int64, last int, before *string) *responses.Products {
+	req := requests.GetUserProductsRequest{
+		UserID: userID,
+		Last:   &last,
+		Before: before,
+	}
+
+	products, err := service.GetUserProducts(adminContext(), req)
+	require.NoError(t, err)
+	require.NotNil(t, products.PageInfo)
+
+	return products
+}
+
+func createCategory(t *testing.T, service services.Service, name string, parentID *int64) *responses.Category {
+	category, err := service.CreateCategory(adminContext(), requests.CreateCategoryRequest{Name: name, ParentID: parentID})
+	require.NoError(t, err)
+	require.NotZero(t, category.ID)
+	require.Equal(t, name, category.Name)
+
+	return category
+}
+
+func categoryIDs(categories []*responses.Category) []int64 {
+	ids := make([]int64, len(categories))
+	for i, category := range categories {
+		ids[i] = category.ID
+	}
+
+	return ids
+}
+
+func tagNames(tags []*responses.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+
+	return names
+}
+
+func requireEdges(t *testing.T, products *responses.Products, expected ...*responses.Product) {
+	require.Len(t, products.Edges, len(expected))
+	for i, product := range expected {
+		require.Equal(t, product.ID, products.Edges[i].Node.ID)
+		require.NotEmpty(t, products.Edges[i].Cursor)
+	}
+}
+
+// requireBulkErrors checks that exactly the items in codes failed, with the
+// given codes.
+func requireBulkErrors(t *testing.T, result *responses.BulkProductsResult, codes map[int]services.ErrorCode) {
+	for i, item := range result.Results {
+		require.Equal(t, i, item.Index)
+		if code, ok := codes[i]; ok {
+			require.NotNil(t, item.Error, "item %d", i)
+			require.Equal(t, string(code), item.Error.Code, item.Error.Message)
+			continue
+		}
+		require.Nil(t, item.Error, "item %d", i)
+	}
+	require.Equal(t, len(codes), result.Failed)
+}
+
+func requireCode(t *testing.T, code services.ErrorCode, err error) {
+	require.Error(t, err)
+	require.Equal(t, code, services.ErrorCodeOf(err), err.Error())
+}
@@ -0,0 +1,2454 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/db/postgres/repositories"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/requests"
+	"sqlc-rest-api/responses"
+	"time"
+)
+
+// SQLService stores everything in a SQL database through the postgres
+// Querier. Other databases plug in with a Querier translating the postgres
+// queries to their own, see NewSqliteService.
+type SQLService struct {
+	Repo      repositories.Querier
+	DB        *sql.DB
+	TxOptions TxOptions
+
+	Options
+}
+
+// NewPostgresService returns a SQLService storing everything in postgres.
+func NewPostgresService(db *sql.DB, pqrepo repositories.Querier) *SQLService {
+	return &SQLService{
+		Repo:      pqrepo,
+		DB:        db,
+		TxOptions: DefaultTxOptions(),
+	}
+}
+
+func (s *SQLService) CreateProduct(ctx context.Context, req requests.CreateProductRequest) (*responses.Product, error) {
+	currency, err := s.Currencies.currency(req.Currency)
+	if err != nil {
+		return &responses.Product{}, err
+	}
+
+	userID, err := newProductOwner(ctx, s.Policy, req.UserID)
+	if err != nil {
+		return &responses.Product{}, err
+	}
+
+	arg := repositories.CreateProductParams{
+		UserID:   userID,
+		Name:     req.Name,
+		Price:    req.Price,
+		Currency: currency,
+	}
+
+	prod, err := s.Repo.CreateProduct(ctx, s.DB, arg)
+	if err != nil {
+		return &responses.Product{}, dbError(err, "product", 0)
+	}
+
+	// a new product has nothing in stock yet
+	product := helpers.ProductResponse(prod)
+	product.Stock = &responses.Stock{ProductID: prod.ID}
+	return product, nil
+}
+
+func (s *SQLService) DeleteProduct(ctx context.Context, req requests.DeleteProductRequest) (*responses.DeletedProduct, error) {
+	var id int64
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		if err := s.authorizeProduct(ctx, q, tx, ActionDelete, req.ID); err != nil {
+			return err
+		}
+
+		// soft deletes skip products already in the trash, permanent
+		// deletes remove live and trashed products alike
+		deleteProduct := q.SoftDeleteProduct
+		if req.Permanent {
+			deleteProduct = q.DeleteProduct
+		}
+
+		var err error
+		id, err = deleteProduct(ctx, tx, req.ID)
+		return dbError(err, "product", req.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.DeletedProduct{
+		Deleted:   true,
+		Permanent: req.Permanent,
+		ProductID: id,
+	}, nil
+}
+
+func (s *SQLService) RestoreProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
+	var prod repositories.Product
+	var stock *responses.Stock
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		if err := s.authorizeProduct(ctx, q, tx, ActionUpdate, req.ID); err != nil {
+			return err
+		}
+
+		var err error
+		prod, err = q.RestoreProduct(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "deleted product", req.ID)
+		}
+
+		stock, err = productStock(ctx, q, tx, prod.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	product := helpers.ProductResponse(prod)
+	product.Stock = stock
+	return product, nil
+}
+
+func (s *SQLService) ListDeletedProducts(ctx context.Context, req requests.ListDeletedProductsRequest) (*responses.ProductList, error) {
+	req, err := normalizeListDeletedProducts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []repositories.Product
+	var total int64
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err = s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		arg := repositories.ListDeletedProductsParams{
+			UserID: nullInt64(req.UserID),
+			Limit:  int32(req.Limit),
+			Offset: int32(req.Offset),
+		}
+
+		results, err = q.ListDeletedProducts(ctx, tx, arg)
+		if err != nil {
+			return dbError(err, "product", 0)
+		}
+
+		total, err = q.CountDeletedProducts(ctx, tx, arg.UserID)
+		return dbError(err, "product", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.ProductListResponse(results, req.Limit, req.Offset, total), nil
+}
+
+func (s *SQLService) PurgeDeletedProducts(ctx context.Context, req requests.PurgeDeletedProductsRequest) (int64, error) {
+	purged, err := s.Repo.PurgeDeletedProducts(ctx, s.DB, req.DeletedBefore)
+	if err != nil {
+		return 0, dbError(err, "product", 0)
+	}
+
+	return purged, nil
+}
+
+func (s *SQLService) GetProduct(ctx context.Context, req requests.BindUriID) (*responses.Product, error) {
+	prod, err := s.Repo.GetProduct(ctx, s.DB, req.ID)
+	if err != nil {
+		return &responses.Product{}, dbError(err, "product", req.ID)
+	}
+
+	product := helpers.ProductResponse(prod)
+	product.Stock, err = productStock(ctx, s.Repo, s.DB, prod.ID)
+	if err != nil {
+		return &responses.Product{}, err
+	}
+
+	return product, nil
+}
+
+func (s *SQLService) GetBatchProducts(ctx context.Context, req requests.GetBatchProductsRequest) ([]*responses.Product, error) {
+	products, err := s.Repo.GetBatchProducts(ctx, s.DB, req.IDs)
+	if err != nil {
+		return nil, dbError(err, "product", 0)
+	}
+
+	return helpers.ProductSliceResponse(products), nil
+}
+
+func (s *SQLService) ListProducts(ctx context.Context, req requests.ListProductsRequest) (*responses.ProductList, error) {
+	req, err := normalizeListProducts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	f := req.Filter
+	var name *string
+	if f.Name != nil {
+		escaped := escapeLike(*f.Name)
+		name = &escaped
+	}
+
+	var results []repositories.Product
+	var total int64
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err = s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		arg := repositories.ListProductsParams{
+			UserID:        nullInt64(f.UserID),
+			Name:          nullString(name),
+			MinPrice:      nullInt64(f.MinPrice),
+			MaxPrice:      nullInt64(f.MaxPrice),
+			CreatedAfter:  nullTime(f.CreatedAfter),
+			CreatedBefore: nullTime(f.CreatedBefore),
+			OrderBy:       string(req.Order.Field),
+			SortDesc:      req.Order.Direction == requests.OrderDesc,
+			Limit:         int32(req.Limit),
+			Offset:        int32(req.Offset),
+		}
+
+		results, err = q.ListProducts(ctx, tx, arg)
+		if err != nil {
+			return dbError(err, "product", 0)
+		}
+
+		countArg := repositories.CountProductsParams{
+			UserID:        arg.UserID,
+			Name:          arg.Name,
+			MinPrice:      arg.MinPrice,
+			MaxPrice:      arg.MaxPrice,
+			CreatedAfter:  arg.CreatedAfter,
+			CreatedBefore: arg.CreatedBefore,
+		}
+
+		total, err = q.CountProducts(ctx, tx, countArg)
+		return dbError(err, "product", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.ProductListResponse(results, req.Limit, req.Offset, total), nil
+}
+
+func (s *SQLService) SearchProducts(ctx context.Context, req requests.SearchProductsRequest) (*responses.Products, error) {
+	search, err := newProductSearch(req, s.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra result tells whether there is another page
+	arg := repositories.SearchProductsParams{
+		Query:     search.query,
+		AfterRank: nullFloat64(search.cursorRank()),
+		AfterID:   nullInt64(search.cursorID()),
+		First:     int32(search.size + 1),
+	}
+
+	rows, err := s.Repo.SearchProducts(ctx, s.DB, arg)
+	if err != nil {
+		return nil, dbError(err, "product", 0)
+	}
+
+	hits := make([]searchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = searchHit{
+			product:   helpers.ProductResponse(row),
+			rank:      row.Rank,
+			highlight: row.Highlight,
+		}
+	}
+
+	return search.response(hits), nil
+}
+
+func (s *SQLService) GetUserProducts(ctx context.Context, req requests.GetUserProductsRequest) (*responses.Products, error) {
+	page, err := newUserProductsPage(req, s.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []repositories.Product
+	var hnp, hpp bool
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err = s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		u, err := q.GetUser(ctx, tx, req.UserID)
+		if err != nil {
+			return dbError(err, "user", req.UserID)
+		}
+
+		if page.backward {
+			arg := repositories.GetUserProductsBeforeParams{
+				UserID:          u.ID,
+				CategoryID:      nullInt64(page.categoryID),
+				Tag:             nullString(page.tag),
+				BeforeCreatedAt: nullTime(page.cursorTime()),
+				BeforeID:        nullInt64(page.cursorID()),
+				Last:            int32(page.size),
+			}
+
+			results, err = q.GetUserProductsBefore(ctx, tx, arg)
+			reverse(results)
+		} else {
+			arg := repositories.GetUserProductsParams{
+				UserID:         u.ID,
+				CategoryID:     nullInt64(page.categoryID),
+				Tag:            nullString(page.tag),
+				AfterCreatedAt: nullTime(page.cursorTime()),
+				AfterID:        nullInt64(page.cursorID()),
+				First:          int32(page.size),
+			}
+
+			results, err = q.GetUserProducts(ctx, tx, arg)
+		}
+		if err != nil || len(results) < 1 {
+			return dbError(err, "user", u.ID)
+		}
+
+		last := results[len(results)-1]
+		hnpArg := repositories.UserProductsHasNextPageParams{
+			UserID:     u.ID,
+			CategoryID: nullInt64(page.categoryID),
+			Tag:        nullString(page.tag),
+			CreatedAt:  last.CreatedAt.Time,
+			ID:         last.ID,
+		}
+
+		hnp, err = q.UserProductsHasNextPage(ctx, tx, hnpArg)
+		if err != nil {
+			return dbError(err, "user", u.ID)
+		}
+
+		first := results[0]
+		hppArg := repositories.UserProductsHasPreviousPageParams{
+			UserID:     u.ID,
+			CategoryID: nullInt64(page.categoryID),
+			Tag:        nullString(page.tag),
+			CreatedAt:  first.CreatedAt.Time,
+			ID:         first.ID,
+		}
+
+		hpp, err = q.UserProductsHasPreviousPage(ctx, tx, hppArg)
+		return dbError(err, "user", u.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.ProductsResponse(results, hnp, hpp), nil
+}
+
+func (s *SQLService) GetBatchUserProducts(ctx context.Context, req requests.GetBatchUserProductsRequest) ([]*responses.Products, error) {
+	page, err := newBatchUserProductsPage(req, s.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []repositories.Product
+	var beyondCursor []int64
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err = s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		// one extra product per user tells whether there is another page
+		if page.backward {
+			arg := repositories.GetBatchUserProductsBeforeParams{
+				UserIds:         req.UserIDs,
+				CategoryID:      nullInt64(page.categoryID),
+				Tag:             nullString(page.tag),
+				BeforeCreatedAt: nullTime(page.cursorTime()),
+				BeforeID:        nullInt64(page.cursorID()),
+				Last:            int64(page.size + 1),
+			}
+
+			results, err = q.GetBatchUserProductsBefore(ctx, tx, arg)
+		} else {
+			arg := repositories.GetBatchUserProductsParams{
+				UserIds:        req.UserIDs,
+				CategoryID:     nullInt64(page.categoryID),
+				Tag:            nullString(page.tag),
+				AfterCreatedAt: nullTime(page.cursorTime()),
+				AfterID:        nullInt64(page.cursorID()),
+				First:          int64(page.size + 1),
+			}
+
+			results, err = q.GetBatchUserProducts(ctx, tx, arg)
+		}
+		if err != nil || page.cursor == nil {
+			return dbError(err, "product", 0)
+		}
+
+		if page.backward {
+			arg := repositories.UsersWithProductsNotNewerThanParams{
+				UserIds:    req.UserIDs,
+				CategoryID: nullInt64(page.categoryID),
+				Tag:        nullString(page.tag),
+				CreatedAt:  page.cursor.CreatedAt,
+				ID:         page.cursor.ID,
+			}
+
+			beyondCursor, err = q.UsersWithProductsNotNewerThan(ctx, tx, arg)
+		} else {
+			arg := repositories.UsersWithProductsNotOlderThanParams{
+				UserIds:    req.UserIDs,
+				CategoryID: nullInt64(page.categoryID),
+				Tag:        nullString(page.tag),
+				CreatedAt:  page.cursor.CreatedAt,
+				ID:         page.cursor.ID,
+			}
+
+			beyondCursor, err = q.UsersWithProductsNotOlderThan(ctx, tx, arg)
+		}
+		return dbError(err, "product", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return splitUserProductsPages(req.UserIDs, page, helpers.ProductSliceResponse(results), beyondCursor), nil
+}
+
+func (s *SQLService) UpdateProduct(ctx context.Context, req requests.UpdateProductRequest) (*responses.Product, error) {
+	if err := checkUpdatedCurrency(req.Currency); err != nil {
+		return &responses.Product{}, err
+	}
+
+	var updated repositories.Product
+	var stock *responses.Stock
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		prod, err := q.GetProduct(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "product", req.ID)
+		}
+
+		if err := authorizeProduct(ctx, s.Policy, ActionUpdate, prod.ID, prod.UserID); err != nil {
+			return err
+		}
+
+		if err := checkVersion("product", prod.ID, req.ExpectedVersion, prod.Version); err != nil {
+			return err
+		}
+
+		arg := repositories.UpdateProductParams{
+			ID:              prod.ID,
+			Name:            req.Name,
+			Price:           req.Price,
+			Currency:        updatedCurrency(req.Currency, prod.Currency),
+			ExpectedVersion: nullInt64(req.ExpectedVersion),
+		}
+
+		updated, err = q.UpdateProduct(ctx, tx, arg)
+		if errors.Is(err, sql.ErrNoRows) && req.ExpectedVersion != nil {
+			return concurrentUpdateError("product", prod.ID, *req.ExpectedVersion)
+		}
+		if err != nil {
+			return dbError(err, "product", prod.ID)
+		}
+
+		stock, err = productStock(ctx, q, tx, prod.ID)
+		return err
+	})
+	if err != nil {
+		return &responses.Product{}, err
+	}
+
+	product := helpers.ProductResponse(updated)
+	product.Stock = stock
+	return product, nil
+}
+
+func (s *SQLService) PatchProduct(ctx context.Context, req requests.PatchProductRequest) (*responses.Product, error) {
+	if err := validateProductPatch(req); err != nil {
+		return nil, err
+	}
+
+	var patched repositories.Product
+	var stock *responses.Stock
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) (err error) {
+		patched, err = s.patchProduct(ctx, q, tx, req)
+		if err != nil {
+			return err
+		}
+
+		stock, err = productStock(ctx, q, tx, patched.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	product := helpers.ProductResponse(patched)
+	product.Stock = stock
+	return product, nil
+}
+
+func (s *SQLService) patchProduct(ctx context.Context, q repositories.Querier, tx repositories.DBTX, req requests.PatchProductRequest) (repositories.Product, error) {
+	prod, err := q.GetProduct(ctx, tx, req.ID)
+	if err != nil {
+		return prod, dbError(err, "product", req.ID)
+	}
+
+	if err := authorizeProduct(ctx, s.Policy, ActionUpdate, prod.ID, prod.UserID); err != nil {
+		return prod, err
+	}
+
+	if err := checkVersion("product", prod.ID, req.ExpectedVersion, prod.Version); err != nil {
+		return prod, err
+	}
+
+	arg := repositories.PatchProductParams{
+		Name:            nullString(req.Name),
+		Price:           nullInt64(req.Price),
+		Currency:        nullString(req.Currency),
+		ID:              prod.ID,
+		ExpectedVersion: nullInt64(req.ExpectedVersion),
+	}
+
+	patched, err := q.PatchProduct(ctx, tx, arg)
+	if errors.Is(err, sql.ErrNoRows) && req.ExpectedVersion != nil {
+		return patched, concurrentUpdateError("product", prod.ID, *req.ExpectedVersion)
+	}
+
+	return patched, dbError(err, "product", prod.ID)
+}
+
+func (s *SQLService) BulkCreateProducts(ctx context.Context, req requests.BulkCreateProductsRequest) (*responses.BulkProductsResult, error) {
+	if err := checkBulkSize(len(req.Products)); err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *responses.BulkProductsResult
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		result = newBulkResult(len(req.Products))
+		validateNewProducts(result, req.Products)
+		products := authorizeNewProducts(ctx, s.Policy, caller, result, req.Products)
+
+		users, err := q.GetBatchUsers(ctx, tx, bulkUserIDs(result, products))
+		if err != nil {
+			return dbError(err, "user", 0)
+		}
+
+		found := make(map[int64]bool, len(users))
+		for _, user := range users {
+			found[user.ID] = true
+		}
+		requireUsers(result, products, found)
+
+		if req.Atomic && bulkFailed(result) {
+			return errBulkRolledBack
+		}
+
+		pending := bulkPending(result)
+		if len(pending) == 0 {
+			return nil
+		}
+
+		var arg repositories.BulkCreateProductsParams
+		for _, i := range pending {
+			arg.UserIds = append(arg.UserIds, products[i].UserID)
+			arg.Names = append(arg.Names, products[i].Name)
+			arg.Prices = append(arg.Prices, products[i].Price)
+			arg.Currencies = append(arg.Currencies, s.Currencies.orDefault(products[i].Currency))
+		}
+
+		created, err := q.BulkCreateProducts(ctx, tx, arg)
+		if err != nil {
+			return dbError(err, "product", 0)
+		}
+
+		// ids are handed out in insert order but RETURNING has no order
+		sort.Slice(created, func(i, j int) bool { return created[i].ID < created[j].ID })
+		for j, i := range pending {
+			result.Results[i].Product = helpers.ProductResponse(created[j])
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkRolledBack) {
+		return nil, err
+	}
+
+	return finishBulk(result, req.Atomic), nil
+}
+
+func (s *SQLService) BulkPatchProducts(ctx context.Context, req requests.BulkPatchProductsRequest) (*responses.BulkProductsResult, error) {
+	if err := checkBulkSize(len(req.Products)); err != nil {
+		return nil, err
+	}
+
+	if _, err := callerOf(ctx); err != nil {
+		return nil, err
+	}
+
+	result := newBulkResult(len(req.Products))
+	validatePatches(result, req.Products)
+
+	if !req.Atomic {
+		for _, i := range bulkPending(result) {
+			prod, err := s.PatchProduct(ctx, req.Products[i])
+			if err != nil {
+				bulkFail(result, i, err)
+				continue
+			}
+			result.Results[i].Product = prod
+		}
+
+		return finishBulk(result, false), nil
+	}
+
+	if bulkFailed(result) {
+		return finishBulk(result, true), nil
+	}
+
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		for i, item := range req.Products {
+			prod, err := s.patchProduct(ctx, q, tx, item)
+			if isRetryable(err) {
+				return err
+			}
+			if err != nil {
+				bulkFail(result, i, err)
+				return errBulkRolledBack
+			}
+			result.Results[i].Product = helpers.ProductResponse(prod)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkRolledBack) {
+		return nil, err
+	}
+
+	return finishBulk(result, true), nil
+}
+
+func (s *SQLService) BulkDeleteProducts(ctx context.Context, req requests.BulkDeleteProductsRequest) (*responses.BulkProductsResult, error) {
+	if err := checkBulkSize(len(req.IDs)); err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *responses.BulkProductsResult
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		result = newBulkResult(len(req.IDs))
+		validateDeletes(result, req.IDs)
+
+		owners, err := s.productOwners(ctx, q, tx, bulkDeleteIDs(result, req.IDs))
+		if err != nil {
+			return err
+		}
+		authorizeDeletes(ctx, s.Policy, caller, result, req.IDs, owners)
+
+		if req.Atomic && bulkFailed(result) {
+			return errBulkRolledBack
+		}
+
+		ids := bulkDeleteIDs(result, req.IDs)
+		if len(ids) == 0 {
+			return nil
+		}
+
+		deleteProducts := q.BulkSoftDeleteProducts
+		if req.Permanent {
+			deleteProducts = q.BulkDeleteProducts
+		}
+
+		deleted, err := deleteProducts(ctx, tx, ids)
+		if err != nil {
+			return dbError(err, "product", 0)
+		}
+		bulkDeleted(result, req.IDs, deleted, req.Permanent)
+
+		if req.Atomic && bulkFailed(result) {
+			return errBulkRolledBack
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkRolledBack) {
+		return nil, err
+	}
+
+	return finishBulk(result, req.Atomic), nil
+}
+
+// authorizeProduct asks the policy whether the caller of ctx may apply action
+// to the product id, trashed products included.
+func (s *SQLService) authorizeProduct(ctx context.Context, q repositories.Querier, tx repositories.DBTX, action Action, id int64) error {
+	owners, err := s.productOwners(ctx, q, tx, []int64{id})
+	if err != nil {
+		return err
+	}
+
+	ownerID, ok := owners[id]
+	if !ok {
+		return dbError(sql.ErrNoRows, "product", id)
+	}
+
+	return authorizeProduct(ctx, s.Policy, action, id, ownerID)
+}
+
+// productOwners maps the ids of the stored products among ids to their
+// owner.
+func (s *SQLService) productOwners(ctx context.Context, q repositories.Querier, tx repositories.DBTX, ids []int64) (map[int64]int64, error) {
+	rows, err := q.GetProductOwners(ctx, tx, ids)
+	if err != nil {
+		return nil, dbError(err, "product", 0)
+	}
+
+	owners := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		owners[row.ID] = row.UserID
+	}
+
+	return owners, nil
+}
+
+func (s *SQLService) CreateUser(ctx context.Context, req requests.CreateUserRequest) (*responses.User, error) {
+	email, err := s.Emails.normalize(req.Email)
+	if err != nil {
+		return &responses.User{}, err
+	}
+
+	arg := repositories.CreateUserParams{
+		Name:  req.Name,
+		Email: email,
+	}
+
+	user, err := s.Repo.CreateUser(ctx, s.DB, arg)
+	if err != nil {
+		return &responses.User{}, userEmailError(err, email, 0)
+	}
+
+	return helpers.UserResponse(user), nil
+}
+
+func (s *SQLService) GetUser(ctx context.Context, req requests.BindUriID) (*responses.User, error) {
+	user, err := s.Repo.GetUser(ctx, s.DB, req.ID)
+	if err != nil {
+		return &responses.User{}, dbError(err, "user", req.ID)
+	}
+
+	return helpers.UserResponse(user), nil
+}
+
+func (s *SQLService) UpdateUser(ctx context.Context, req requests.UpdateUserRequest) (*responses.User, error) {
+	email, err := s.Emails.normalize(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated repositories.User
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		user, err := q.GetUser(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "user", req.ID)
+		}
+
+		if err := checkVersion("user", user.ID, req.ExpectedVersion, user.Version); err != nil {
+			return err
+		}
+
+		arg := repositories.UpdateUserParams{
+			Name:            req.Name,
+			Email:           email,
+			ID:              user.ID,
+			ExpectedVersion: nullInt64(req.ExpectedVersion),
+		}
+
+		updated, err = q.UpdateUser(ctx, tx, arg)
+		if errors.Is(err, sql.ErrNoRows) && req.ExpectedVersion != nil {
+			return concurrentUpdateError("user", user.ID, *req.ExpectedVersion)
+		}
+		return userEmailError(err, email, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.UserResponse(updated), nil
+}
+
+func (s *SQLService) DeleteUser(ctx context.Context, req requests.DeleteUserRequest) (*responses.DeletedUser, error) {
+	policy, reassignTo, err := s.UserDeletion.resolve(req)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := &responses.DeletedUser{Deleted: true, UserID: req.ID, Policy: policy}
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		if _, err := q.GetUser(ctx, tx, req.ID); err != nil {
+			return dbError(err, "user", req.ID)
+		}
+
+		// orders are never deleted or reassigned with the user
+		orders, err := q.CountUserOrders(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "order", 0)
+		}
+		if orders > 0 {
+			return hasOrdersError(req.ID, orders)
+		}
+
+		switch policy {
+		case requests.UserDeleteCascade:
+			deleted.Products, err = q.DeleteUserProducts(ctx, tx, req.ID)
+		case requests.UserDeleteReassign:
+			if _, err := q.GetUser(ctx, tx, reassignTo); errors.Is(err, sql.ErrNoRows) {
+				return reassignUserError(reassignTo)
+			} else if err != nil {
+				return dbError(err, "user", reassignTo)
+			}
+
+			arg := repositories.ReassignUserProductsParams{
+				ToUserID:   reassignTo,
+				FromUserID: req.ID,
+			}
+			deleted.Products, err = q.ReassignUserProducts(ctx, tx, arg)
+			deleted.ReassignedTo = &reassignTo
+		default:
+			deleted.Products, err = q.CountUserProducts(ctx, tx, req.ID)
+			if err == nil && deleted.Products > 0 {
+				return ownsProductsError(req.ID, deleted.Products)
+			}
+		}
+		if err != nil {
+			return dbError(err, "product", 0)
+		}
+
+		_, err = q.DeleteUser(ctx, tx, req.ID)
+		return dbError(err, "user", req.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+func (s *SQLService) ListUsers(ctx context.Context, req requests.ListUsersRequest) (*responses.Users, error) {
+	page, err := newUsersPage(req, s.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// one extra user tells whether there is another page
+	arg := repositories.ListUsersParams{
+		AfterID: nullInt64(page.after),
+		First:   int32(page.size + 1),
+	}
+
+	users, err := s.Repo.ListUsers(ctx, s.DB, arg)
+	if err != nil {
+		return nil, dbError(err, "user", 0)
+	}
+
+	hasNextPage := len(users) > page.size
+	if hasNextPage {
+		users = users[:page.size]
+	}
+
+	return helpers.UsersResponse(users, hasNextPage, page.after != nil), nil
+}
+
+func (s *SQLService) PatchUser(ctx context.Context, req requests.PatchUserRequest) (*responses.User, error) {
+	req, err := s.Emails.normalizePatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched repositories.User
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		user, err := q.GetUser(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "user", req.ID)
+		}
+
+		if err := checkVersion("user", user.ID, req.ExpectedVersion, user.Version); err != nil {
+			return err
+		}
+
+		arg := repositories.PatchUserParams{
+			Name:            nullString(req.Name),
+			Email:           nullString(req.Email),
+			ID:              user.ID,
+			ExpectedVersion: nullInt64(req.ExpectedVersion),
+		}
+
+		patched, err = q.PatchUser(ctx, tx, arg)
+		if errors.Is(err, sql.ErrNoRows) && req.ExpectedVersion != nil {
+			return concurrentUpdateError("user", user.ID, *req.ExpectedVersion)
+		}
+		if err != nil && req.Email != nil {
+			return userEmailError(err, *req.Email, user.ID)
+		}
+		return dbError(err, "user", user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.UserResponse(patched), nil
+}
+
+func (s *SQLService) GetBatchUsers(ctx context.Context, req requests.GetBatchUsersRequest) ([]*responses.User, error) {
+	users, err := s.Repo.GetBatchUsers(ctx, s.DB, req.IDs)
+	if err != nil {
+		return nil, dbError(err, "user", 0)
+	}
+
+	return helpers.UserSliceResponse(users), nil
+}
+
+func (s *SQLService) SetExchangeRate(ctx context.Context, req requests.SetExchangeRateRequest) (*responses.ExchangeRate, error) {
+	if err := checkExchangeRate(req); err != nil {
+		return nil, err
+	}
+
+	arg := repositories.SetExchangeRateParams{
+		Base:  req.Base,
+		Quote: req.Quote,
+		Rate:  req.Rate,
+	}
+
+	rate, err := s.Repo.SetExchangeRate(ctx, s.DB, arg)
+	if err != nil {
+		return nil, dbError(err, "exchange rate", 0)
+	}
+
+	return helpers.ExchangeRateResponse(rate), nil
+}
+
+func (s *SQLService) ListExchangeRates(ctx context.Context) ([]*responses.ExchangeRate, error) {
+	rates, err := s.Repo.ListExchangeRates(ctx, s.DB)
+	if err != nil {
+		return nil, dbError(err, "exchange rate", 0)
+	}
+
+	return helpers.ExchangeRateSliceResponse(rates), nil
+}
+
+func (s *SQLService) ConvertPrices(ctx context.Context, prices []responses.Money, currency string) ([]responses.Money, error) {
+	rates, err := s.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Currencies.convertPrices(rates, prices, currency)
+}
+
+func (s *SQLService) CreateCategory(ctx context.Context, req requests.CreateCategoryRequest) (*responses.Category, error) {
+	name, err := normalizeCategoryName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCategoryParent(req.ParentID); err != nil {
+		return nil, err
+	}
+
+	var created repositories.Category
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		if req.ParentID != nil {
+			if _, err := q.GetCategory(ctx, tx, *req.ParentID); errors.Is(err, sql.ErrNoRows) {
+				return parentNotFoundError(*req.ParentID)
+			} else if err != nil {
+				return dbError(err, "category", *req.ParentID)
+			}
+		}
+
+		arg := repositories.CreateCategoryParams{
+			Name:     name,
+			ParentID: nullInt64(req.ParentID),
+		}
+
+		created, err = q.CreateCategory(ctx, tx, arg)
+		return categoryError(err, name, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.CategoryResponse(created), nil
+}
+
+func (s *SQLService) GetCategory(ctx context.Context, req requests.BindUriID) (*responses.Category, error) {
+	category, err := s.Repo.GetCategory(ctx, s.DB, req.ID)
+	if err != nil {
+		return nil, dbError(err, "category", req.ID)
+	}
+
+	return helpers.CategoryResponse(category), nil
+}
+
+func (s *SQLService) GetBatchCategories(ctx context.Context, req requests.GetBatchCategoriesRequest) ([]*responses.Category, error) {
+	categories, err := s.Repo.GetBatchCategories(ctx, s.DB, req.IDs)
+	if err != nil {
+		return nil, dbError(err, "category", 0)
+	}
+
+	return helpers.CategorySliceResponse(categories), nil
+}
+
+func (s *SQLService) ListCategories(ctx context.Context, req requests.ListCategoriesRequest) ([]*responses.Category, error) {
+	if err := checkCategoryParent(req.ParentID); err != nil {
+		return nil, err
+	}
+
+	var results []repositories.Category
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err := s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		if req.ParentID != nil {
+			if _, err := q.GetCategory(ctx, tx, *req.ParentID); err != nil {
+				return dbError(err, "category", *req.ParentID)
+			}
+		}
+
+		var err error
+		results, err = q.ListCategories(ctx, tx, nullInt64(req.ParentID))
+		return dbError(err, "category", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.CategorySliceResponse(results), nil
+}
+
+func (s *SQLService) UpdateCategory(ctx context.Context, req requests.UpdateCategoryRequest) (*responses.Category, error) {
+	name, err := normalizeCategoryName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCategoryParent(req.ParentID); err != nil {
+		return nil, err
+	}
+
+	var updated repositories.Category
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		category, err := q.GetCategory(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "category", req.ID)
+		}
+
+		if req.ParentID != nil {
+			ancestors, err := q.GetCategoryAncestors(ctx, tx, *req.ParentID)
+			if err != nil {
+				return dbError(err, "category", *req.ParentID)
+			}
+
+			if len(ancestors) == 0 {
+				return parentNotFoundError(*req.ParentID)
+			}
+
+			if err := checkCategoryMove(category.ID, ancestors); err != nil {
+				return err
+			}
+		}
+
+		arg := repositories.UpdateCategoryParams{
+			ID:       category.ID,
+			Name:     name,
+			ParentID: nullInt64(req.ParentID),
+		}
+
+		updated, err = q.UpdateCategory(ctx, tx, arg)
+		return categoryError(err, name, category.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.CategoryResponse(updated), nil
+}
+
+func (s *SQLService) DeleteCategory(ctx context.Context, req requests.BindUriID) error {
+	return s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		children, err := q.CountChildCategories(ctx, tx, nullInt64(&req.ID))
+		if err != nil {
+			return dbError(err, "category", req.ID)
+		}
+
+		if children > 0 {
+			return hasChildrenError(req.ID, children)
+		}
+
+		_, err = q.DeleteCategory(ctx, tx, req.ID)
+		return dbError(err, "category", req.ID)
+	})
+}
+
+func (s *SQLService) CreateTag(ctx context.Context, req requests.CreateTagRequest) (*responses.Tag, error) {
+	name, err := normalizeTag(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.Repo.CreateTag(ctx, s.DB, name)
+	if err != nil {
+		return nil, tagError(err, name)
+	}
+
+	return helpers.TagResponse(tag), nil
+}
+
+func (s *SQLService) ListTags(ctx context.Context) ([]*responses.Tag, error) {
+	tags, err := s.Repo.ListTags(ctx, s.DB)
+	if err != nil {
+		return nil, dbError(err, "tag", 0)
+	}
+
+	return helpers.TagSliceResponse(tags), nil
+}
+
+func (s *SQLService) DeleteTag(ctx context.Context, req requests.BindUriID) error {
+	_, err := s.Repo.DeleteTag(ctx, s.DB, req.ID)
+	return dbError(err, "tag", req.ID)
+}
+
+func (s *SQLService) SetProductCategories(ctx context.Context, req requests.SetProductCategoriesRequest) ([]*responses.Category, error) {
+	req, err := validateSetProductCategories(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []repositories.GetBatchProductCategoriesRow
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		prod, err := q.GetProduct(ctx, tx, req.ProductID)
+		if err != nil {
+			return dbError(err, "product", req.ProductID)
+		}
+
+		found, err := q.GetBatchCategories(ctx, tx, req.CategoryIDs)
+		if err != nil {
+			return dbError(err, "category", 0)
+		}
+
+		if id, ok := missingCategory(req.CategoryIDs, helpers.CategorySliceResponse(found)); ok {
+			return categoryNotFoundError(id)
+		}
+
+		if err := q.DeleteProductCategories(ctx, tx, prod.ID); err != nil {
+			return dbError(err, "product", prod.ID)
+		}
+
+		arg := repositories.AddProductCategoriesParams{
+			ProductID:   prod.ID,
+			CategoryIds: req.CategoryIDs,
+		}
+
+		if err := q.AddProductCategories(ctx, tx, arg); err != nil {
+			return dbError(err, "product", prod.ID)
+		}
+
+		results, err = q.GetBatchProductCategories(ctx, tx, []int64{prod.ID})
+		return dbError(err, "product", prod.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return productCategories([]int64{req.ProductID}, results)[0], nil
+}
+
+func (s *SQLService) SetProductTags(ctx context.Context, req requests.SetProductTagsRequest) ([]*responses.Tag, error) {
+	req, err := validateSetProductTags(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []repositories.GetBatchProductTagsRow
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		prod, err := q.GetProduct(ctx, tx, req.ProductID)
+		if err != nil {
+			return dbError(err, "product", req.ProductID)
+		}
+
+		if err := q.DeleteProductTags(ctx, tx, prod.ID); err != nil {
+			return dbError(err, "product", prod.ID)
+		}
+
+		tags, err := q.UpsertTags(ctx, tx, req.Tags)
+		if err != nil {
+			return dbError(err, "tag", 0)
+		}
+
+		arg := repositories.AddProductTagsParams{ProductID: prod.ID, TagIds: make([]int64, len(tags))}
+		for i, tag := range tags {
+			arg.TagIds[i] = tag.ID
+		}
+
+		if err := q.AddProductTags(ctx, tx, arg); err != nil {
+			return dbError(err, "product", prod.ID)
+		}
+
+		results, err = q.GetBatchProductTags(ctx, tx, []int64{prod.ID})
+		return dbError(err, "product", prod.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return productTags([]int64{req.ProductID}, results)[0], nil
+}
+
+func (s *SQLService) GetBatchProductCategories(ctx context.Context, req requests.GetBatchProductCategoriesRequest) ([][]*responses.Category, error) {
+	results, err := s.Repo.GetBatchProductCategories(ctx, s.DB, req.ProductIDs)
+	if err != nil {
+		return nil, dbError(err, "category", 0)
+	}
+
+	return productCategories(req.ProductIDs, results), nil
+}
+
+func (s *SQLService) GetBatchProductTags(ctx context.Context, req requests.GetBatchProductTagsRequest) ([][]*responses.Tag, error) {
+	results, err := s.Repo.GetBatchProductTags(ctx, s.DB, req.ProductIDs)
+	if err != nil {
+		return nil, dbError(err, "tag", 0)
+	}
+
+	return productTags(req.ProductIDs, results), nil
+}
+
+func (s *SQLService) GetStock(ctx context.Context, req requests.BindUriID) (*responses.Stock, error) {
+	var stock *responses.Stock
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err := s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		if _, err := q.GetProduct(ctx, tx, req.ID); err != nil {
+			return dbError(err, "product", req.ID)
+		}
+
+		var err error
+		stock, err = productStock(ctx, q, tx, req.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+func (s *SQLService) GetBatchStock(ctx context.Context, req requests.GetBatchStockRequest) ([]*responses.Stock, error) {
+	inventory, err := s.Repo.GetBatchInventory(ctx, s.DB, req.ProductIDs)
+	if err != nil {
+		return nil, dbError(err, "product", 0)
+	}
+
+	stocks := make([]*responses.Stock, len(inventory))
+	for i, row := range inventory {
+		stocks[i] = helpers.StockResponse(row)
+	}
+
+	return stocksByProduct(req.ProductIDs, stocks), nil
+}
+
+func (s *SQLService) AdjustStock(ctx context.Context, req requests.AdjustStockRequest) (*responses.Stock, error) {
+	req, err := validateAdjustStock(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stock *responses.Stock
+	err = s.WithTxOptions(ctx, s.inventoryTxOptions(), func(q repositories.Querier, tx repositories.DBTX) error {
+		inventory, err := lockInventory(ctx, q, tx, req.ProductID)
+		if err != nil {
+			return err
+		}
+
+		onHand, err := adjustedOnHand(req.ProductID, inventory.OnHand, inventory.Reserved, req.Delta)
+		if err != nil {
+			return err
+		}
+
+		inventory, err = q.SetInventory(ctx, tx, repositories.SetInventoryParams{
+			OnHand:    onHand,
+			Reserved:  inventory.Reserved,
+			ProductID: req.ProductID,
+		})
+		if err != nil {
+			return dbError(err, "product", req.ProductID)
+		}
+
+		_, err = q.CreateStockAdjustment(ctx, tx, repositories.CreateStockAdjustmentParams{
+			ProductID: req.ProductID,
+			Delta:     req.Delta,
+			Reason:    string(req.Reason),
+			Note:      req.Note,
+		})
+		if err != nil {
+			return dbError(err, "product", req.ProductID)
+		}
+
+		stock = helpers.StockResponse(inventory)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+func (s *SQLService) ListStockAdjustments(ctx context.Context, req requests.ListStockAdjustmentsRequest) ([]*responses.StockAdjustment, error) {
+	req, err := normalizeListStockAdjustments(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var adjustments []repositories.StockAdjustment
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err = s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		if _, err := q.GetProduct(ctx, tx, req.ProductID); err != nil {
+			return dbError(err, "product", req.ProductID)
+		}
+
+		var err error
+		adjustments, err = q.ListStockAdjustments(ctx, tx, repositories.ListStockAdjustmentsParams{
+			ProductID: req.ProductID,
+			Limit:     int32(req.Limit),
+		})
+		return dbError(err, "product", req.ProductID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.StockAdjustmentSliceResponse(adjustments), nil
+}
+
+func (s *SQLService) CreateReservation(ctx context.Context, req requests.CreateReservationRequest) (*responses.Reservation, error) {
+	expiresAt, err := reservationExpiry(req, s.ReservationTTL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservation *responses.Reservation
+	err = s.WithTxOptions(ctx, s.inventoryTxOptions(), func(q repositories.Querier, tx repositories.DBTX) error {
+		inventory, err := lockInventory(ctx, q, tx, req.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkAvailable(req.ProductID, inventory.OnHand, inventory.Reserved, req.Quantity); err != nil {
+			return err
+		}
+
+		_, err = q.SetInventory(ctx, tx, repositories.SetInventoryParams{
+			OnHand:    inventory.OnHand,
+			Reserved:  inventory.Reserved + req.Quantity,
+			ProductID: req.ProductID,
+		})
+		if err != nil {
+			return dbError(err, "product", req.ProductID)
+		}
+
+		res, err := q.CreateReservation(ctx, tx, repositories.CreateReservationParams{
+			ProductID: req.ProductID,
+			Quantity:  req.Quantity,
+			ExpiresAt: expiresAt,
+			UserID:    sql.NullInt64{Int64: caller.ID, Valid: true},
+		})
+		if err != nil {
+			return dbError(err, "reservation", 0)
+		}
+
+		reservation = helpers.ReservationResponse(res)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (s *SQLService) GetReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	res, err := s.Repo.GetReservation(ctx, s.DB, req.ID)
+	if err != nil {
+		return nil, dbError(err, "reservation", req.ID)
+	}
+
+	if err := authorizeOrderRead(ctx, "reservation", res.ID, res.UserID.Int64); err != nil {
+		return nil, err
+	}
+
+	return helpers.ReservationResponse(res), nil
+}
+
+func (s *SQLService) ReleaseReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	return s.closeReservation(ctx, req.ID, responses.ReservationReleased)
+}
+
+func (s *SQLService) CommitReservation(ctx context.Context, req requests.BindUriID) (*responses.Reservation, error) {
+	return s.closeReservation(ctx, req.ID, responses.ReservationCommitted)
+}
+
+// closeReservation moves an active reservation to status and gives back the
+// units it reserved. The reservation row is locked before the inventory row,
+// the same order ReleaseExpiredReservations uses.
+func (s *SQLService) closeReservation(ctx context.Context, id int64, status responses.ReservationStatus) (*responses.Reservation, error) {
+	var reservation *responses.Reservation
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		res, err := q.CloseReservation(ctx, tx, repositories.CloseReservationParams{Status: string(status), ID: id})
+		if errors.Is(err, sql.ErrNoRows) {
+			current, err := q.GetReservation(ctx, tx, id)
+			if err != nil {
+				return dbError(err, "reservation", id)
+			}
+			if err := authorizeOrder(ctx, "reservation", id, current.UserID.Int64); err != nil {
+				return err
+			}
+			return reservationClosedError(helpers.ReservationResponse(current))
+		}
+		if err != nil {
+			return dbError(err, "reservation", id)
+		}
+
+		// refusing the caller rolls the close back
+		if err := authorizeOrder(ctx, "reservation", id, res.UserID.Int64); err != nil {
+			return err
+		}
+
+		reservation = helpers.ReservationResponse(res)
+		if err := checkCommit(reservation, status, time.Now()); err != nil {
+			return err
+		}
+
+		inventory, err := q.LockInventory(ctx, tx, res.ProductID)
+		if err != nil {
+			return dbError(err, "product", res.ProductID)
+		}
+
+		onHand, reserved := closedStock(inventory.OnHand, inventory.Reserved, res.Quantity, status)
+		_, err = q.SetInventory(ctx, tx, repositories.SetInventoryParams{
+			OnHand:    onHand,
+			Reserved:  reserved,
+			ProductID: res.ProductID,
+		})
+		if err != nil {
+			return dbError(err, "product", res.ProductID)
+		}
+
+		if status != responses.ReservationCommitted {
+			return nil
+		}
+
+		_, err = q.CreateStockAdjustment(ctx, tx, repositories.CreateStockAdjustmentParams{
+			ProductID: res.ProductID,
+			Delta:     -res.Quantity,
+			Reason:    string(requests.StockSold),
+			Note:      commitNote(res.ID),
+		})
+		return dbError(err, "product", res.ProductID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ReleaseExpiredReservations skips the reservations another transaction is
+// closing, they are either gone or picked up by the next sweep.
+func (s *SQLService) ReleaseExpiredReservations(ctx context.Context, req requests.ReleaseExpiredReservationsRequest) (int64, error) {
+	var released int64
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		expired, err := q.ExpireReservations(ctx, tx, req.ExpiredBefore)
+		if err != nil {
+			return dbError(err, "reservation", 0)
+		}
+
+		reservations := make([]*responses.Reservation, len(expired))
+		for i, res := range expired {
+			reservations[i] = helpers.ReservationResponse(res)
+		}
+
+		productIDs, quantities := reservedByProduct(reservations)
+		for _, id := range productIDs {
+			inventory, err := q.LockInventory(ctx, tx, id)
+			if err != nil {
+				return dbError(err, "product", id)
+			}
+
+			_, err = q.SetInventory(ctx, tx, repositories.SetInventoryParams{
+				OnHand:    inventory.OnHand,
+				Reserved:  inventory.Reserved - quantities[id],
+				ProductID: id,
+			})
+			if err != nil {
+				return dbError(err, "product", id)
+			}
+		}
+
+		released = int64(len(expired))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return released, nil
+}
+
+func (s *SQLService) CreateOrder(ctx context.Context, req requests.CreateOrderRequest) (*responses.Order, error) {
+	if err := validateCreateOrder(req); err != nil {
+		return nil, err
+	}
+
+	userID, err := newOrderOwner(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	req.UserID = userID
+
+	var order repositories.Order
+	var items []repositories.OrderItem
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		if _, err := q.GetUser(ctx, tx, req.UserID); errors.Is(err, sql.ErrNoRows) {
+			return NewError(ErrForeignKeyViolation, "user with id %d not found", req.UserID)
+		} else if err != nil {
+			return dbError(err, "user", req.UserID)
+		}
+
+		products := make([]*responses.Product, len(req.Items))
+		for i, item := range req.Items {
+			prod, err := q.GetProduct(ctx, tx, item.ProductID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return productNotOrderableError(item.ProductID)
+			} else if err != nil {
+				return dbError(err, "product", item.ProductID)
+			}
+			products[i] = helpers.ProductResponse(prod)
+		}
+
+		rates, err := q.ListExchangeRates(ctx, tx)
+		if err != nil {
+			return dbError(err, "exchange rate", 0)
+		}
+
+		currency, lines, total, err := s.Currencies.priceOrder(req, products, helpers.ExchangeRateSliceResponse(rates))
+		if err != nil {
+			return err
+		}
+
+		arg := repositories.CreateOrderParams{
+			UserID:   req.UserID,
+			Currency: currency,
+			Total:    total,
+		}
+
+		order, err = q.CreateOrder(ctx, tx, arg)
+		if err != nil {
+			return dbError(err, "order", 0)
+		}
+
+		items = make([]repositories.OrderItem, len(lines))
+		for i, line := range lines {
+			arg := repositories.CreateOrderItemParams{
+				OrderID:     order.ID,
+				ProductID:   nullInt64(&line.productID),
+				ProductName: line.productName,
+				UnitPrice:   line.unitPrice,
+				Quantity:    line.quantity,
+			}
+
+			items[i], err = q.CreateOrderItem(ctx, tx, arg)
+			if err != nil {
+				return dbError(err, "order", order.ID)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.OrderResponse(order, items), nil
+}
+
+func (s *SQLService) GetOrder(ctx context.Context, req requests.BindUriID) (*responses.Order, error) {
+	var order repositories.Order
+	var items []repositories.OrderItem
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err := s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		var err error
+		order, err = q.GetOrder(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "order", req.ID)
+		}
+
+		if err := authorizeOrderRead(ctx, "order", order.ID, order.UserID); err != nil {
+			return err
+		}
+
+		items, err = q.GetOrderItems(ctx, tx, []int64{order.ID})
+		return dbError(err, "order", order.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.OrderResponse(order, items), nil
+}
+
+func (s *SQLService) UpdateOrderStatus(ctx context.Context, req requests.UpdateOrderStatusRequest) (*responses.Order, error) {
+	var order repositories.Order
+	var items []repositories.OrderItem
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		current, err := q.GetOrder(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "order", req.ID)
+		}
+
+		from := requests.OrderStatus(current.Status)
+		if err := checkOrderTransition(current.ID, from, req.Status); err != nil {
+			return err
+		}
+
+		arg := repositories.UpdateOrderStatusParams{
+			Status:     string(req.Status),
+			ID:         current.ID,
+			FromStatus: current.Status,
+		}
+
+		order, err = q.UpdateOrderStatus(ctx, tx, arg)
+		if errors.Is(err, sql.ErrNoRows) {
+			return orderStatusChangedError(current.ID, from)
+		} else if err != nil {
+			return dbError(err, "order", current.ID)
+		}
+
+		items, err = q.GetOrderItems(ctx, tx, []int64{order.ID})
+		return dbError(err, "order", order.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.OrderResponse(order, items), nil
+}
+
+func (s *SQLService) GetUserOrders(ctx context.Context, req requests.GetUserOrdersRequest) (*responses.Orders, error) {
+	page, err := newOrdersPage(req, s.MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeUserOrders(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	var orders []repositories.Order
+	var items []repositories.OrderItem
+	var hasNextPage bool
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err = s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		if _, err := q.GetUser(ctx, tx, req.UserID); err != nil {
+			return dbError(err, "user", req.UserID)
+		}
+
+		// one extra order tells whether there is another page
+		arg := repositories.GetUserOrdersParams{
+			UserID:  req.UserID,
+			AfterID: nullInt64(page.after),
+			First:   int32(page.size + 1),
+		}
+
+		orders, err = q.GetUserOrders(ctx, tx, arg)
+		if err != nil {
+			return dbError(err, "order", 0)
+		}
+
+		hasNextPage = len(orders) > page.size
+		if hasNextPage {
+			orders = orders[:page.size]
+		}
+
+		ids := make([]int64, len(orders))
+		for i, order := range orders {
+			ids[i] = order.ID
+		}
+
+		items, err = q.GetOrderItems(ctx, tx, ids)
+		return dbError(err, "order", 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.OrdersResponse(orders, items, hasNextPage, page.after != nil), nil
+}
+
+func (s *SQLService) StartPayment(ctx context.Context, req requests.StartPaymentRequest) (*responses.Payment, error) {
+	if err := validateStartPayment(req); err != nil {
+		return nil, err
+	}
+
+	caller, err := callerOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount responses.Money
+	var payerID int64
+	opts := s.TxOptions
+	opts.ReadOnly = true
+	err = s.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+		var err error
+		amount, payerID, err = paymentAmount(ctx, q, tx, caller, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the intent is created outside of the transaction, the provider may be
+	// slow and the unique index on order_id still stops double payments
+	intent, err := createIntent(ctx, s.Payments, amount, paymentReference(req))
+	if err != nil {
+		return nil, err
+	}
+
+	arg := repositories.CreatePaymentParams{
+		OrderID:   nullInt64(req.OrderID),
+		ProductID: nullInt64(req.ProductID),
+		Provider:  s.Payments.Name(),
+		IntentID:  intent.ID,
+		Amount:    amount.Amount,
+		Currency:  amount.Currency,
+		UserID:    sql.NullInt64{Int64: payerID, Valid: true},
+	}
+
+	payment, err := s.Repo.CreatePayment(ctx, s.DB, arg)
+	if err != nil {
+		return nil, paymentError(err, arg.OrderID.Int64)
+	}
+
+	res := helpers.PaymentResponse(payment)
+	res.ClientSecret = intent.ClientSecret
+	return res, nil
+}
+
+func (s *SQLService) GetPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	payment, err := s.Repo.GetPayment(ctx, s.DB, req.ID)
+	if err != nil {
+		return nil, dbError(err, "payment", req.ID)
+	}
+
+	if err := authorizeOrderRead(ctx, "payment", payment.ID, payment.UserID.Int64); err != nil {
+		return nil, err
+	}
+
+	return helpers.PaymentResponse(payment), nil
+}
+
+func (s *SQLService) ConfirmPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	var payment *responses.Payment
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		// the payment stays locked until the capture is recorded, concurrent
+		// confirms wait and find it settled
+		locked, err := q.LockPayment(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "payment", req.ID)
+		}
+		payment = helpers.PaymentResponse(locked)
+
+		if err := authorizeOrder(ctx, "payment", locked.ID, locked.UserID.Int64); err != nil {
+			return err
+		}
+
+		capture, err := checkConfirm(payment)
+		if !capture {
+			return err
+		}
+
+		// orders cancelled since the payment started are not charged
+		if payment.OrderID != nil {
+			order, err := q.GetOrder(ctx, tx, *payment.OrderID)
+			if err != nil {
+				return dbError(err, "order", *payment.OrderID)
+			}
+
+			if status := requests.OrderStatus(order.Status); status != requests.OrderPending {
+				return orderNotPayableError(order.ID, status)
+			}
+		}
+
+		status, err := capturePayment(ctx, s.Payments, payment)
+		if err != nil {
+			return err
+		}
+
+		settled, err := movePayment(ctx, q, tx, payment.ID, status)
+		payment = helpers.PaymentResponse(settled)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (s *SQLService) RefundPayment(ctx context.Context, req requests.BindUriID) (*responses.Payment, error) {
+	var payment *responses.Payment
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		// like confirms, the payment stays locked until the refund is
+		// recorded so concurrent refunds find it refunded
+		locked, err := q.LockPayment(ctx, tx, req.ID)
+		if err != nil {
+			return dbError(err, "payment", req.ID)
+		}
+		payment = helpers.PaymentResponse(locked)
+
+		if err := checkRefund(payment); err != nil {
+			return err
+		}
+
+		status, err := refundPayment(ctx, s.Payments, payment)
+		if err != nil {
+			return err
+		}
+
+		settled, err := movePayment(ctx, q, tx, payment.ID, status)
+		payment = helpers.PaymentResponse(settled)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (s *SQLService) HandlePaymentEvent(ctx context.Context, event payments.Event) (*responses.Payment, error) {
+	status := responses.PaymentStatus(event.Status)
+	if err := checkPaymentStatus(status); err != nil {
+		return nil, err
+	}
+
+	if s.Payments == nil {
+		return nil, errNoPaymentProvider
+	}
+
+	arg := repositories.GetPaymentByIntentParams{
+		Provider: s.Payments.Name(),
+		IntentID: event.IntentID,
+	}
+
+	payment, err := s.Repo.GetPaymentByIntent(ctx, s.DB, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, paymentIntentNotFoundError(event.IntentID)
+	} else if err != nil {
+		return nil, dbError(err, "payment", 0)
+	}
+
+	return s.settlePayment(ctx, payment.ID, status)
+}
+
+// settlePayment moves payment id to status. Repeated and stale updates leave
+// the payment as it is, so webhooks can be delivered more than once and in
+// any order. Orders are paid once their payment succeeds.
+func (s *SQLService) settlePayment(ctx context.Context, id int64, status responses.PaymentStatus) (*responses.Payment, error) {
+	var payment repositories.Payment
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) (err error) {
+		payment, err = movePayment(ctx, q, tx, id, status)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.PaymentResponse(payment), nil
+}
+
+// movePayment moves payment id to status inside tx and pays its order when
+// the payment succeeded. Payments that can not move to status are left as
+// they are.
+func movePayment(ctx context.Context, q repositories.Querier, tx repositories.DBTX, id int64, status responses.PaymentStatus) (repositories.Payment, error) {
+	payment, err := q.GetPayment(ctx, tx, id)
+	if err != nil {
+		return payment, dbError(err, "payment", id)
+	}
+
+	from := responses.PaymentStatus(payment.Status)
+	if !canMovePayment(from, status) {
+		return payment, nil
+	}
+
+	arg := repositories.UpdatePaymentStatusParams{
+		Status:     string(status),
+		ID:         id,
+		FromStatus: payment.Status,
+	}
+
+	payment, err = q.UpdatePaymentStatus(ctx, tx, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return payment, paymentStatusChangedError(id, from)
+	} else if err != nil {
+		return payment, dbError(err, "payment", id)
+	}
+
+	if status != responses.PaymentSucceeded || !payment.OrderID.Valid {
+		return payment, nil
+	}
+
+	_, err = q.UpdateOrderStatus(ctx, tx, repositories.UpdateOrderStatusParams{
+		Status:     string(requests.OrderPaid),
+		ID:         payment.OrderID.Int64,
+		FromStatus: string(requests.OrderPending),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return payment, nil
+	}
+	return payment, dbError(err, "order", payment.OrderID.Int64)
+}
+
+func (s *SQLService) Register(ctx context.Context, req requests.RegisterRequest) (*responses.Session, error) {
+	email, err := s.Emails.normalize(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePassword(req.Password); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	refresh, err := newRefreshToken(s.Tokens, now)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var user repositories.User
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		var err error
+		user, err = q.CreateUser(ctx, tx, repositories.CreateUserParams{Name: req.Name, Email: email})
+		if err != nil {
+			return userEmailError(err, email, 0)
+		}
+
+		arg := repositories.SetUserPasswordParams{
+			PasswordHash: nullString(&hash),
+			ID:           user.ID,
+		}
+		if err := q.SetUserPassword(ctx, tx, arg); err != nil {
+			return dbError(err, "user", user.ID)
+		}
+
+		return s.storeRefreshToken(ctx, q, tx, user.ID, refresh)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSession(s.Tokens, helpers.UserResponse(user), refresh, now)
+}
+
+func (s *SQLService) Login(ctx context.Context, req requests.LoginRequest) (*responses.Session, error) {
+	now := time.Now()
+	refresh, err := newRefreshToken(s.Tokens, now)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.Repo.GetUserByEmail(ctx, s.DB, loginEmail(req.Email))
+	if errors.Is(err, sql.ErrNoRows) {
+		user.PasswordHash = sql.NullString{}
+	} else if err != nil {
+		return nil, dbError(err, "user", 0)
+	}
+
+	if err := checkPassword(user.PasswordHash, req.Password); err != nil {
+		return nil, err
+	}
+
+	err = s.storeRefreshToken(ctx, s.Repo, s.DB, user.ID, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.withRoles(ctx, s.Repo, s.DB, helpers.UserResponse(user))
+	if err != nil {
+		return nil, err
+	}
+
+	return newSession(s.Tokens, resp, refresh, now)
+}
+
+func (s *SQLService) RefreshSession(ctx context.Context, req requests.RefreshTokenRequest) (*responses.Session, error) {
+	now := time.Now()
+	refresh, err := newRefreshToken(s.Tokens, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var user repositories.User
+	var reused bool
+	err = s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		token, err := q.GetRefreshToken(ctx, tx, auth.HashRefreshToken(req.RefreshToken))
+		if errors.Is(err, sql.ErrNoRows) {
+			return invalidRefreshTokenError()
+		} else if err != nil {
+			return dbError(err, "refresh token", 0)
+		}
+
+		if !token.ExpiresAt.After(now) {
+			return invalidRefreshTokenError()
+		}
+
+		rows, err := q.RevokeRefreshToken(ctx, tx, token.ID)
+		if err != nil {
+			return dbError(err, "refresh token", token.ID)
+		}
+
+		// a token used twice was stolen, every session of the user ends
+		if rows == 0 {
+			reused = true
+			_, err := q.RevokeUserRefreshTokens(ctx, tx, token.UserID)
+			return dbError(err, "refresh token", 0)
+		}
+
+		user, err = q.GetUser(ctx, tx, token.UserID)
+		if err != nil {
+			return dbError(err, "user", token.UserID)
+		}
+
+		return s.storeRefreshToken(ctx, q, tx, user.ID, refresh)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reused {
+		return nil, invalidRefreshTokenError()
+	}
+
+	resp, err := s.withRoles(ctx, s.Repo, s.DB, helpers.UserResponse(user))
+	if err != nil {
+		return nil, err
+	}
+
+	return newSession(s.Tokens, resp, refresh, now)
+}
+
+func (s *SQLService) Logout(ctx context.Context, req requests.RefreshTokenRequest) error {
+	token, err := s.Repo.GetRefreshToken(ctx, s.DB, auth.HashRefreshToken(req.RefreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return dbError(err, "refresh token", 0)
+	}
+
+	_, err = s.Repo.RevokeRefreshToken(ctx, s.DB, token.ID)
+	return dbError(err, "refresh token", token.ID)
+}
+
+func (s *SQLService) Authenticate(ctx context.Context, token string) (*responses.User, error) {
+	id, err := accessTokenUser(s.Tokens, token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.Repo.GetUser(ctx, s.DB, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, userGoneError()
+	} else if err != nil {
+		return nil, dbError(err, "user", id)
+	}
+
+	return s.withRoles(ctx, s.Repo, s.DB, helpers.UserResponse(user))
+}
+
+func (s *SQLService) ListRoles(ctx context.Context) ([]*responses.Role, error) {
+	roles, err := s.Repo.ListRoles(ctx, s.DB)
+	if err != nil {
+		return nil, dbError(err, "role", 0)
+	}
+
+	grants, err := s.Repo.ListRolePermissions(ctx, s.DB)
+	if err != nil {
+		return nil, dbError(err, "role", 0)
+	}
+
+	return helpers.RoleSliceResponse(roles, grants), nil
+}
+
+func (s *SQLService) GetUserRoles(ctx context.Context, req requests.BindUriID) ([]requests.Role, error) {
+	if _, err := s.Repo.GetUser(ctx, s.DB, req.ID); err != nil {
+		return nil, dbError(err, "user", req.ID)
+	}
+
+	return s.userRoles(ctx, s.Repo, s.DB, req.ID)
+}
+
+func (s *SQLService) AssignRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error) {
+	if err := validateRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	var roles []requests.Role
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		if _, err := q.GetUser(ctx, tx, req.UserID); err != nil {
+			return dbError(err, "user", req.UserID)
+		}
+
+		arg := repositories.AssignRoleParams{UserID: req.UserID, Role: string(req.Role)}
+		if _, err := q.AssignRole(ctx, tx, arg); err != nil {
+			return dbError(err, "user", req.UserID)
+		}
+
+		var err error
+		roles, err = s.userRoles(ctx, q, tx, req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (s *SQLService) RevokeRole(ctx context.Context, req requests.UserRoleRequest) ([]requests.Role, error) {
+	if err := validateRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	var roles []requests.Role
+	err := s.WithTx(ctx, func(q repositories.Querier, tx repositories.DBTX) error {
+		if _, err := q.GetUser(ctx, tx, req.UserID); err != nil {
+			return dbError(err, "user", req.UserID)
+		}
+
+		arg := repositories.RevokeRoleParams{UserID: req.UserID, Role: string(req.Role)}
+		rows, err := q.RevokeRole(ctx, tx, arg)
+		if err != nil {
+			return dbError(err, "user", req.UserID)
+		}
+
+		if rows > 0 && req.Role == requests.RoleAdmin {
+			admins, err := q.CountRoleUsers(ctx, tx, string(requests.RoleAdmin))
+			if err != nil {
+				return dbError(err, "role", 0)
+			}
+			if admins == 0 {
+				return lastAdminError()
+			}
+		}
+
+		roles, err = s.userRoles(ctx, q, tx, req.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (s *SQLService) CreateAPIKey(ctx context.Context, req requests.CreateAPIKeyRequest) (*responses.NewAPIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, scopes, err := validateAPIKey(req.Name, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := apiKeyExpiry(req.ExpiresAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	arg := repositories.CreateAPIKeyParams{
+		UserID:    caller.ID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+
+	created, err := s.Repo.CreateAPIKey(ctx, s.DB, arg)
+	if err != nil {
+		return nil, dbError(err, "API key", 0)
+	}
+
+	return &responses.NewAPIKey{APIKey: helpers.APIKeyResponse(created), Key: key}, nil
+}
+
+func (s *SQLService) ListAPIKeys(ctx context.Context) ([]*responses.APIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.Repo.ListUserAPIKeys(ctx, s.DB, caller.ID)
+	if err != nil {
+		return nil, dbError(err, "API key", 0)
+	}
+
+	return helpers.APIKeySliceResponse(keys), nil
+}
+
+func (s *SQLService) GetAPIKey(ctx context.Context, req requests.BindUriID) (*responses.APIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := repositories.GetUserAPIKeyParams{ID: req.ID, UserID: caller.ID}
+	key, err := s.Repo.GetUserAPIKey(ctx, s.DB, arg)
+	if err != nil {
+		return nil, dbError(err, "API key", req.ID)
+	}
+
+	return helpers.APIKeyResponse(key), nil
+}
+
+func (s *SQLService) UpdateAPIKey(ctx context.Context, req requests.UpdateAPIKeyRequest) (*responses.APIKey, error) {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, scopes, err := validateAPIKey(req.Name, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := repositories.UpdateAPIKeyParams{Name: name, Scopes: scopes, ID: req.ID, UserID: caller.ID}
+	key, err := s.Repo.UpdateAPIKey(ctx, s.DB, arg)
+	if err != nil {
+		return nil, dbError(err, "API key", req.ID)
+	}
+
+	return helpers.APIKeyResponse(key), nil
+}
+
+func (s *SQLService) DeleteAPIKey(ctx context.Context, req requests.BindUriID) error {
+	caller, err := keyOwner(ctx)
+	if err != nil {
+		return err
+	}
+
+	arg := repositories.DeleteAPIKeyParams{ID: req.ID, UserID: caller.ID}
+	rows, err := s.Repo.DeleteAPIKey(ctx, s.DB, arg)
+	if err != nil {
+		return dbError(err, "API key", req.ID)
+	}
+
+	if rows == 0 {
+		return NotFoundError("API key with id %d not found", req.ID)
+	}
+
+	return nil
+}
+
+func (s *SQLService) AuthenticateAPIKey(ctx context.Context, key string) (*responses.User, *responses.APIKey, error) {
+	prefix, err := apiKeyPrefix(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored, err := s.Repo.GetAPIKeyByPrefix(ctx, s.DB, prefix)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, invalidAPIKeyError()
+	} else if err != nil {
+		return nil, nil, dbError(err, "API key", 0)
+	}
+
+	now := time.Now().UTC()
+	if err := checkAPIKey(key, stored.KeyHash, stored.ExpiresAt, now); err != nil {
+		return nil, nil, err
+	}
+
+	if staleAPIKey(stored.LastUsedAt, now) {
+		stored.LastUsedAt = sql.NullTime{Time: now, Valid: true}
+		arg := repositories.TouchAPIKeyParams{LastUsedAt: stored.LastUsedAt, ID: stored.ID}
+		if err := s.Repo.TouchAPIKey(ctx, s.DB, arg); err != nil {
+			return nil, nil, dbError(err, "API key", stored.ID)
+		}
+	}
+
+	user, err := s.Repo.GetUser(ctx, s.DB, stored.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, userGoneError()
+	} else if err != nil {
+		return nil, nil, dbError(err, "user", stored.UserID)
+	}
+
+	resp, err := s.withRoles(ctx, s.Repo, s.DB, helpers.UserResponse(user))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, helpers.APIKeyResponse(stored), nil
+}
+
+func (s *SQLService) storeRefreshToken(ctx context.Context, q repositories.Querier, db repositories.DBTX, userID int64, refresh refreshToken) error {
+	arg := repositories.CreateRefreshTokenParams{
+		UserID:    userID,
+		TokenHash: refresh.hash,
+		ExpiresAt: refresh.expiresAt,
+	}
+
+	_, err := q.CreateRefreshToken(ctx, db, arg)
+	return dbError(err, "refresh token", 0)
+}
+
+// userRoles returns the roles of a user in the order of their ids.
+func (s *SQLService) userRoles(ctx context.Context, q repositories.Querier, db repositories.DBTX, userID int64) ([]requests.Role, error) {
+	names, err := q.GetUserRoles(ctx, db, userID)
+	if err != nil {
+		return nil, dbError(err, "user", userID)
+	}
+
+	return roleNames(names), nil
+}
+
+// withRoles loads the roles of user and the permissions they grant.
+func (s *SQLService) withRoles(ctx context.Context, q repositories.Querier, db repositories.DBTX, user *responses.User) (*responses.User, error) {
+	roles, err := s.userRoles(ctx, q, db, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := q.GetUserPermissions(ctx, db, user.ID)
+	if err != nil {
+		return nil, dbError(err, "user", user.ID)
+	}
+
+	user.Roles = roles
+	user.Permissions = permissions
+	return user, nil
+}
+
+// inventoryTxOptions are the options of transactions changing stock through
+// lockInventory. The row lock already orders them, at read committed one
+// waiting for the lock reads the stock the other committed instead of
+// failing with a serialization error.
+func (s *SQLService) inventoryTxOptions() TxOptions {
+	opts := s.TxOptions
+	opts.Isolation = sql.LevelReadCommitted
+	return opts
+}
+
+// lockInventory locks the inventory row of a live product until the
+// transaction ends, the row is created first for products that never had
+// stock.
+func lockInventory(ctx context.Context, q repositories.Querier, tx repositories.DBTX, productID int64) (repositories.Inventory, error) {
+	if _, err := q.GetProduct(ctx, tx, productID); err != nil {
+		return repositories.Inventory{}, dbError(err, "product", productID)
+	}
+
+	if err := q.EnsureInventory(ctx, tx, productID); err != nil {
+		return repositories.Inventory{}, dbError(err, "product", productID)
+	}
+
+	inventory, err := q.LockInventory(ctx, tx, productID)
+	if err != nil {
+		return repositories.Inventory{}, dbError(err, "product", productID)
+	}
+
+	return inventory, nil
+}
+
+func productStock(ctx context.Context, q repositories.Querier, db repositories.DBTX, productID int64) (*responses.Stock, error) {
+	inventory, err := q.GetBatchInventory(ctx, db, []int64{productID})
+	if err != nil {
+		return nil, dbError(err, "product", productID)
+	}
+
+	if len(inventory) == 0 {
+		return &responses.Stock{ProductID: productID}, nil
+	}
+
+	return helpers.StockResponse(inventory[0]), nil
+}
+
+// paymentAmount returns what the target of req costs and the user paying it:
+// the owner of the order, or caller for products. Only pending orders and live
+// products can be paid.
+func paymentAmount(ctx context.Context, q repositories.Querier, tx repositories.DBTX, caller *responses.User, req requests.StartPaymentRequest) (responses.Money, int64, error) {
+	if req.OrderID == nil {
+		prod, err := q.GetProduct(ctx, tx, *req.ProductID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return responses.Money{}, 0, productNotOrderableError(*req.ProductID)
+		} else if err != nil {
+			return responses.Money{}, 0, dbError(err, "product", *req.ProductID)
+		}
+
+		return responses.Money{Amount: prod.Price, Currency: prod.Currency}, caller.ID, nil
+	}
+
+	order, err := q.GetOrder(ctx, tx, *req.OrderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return responses.Money{}, 0, NewError(ErrForeignKeyViolation, "order with id %d not found", *req.OrderID)
+	} else if err != nil {
+		return responses.Money{}, 0, dbError(err, "order", *req.OrderID)
+	}
+
+	if err := checkOrderOwner(caller, "order", order.ID, order.UserID); err != nil {
+		return responses.Money{}, 0, err
+	}
+
+	if status := requests.OrderStatus(order.Status); status != requests.OrderPending {
+		return responses.Money{}, 0, orderNotPayableError(order.ID, status)
+	}
+
+	return responses.Money{Amount: order.Total, Currency: order.Currency}, order.UserID, nil
+}
+
+func productCategories(productIDs []int64, rows []repositories.GetBatchProductCategoriesRow) [][]*responses.Category {
+	return groupByProduct(productIDs, rows, func(r repositories.GetBatchProductCategoriesRow) (int64, *responses.Category) {
+		return r.ProductID, helpers.CategoryResponse(r)
+	})
+}
+
+func productTags(productIDs []int64, rows []repositories.GetBatchProductTagsRow) [][]*responses.Tag {
+	return groupByProduct(productIDs, rows, func(r repositories.GetBatchProductTagsRow) (int64, *responses.Tag) {
+		return r.ProductID, helpers.TagResponse(r)
+	})
+}
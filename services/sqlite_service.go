@@ -0,0 +1,814 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sqlc-rest-api/db/postgres/repositories"
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+	"time"
+
+	sqliterepo "sqlc-rest-api/db/sqlite/repositories"
+)
+
+// NewSqliteService returns a SQLService storing everything in sqlite. Sqlite
+// transactions are always serializable, the isolation levels of TxOptions
+// are ignored and nothing is retried.
+func NewSqliteService(db *sql.DB, sqliteRepo sqliterepo.Querier) *SQLService {
+	return NewPostgresService(db, sqliteQuerier{repo: sqliteRepo})
+}
+
+// sqliteQuerier runs the postgres queries on sqlite. Most of them map one to
+// one, sqlite has no array parameters so lists are passed as json arrays,
+// and the queries postgres answers with features sqlite lacks are emulated.
+type sqliteQuerier struct {
+	repo sqliterepo.Querier
+}
+
+var _ repositories.Querier = sqliteQuerier{}
+
+func (q sqliteQuerier) AddProductCategories(ctx context.Context, db repositories.DBTX, arg repositories.AddProductCategoriesParams) error {
+	categoryIDs, err := jsonArray(arg.CategoryIds)
+	if err != nil {
+		return err
+	}
+
+	return q.repo.AddProductCategories(ctx, db, sqliterepo.AddProductCategoriesParams{
+		ProductID:   arg.ProductID,
+		CategoryIds: categoryIDs,
+	})
+}
+
+func (q sqliteQuerier) AddProductTags(ctx context.Context, db repositories.DBTX, arg repositories.AddProductTagsParams) error {
+	tagIDs, err := jsonArray(arg.TagIds)
+	if err != nil {
+		return err
+	}
+
+	return q.repo.AddProductTags(ctx, db, sqliterepo.AddProductTagsParams{
+		ProductID: arg.ProductID,
+		TagIds:    tagIDs,
+	})
+}
+
+func (q sqliteQuerier) AssignRole(ctx context.Context, db repositories.DBTX, arg repositories.AssignRoleParams) (int64, error) {
+	return q.repo.AssignRole(ctx, db, sqliterepo.AssignRoleParams(arg))
+}
+
+// BulkCreateProducts passes the products as one json array of objects
+// instead of one array per column.
+func (q sqliteQuerier) BulkCreateProducts(ctx context.Context, db repositories.DBTX, arg repositories.BulkCreateProductsParams) ([]repositories.Product, error) {
+	products := make([]requests.CreateProductRequest, len(arg.UserIds))
+	for i := range products {
+		products[i] = requests.CreateProductRequest{
+			UserID:   arg.UserIds[i],
+			Name:     arg.Names[i],
+			Price:    arg.Prices[i],
+			Currency: arg.Currencies[i],
+		}
+	}
+
+	encoded, err := jsonArray(products)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.BulkCreateProducts(ctx, db, encoded)
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) BulkDeleteProducts(ctx context.Context, db repositories.DBTX, ids []int64) ([]int64, error) {
+	encoded, err := jsonArray(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.repo.BulkDeleteProducts(ctx, db, encoded)
+}
+
+func (q sqliteQuerier) BulkSoftDeleteProducts(ctx context.Context, db repositories.DBTX, ids []int64) ([]int64, error) {
+	encoded, err := jsonArray(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.repo.BulkSoftDeleteProducts(ctx, db, encoded)
+}
+
+func (q sqliteQuerier) CloseReservation(ctx context.Context, db repositories.DBTX, arg repositories.CloseReservationParams) (repositories.Reservation, error) {
+	row, err := q.repo.CloseReservation(ctx, db, sqliterepo.CloseReservationParams(arg))
+	return repositories.Reservation(row), err
+}
+
+func (q sqliteQuerier) CountChildCategories(ctx context.Context, db repositories.DBTX, parentID sql.NullInt64) (int64, error) {
+	return q.repo.CountChildCategories(ctx, db, parentID)
+}
+
+func (q sqliteQuerier) CountDeletedProducts(ctx context.Context, db repositories.DBTX, userID sql.NullInt64) (int64, error) {
+	return q.repo.CountDeletedProducts(ctx, db, userID)
+}
+
+func (q sqliteQuerier) CountProducts(ctx context.Context, db repositories.DBTX, arg repositories.CountProductsParams) (int64, error) {
+	return q.repo.CountProducts(ctx, db, sqliterepo.CountProductsParams{
+		UserID:        arg.UserID,
+		Name:          arg.Name,
+		MinPrice:      arg.MinPrice,
+		MaxPrice:      arg.MaxPrice,
+		CreatedAfter:  arg.CreatedAfter,
+		CreatedBefore: arg.CreatedBefore,
+	})
+}
+
+func (q sqliteQuerier) CountRoleUsers(ctx context.Context, db repositories.DBTX, name string) (int64, error) {
+	return q.repo.CountRoleUsers(ctx, db, name)
+}
+
+func (q sqliteQuerier) CountUserOrders(ctx context.Context, db repositories.DBTX, userID int64) (int64, error) {
+	return q.repo.CountUserOrders(ctx, db, userID)
+}
+
+func (q sqliteQuerier) CountUserProducts(ctx context.Context, db repositories.DBTX, userID int64) (int64, error) {
+	return q.repo.CountUserProducts(ctx, db, userID)
+}
+
+func (q sqliteQuerier) CreateAPIKey(ctx context.Context, db repositories.DBTX, arg repositories.CreateAPIKeyParams) (repositories.APIKey, error) {
+	row, err := q.repo.CreateAPIKey(ctx, db, sqliterepo.CreateAPIKeyParams(arg))
+	return repositories.APIKey(row), err
+}
+
+func (q sqliteQuerier) CreateCategory(ctx context.Context, db repositories.DBTX, arg repositories.CreateCategoryParams) (repositories.Category, error) {
+	row, err := q.repo.CreateCategory(ctx, db, sqliterepo.CreateCategoryParams(arg))
+	return repositories.Category(row), err
+}
+
+func (q sqliteQuerier) CreateOrder(ctx context.Context, db repositories.DBTX, arg repositories.CreateOrderParams) (repositories.Order, error) {
+	row, err := q.repo.CreateOrder(ctx, db, sqliterepo.CreateOrderParams(arg))
+	return repositories.Order(row), err
+}
+
+func (q sqliteQuerier) CreateOrderItem(ctx context.Context, db repositories.DBTX, arg repositories.CreateOrderItemParams) (repositories.OrderItem, error) {
+	row, err := q.repo.CreateOrderItem(ctx, db, sqliterepo.CreateOrderItemParams(arg))
+	return repositories.OrderItem(row), err
+}
+
+func (q sqliteQuerier) CreatePayment(ctx context.Context, db repositories.DBTX, arg repositories.CreatePaymentParams) (repositories.Payment, error) {
+	row, err := q.repo.CreatePayment(ctx, db, sqliterepo.CreatePaymentParams(arg))
+	return repositories.Payment(row), err
+}
+
+func (q sqliteQuerier) CreateProduct(ctx context.Context, db repositories.DBTX, arg repositories.CreateProductParams) (repositories.Product, error) {
+	row, err := q.repo.CreateProduct(ctx, db, sqliterepo.CreateProductParams(arg))
+	return postgresProduct(row), err
+}
+
+func (q sqliteQuerier) CreateRefreshToken(ctx context.Context, db repositories.DBTX, arg repositories.CreateRefreshTokenParams) (repositories.RefreshToken, error) {
+	row, err := q.repo.CreateRefreshToken(ctx, db, sqliterepo.CreateRefreshTokenParams(arg))
+	return repositories.RefreshToken(row), err
+}
+
+func (q sqliteQuerier) CreateReservation(ctx context.Context, db repositories.DBTX, arg repositories.CreateReservationParams) (repositories.Reservation, error) {
+	row, err := q.repo.CreateReservation(ctx, db, sqliterepo.CreateReservationParams{
+		ProductID: arg.ProductID,
+		Quantity:  arg.Quantity,
+		ExpiresAt: arg.ExpiresAt.UTC(),
+		UserID:    arg.UserID,
+	})
+	return repositories.Reservation(row), err
+}
+
+func (q sqliteQuerier) CreateStockAdjustment(ctx context.Context, db repositories.DBTX, arg repositories.CreateStockAdjustmentParams) (repositories.StockAdjustment, error) {
+	row, err := q.repo.CreateStockAdjustment(ctx, db, sqliterepo.CreateStockAdjustmentParams(arg))
+	return repositories.StockAdjustment(row), err
+}
+
+func (q sqliteQuerier) CreateTag(ctx context.Context, db repositories.DBTX, name string) (repositories.Tag, error) {
+	row, err := q.repo.CreateTag(ctx, db, name)
+	return repositories.Tag(row), err
+}
+
+func (q sqliteQuerier) CreateUser(ctx context.Context, db repositories.DBTX, arg repositories.CreateUserParams) (repositories.User, error) {
+	row, err := q.repo.CreateUser(ctx, db, sqliterepo.CreateUserParams(arg))
+	return repositories.User(row), err
+}
+
+func (q sqliteQuerier) DeleteAPIKey(ctx context.Context, db repositories.DBTX, arg repositories.DeleteAPIKeyParams) (int64, error) {
+	return q.repo.DeleteAPIKey(ctx, db, sqliterepo.DeleteAPIKeyParams(arg))
+}
+
+func (q sqliteQuerier) DeleteCategory(ctx context.Context, db repositories.DBTX, id int64) (int64, error) {
+	return q.repo.DeleteCategory(ctx, db, id)
+}
+
+func (q sqliteQuerier) DeleteProduct(ctx context.Context, db repositories.DBTX, id int64) (int64, error) {
+	return q.repo.DeleteProduct(ctx, db, id)
+}
+
+func (q sqliteQuerier) DeleteProductCategories(ctx context.Context, db repositories.DBTX, productID int64) error {
+	return q.repo.DeleteProductCategories(ctx, db, productID)
+}
+
+func (q sqliteQuerier) DeleteProductTags(ctx context.Context, db repositories.DBTX, productID int64) error {
+	return q.repo.DeleteProductTags(ctx, db, productID)
+}
+
+func (q sqliteQuerier) DeleteTag(ctx context.Context, db repositories.DBTX, id int64) (int64, error) {
+	return q.repo.DeleteTag(ctx, db, id)
+}
+
+func (q sqliteQuerier) DeleteUser(ctx context.Context, db repositories.DBTX, id int64) (int64, error) {
+	return q.repo.DeleteUser(ctx, db, id)
+}
+
+func (q sqliteQuerier) DeleteUserProducts(ctx context.Context, db repositories.DBTX, userID int64) (int64, error) {
+	return q.repo.DeleteUserProducts(ctx, db, userID)
+}
+
+func (q sqliteQuerier) EnsureInventory(ctx context.Context, db repositories.DBTX, productID int64) error {
+	return q.repo.EnsureInventory(ctx, db, productID)
+}
+
+func (q sqliteQuerier) ExpireReservations(ctx context.Context, db repositories.DBTX, expiredBefore time.Time) ([]repositories.Reservation, error) {
+	rows, err := q.repo.ExpireReservations(ctx, db, expiredBefore.UTC())
+	return convertRows(rows, err, func(row sqliterepo.Reservation) repositories.Reservation { return repositories.Reservation(row) })
+}
+
+func (q sqliteQuerier) GetAPIKeyByPrefix(ctx context.Context, db repositories.DBTX, prefix string) (repositories.APIKey, error) {
+	row, err := q.repo.GetAPIKeyByPrefix(ctx, db, prefix)
+	return repositories.APIKey(row), err
+}
+
+func (q sqliteQuerier) GetBatchCategories(ctx context.Context, db repositories.DBTX, ids []int64) ([]repositories.Category, error) {
+	encoded, err := jsonArray(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchCategories(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.Category) repositories.Category { return repositories.Category(row) })
+}
+
+func (q sqliteQuerier) GetBatchInventory(ctx context.Context, db repositories.DBTX, productIds []int64) ([]repositories.Inventory, error) {
+	encoded, err := jsonArray(productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchInventory(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.Inventory) repositories.Inventory { return repositories.Inventory(row) })
+}
+
+func (q sqliteQuerier) GetBatchProductCategories(ctx context.Context, db repositories.DBTX, productIds []int64) ([]repositories.GetBatchProductCategoriesRow, error) {
+	encoded, err := jsonArray(productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchProductCategories(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.GetBatchProductCategoriesRow) repositories.GetBatchProductCategoriesRow {
+		return repositories.GetBatchProductCategoriesRow(row)
+	})
+}
+
+func (q sqliteQuerier) GetBatchProductTags(ctx context.Context, db repositories.DBTX, productIds []int64) ([]repositories.GetBatchProductTagsRow, error) {
+	encoded, err := jsonArray(productIds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchProductTags(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.GetBatchProductTagsRow) repositories.GetBatchProductTagsRow {
+		return repositories.GetBatchProductTagsRow(row)
+	})
+}
+
+func (q sqliteQuerier) GetBatchProducts(ctx context.Context, db repositories.DBTX, ids []int64) ([]repositories.Product, error) {
+	encoded, err := jsonArray(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchProducts(ctx, db, encoded)
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) GetBatchUserProducts(ctx context.Context, db repositories.DBTX, arg repositories.GetBatchUserProductsParams) ([]repositories.Product, error) {
+	userIDs, err := jsonArray(arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchUserProducts(ctx, db, sqliterepo.GetBatchUserProductsParams{
+		UserIds:        userIDs,
+		CategoryID:     arg.CategoryID,
+		Tag:            arg.Tag,
+		AfterCreatedAt: arg.AfterCreatedAt,
+		AfterID:        arg.AfterID,
+		First:          arg.First,
+	})
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) GetBatchUserProductsBefore(ctx context.Context, db repositories.DBTX, arg repositories.GetBatchUserProductsBeforeParams) ([]repositories.Product, error) {
+	userIDs, err := jsonArray(arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchUserProductsBefore(ctx, db, sqliterepo.GetBatchUserProductsBeforeParams{
+		UserIds:         userIDs,
+		CategoryID:      arg.CategoryID,
+		Tag:             arg.Tag,
+		BeforeCreatedAt: arg.BeforeCreatedAt,
+		BeforeID:        arg.BeforeID,
+		Last:            arg.Last,
+	})
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) GetBatchUsers(ctx context.Context, db repositories.DBTX, ids []int64) ([]repositories.User, error) {
+	encoded, err := jsonArray(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetBatchUsers(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.User) repositories.User { return repositories.User(row) })
+}
+
+func (q sqliteQuerier) GetCategory(ctx context.Context, db repositories.DBTX, id int64) (repositories.Category, error) {
+	row, err := q.repo.GetCategory(ctx, db, id)
+	return repositories.Category(row), err
+}
+
+func (q sqliteQuerier) GetCategoryAncestors(ctx context.Context, db repositories.DBTX, id int64) ([]int64, error) {
+	return q.repo.GetCategoryAncestors(ctx, db, id)
+}
+
+func (q sqliteQuerier) GetOrder(ctx context.Context, db repositories.DBTX, id int64) (repositories.Order, error) {
+	row, err := q.repo.GetOrder(ctx, db, id)
+	return repositories.Order(row), err
+}
+
+func (q sqliteQuerier) GetOrderItems(ctx context.Context, db repositories.DBTX, orderIds []int64) ([]repositories.OrderItem, error) {
+	encoded, err := jsonArray(orderIds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetOrderItems(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.OrderItem) repositories.OrderItem { return repositories.OrderItem(row) })
+}
+
+func (q sqliteQuerier) GetPayment(ctx context.Context, db repositories.DBTX, id int64) (repositories.Payment, error) {
+	row, err := q.repo.GetPayment(ctx, db, id)
+	return repositories.Payment(row), err
+}
+
+func (q sqliteQuerier) GetPaymentByIntent(ctx context.Context, db repositories.DBTX, arg repositories.GetPaymentByIntentParams) (repositories.Payment, error) {
+	row, err := q.repo.GetPaymentByIntent(ctx, db, sqliterepo.GetPaymentByIntentParams(arg))
+	return repositories.Payment(row), err
+}
+
+func (q sqliteQuerier) GetProduct(ctx context.Context, db repositories.DBTX, id int64) (repositories.Product, error) {
+	row, err := q.repo.GetProduct(ctx, db, id)
+	return postgresProduct(row), err
+}
+
+func (q sqliteQuerier) GetProductOwners(ctx context.Context, db repositories.DBTX, ids []int64) ([]repositories.GetProductOwnersRow, error) {
+	encoded, err := jsonArray(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.GetProductOwners(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.GetProductOwnersRow) repositories.GetProductOwnersRow {
+		return repositories.GetProductOwnersRow(row)
+	})
+}
+
+func (q sqliteQuerier) GetRefreshToken(ctx context.Context, db repositories.DBTX, tokenHash string) (repositories.RefreshToken, error) {
+	row, err := q.repo.GetRefreshToken(ctx, db, tokenHash)
+	return repositories.RefreshToken(row), err
+}
+
+func (q sqliteQuerier) GetReservation(ctx context.Context, db repositories.DBTX, id int64) (repositories.Reservation, error) {
+	row, err := q.repo.GetReservation(ctx, db, id)
+	return repositories.Reservation(row), err
+}
+
+func (q sqliteQuerier) GetUser(ctx context.Context, db repositories.DBTX, id int64) (repositories.User, error) {
+	row, err := q.repo.GetUser(ctx, db, id)
+	return repositories.User(row), err
+}
+
+func (q sqliteQuerier) GetUserAPIKey(ctx context.Context, db repositories.DBTX, arg repositories.GetUserAPIKeyParams) (repositories.APIKey, error) {
+	row, err := q.repo.GetUserAPIKey(ctx, db, sqliterepo.GetUserAPIKeyParams(arg))
+	return repositories.APIKey(row), err
+}
+
+func (q sqliteQuerier) GetUserByEmail(ctx context.Context, db repositories.DBTX, email string) (repositories.User, error) {
+	row, err := q.repo.GetUserByEmail(ctx, db, email)
+	return repositories.User(row), err
+}
+
+func (q sqliteQuerier) GetUserOrders(ctx context.Context, db repositories.DBTX, arg repositories.GetUserOrdersParams) ([]repositories.Order, error) {
+	rows, err := q.repo.GetUserOrders(ctx, db, sqliterepo.GetUserOrdersParams{
+		UserID:  arg.UserID,
+		AfterID: arg.AfterID,
+		First:   int64(arg.First),
+	})
+	return convertRows(rows, err, func(row sqliterepo.Order) repositories.Order { return repositories.Order(row) })
+}
+
+func (q sqliteQuerier) GetUserPermissions(ctx context.Context, db repositories.DBTX, userID int64) ([]string, error) {
+	return q.repo.GetUserPermissions(ctx, db, userID)
+}
+
+func (q sqliteQuerier) GetUserProducts(ctx context.Context, db repositories.DBTX, arg repositories.GetUserProductsParams) ([]repositories.Product, error) {
+	rows, err := q.repo.GetUserProducts(ctx, db, sqliterepo.GetUserProductsParams{
+		UserID:         arg.UserID,
+		CategoryID:     arg.CategoryID,
+		Tag:            arg.Tag,
+		AfterCreatedAt: arg.AfterCreatedAt,
+		AfterID:        arg.AfterID,
+		First:          int64(arg.First),
+	})
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) GetUserProductsBefore(ctx context.Context, db repositories.DBTX, arg repositories.GetUserProductsBeforeParams) ([]repositories.Product, error) {
+	rows, err := q.repo.GetUserProductsBefore(ctx, db, sqliterepo.GetUserProductsBeforeParams{
+		UserID:          arg.UserID,
+		CategoryID:      arg.CategoryID,
+		Tag:             arg.Tag,
+		BeforeCreatedAt: arg.BeforeCreatedAt,
+		BeforeID:        arg.BeforeID,
+		Last:            int64(arg.Last),
+	})
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) GetUserRoles(ctx context.Context, db repositories.DBTX, userID int64) ([]string, error) {
+	return q.repo.GetUserRoles(ctx, db, userID)
+}
+
+func (q sqliteQuerier) ListCategories(ctx context.Context, db repositories.DBTX, parentID sql.NullInt64) ([]repositories.Category, error) {
+	rows, err := q.repo.ListCategories(ctx, db, parentID)
+	return convertRows(rows, err, func(row sqliterepo.Category) repositories.Category { return repositories.Category(row) })
+}
+
+func (q sqliteQuerier) ListDeletedProducts(ctx context.Context, db repositories.DBTX, arg repositories.ListDeletedProductsParams) ([]repositories.Product, error) {
+	rows, err := q.repo.ListDeletedProducts(ctx, db, sqliterepo.ListDeletedProductsParams{
+		UserID: arg.UserID,
+		Limit:  int64(arg.Limit),
+		Offset: int64(arg.Offset),
+	})
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) ListExchangeRates(ctx context.Context, db repositories.DBTX) ([]repositories.ExchangeRate, error) {
+	rows, err := q.repo.ListExchangeRates(ctx, db)
+	return convertRows(rows, err, func(row sqliterepo.ExchangeRate) repositories.ExchangeRate { return repositories.ExchangeRate(row) })
+}
+
+func (q sqliteQuerier) ListProducts(ctx context.Context, db repositories.DBTX, arg repositories.ListProductsParams) ([]repositories.Product, error) {
+	rows, err := q.repo.ListProducts(ctx, db, sqliterepo.ListProductsParams{
+		UserID:        arg.UserID,
+		Name:          arg.Name,
+		MinPrice:      arg.MinPrice,
+		MaxPrice:      arg.MaxPrice,
+		CreatedAfter:  arg.CreatedAfter,
+		CreatedBefore: arg.CreatedBefore,
+		OrderBy:       arg.OrderBy,
+		SortDesc:      arg.SortDesc,
+		Limit:         int64(arg.Limit),
+		Offset:        int64(arg.Offset),
+	})
+	return convertRows(rows, err, postgresProduct)
+}
+
+func (q sqliteQuerier) ListRolePermissions(ctx context.Context, db repositories.DBTX) ([]repositories.ListRolePermissionsRow, error) {
+	rows, err := q.repo.ListRolePermissions(ctx, db)
+	return convertRows(rows, err, func(row sqliterepo.ListRolePermissionsRow) repositories.ListRolePermissionsRow {
+		return repositories.ListRolePermissionsRow(row)
+	})
+}
+
+func (q sqliteQuerier) ListRoles(ctx context.Context, db repositories.DBTX) ([]repositories.Role, error) {
+	rows, err := q.repo.ListRoles(ctx, db)
+	return convertRows(rows, err, func(row sqliterepo.Role) repositories.Role { return repositories.Role(row) })
+}
+
+func (q sqliteQuerier) ListStockAdjustments(ctx context.Context, db repositories.DBTX, arg repositories.ListStockAdjustmentsParams) ([]repositories.StockAdjustment, error) {
+	rows, err := q.repo.ListStockAdjustments(ctx, db, sqliterepo.ListStockAdjustmentsParams{
+		ProductID: arg.ProductID,
+		Limit:     int64(arg.Limit),
+	})
+	return convertRows(rows, err, func(row sqliterepo.StockAdjustment) repositories.StockAdjustment {
+		return repositories.StockAdjustment(row)
+	})
+}
+
+func (q sqliteQuerier) ListTags(ctx context.Context, db repositories.DBTX) ([]repositories.Tag, error) {
+	rows, err := q.repo.ListTags(ctx, db)
+	return convertRows(rows, err, func(row sqliterepo.Tag) repositories.Tag { return repositories.Tag(row) })
+}
+
+func (q sqliteQuerier) ListUserAPIKeys(ctx context.Context, db repositories.DBTX, userID int64) ([]repositories.APIKey, error) {
+	rows, err := q.repo.ListUserAPIKeys(ctx, db, userID)
+	return convertRows(rows, err, func(row sqliterepo.APIKey) repositories.APIKey { return repositories.APIKey(row) })
+}
+
+func (q sqliteQuerier) ListUsers(ctx context.Context, db repositories.DBTX, arg repositories.ListUsersParams) ([]repositories.User, error) {
+	rows, err := q.repo.ListUsers(ctx, db, sqliterepo.ListUsersParams{
+		AfterID: arg.AfterID,
+		First:   int64(arg.First),
+	})
+	return convertRows(rows, err, func(row sqliterepo.User) repositories.User { return repositories.User(row) })
+}
+
+// LockInventory reads the inventory row, sqlite has no row locks. The write
+// lock a transaction takes keeps other writers out until it ends,
+// transactions take it when they begin, see drivers.Sqlite, so reading the
+// product first never has to upgrade a read lock another writer blocks.
+func (q sqliteQuerier) LockInventory(ctx context.Context, db repositories.DBTX, productID int64) (repositories.Inventory, error) {
+	row, err := q.repo.GetInventory(ctx, db, productID)
+	return repositories.Inventory(row), err
+}
+
+func (q sqliteQuerier) LockPayment(ctx context.Context, db repositories.DBTX, id int64) (repositories.Payment, error) {
+	row, err := q.repo.LockPayment(ctx, db, id)
+	return repositories.Payment(row), err
+}
+
+func (q sqliteQuerier) PatchProduct(ctx context.Context, db repositories.DBTX, arg repositories.PatchProductParams) (repositories.Product, error) {
+	row, err := q.repo.PatchProduct(ctx, db, sqliterepo.PatchProductParams{
+		Name:            arg.Name,
+		Price:           arg.Price,
+		Currency:        arg.Currency,
+		ID:              arg.ID,
+		ExpectedVersion: arg.ExpectedVersion,
+	})
+	return postgresProduct(row), err
+}
+
+func (q sqliteQuerier) PatchUser(ctx context.Context, db repositories.DBTX, arg repositories.PatchUserParams) (repositories.User, error) {
+	row, err := q.repo.PatchUser(ctx, db, sqliterepo.PatchUserParams{
+		Name:            arg.Name,
+		Email:           arg.Email,
+		ID:              arg.ID,
+		ExpectedVersion: arg.ExpectedVersion,
+	})
+	return repositories.User(row), err
+}
+
+func (q sqliteQuerier) PurgeDeletedProducts(ctx context.Context, db repositories.DBTX, deletedBefore time.Time) (int64, error) {
+	return q.repo.PurgeDeletedProducts(ctx, db, deletedBefore.UTC())
+}
+
+func (q sqliteQuerier) ReassignUserProducts(ctx context.Context, db repositories.DBTX, arg repositories.ReassignUserProductsParams) (int64, error) {
+	return q.repo.ReassignUserProducts(ctx, db, sqliterepo.ReassignUserProductsParams(arg))
+}
+
+func (q sqliteQuerier) RestoreProduct(ctx context.Context, db repositories.DBTX, id int64) (repositories.Product, error) {
+	row, err := q.repo.RestoreProduct(ctx, db, id)
+	return postgresProduct(row), err
+}
+
+func (q sqliteQuerier) RevokeRefreshToken(ctx context.Context, db repositories.DBTX, id int64) (int64, error) {
+	return q.repo.RevokeRefreshToken(ctx, db, id)
+}
+
+func (q sqliteQuerier) RevokeRole(ctx context.Context, db repositories.DBTX, arg repositories.RevokeRoleParams) (int64, error) {
+	return q.repo.RevokeRole(ctx, db, sqliterepo.RevokeRoleParams(arg))
+}
+
+func (q sqliteQuerier) RevokeUserRefreshTokens(ctx context.Context, db repositories.DBTX, userID int64) (int64, error) {
+	return q.repo.RevokeUserRefreshTokens(ctx, db, userID)
+}
+
+// SearchProducts ranks the products sharing a trigram with the query in
+// process, sqlite has neither full-text search nor pg_trgm. The ranking
+// follows the postgres query, see productSearch.match.
+func (q sqliteQuerier) SearchProducts(ctx context.Context, db repositories.DBTX, arg repositories.SearchProductsParams) ([]repositories.SearchProductsRow, error) {
+	search := productSearch{
+		query: arg.Query,
+		terms: searchWords(arg.Query),
+		size:  int(arg.First) - 1,
+	}
+	if arg.AfterRank.Valid && arg.AfterID.Valid {
+		search.cursor = &helpers.SearchCursor{Rank: arg.AfterRank.Float64, ID: arg.AfterID.Int64}
+	}
+
+	trigrams, err := jsonArray(search.candidateTrigrams())
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := q.repo.SearchProductCandidates(ctx, db, trigrams)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make(map[int64]repositories.Product, len(candidates))
+	var hits []searchHit
+	for _, candidate := range candidates {
+		prod := postgresProduct(candidate)
+		products[prod.ID] = prod
+		if hit, ok := search.match(helpers.ProductResponse(prod)); ok {
+			hits = append(hits, hit)
+		}
+	}
+
+	hits = search.page(hits)
+	rows := make([]repositories.SearchProductsRow, len(hits))
+	for i, hit := range hits {
+		prod := products[hit.product.ID]
+		rows[i] = repositories.SearchProductsRow{
+			ID:        prod.ID,
+			Name:      prod.Name,
+			Price:     prod.Price,
+			Currency:  prod.Currency,
+			UserID:    prod.UserID,
+			CreatedAt: prod.CreatedAt,
+			Version:   prod.Version,
+			UpdatedAt: prod.UpdatedAt,
+			Rank:      hit.rank,
+			Highlight: hit.highlight,
+		}
+	}
+
+	return rows, nil
+}
+
+func (q sqliteQuerier) SetExchangeRate(ctx context.Context, db repositories.DBTX, arg repositories.SetExchangeRateParams) (repositories.ExchangeRate, error) {
+	row, err := q.repo.SetExchangeRate(ctx, db, sqliterepo.SetExchangeRateParams(arg))
+	return repositories.ExchangeRate(row), err
+}
+
+func (q sqliteQuerier) SetInventory(ctx context.Context, db repositories.DBTX, arg repositories.SetInventoryParams) (repositories.Inventory, error) {
+	row, err := q.repo.SetInventory(ctx, db, sqliterepo.SetInventoryParams(arg))
+	return repositories.Inventory(row), err
+}
+
+func (q sqliteQuerier) SetUserPassword(ctx context.Context, db repositories.DBTX, arg repositories.SetUserPasswordParams) error {
+	return q.repo.SetUserPassword(ctx, db, sqliterepo.SetUserPasswordParams(arg))
+}
+
+func (q sqliteQuerier) SoftDeleteProduct(ctx context.Context, db repositories.DBTX, id int64) (int64, error) {
+	return q.repo.SoftDeleteProduct(ctx, db, id)
+}
+
+func (q sqliteQuerier) TouchAPIKey(ctx context.Context, db repositories.DBTX, arg repositories.TouchAPIKeyParams) error {
+	return q.repo.TouchAPIKey(ctx, db, sqliterepo.TouchAPIKeyParams(arg))
+}
+
+func (q sqliteQuerier) UpdateAPIKey(ctx context.Context, db repositories.DBTX, arg repositories.UpdateAPIKeyParams) (repositories.APIKey, error) {
+	row, err := q.repo.UpdateAPIKey(ctx, db, sqliterepo.UpdateAPIKeyParams(arg))
+	return repositories.APIKey(row), err
+}
+
+func (q sqliteQuerier) UpdateCategory(ctx context.Context, db repositories.DBTX, arg repositories.UpdateCategoryParams) (repositories.Category, error) {
+	row, err := q.repo.UpdateCategory(ctx, db, sqliterepo.UpdateCategoryParams(arg))
+	return repositories.Category(row), err
+}
+
+func (q sqliteQuerier) UpdateOrderStatus(ctx context.Context, db repositories.DBTX, arg repositories.UpdateOrderStatusParams) (repositories.Order, error) {
+	row, err := q.repo.UpdateOrderStatus(ctx, db, sqliterepo.UpdateOrderStatusParams(arg))
+	return repositories.Order(row), err
+}
+
+func (q sqliteQuerier) UpdatePaymentStatus(ctx context.Context, db repositories.DBTX, arg repositories.UpdatePaymentStatusParams) (repositories.Payment, error) {
+	row, err := q.repo.UpdatePaymentStatus(ctx, db, sqliterepo.UpdatePaymentStatusParams(arg))
+	return repositories.Payment(row), err
+}
+
+func (q sqliteQuerier) UpdateProduct(ctx context.Context, db repositories.DBTX, arg repositories.UpdateProductParams) (repositories.Product, error) {
+	row, err := q.repo.UpdateProduct(ctx, db, sqliterepo.UpdateProductParams{
+		Name:            arg.Name,
+		Price:           arg.Price,
+		Currency:        arg.Currency,
+		ID:              arg.ID,
+		ExpectedVersion: arg.ExpectedVersion,
+	})
+	return postgresProduct(row), err
+}
+
+func (q sqliteQuerier) UpdateUser(ctx context.Context, db repositories.DBTX, arg repositories.UpdateUserParams) (repositories.User, error) {
+	row, err := q.repo.UpdateUser(ctx, db, sqliterepo.UpdateUserParams{
+		Name:            arg.Name,
+		Email:           arg.Email,
+		ID:              arg.ID,
+		ExpectedVersion: arg.ExpectedVersion,
+	})
+	return repositories.User(row), err
+}
+
+func (q sqliteQuerier) UpsertTags(ctx context.Context, db repositories.DBTX, names []string) ([]repositories.Tag, error) {
+	encoded, err := jsonArray(names)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.repo.UpsertTags(ctx, db, encoded)
+	return convertRows(rows, err, func(row sqliterepo.Tag) repositories.Tag { return repositories.Tag(row) })
+}
+
+func (q sqliteQuerier) UserProductsHasNextPage(ctx context.Context, db repositories.DBTX, arg repositories.UserProductsHasNextPageParams) (bool, error) {
+	exists, err := q.repo.UserProductsHasNextPage(ctx, db, sqliterepo.UserProductsHasNextPageParams{
+		UserID:     arg.UserID,
+		CategoryID: arg.CategoryID,
+		Tag:        arg.Tag,
+		CreatedAt:  arg.CreatedAt,
+		ID:         arg.ID,
+	})
+	return exists == 1, err
+}
+
+func (q sqliteQuerier) UserProductsHasPreviousPage(ctx context.Context, db repositories.DBTX, arg repositories.UserProductsHasPreviousPageParams) (bool, error) {
+	exists, err := q.repo.UserProductsHasPreviousPage(ctx, db, sqliterepo.UserProductsHasPreviousPageParams{
+		UserID:     arg.UserID,
+		CategoryID: arg.CategoryID,
+		Tag:        arg.Tag,
+		CreatedAt:  arg.CreatedAt,
+		ID:         arg.ID,
+	})
+	return exists == 1, err
+}
+
+func (q sqliteQuerier) UsersWithProductsNotNewerThan(ctx context.Context, db repositories.DBTX, arg repositories.UsersWithProductsNotNewerThanParams) ([]int64, error) {
+	userIDs, err := jsonArray(arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.repo.UsersWithProductsNotNewerThan(ctx, db, sqliterepo.UsersWithProductsNotNewerThanParams{
+		UserIds:    userIDs,
+		CategoryID: arg.CategoryID,
+		Tag:        arg.Tag,
+		CreatedAt:  arg.CreatedAt,
+		ID:         arg.ID,
+	})
+}
+
+func (q sqliteQuerier) UsersWithProductsNotOlderThan(ctx context.Context, db repositories.DBTX, arg repositories.UsersWithProductsNotOlderThanParams) ([]int64, error) {
+	userIDs, err := jsonArray(arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.repo.UsersWithProductsNotOlderThan(ctx, db, sqliterepo.UsersWithProductsNotOlderThanParams{
+		UserIds:    userIDs,
+		CategoryID: arg.CategoryID,
+		Tag:        arg.Tag,
+		CreatedAt:  arg.CreatedAt,
+		ID:         arg.ID,
+	})
+}
+
+// postgresProduct converts a sqlite product, it only lacks the search vector
+// the service never reads.
+func postgresProduct(prod sqliterepo.Product) repositories.Product {
+	return repositories.Product{
+		ID:        prod.ID,
+		Name:      prod.Name,
+		Price:     prod.Price,
+		UserID:    prod.UserID,
+		CreatedAt: prod.CreatedAt,
+		DeletedAt: prod.DeletedAt,
+		Version:   prod.Version,
+		UpdatedAt: prod.UpdatedAt,
+		Currency:  prod.Currency,
+	}
+}
+
+// convertRows converts the rows of a sqlite query to their postgres type,
+// keeping a nil result nil.
+func convertRows[From, To any](rows []From, err error, convert func(From) To) ([]To, error) {
+	if err != nil || rows == nil {
+		return nil, err
+	}
+
+	converted := make([]To, len(rows))
+	for i, row := range rows {
+		converted[i] = convert(row)
+	}
+
+	return converted, nil
+}
+
+// jsonArray encodes values for the json_each based lists of the sqlite
+// queries, sqlite has no array parameters.
+func jsonArray[T any](values []T) (string, error) {
+	if values == nil {
+		values = []T{}
+	}
+
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return "", &Error{Code: ErrInternal, Err: err}
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,54 @@
+package services_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sqlc-rest-api/auth"
+	"sqlc-rest-api/config"
+	"sqlc-rest-api/db/drivers"
+	"sqlc-rest-api/payments"
+	"sqlc-rest-api/services"
+	"sqlc-rest-api/services/servicetest"
+	"testing"
+
+	sqliterepo "sqlc-rest-api/db/sqlite/repositories"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteService(t *testing.T) {
+	servicetest.Run(t, func(t *testing.T) services.Service {
+		service := newSqliteService(t)
+		service.Payments = payments.NewFake()
+		service.Tokens = auth.Tokens{Secret: []byte("secret")}
+		return service
+	})
+}
+
+// newSqliteService opens a new database file with the migrations applied.
+func newSqliteService(t *testing.T) *services.SQLService {
+	env := config.Environment{
+		DBName: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	db, err := drivers.NewSqlite(env).Connect()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	// apply the same migrations golang-migrate would
+	migrations, err := filepath.Glob("../db/sqlite/schemas/*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	sort.Strings(migrations)
+
+	for _, migration := range migrations {
+		schema, err := os.ReadFile(migration)
+		require.NoError(t, err)
+
+		_, err = db.Exec(string(schema))
+		require.NoError(t, err, migration)
+	}
+
+	return services.NewSqliteService(db, sqliterepo.New())
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sqlc-rest-api/db/postgres/repositories"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+)
+
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+	// MaxRetries is how many times a transaction is retried when postgres
+	// aborts it with a serialization failure or a deadlock.
+	MaxRetries int
+	// RetryBackoff is multiplied by the attempt number between retries.
+	RetryBackoff time.Duration
+}
+
+func DefaultTxOptions() TxOptions {
+	return TxOptions{
+		Isolation:    sql.LevelRepeatableRead,
+		MaxRetries:   3,
+		RetryBackoff: 10 * time.Millisecond,
+	}
+}
+
+// NewTxOptions is DefaultTxOptions with the isolation level and retry count
+// overridden. Isolation is "read_committed", "repeatable_read" or
+// "serializable", empty keeps the default. Zero maxRetries keeps the default
+// and a negative one disables retrying.
+func NewTxOptions(isolation string, maxRetries int) (TxOptions, error) {
+	opts := DefaultTxOptions()
+
+	switch isolation {
+	case "":
+	case "read_committed":
+		opts.Isolation = sql.LevelReadCommitted
+	case "repeatable_read":
+		opts.Isolation = sql.LevelRepeatableRead
+	case "serializable":
+		opts.Isolation = sql.LevelSerializable
+	default:
+		return TxOptions{}, fmt.Errorf("unknown isolation level %q", isolation)
+	}
+
+	switch {
+	case maxRetries < 0:
+		opts.MaxRetries = 0
+	case maxRetries > 0:
+		opts.MaxRetries = maxRetries
+	}
+
+	return opts, nil
+}
+
+// TxFunc receives the querier together with the transaction that must be
+// passed to every query so they all run inside the same unit of work.
+type TxFunc func(q repositories.Querier, tx repositories.DBTX) error
+
+// WithTx runs fn inside a transaction using s.TxOptions.
+func (s *SQLService) WithTx(ctx context.Context, fn TxFunc) error {
+	return s.WithTxOptions(ctx, s.TxOptions, fn)
+}
+
+// WithTxOptions runs fn inside a transaction. The transaction is committed when
+// fn returns nil and rolled back when it returns an error or panics.
+// Serialization failures are retried up to opts.MaxRetries times.
+func (s *SQLService) WithTxOptions(ctx context.Context, opts TxOptions, fn TxFunc) error {
+	var err error
+	for attempt := 0; attempt <= opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(opts.RetryBackoff * time.Duration(attempt)):
+			}
+		}
+
+		err = s.runTx(ctx, opts, fn)
+		if !isRetryable(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (s *SQLService) runTx(ctx context.Context, opts TxOptions, fn TxFunc) (err error) {
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{
+		Isolation: opts.Isolation,
+		ReadOnly:  opts.ReadOnly,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(s.Repo, tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w: rollback failed: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+}
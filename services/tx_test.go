@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sqlc-rest-api/db/postgres/repositories"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTxTestService backs a SQLService with an in memory sqlite database,
+// runTx only needs database/sql so the transaction handling can be tested
+// without postgres.
+func newTxTestService(t *testing.T) *SQLService {
+	db, err := sql.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	// every connection would get its own in memory database
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE items (name TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	return NewPostgresService(db, repositories.New())
+}
+
+func insertItem(ctx context.Context, tx repositories.DBTX) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO items (name) VALUES ('item')")
+	return err
+}
+
+func countItems(t *testing.T, service *SQLService) int {
+	var count int
+	require.NoError(t, service.DB.QueryRow("SELECT count(*) FROM items").Scan(&count))
+	return count
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.True(t, isRetryable(&pq.Error{Code: pqSerializationFailure}))
+	require.True(t, isRetryable(&pq.Error{Code: pqDeadlockDetected}))
+	require.True(t, isRetryable(fmt.Errorf("wrapped: %w", &pq.Error{Code: pqSerializationFailure})))
+
+	require.False(t, isRetryable(nil))
+	require.False(t, isRetryable(errors.New("40001")))
+	require.False(t, isRetryable(&pq.Error{Code: "23505"}))
+}
+
+func TestRunTx(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commit", func(t *testing.T) {
+		service := newTxTestService(t)
+
+		err := service.runTx(ctx, TxOptions{}, func(q repositories.Querier, tx repositories.DBTX) error {
+			return insertItem(ctx, tx)
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, countItems(t, service))
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		service := newTxTestService(t)
+		failure := errors.New("failure")
+
+		err := service.runTx(ctx, TxOptions{}, func(q repositories.Querier, tx repositories.DBTX) error {
+			require.NoError(t, insertItem(ctx, tx))
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+		require.Equal(t, 0, countItems(t, service))
+	})
+
+	t.Run("rollback on panic", func(t *testing.T) {
+		service := newTxTestService(t)
+
+		require.PanicsWithValue(t, "boom", func() {
+			_ = service.runTx(ctx, TxOptions{}, func(q repositories.Querier, tx repositories.DBTX) error {
+				require.NoError(t, insertItem(ctx, tx))
+				panic("boom")
+			})
+		})
+		require.Equal(t, 0, countItems(t, service))
+	})
+}
+
+func TestWithTxOptionsRetries(t *testing.T) {
+	ctx := context.Background()
+	opts := TxOptions{MaxRetries: 3}
+
+	t.Run("retries until success", func(t *testing.T) {
+		service := newTxTestService(t)
+		codes := []pq.ErrorCode{pqSerializationFailure, pqDeadlockDetected}
+
+		attempts := 0
+		err := service.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+			attempts++
+			require.NoError(t, insertItem(ctx, tx))
+			if attempts <= len(codes) {
+				return &pq.Error{Code: codes[attempts-1]}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+		// the aborted attempts were rolled back
+		require.Equal(t, 1, countItems(t, service))
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		service := newTxTestService(t)
+
+		attempts := 0
+		err := service.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+			attempts++
+			return &pq.Error{Code: pqSerializationFailure}
+		})
+		require.True(t, isRetryable(err))
+		require.Equal(t, opts.MaxRetries+1, attempts)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		service := newTxTestService(t)
+		failure := errors.New("failure")
+
+		attempts := 0
+		err := service.WithTxOptions(ctx, opts, func(q repositories.Querier, tx repositories.DBTX) error {
+			attempts++
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func TestNewTxOptions(t *testing.T) {
+	opts, err := NewTxOptions("", 0)
+	require.NoError(t, err)
+	require.Equal(t, DefaultTxOptions(), opts)
+
+	opts, err = NewTxOptions("serializable", 5)
+	require.NoError(t, err)
+	require.Equal(t, sql.LevelSerializable, opts.Isolation)
+	require.Equal(t, 5, opts.MaxRetries)
+
+	opts, err = NewTxOptions("read_committed", -1)
+	require.NoError(t, err)
+	require.Equal(t, sql.LevelReadCommitted, opts.Isolation)
+	require.Equal(t, 0, opts.MaxRetries)
+
+	_, err = NewTxOptions("snapshot", 0)
+	require.Error(t, err)
+}
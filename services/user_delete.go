@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"sqlc-rest-api/requests"
+)
+
+// UserDeletion is the policy DeleteUser applies when the request does not
+// choose one, ReassignTo is the default recipient of the reassign policy.
+type UserDeletion struct {
+	Policy     requests.UserDeletePolicy
+	ReassignTo int64
+}
+
+// Validate reports configurations DeleteUser could never apply.
+func (d UserDeletion) Validate() error {
+	switch d.Policy {
+	case "", requests.UserDeleteRestrict, requests.UserDeleteCascade, requests.UserDeleteReassign:
+	default:
+		return fmt.Errorf("unknown user delete policy %q", d.Policy)
+	}
+
+	if d.ReassignTo < 0 {
+		return fmt.Errorf("invalid user to reassign products to %d", d.ReassignTo)
+	}
+
+	return nil
+}
+
+// resolve returns the policy to delete the user of req with and, for the
+// reassign policy, the user receiving its products.
+func (d UserDeletion) resolve(req requests.DeleteUserRequest) (requests.UserDeletePolicy, int64, error) {
+	policy := req.Policy
+	if policy == "" {
+		policy = d.Policy
+	}
+	if policy == "" {
+		policy = requests.UserDeleteRestrict
+	}
+
+	switch policy {
+	case requests.UserDeleteRestrict, requests.UserDeleteCascade:
+		if req.ReassignTo != nil {
+			return policy, 0, ValidationError("reassign_to only applies to the reassign policy")
+		}
+		return policy, 0, nil
+	case requests.UserDeleteReassign:
+	default:
+		return policy, 0, ValidationError("unknown user delete policy %q", policy)
+	}
+
+	to := d.ReassignTo
+	if req.ReassignTo != nil {
+		to = *req.ReassignTo
+	}
+
+	if to < 1 {
+		return policy, 0, ValidationError("reassign_to is required by the reassign policy")
+	}
+
+	if to == req.ID {
+		return policy, 0, ValidationError("cannot reassign the products of user with id %d to itself", req.ID)
+	}
+
+	return policy, to, nil
+}
+
+func ownsProductsError(id int64, products int64) error {
+	return ConflictError("user with id %d still owns %d products", id, products)
+}
+
+func reassignUserError(id int64) error {
+	return NewError(ErrForeignKeyViolation, "user with id %d to reassign products to not found", id)
+}
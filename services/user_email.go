@@ -0,0 +1,130 @@
+package services
+
+import (
+	"net/mail"
+	"sqlc-rest-api/requests"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxEmailLength is the longest address SMTP can deliver to (RFC 5321).
+const maxEmailLength = 254
+
+// usersEmailIndex is the case-insensitive unique index on users.email.
+const usersEmailIndex = "users_email_key"
+
+// EmailPolicy validates and normalizes the emails of users. Addresses at a
+// blocked domain, or at one of its subdomains, are refused, which is meant
+// for disposable email providers. The zero value blocks nothing.
+type EmailPolicy struct {
+	blocked map[string]bool
+}
+
+func NewEmailPolicy(blockedDomains []string) EmailPolicy {
+	policy := EmailPolicy{blocked: make(map[string]bool, len(blockedDomains))}
+	for _, domain := range blockedDomains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain != "" {
+			policy.blocked[domain] = true
+		}
+	}
+
+	return policy
+}
+
+// normalize returns email in the form it is stored in: NFC normalized,
+// without surrounding spaces and with a lower case domain. The local part
+// keeps its case, uniqueness ignores the case of its ASCII letters through the
+// index on LOWER(email), see sameEmail.
+func (p EmailPolicy) normalize(email string) (string, error) {
+	email = norm.NFC.String(strings.TrimSpace(email))
+	if email == "" {
+		return "", ValidationError("email is required")
+	}
+
+	if len(email) > maxEmailLength {
+		return "", ValidationError("email must be at most %d bytes long", maxEmailLength)
+	}
+
+	// ParseAddress accepts whole RFC 5322 mailboxes, only a bare addr-spec
+	// is an email.
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || strings.ContainsAny(email, "<>()") {
+		return "", ValidationError("email %q is not a valid address", email)
+	}
+
+	at := strings.LastIndex(email, "@")
+	local, domain := email[:at], strings.ToLower(email[at+1:])
+	if !strings.Contains(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", ValidationError("email %q is not a valid address", email)
+	}
+
+	if p.isBlocked(domain) {
+		return "", ValidationError("email domain %q is not allowed", domain)
+	}
+
+	return local + "@" + domain, nil
+}
+
+// normalizePatch validates req like validateUserPatch and normalizes the email
+// it sets.
+func (p EmailPolicy) normalizePatch(req requests.PatchUserRequest) (requests.PatchUserRequest, error) {
+	if err := validateUserPatch(req); err != nil {
+		return req, err
+	}
+
+	if req.Email != nil {
+		email, err := p.normalize(*req.Email)
+		if err != nil {
+			return req, err
+		}
+		req.Email = &email
+	}
+
+	return req, nil
+}
+
+func (p EmailPolicy) isBlocked(domain string) bool {
+	for {
+		if p.blocked[domain] {
+			return true
+		}
+
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// sameEmail compares emails the way the unique index does: SQLite's LOWER
+// only folds ASCII letters, so the Postgres index and sameEmail don't fold
+// the others either, and every backend treats the same emails as taken.
+func sameEmail(a, b string) bool {
+	return asciiLower(a) == asciiLower(b)
+}
+
+func asciiLower(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, s)
+}
+
+func emailTakenError(email string) error {
+	return ConflictError("user with email %q already exists", email)
+}
+
+// userEmailError reports violations of the unique email index as
+// emailTakenError, other errors are classified by dbError.
+func userEmailError(err error, email string, id int64) error {
+	if isUniqueViolation(err, usersEmailIndex) {
+		return emailTakenError(email)
+	}
+
+	return dbError(err, "user", id)
+}
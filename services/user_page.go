@@ -0,0 +1,46 @@
+package services
+
+import (
+	"sqlc-rest-api/helpers"
+	"sqlc-rest-api/requests"
+)
+
+// idPage is a validated page of a list paged with id cursors, users are
+// listed in id order and orders newest first.
+type idPage struct {
+	size  int
+	after *int64
+}
+
+func newIDPage(first *int, after *string, maxPageSize int) (idPage, error) {
+	if maxPageSize < 1 {
+		maxPageSize = DefaultMaxPageSize
+	}
+
+	page := idPage{size: DefaultPageSize}
+	if first != nil {
+		page.size = *first
+	}
+
+	if page.size < 1 || page.size > maxPageSize {
+		return page, ValidationError("page size must be between 1 and %d", maxPageSize)
+	}
+
+	if after != nil {
+		id, err := helpers.DecodeIDCursor(*after)
+		if err != nil {
+			return page, &Error{Code: ErrBadRequest, Message: "invalid cursor", Err: err}
+		}
+		page.after = &id
+	}
+
+	return page, nil
+}
+
+func newUsersPage(req requests.ListUsersRequest, maxPageSize int) (idPage, error) {
+	return newIDPage(req.First, req.After, maxPageSize)
+}
+
+func newOrdersPage(req requests.GetUserOrdersRequest, maxPageSize int) (idPage, error) {
+	return newIDPage(req.First, req.After, maxPageSize)
+}
@@ -0,0 +1,17 @@
+package services
+
+// checkVersion fails with ErrPreconditionFailed when the caller expects
+// another version of the resource than the stored one.
+func checkVersion(resource string, id int64, expected *int64, version int64) error {
+	if expected == nil || *expected == version {
+		return nil
+	}
+
+	return PreconditionFailedError("%s with id %d is at version %d, expected version %d", resource, id, version, *expected)
+}
+
+// concurrentUpdateError is returned when a versioned write found no row
+// because another writer got there first.
+func concurrentUpdateError(resource string, id int64, expected int64) error {
+	return PreconditionFailedError("%s with id %d was modified concurrently, expected version %d", resource, id, expected)
+}